@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/client"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/gofiber/fiber/v2"
+)
+
+// canAccessAccount check if the authenticated client owns the given account, denied when no client was authenticated
+func canAccessAccount(c *fiber.Ctx, accountID int) bool {
+	cl, ok := c.Locals(client.ClientKeyType).(*entity.Client)
+	if !ok {
+		return false
+	}
+
+	return cl.CanAccessAccount(accountID)
+}
+
+// canAccessCustomers check if the authenticated client may access the customers, denied when no client was
+// authenticated
+func canAccessCustomers(c *fiber.Ctx) bool {
+	cl, ok := c.Locals(client.ClientKeyType).(*entity.Client)
+	if !ok {
+		return false
+	}
+
+	return cl.CanAccessCustomers()
+}
+
+// currentClientID is the id of the authenticated client, zero when the authentication is disabled
+func currentClientID(c *fiber.Ctx) int {
+	cl, ok := c.Locals(client.ClientKeyType).(*entity.Client)
+	if !ok {
+		return 0
+	}
+
+	return cl.ID
+}
+
+// canAccessJob check if the authenticated client enqueued the given job, denied when no client was authenticated
+func canAccessJob(c *fiber.Ctx, j *entity.Job) bool {
+	cl, ok := c.Locals(client.ClientKeyType).(*entity.Client)
+	if !ok {
+		return false
+	}
+
+	return cl.CanAccessJob(j)
+}
+
+func forbiddenAccount(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusForbidden).JSON(
+		presenter.ErrorResponse{Title: "Forbidden", Detail: "the account does not belong to the client"},
+	)
+}
+
+func forbiddenCustomer(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusForbidden).JSON(
+		presenter.ErrorResponse{Title: "Forbidden", Detail: "the customers are not available to the client"},
+	)
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"github.com/brunomdev/digital-account/domain/client"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+// backOfficeClient is authenticated on the handler tests which don't set a client
+var backOfficeClient = &entity.Client{ID: 10, Name: "backoffice", Role: entity.RoleBackOffice}
+
+// useClient authenticate the requests of the test app as the given client, as the back office when nil
+func useClient(app *fiber.App, cl *entity.Client) {
+	if cl == nil {
+		cl = backOfficeClient
+	}
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(client.ClientKeyType, cl)
+		return c.Next()
+	})
+}
+
+func Test_canAccessAccount(t *testing.T) {
+	testCases := []struct {
+		name      string
+		client    *entity.Client
+		accountID int
+		want      bool
+	}{
+		{
+			name:      "Denied without client",
+			accountID: 1,
+			want:      false,
+		},
+		{
+			name:      "Denied account of another customer",
+			client:    &entity.Client{ID: 1, Role: entity.RoleCustomer, AccountID: 2},
+			accountID: 1,
+			want:      false,
+		},
+		{
+			name:      "Allowed own account",
+			client:    &entity.Client{ID: 1, Role: entity.RoleCustomer, AccountID: 1},
+			accountID: 1,
+			want:      true,
+		},
+		{
+			name:      "Allowed back office",
+			client:    backOfficeClient,
+			accountID: 1,
+			want:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+
+			var got bool
+			app.Get("/", func(c *fiber.Ctx) error {
+				if tc.client != nil {
+					c.Locals(client.ClientKeyType, tc.client)
+				}
+
+				got = canAccessAccount(c, tc.accountID)
+
+				return nil
+			})
+
+			_, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -3,14 +3,17 @@ package handlers
 import (
 	"github.com/brunomdev/digital-account/app/api/presenter"
 	"github.com/brunomdev/digital-account/domain/account"
+	"github.com/brunomdev/digital-account/entity"
 	"github.com/brunomdev/digital-account/infra/log"
 	validator "github.com/brunomdev/digital-account/pkg/validate"
 	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
 )
 
 type AccountHandler interface {
 	Create(c *fiber.Ctx) error
 	Get(c *fiber.Ctx) error
+	UpdateCreditLimit(c *fiber.Ctx) error
 }
 
 type accountHandler struct {
@@ -45,9 +48,9 @@ func (h *accountHandler) Create(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusUnprocessableEntity).JSON(errs)
 	}
 
-	acc, err := h.service.Create(c.Context(), input.DocumentNumber, input.AvailableCreditLimit)
+	acc, err := h.service.Create(c.UserContext(), input.DocumentNumber, input.AvailableCreditLimit)
 	if err != nil {
-		log.Error(c.Context(), "unable to create account", err)
+		log.Error(c.UserContext(), "unable to create account", err)
 
 		return c.Status(fiber.StatusInternalServerError).JSON(
 			presenter.ErrorResponse{Title: "Error while creating Account"},
@@ -58,6 +61,7 @@ func (h *accountHandler) Create(c *fiber.Ctx) error {
 		ID:                   acc.ID,
 		DocumentNumber:       acc.DocumentNumber,
 		AvailableCreditLimit: acc.AvailabelCreditLimit,
+		CustomerID:           acc.CustomerID,
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(resp)
@@ -75,9 +79,13 @@ func (h *accountHandler) Get(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusUnprocessableEntity).JSON(errs)
 	}
 
-	acc, err := h.service.Get(c.Context(), input.ID)
+	if !canAccessAccount(c, input.ID) {
+		return forbiddenAccount(c)
+	}
+
+	acc, err := h.service.Get(c.UserContext(), input.ID)
 	if err != nil {
-		log.Error(c.Context(), "unable to find account", err)
+		log.Error(c.UserContext(), "unable to find account", err)
 
 		return c.Status(fiber.StatusInternalServerError).JSON(
 			presenter.ErrorResponse{Title: "Account not found"},
@@ -88,6 +96,59 @@ func (h *accountHandler) Get(c *fiber.Ctx) error {
 		ID:                   acc.ID,
 		DocumentNumber:       acc.DocumentNumber,
 		AvailableCreditLimit: acc.AvailabelCreditLimit,
+		CustomerID:           acc.CustomerID,
+	}
+
+	return c.JSON(resp)
+}
+
+func (h *accountHandler) UpdateCreditLimit(c *fiber.Ctx) error {
+	var input struct {
+		ID                   int     `json:"-" validate:"required,min=1"`
+		AvailableCreditLimit float64 `json:"available_credit_limit" validate:"min=0"`
+	}
+
+	err := c.BodyParser(&input)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).
+			JSON(
+				presenter.ErrorResponse{
+					Title:  "Unable to parse body",
+					Detail: err.Error(),
+				},
+			)
+	}
+
+	input.ID, _ = c.ParamsInt("id")
+
+	errs := validator.ValidateStruct(input)
+	if errs != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(errs)
+	}
+
+	if !canAccessAccount(c, input.ID) {
+		return forbiddenAccount(c)
+	}
+
+	acc, err := h.service.AdjustCreditLimit(c.UserContext(), input.ID, input.AvailableCreditLimit)
+	if errors.Is(err, entity.ErrNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(
+			presenter.ErrorResponse{Title: "Account not found", Detail: err.Error()},
+		)
+	}
+	if err != nil {
+		log.Error(c.UserContext(), "unable to update account credit limit", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			presenter.ErrorResponse{Title: "Error while updating Account credit limit"},
+		)
+	}
+
+	resp := presenter.AccountResponse{
+		ID:                   acc.ID,
+		DocumentNumber:       acc.DocumentNumber,
+		AvailableCreditLimit: acc.AvailabelCreditLimit,
+		CustomerID:           acc.CustomerID,
 	}
 
 	return c.JSON(resp)
@@ -131,6 +131,7 @@ func Test_accountHandler_Get(t *testing.T) {
 	testCases := []struct {
 		name         string
 		eventService func(ctrl *gomock.Controller) account.Service
+		client       *entity.Client
 		args         args
 		wantStatus   int
 		wantBody     func() ([]byte, error)
@@ -151,6 +152,21 @@ func Test_accountHandler_Get(t *testing.T) {
 				})
 			},
 		},
+		{
+			name: "Error account of another customer",
+			eventService: func(ctrl *gomock.Controller) account.Service {
+				return mock_account.NewMockService(ctrl)
+			},
+			client:     &entity.Client{ID: 1, Role: entity.RoleCustomer, AccountID: 1},
+			args:       args{accountID: 2},
+			wantStatus: http.StatusForbidden,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Forbidden",
+					Detail: "the account does not belong to the client",
+				})
+			},
+		},
 		{
 			name: "Error service",
 			eventService: func(ctrl *gomock.Controller) account.Service {
@@ -184,6 +200,7 @@ func Test_accountHandler_Get(t *testing.T) {
 
 				return svc
 			},
+			client:     &entity.Client{ID: 1, Role: entity.RoleCustomer, AccountID: 2},
 			args:       args{accountID: 2},
 			wantStatus: http.StatusOK,
 			wantBody: func() ([]byte, error) {
@@ -203,6 +220,8 @@ func Test_accountHandler_Get(t *testing.T) {
 
 			app := fiber.New()
 
+			useClient(app, tc.client)
+
 			handler := NewAccountHandler(tc.eventService(ctrl))
 
 			app.Get("/accounts/:id", handler.Get)
@@ -221,3 +240,141 @@ func Test_accountHandler_Get(t *testing.T) {
 		})
 	}
 }
+
+func Test_accountHandler_UpdateCreditLimit(t *testing.T) {
+	type args struct {
+		accountID int
+	}
+	testCases := []struct {
+		name       string
+		svcArgs    func(ctrl *gomock.Controller) account.Service
+		args       args
+		reqBody    []byte
+		wantStatus int
+		wantBody   func() ([]byte, error)
+	}{
+		{
+			name: "Error bodyParser",
+			svcArgs: func(ctrl *gomock.Controller) account.Service {
+				return mock_account.NewMockService(ctrl)
+			},
+			args:       args{accountID: 1},
+			reqBody:    []byte(`{"available_credit_limit": 100,}`),
+			wantStatus: http.StatusBadRequest,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Unable to parse body",
+					Detail: "invalid character '}' looking for beginning of value",
+				})
+			},
+		},
+		{
+			name: "Error validation",
+			svcArgs: func(ctrl *gomock.Controller) account.Service {
+				return mock_account.NewMockService(ctrl)
+			},
+			args:       args{accountID: 1},
+			reqBody:    []byte(`{"available_credit_limit": -1}`),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal([]presenter.ErrorResponse{
+					{
+						Source: "AvailableCreditLimit",
+						Detail: "AvailableCreditLimit must be 0 or greater",
+					},
+				})
+			},
+		},
+		{
+			name: "Error not found",
+			svcArgs: func(ctrl *gomock.Controller) account.Service {
+				svc := mock_account.NewMockService(ctrl)
+
+				svc.EXPECT().AdjustCreditLimit(gomock.Any(), 1, 100.00).
+					Return(nil, errors.Wrap(entity.ErrNotFound, "account"))
+
+				return svc
+			},
+			args:       args{accountID: 1},
+			reqBody:    []byte(`{"available_credit_limit": 100}`),
+			wantStatus: http.StatusNotFound,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Account not found",
+					Detail: "account: not found",
+				})
+			},
+		},
+		{
+			name: "Error service",
+			svcArgs: func(ctrl *gomock.Controller) account.Service {
+				svc := mock_account.NewMockService(ctrl)
+
+				svc.EXPECT().AdjustCreditLimit(gomock.Any(), 1, 100.00).Return(nil, errors.New("error"))
+
+				return svc
+			},
+			args:       args{accountID: 1},
+			reqBody:    []byte(`{"available_credit_limit": 100}`),
+			wantStatus: http.StatusInternalServerError,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{Title: "Error while updating Account credit limit"})
+			},
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) account.Service {
+				svc := mock_account.NewMockService(ctrl)
+
+				svc.EXPECT().AdjustCreditLimit(gomock.Any(), 1, 100.00).
+					DoAndReturn(func(ctx context.Context, id int, availableCreditLimit float64) (*entity.Account, error) {
+						return &entity.Account{
+							ID:                   id,
+							DocumentNumber:       "12345678900",
+							AvailabelCreditLimit: availableCreditLimit,
+						}, nil
+					})
+
+				return svc
+			},
+			args:       args{accountID: 1},
+			reqBody:    []byte(`{"available_credit_limit": 100}`),
+			wantStatus: http.StatusOK,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.AccountResponse{
+					ID:                   1,
+					DocumentNumber:       "12345678900",
+					AvailableCreditLimit: 100,
+				})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := fiber.New()
+
+			useClient(app, nil)
+
+			handler := NewAccountHandler(tc.svcArgs(ctrl))
+
+			app.Patch("/accounts/:id/credit-limit", handler.UpdateCreditLimit)
+
+			wantBody, err := tc.wantBody()
+			assert.NoError(t, err)
+
+			apitest.New().
+				HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+				Patchf("/accounts/%d/credit-limit", tc.args.accountID).
+				Body(string(tc.reqBody)).
+				Header(fiber.HeaderContentType, fiber.MIMEApplicationJSON).
+				Expect(t).
+				Status(tc.wantStatus).
+				Body(string(wantBody)).
+				End()
+		})
+	}
+}
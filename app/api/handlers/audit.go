@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/audit"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/infra/log"
+	validator "github.com/brunomdev/digital-account/pkg/validate"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AuditHandler interface {
+	List(c *fiber.Ctx) error
+	Verify(c *fiber.Ctx) error
+}
+
+type auditHandler struct {
+	service audit.Service
+}
+
+func NewAuditHandler(service audit.Service) AuditHandler {
+	return &auditHandler{
+		service: service,
+	}
+}
+
+// List the audit trail of an entity, newest first. The purges of the transaction attempts are recorded with id 0.
+func (h *auditHandler) List(c *fiber.Ctx) error {
+	var input struct {
+		Entity string `query:"entity" validate:"required,oneof=account operation_type transaction transaction_attempt"`
+		ID     int    `query:"id" validate:"min=0"`
+		Limit  int    `query:"limit" validate:"min=1,max=500"`
+	}
+
+	input.Limit = 100
+
+	err := c.QueryParser(&input)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).
+			JSON(
+				presenter.ErrorResponse{
+					Title:  "Unable to parse query",
+					Detail: err.Error(),
+				},
+			)
+	}
+
+	errs := validator.ValidateStruct(input)
+	if errs != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(errs)
+	}
+
+	entries, err := h.service.List(c.UserContext(), entity.AuditEntity(input.Entity), input.ID, input.Limit)
+	if err != nil {
+		log.Error(c.UserContext(), "unable to list audit entries", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			presenter.ErrorResponse{Title: "Error while listing Audit entries"},
+		)
+	}
+
+	resp := make([]presenter.AuditEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		resp = append(resp, presenter.NewAuditEntryResponse(entry))
+	}
+
+	return c.JSON(resp)
+}
+
+// Verify walk the audit chain, a changed or removed entry is reported with the id of the first broken one
+func (h *auditHandler) Verify(c *fiber.Ctx) error {
+	verification, err := h.service.Verify(c.UserContext())
+	if err != nil {
+		log.Error(c.UserContext(), "unable to verify audit chain", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			presenter.ErrorResponse{Title: "Error while verifying Audit chain"},
+		)
+	}
+
+	if !verification.Valid() {
+		log.Warn(c.UserContext(), "audit chain broken", log.Event{"entry_id": verification.BrokenEntryID})
+	}
+
+	return c.JSON(presenter.NewAuditVerificationResponse(verification))
+}
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/audit"
+	"github.com/brunomdev/digital-account/domain/audit/mock_audit"
+	"github.com/brunomdev/digital-account/entity"
+	testHelper "github.com/brunomdev/digital-account/pkg/tests"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/steinfletcher/apitest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_auditHandler_List(t *testing.T) {
+	createdAt := time.Date(2022, 3, 31, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		svcArgs    func(ctrl *gomock.Controller) audit.Service
+		query      map[string]string
+		wantStatus int
+		wantBody   func() ([]byte, error)
+	}{
+		{
+			name: "Error validation",
+			svcArgs: func(ctrl *gomock.Controller) audit.Service {
+				return mock_audit.NewMockService(ctrl)
+			},
+			query:      map[string]string{"entity": "customer", "id": "1"},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal([]presenter.ErrorResponse{
+					{
+						Source: "Entity",
+						Detail: "Entity must be one of [account operation_type transaction transaction_attempt]",
+					},
+				})
+			},
+		},
+		{
+			name: "Error service",
+			svcArgs: func(ctrl *gomock.Controller) audit.Service {
+				svc := mock_audit.NewMockService(ctrl)
+				svc.EXPECT().List(gomock.Any(), entity.AuditEntityAccount, 1, 100).Return(nil, errors.New("error"))
+
+				return svc
+			},
+			query:      map[string]string{"entity": "account", "id": "1"},
+			wantStatus: http.StatusInternalServerError,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{Title: "Error while listing Audit entries"})
+			},
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) audit.Service {
+				svc := mock_audit.NewMockService(ctrl)
+				svc.EXPECT().List(gomock.Any(), entity.AuditEntityAccount, 1, 10).
+					Return([]*entity.AuditEntry{
+						{
+							ID:        2,
+							Actor:     "backoffice",
+							RequestID: "request-2",
+							Action:    entity.AuditActionUpdateCreditLimit,
+							Entity:    entity.AuditEntityAccount,
+							EntityID:  1,
+							Before:    `{"limit":100}`,
+							After:     `{"limit":50}`,
+							PrevHash:  "a1",
+							Hash:      "b2",
+							CreatedAt: createdAt,
+						},
+						{
+							ID:        1,
+							Actor:     "system",
+							Action:    entity.AuditActionCreate,
+							Entity:    entity.AuditEntityAccount,
+							EntityID:  1,
+							After:     `{"limit":100}`,
+							Hash:      "a1",
+							CreatedAt: createdAt,
+						},
+					}, nil)
+
+				return svc
+			},
+			query:      map[string]string{"entity": "account", "id": "1", "limit": "10"},
+			wantStatus: http.StatusOK,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal([]map[string]interface{}{
+					{
+						"id":         2,
+						"actor":      "backoffice",
+						"request_id": "request-2",
+						"action":     "update_credit_limit",
+						"entity":     "account",
+						"entity_id":  1,
+						"before":     map[string]int{"limit": 100},
+						"after":      map[string]int{"limit": 50},
+						"prev_hash":  "a1",
+						"hash":       "b2",
+						"created_at": createdAt,
+					},
+					{
+						"id":         1,
+						"actor":      "system",
+						"action":     "create",
+						"entity":     "account",
+						"entity_id":  1,
+						"after":      map[string]int{"limit": 100},
+						"prev_hash":  "",
+						"hash":       "a1",
+						"created_at": createdAt,
+					},
+				})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := fiber.New()
+
+			handler := NewAuditHandler(tc.svcArgs(ctrl))
+
+			app.Get("/audit", handler.List)
+
+			wantBody, err := tc.wantBody()
+			assert.NoError(t, err)
+
+			apitest.New().
+				HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+				Get("/audit").
+				QueryParams(tc.query).
+				Expect(t).
+				Status(tc.wantStatus).
+				Body(string(wantBody)).
+				End()
+		})
+	}
+}
+
+func Test_auditHandler_Verify(t *testing.T) {
+	testCases := []struct {
+		name       string
+		svcArgs    func(ctrl *gomock.Controller) audit.Service
+		wantStatus int
+		wantBody   func() ([]byte, error)
+	}{
+		{
+			name: "Error service",
+			svcArgs: func(ctrl *gomock.Controller) audit.Service {
+				svc := mock_audit.NewMockService(ctrl)
+				svc.EXPECT().Verify(gomock.Any()).Return(nil, errors.New("error"))
+
+				return svc
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{Title: "Error while verifying Audit chain"})
+			},
+		},
+		{
+			name: "Success broken chain",
+			svcArgs: func(ctrl *gomock.Controller) audit.Service {
+				svc := mock_audit.NewMockService(ctrl)
+				svc.EXPECT().Verify(gomock.Any()).Return(&entity.AuditVerification{Entries: 2, BrokenEntryID: 2}, nil)
+
+				return svc
+			},
+			wantStatus: http.StatusOK,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(map[string]interface{}{"valid": false, "entries": 2, "broken_entry_id": 2})
+			},
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) audit.Service {
+				svc := mock_audit.NewMockService(ctrl)
+				svc.EXPECT().Verify(gomock.Any()).Return(&entity.AuditVerification{Entries: 3}, nil)
+
+				return svc
+			},
+			wantStatus: http.StatusOK,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(map[string]interface{}{"valid": true, "entries": 3})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := fiber.New()
+
+			handler := NewAuditHandler(tc.svcArgs(ctrl))
+
+			app.Get("/audit/verify", handler.Verify)
+
+			wantBody, err := tc.wantBody()
+			assert.NoError(t, err)
+
+			apitest.New().
+				HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+				Get("/audit/verify").
+				Expect(t).
+				Status(tc.wantStatus).
+				Body(string(wantBody)).
+				End()
+		})
+	}
+}
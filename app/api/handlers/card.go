@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/card"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/infra/log"
+	validator "github.com/brunomdev/digital-account/pkg/validate"
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+)
+
+type CardHandler interface {
+	Issue(c *fiber.Ctx) error
+	Get(c *fiber.Ctx) error
+	Block(c *fiber.Ctx) error
+	Replace(c *fiber.Ctx) error
+}
+
+type cardHandler struct {
+	service card.Service
+}
+
+func NewCardHandler(service card.Service) CardHandler {
+	return &cardHandler{
+		service: service,
+	}
+}
+
+// Issue a new card to the account
+func (h *cardHandler) Issue(c *fiber.Ctx) error {
+	var input struct {
+		AccountID int `json:"-" validate:"required,min=1"`
+		presenter.CardRequest
+	}
+
+	err := c.BodyParser(&input.CardRequest)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			presenter.ErrorResponse{Title: "Unable to parse body", Detail: err.Error()},
+		)
+	}
+
+	input.AccountID, _ = c.ParamsInt("id")
+
+	errs := validator.ValidateStruct(input)
+	if errs != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(errs)
+	}
+
+	if !canAccessAccount(c, input.AccountID) {
+		return forbiddenAccount(c)
+	}
+
+	issued, err := h.service.Issue(c.UserContext(), input.AccountID, input.Type, input.Limit)
+	if errors.Is(err, entity.ErrNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(
+			presenter.ErrorResponse{Title: "Account not found", Detail: err.Error()},
+		)
+	}
+	if errors.Is(err, entity.ErrInvalidCard) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(
+			presenter.ErrorResponse{Title: "Invalid card", Detail: err.Error()},
+		)
+	}
+	if err != nil {
+		log.Error(c.UserContext(), "unable to issue card", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			presenter.ErrorResponse{Title: "Error while issuing Card"},
+		)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(presenter.NewCardResponse(issued))
+}
+
+func (h *cardHandler) Get(c *fiber.Ctx) error {
+	found, err := h.find(c)
+	if err != nil || found == nil {
+		return err
+	}
+
+	return c.JSON(presenter.NewCardResponse(found))
+}
+
+// Block the card, blocking a blocked card is a no-op
+func (h *cardHandler) Block(c *fiber.Ctx) error {
+	found, err := h.find(c)
+	if err != nil || found == nil {
+		return err
+	}
+
+	blocked, err := h.service.Block(c.UserContext(), found.ID)
+	if errors.Is(err, entity.ErrCardNotActive) {
+		return cardNotActive(c, err)
+	}
+	if err != nil {
+		log.Error(c.UserContext(), "unable to block card", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			presenter.ErrorResponse{Title: "Error while blocking Card"},
+		)
+	}
+
+	return c.JSON(presenter.NewCardResponse(blocked))
+}
+
+// Replace the card with a new one of the same type and limit
+func (h *cardHandler) Replace(c *fiber.Ctx) error {
+	found, err := h.find(c)
+	if err != nil || found == nil {
+		return err
+	}
+
+	replacement, err := h.service.Replace(c.UserContext(), found.ID)
+	if errors.Is(err, entity.ErrCardNotActive) {
+		return cardNotActive(c, err)
+	}
+	if err != nil {
+		log.Error(c.UserContext(), "unable to replace card", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			presenter.ErrorResponse{Title: "Error while replacing Card"},
+		)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(presenter.NewCardResponse(replacement))
+}
+
+// find the card of the path accessible by the client, a nil card means the response was already written
+func (h *cardHandler) find(c *fiber.Ctx) (*entity.Card, error) {
+	var input struct {
+		ID int `validate:"required,min=1"`
+	}
+
+	input.ID, _ = c.ParamsInt("id")
+
+	errs := validator.ValidateStruct(input)
+	if errs != nil {
+		return nil, c.Status(fiber.StatusUnprocessableEntity).JSON(errs)
+	}
+
+	found, err := h.service.Get(c.UserContext(), input.ID)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, c.Status(fiber.StatusNotFound).JSON(
+			presenter.ErrorResponse{Title: "Card not found", Detail: err.Error()},
+		)
+	}
+	if err != nil {
+		log.Error(c.UserContext(), "unable to find card", err)
+
+		return nil, c.Status(fiber.StatusInternalServerError).JSON(
+			presenter.ErrorResponse{Title: "Error while finding Card"},
+		)
+	}
+
+	if !canAccessAccount(c, found.AccountID) {
+		return nil, forbiddenAccount(c)
+	}
+
+	return found, nil
+}
+
+func cardNotActive(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(
+		presenter.ErrorResponse{Title: "Card not active", Detail: err.Error()},
+	)
+}
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/card"
+	"github.com/brunomdev/digital-account/domain/card/mock_card"
+	"github.com/brunomdev/digital-account/entity"
+	testHelper "github.com/brunomdev/digital-account/pkg/tests"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/steinfletcher/apitest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func newCard(status entity.CardStatus) *entity.Card {
+	return &entity.Card{
+		ID:          1,
+		AccountID:   1,
+		Type:        entity.CardTypeVirtual,
+		MaskedPAN:   "516292******1234",
+		Token:       "tok_0123456789abcdef0123456789abcdef",
+		ExpiryMonth: 3,
+		ExpiryYear:  2027,
+		Status:      status,
+		Limit:       500,
+	}
+}
+
+func Test_cardHandler_Issue(t *testing.T) {
+	testCases := []struct {
+		name       string
+		svcArgs    func(ctrl *gomock.Controller) card.Service
+		client     *entity.Client
+		reqBody    []byte
+		wantStatus int
+		wantBody   func() ([]byte, error)
+	}{
+		{
+			name: "Error validation",
+			svcArgs: func(ctrl *gomock.Controller) card.Service {
+				return mock_card.NewMockService(ctrl)
+			},
+			reqBody:    []byte(`{"type": "prepaid"}`),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal([]presenter.ErrorResponse{
+					{
+						Source: "Type",
+						Detail: "Type must be one of [virtual physical]",
+					},
+				})
+			},
+		},
+		{
+			name: "Error account of another customer",
+			svcArgs: func(ctrl *gomock.Controller) card.Service {
+				return mock_card.NewMockService(ctrl)
+			},
+			client:     &entity.Client{ID: 1, Role: entity.RoleCustomer, AccountID: 2},
+			reqBody:    []byte(`{"type": "virtual"}`),
+			wantStatus: http.StatusForbidden,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Forbidden",
+					Detail: "the account does not belong to the client",
+				})
+			},
+		},
+		{
+			name: "Error account not found",
+			svcArgs: func(ctrl *gomock.Controller) card.Service {
+				svc := mock_card.NewMockService(ctrl)
+
+				svc.EXPECT().Issue(gomock.Any(), 1, entity.CardTypeVirtual, 0.0).
+					Return(nil, errors.Wrap(entity.ErrNotFound, "account"))
+
+				return svc
+			},
+			reqBody:    []byte(`{"type": "virtual"}`),
+			wantStatus: http.StatusNotFound,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Account not found",
+					Detail: "account: not found",
+				})
+			},
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) card.Service {
+				svc := mock_card.NewMockService(ctrl)
+
+				svc.EXPECT().Issue(gomock.Any(), 1, entity.CardTypeVirtual, 500.0).
+					Return(newCard(entity.CardStatusActive), nil)
+
+				return svc
+			},
+			client:     &entity.Client{ID: 1, Role: entity.RoleCustomer, AccountID: 1},
+			reqBody:    []byte(`{"type": "virtual", "limit": 500}`),
+			wantStatus: http.StatusCreated,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.NewCardResponse(newCard(entity.CardStatusActive)))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := fiber.New()
+
+			useClient(app, tc.client)
+
+			handler := NewCardHandler(tc.svcArgs(ctrl))
+
+			app.Post("/accounts/:id/cards", handler.Issue)
+
+			wantBody, err := tc.wantBody()
+			assert.NoError(t, err)
+
+			apitest.New().
+				HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+				Post("/accounts/1/cards").
+				Body(string(tc.reqBody)).
+				Header(fiber.HeaderContentType, fiber.MIMEApplicationJSON).
+				Expect(t).
+				Status(tc.wantStatus).
+				Body(string(wantBody)).
+				End()
+		})
+	}
+}
+
+func Test_cardHandler_Block(t *testing.T) {
+	testCases := []struct {
+		name       string
+		svcArgs    func(ctrl *gomock.Controller) card.Service
+		client     *entity.Client
+		wantStatus int
+		wantBody   func() ([]byte, error)
+	}{
+		{
+			name: "Error not found",
+			svcArgs: func(ctrl *gomock.Controller) card.Service {
+				svc := mock_card.NewMockService(ctrl)
+
+				svc.EXPECT().Get(gomock.Any(), 1).Return(nil, errors.Wrap(entity.ErrNotFound, "card"))
+
+				return svc
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Card not found",
+					Detail: "card: not found",
+				})
+			},
+		},
+		{
+			name: "Error card of another customer",
+			svcArgs: func(ctrl *gomock.Controller) card.Service {
+				svc := mock_card.NewMockService(ctrl)
+
+				svc.EXPECT().Get(gomock.Any(), 1).Return(newCard(entity.CardStatusActive), nil)
+
+				return svc
+			},
+			client:     &entity.Client{ID: 1, Role: entity.RoleCustomer, AccountID: 2},
+			wantStatus: http.StatusForbidden,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Forbidden",
+					Detail: "the account does not belong to the client",
+				})
+			},
+		},
+		{
+			name: "Error replaced card",
+			svcArgs: func(ctrl *gomock.Controller) card.Service {
+				svc := mock_card.NewMockService(ctrl)
+
+				svc.EXPECT().Get(gomock.Any(), 1).Return(newCard(entity.CardStatusReplaced), nil)
+				svc.EXPECT().Block(gomock.Any(), 1).Return(nil, entity.ErrCardNotActive)
+
+				return svc
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Card not active",
+					Detail: "card is not active",
+				})
+			},
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) card.Service {
+				svc := mock_card.NewMockService(ctrl)
+
+				svc.EXPECT().Get(gomock.Any(), 1).Return(newCard(entity.CardStatusActive), nil)
+				svc.EXPECT().Block(gomock.Any(), 1).
+					DoAndReturn(func(ctx context.Context, id int) (*entity.Card, error) {
+						return newCard(entity.CardStatusBlocked), nil
+					})
+
+				return svc
+			},
+			client:     &entity.Client{ID: 1, Role: entity.RoleCustomer, AccountID: 1},
+			wantStatus: http.StatusOK,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.NewCardResponse(newCard(entity.CardStatusBlocked)))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := fiber.New()
+
+			useClient(app, tc.client)
+
+			handler := NewCardHandler(tc.svcArgs(ctrl))
+
+			app.Post("/cards/:id/block", handler.Block)
+
+			wantBody, err := tc.wantBody()
+			assert.NoError(t, err)
+
+			apitest.New().
+				HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+				Post("/cards/1/block").
+				Header(fiber.HeaderContentType, fiber.MIMEApplicationJSON).
+				Expect(t).
+				Status(tc.wantStatus).
+				Body(string(wantBody)).
+				End()
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/customer"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/infra/log"
+	validator "github.com/brunomdev/digital-account/pkg/validate"
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+)
+
+type CustomerHandler interface {
+	Create(c *fiber.Ctx) error
+	Get(c *fiber.Ctx) error
+	Update(c *fiber.Ctx) error
+	OpenAccount(c *fiber.Ctx) error
+}
+
+type customerHandler struct {
+	service customer.Service
+}
+
+func NewCustomerHandler(service customer.Service) CustomerHandler {
+	return &customerHandler{
+		service: service,
+	}
+}
+
+func (h *customerHandler) Create(c *fiber.Ctx) error {
+	var input presenter.CustomerRequest
+
+	err := c.BodyParser(&input)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			presenter.ErrorResponse{Title: "Unable to parse body", Detail: err.Error()},
+		)
+	}
+
+	errs := validator.ValidateStruct(input)
+	if errs != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(errs)
+	}
+
+	if !canAccessCustomers(c) {
+		return forbiddenCustomer(c)
+	}
+
+	created, err := h.service.Create(c.UserContext(), input.Customer(0))
+	if errors.Is(err, entity.ErrInvalidCustomer) {
+		return invalidCustomer(c)
+	}
+	if err != nil {
+		log.Error(c.UserContext(), "unable to create customer", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			presenter.ErrorResponse{Title: "Error while creating Customer"},
+		)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(presenter.NewCustomerResponse(created))
+}
+
+func (h *customerHandler) Get(c *fiber.Ctx) error {
+	var input struct {
+		ID int `validate:"required,min=1"`
+	}
+
+	input.ID, _ = c.ParamsInt("id")
+
+	errs := validator.ValidateStruct(input)
+	if errs != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(errs)
+	}
+
+	if !canAccessCustomers(c) {
+		return forbiddenCustomer(c)
+	}
+
+	found, err := h.service.Get(c.UserContext(), input.ID)
+	if errors.Is(err, entity.ErrNotFound) {
+		return customerNotFound(c, err)
+	}
+	if err != nil {
+		log.Error(c.UserContext(), "unable to find customer", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			presenter.ErrorResponse{Title: "Error while finding Customer"},
+		)
+	}
+
+	return c.JSON(presenter.NewCustomerResponse(found))
+}
+
+// Update replace the whole profile of the customer
+func (h *customerHandler) Update(c *fiber.Ctx) error {
+	var input struct {
+		ID int `json:"-" validate:"required,min=1"`
+		presenter.CustomerRequest
+	}
+
+	err := c.BodyParser(&input.CustomerRequest)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			presenter.ErrorResponse{Title: "Unable to parse body", Detail: err.Error()},
+		)
+	}
+
+	input.ID, _ = c.ParamsInt("id")
+
+	errs := validator.ValidateStruct(input)
+	if errs != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(errs)
+	}
+
+	if !canAccessCustomers(c) {
+		return forbiddenCustomer(c)
+	}
+
+	updated, err := h.service.Update(c.UserContext(), input.Customer(input.ID))
+	if errors.Is(err, entity.ErrInvalidCustomer) {
+		return invalidCustomer(c)
+	}
+	if errors.Is(err, entity.ErrNotFound) {
+		return customerNotFound(c, err)
+	}
+	if err != nil {
+		log.Error(c.UserContext(), "unable to update customer", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			presenter.ErrorResponse{Title: "Error while updating Customer"},
+		)
+	}
+
+	return c.JSON(presenter.NewCustomerResponse(updated))
+}
+
+// OpenAccount create an account held by the customer
+func (h *customerHandler) OpenAccount(c *fiber.Ctx) error {
+	var input struct {
+		CustomerID           int     `json:"-" validate:"required,min=1"`
+		DocumentNumber       string  `json:"document_number" validate:"required"`
+		AvailableCreditLimit float64 `json:"available_credit_limit" validate:"min=0"`
+	}
+
+	err := c.BodyParser(&input)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			presenter.ErrorResponse{Title: "Unable to parse body", Detail: err.Error()},
+		)
+	}
+
+	input.CustomerID, _ = c.ParamsInt("id")
+
+	errs := validator.ValidateStruct(input)
+	if errs != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(errs)
+	}
+
+	if !canAccessCustomers(c) {
+		return forbiddenCustomer(c)
+	}
+
+	acc, err := h.service.OpenAccount(c.UserContext(), input.CustomerID, input.DocumentNumber, input.AvailableCreditLimit)
+	if errors.Is(err, entity.ErrNotFound) {
+		return customerNotFound(c, err)
+	}
+	if err != nil {
+		log.Error(c.UserContext(), "unable to open customer account", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			presenter.ErrorResponse{Title: "Error while creating Account"},
+		)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(presenter.AccountResponse{
+		ID:                   acc.ID,
+		DocumentNumber:       acc.DocumentNumber,
+		AvailableCreditLimit: acc.AvailabelCreditLimit,
+		CustomerID:           acc.CustomerID,
+	})
+}
+
+func invalidCustomer(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(
+		presenter.ErrorResponse{
+			Title:  "Invalid customer",
+			Detail: "a customer is either a person with name and birth date or a company with company name",
+		},
+	)
+}
+
+func customerNotFound(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusNotFound).JSON(
+		presenter.ErrorResponse{Title: "Customer not found", Detail: err.Error()},
+	)
+}
@@ -0,0 +1,409 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/customer"
+	"github.com/brunomdev/digital-account/domain/customer/mock_customer"
+	"github.com/brunomdev/digital-account/entity"
+	testHelper "github.com/brunomdev/digital-account/pkg/tests"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/steinfletcher/apitest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func Test_customerHandler_Create(t *testing.T) {
+	testCases := []struct {
+		name       string
+		svcArgs    func(ctrl *gomock.Controller) customer.Service
+		client     *entity.Client
+		reqBody    []byte
+		wantStatus int
+		wantBody   func() ([]byte, error)
+	}{
+		{
+			name: "Error validation",
+			svcArgs: func(ctrl *gomock.Controller) customer.Service {
+				return mock_customer.NewMockService(ctrl)
+			},
+			reqBody:    []byte(`{"name": "Maria Silva"}`),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal([]presenter.ErrorResponse{
+					{
+						Source: "Email",
+						Detail: "Email is a required field",
+					},
+				})
+			},
+		},
+		{
+			name: "Error customer client",
+			svcArgs: func(ctrl *gomock.Controller) customer.Service {
+				return mock_customer.NewMockService(ctrl)
+			},
+			client:     &entity.Client{ID: 1, Role: entity.RoleCustomer, AccountID: 1},
+			reqBody:    []byte(`{"company_name": "Silva LTDA", "email": "contato@silva.com.br"}`),
+			wantStatus: http.StatusForbidden,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Forbidden",
+					Detail: "the customers are not available to the client",
+				})
+			},
+		},
+		{
+			name: "Error invalid customer",
+			svcArgs: func(ctrl *gomock.Controller) customer.Service {
+				svc := mock_customer.NewMockService(ctrl)
+
+				svc.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, entity.ErrInvalidCustomer)
+
+				return svc
+			},
+			reqBody:    []byte(`{"name": "Maria Silva", "email": "maria@example.com"}`),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Invalid customer",
+					Detail: "a customer is either a person with name and birth date or a company with company name",
+				})
+			},
+		},
+		{
+			name: "Error service",
+			svcArgs: func(ctrl *gomock.Controller) customer.Service {
+				svc := mock_customer.NewMockService(ctrl)
+
+				svc.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+
+				return svc
+			},
+			reqBody:    []byte(`{"company_name": "Silva LTDA", "email": "contato@silva.com.br"}`),
+			wantStatus: http.StatusInternalServerError,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{Title: "Error while creating Customer"})
+			},
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) customer.Service {
+				svc := mock_customer.NewMockService(ctrl)
+
+				svc.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, c *entity.Customer) (*entity.Customer, error) {
+						created := *c
+						created.ID = 1
+						return &created, nil
+					})
+
+				return svc
+			},
+			reqBody: []byte(`{"name": "Maria Silva", "birth_date": "1990-01-31", "email": "maria@example.com",
+				"address": {"city": "Sao Paulo", "country": "BR"}}`),
+			wantStatus: http.StatusCreated,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.CustomerResponse{
+					ID:        1,
+					Name:      "Maria Silva",
+					BirthDate: "1990-01-31",
+					Email:     "maria@example.com",
+					Address:   presenter.AddressPayload{City: "Sao Paulo", Country: "BR"},
+				})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := fiber.New()
+
+			useClient(app, tc.client)
+
+			handler := NewCustomerHandler(tc.svcArgs(ctrl))
+
+			app.Post("/customers", handler.Create)
+
+			wantBody, err := tc.wantBody()
+			assert.NoError(t, err)
+
+			apitest.New().
+				HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+				Post("/customers").
+				Body(string(tc.reqBody)).
+				Header(fiber.HeaderContentType, fiber.MIMEApplicationJSON).
+				Expect(t).
+				Status(tc.wantStatus).
+				Body(string(wantBody)).
+				End()
+		})
+	}
+}
+
+func Test_customerHandler_Get(t *testing.T) {
+	testCases := []struct {
+		name       string
+		svcArgs    func(ctrl *gomock.Controller) customer.Service
+		client     *entity.Client
+		wantStatus int
+		wantBody   func() ([]byte, error)
+	}{
+		{
+			name: "Error customer client",
+			svcArgs: func(ctrl *gomock.Controller) customer.Service {
+				return mock_customer.NewMockService(ctrl)
+			},
+			client:     &entity.Client{ID: 1, Role: entity.RoleCustomer, AccountID: 1},
+			wantStatus: http.StatusForbidden,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Forbidden",
+					Detail: "the customers are not available to the client",
+				})
+			},
+		},
+		{
+			name: "Error not found",
+			svcArgs: func(ctrl *gomock.Controller) customer.Service {
+				svc := mock_customer.NewMockService(ctrl)
+
+				svc.EXPECT().Get(gomock.Any(), 1).Return(nil, errors.Wrap(entity.ErrNotFound, "customer"))
+
+				return svc
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{Title: "Customer not found", Detail: "customer: not found"})
+			},
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) customer.Service {
+				svc := mock_customer.NewMockService(ctrl)
+
+				svc.EXPECT().Get(gomock.Any(), 1).
+					Return(&entity.Customer{ID: 1, CompanyName: "Silva LTDA", Email: "contato@silva.com.br"}, nil)
+
+				return svc
+			},
+			wantStatus: http.StatusOK,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.CustomerResponse{
+					ID:          1,
+					CompanyName: "Silva LTDA",
+					Email:       "contato@silva.com.br",
+				})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := fiber.New()
+
+			useClient(app, tc.client)
+
+			handler := NewCustomerHandler(tc.svcArgs(ctrl))
+
+			app.Get("/customers/:id", handler.Get)
+
+			wantBody, err := tc.wantBody()
+			assert.NoError(t, err)
+
+			apitest.New().
+				HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+				Get("/customers/1").
+				Expect(t).
+				Status(tc.wantStatus).
+				Body(string(wantBody)).
+				End()
+		})
+	}
+}
+
+func Test_customerHandler_Update(t *testing.T) {
+	testCases := []struct {
+		name       string
+		svcArgs    func(ctrl *gomock.Controller) customer.Service
+		client     *entity.Client
+		wantStatus int
+		wantBody   func() ([]byte, error)
+	}{
+		{
+			name: "Error customer client",
+			svcArgs: func(ctrl *gomock.Controller) customer.Service {
+				return mock_customer.NewMockService(ctrl)
+			},
+			client:     &entity.Client{ID: 1, Role: entity.RoleCustomer, AccountID: 1},
+			wantStatus: http.StatusForbidden,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Forbidden",
+					Detail: "the customers are not available to the client",
+				})
+			},
+		},
+		{
+			name: "Error not found",
+			svcArgs: func(ctrl *gomock.Controller) customer.Service {
+				svc := mock_customer.NewMockService(ctrl)
+
+				svc.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, errors.Wrap(entity.ErrNotFound, "customer"))
+
+				return svc
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{Title: "Customer not found", Detail: "customer: not found"})
+			},
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) customer.Service {
+				svc := mock_customer.NewMockService(ctrl)
+
+				svc.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, c *entity.Customer) (*entity.Customer, error) {
+						return c, nil
+					})
+
+				return svc
+			},
+			wantStatus: http.StatusOK,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.CustomerResponse{
+					ID:          1,
+					CompanyName: "Silva LTDA",
+					Email:       "contato@silva.com.br",
+				})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := fiber.New()
+
+			useClient(app, tc.client)
+
+			handler := NewCustomerHandler(tc.svcArgs(ctrl))
+
+			app.Put("/customers/:id", handler.Update)
+
+			wantBody, err := tc.wantBody()
+			assert.NoError(t, err)
+
+			apitest.New().
+				HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+				Put("/customers/1").
+				Body(`{"company_name": "Silva LTDA", "email": "contato@silva.com.br"}`).
+				Header(fiber.HeaderContentType, fiber.MIMEApplicationJSON).
+				Expect(t).
+				Status(tc.wantStatus).
+				Body(string(wantBody)).
+				End()
+		})
+	}
+}
+
+func Test_customerHandler_OpenAccount(t *testing.T) {
+	testCases := []struct {
+		name       string
+		svcArgs    func(ctrl *gomock.Controller) customer.Service
+		client     *entity.Client
+		wantStatus int
+		wantBody   func() ([]byte, error)
+	}{
+		{
+			name: "Error customer client",
+			svcArgs: func(ctrl *gomock.Controller) customer.Service {
+				return mock_customer.NewMockService(ctrl)
+			},
+			client:     &entity.Client{ID: 1, Role: entity.RoleCustomer, AccountID: 1},
+			wantStatus: http.StatusForbidden,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Forbidden",
+					Detail: "the customers are not available to the client",
+				})
+			},
+		},
+		{
+			name: "Error not found",
+			svcArgs: func(ctrl *gomock.Controller) customer.Service {
+				svc := mock_customer.NewMockService(ctrl)
+
+				svc.EXPECT().OpenAccount(gomock.Any(), 1, "12345678900", 100.0).
+					Return(nil, errors.Wrap(entity.ErrNotFound, "customer"))
+
+				return svc
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{Title: "Customer not found", Detail: "customer: not found"})
+			},
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) customer.Service {
+				svc := mock_customer.NewMockService(ctrl)
+
+				svc.EXPECT().OpenAccount(gomock.Any(), 1, "12345678900", 100.0).
+					Return(&entity.Account{ID: 2, DocumentNumber: "12345678900", AvailabelCreditLimit: 100, CustomerID: 1}, nil)
+
+				return svc
+			},
+			wantStatus: http.StatusCreated,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.AccountResponse{
+					ID:                   2,
+					DocumentNumber:       "12345678900",
+					AvailableCreditLimit: 100,
+					CustomerID:           1,
+				})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := fiber.New()
+
+			useClient(app, tc.client)
+
+			handler := NewCustomerHandler(tc.svcArgs(ctrl))
+
+			app.Post("/customers/:id/accounts", handler.OpenAccount)
+
+			wantBody, err := tc.wantBody()
+			assert.NoError(t, err)
+
+			apitest.New().
+				HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+				Post("/customers/1/accounts").
+				Body(`{"document_number": "12345678900", "available_credit_limit": 100}`).
+				Header(fiber.HeaderContentType, fiber.MIMEApplicationJSON).
+				Expect(t).
+				Status(tc.wantStatus).
+				Body(string(wantBody)).
+				End()
+		})
+	}
+}
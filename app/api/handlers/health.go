@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/health"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/gofiber/fiber/v2"
+)
+
+type HealthHandler interface {
+	Live(c *fiber.Ctx) error
+	Ready(c *fiber.Ctx) error
+}
+
+type healthHandler struct {
+	service health.Service
+}
+
+func NewHealthHandler(service health.Service) HealthHandler {
+	return &healthHandler{
+		service: service,
+	}
+}
+
+// Live answer while the process is able to handle requests
+func (h *healthHandler) Live(c *fiber.Ctx) error {
+	return c.JSON(presenter.HealthResponse{Status: string(entity.HealthStatusUp)})
+}
+
+// Ready answer 503 when a dependency is unavailable or the instance is shutting down
+func (h *healthHandler) Ready(c *fiber.Ctx) error {
+	ready, checks := h.service.Ready(c.UserContext())
+
+	resp := presenter.HealthResponse{
+		Status: string(entity.HealthStatusUp),
+		Checks: make([]presenter.HealthCheckResponse, 0, len(checks)),
+	}
+
+	for _, check := range checks {
+		resp.Checks = append(resp.Checks, presenter.HealthCheckResponse{
+			Name:   check.Name,
+			Status: string(check.Status),
+			Detail: check.Detail,
+		})
+	}
+
+	if !ready {
+		resp.Status = string(entity.HealthStatusDown)
+
+		return c.Status(fiber.StatusServiceUnavailable).JSON(resp)
+	}
+
+	return c.JSON(resp)
+}
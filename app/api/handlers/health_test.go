@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/health"
+	"github.com/brunomdev/digital-account/domain/health/mock_health"
+	"github.com/brunomdev/digital-account/entity"
+	testHelper "github.com/brunomdev/digital-account/pkg/tests"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/steinfletcher/apitest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func Test_healthHandler_Live(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	app := fiber.New()
+
+	handler := NewHealthHandler(mock_health.NewMockService(ctrl))
+
+	app.Get("/healthz", handler.Live)
+
+	wantBody, err := json.Marshal(presenter.HealthResponse{Status: "up"})
+	assert.NoError(t, err)
+
+	apitest.New().
+		HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+		Get("/healthz").
+		Expect(t).
+		Status(http.StatusOK).
+		Body(string(wantBody)).
+		End()
+}
+
+func Test_healthHandler_Ready(t *testing.T) {
+	testCases := []struct {
+		name       string
+		svcArgs    func(ctrl *gomock.Controller) health.Service
+		wantStatus int
+		wantBody   func() ([]byte, error)
+	}{
+		{
+			name: "Not ready",
+			svcArgs: func(ctrl *gomock.Controller) health.Service {
+				svc := mock_health.NewMockService(ctrl)
+
+				svc.EXPECT().Ready(gomock.Any()).Return(false, []*entity.HealthCheck{
+					{Name: "draining", Status: entity.HealthStatusDown, Detail: "shutting down"},
+					{Name: "database", Status: entity.HealthStatusUp},
+				})
+
+				return svc
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.HealthResponse{
+					Status: "down",
+					Checks: []presenter.HealthCheckResponse{
+						{Name: "draining", Status: "down", Detail: "shutting down"},
+						{Name: "database", Status: "up"},
+					},
+				})
+			},
+		},
+		{
+			name: "Ready",
+			svcArgs: func(ctrl *gomock.Controller) health.Service {
+				svc := mock_health.NewMockService(ctrl)
+
+				svc.EXPECT().Ready(gomock.Any()).Return(true, []*entity.HealthCheck{
+					{Name: "draining", Status: entity.HealthStatusUp},
+					{Name: "database", Status: entity.HealthStatusUp},
+				})
+
+				return svc
+			},
+			wantStatus: http.StatusOK,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.HealthResponse{
+					Status: "up",
+					Checks: []presenter.HealthCheckResponse{
+						{Name: "draining", Status: "up"},
+						{Name: "database", Status: "up"},
+					},
+				})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := fiber.New()
+
+			handler := NewHealthHandler(tc.svcArgs(ctrl))
+
+			app.Get("/readyz", handler.Ready)
+
+			wantBody, err := tc.wantBody()
+			assert.NoError(t, err)
+
+			apitest.New().
+				HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+				Get("/readyz").
+				Expect(t).
+				Status(tc.wantStatus).
+				Body(string(wantBody)).
+				End()
+		})
+	}
+}
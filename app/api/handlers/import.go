@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/app/worker"
+	"github.com/brunomdev/digital-account/domain/importer"
+	"github.com/brunomdev/digital-account/domain/job"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/infra/log"
+	validator "github.com/brunomdev/digital-account/pkg/validate"
+	"github.com/gofiber/fiber/v2"
+	"strings"
+)
+
+type ImportHandler interface {
+	Import(c *fiber.Ctx) error
+}
+
+type importHandler struct {
+	service     importer.Service
+	jobService  job.Service
+	parallelism int
+}
+
+// NewImportHandler with the parallelism used when the request doesn't inform it
+func NewImportHandler(service importer.Service, jobService job.Service, parallelism int) ImportHandler {
+	return &importHandler{
+		service:     service,
+		jobService:  jobService,
+		parallelism: parallelism,
+	}
+}
+
+// Import the transactions of the CSV or JSONL body, the format comes from the query or the Content-Type. With async
+// the rows are enqueued as a job, polled on GET /jobs/:id.
+func (h *importHandler) Import(c *fiber.Ctx) error {
+	var input struct {
+		Format      string `query:"format" validate:"required,oneof=csv jsonl"`
+		Parallelism int    `query:"parallelism" validate:"min=1,max=32"`
+		Async       bool   `query:"async"`
+	}
+
+	input.Format = importFormat(c.Get(fiber.HeaderContentType))
+	input.Parallelism = h.parallelism
+
+	err := c.QueryParser(&input)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).
+			JSON(
+				presenter.ErrorResponse{
+					Title:  "Unable to parse query",
+					Detail: err.Error(),
+				},
+			)
+	}
+
+	errs := validator.ValidateStruct(input)
+	if errs != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(errs)
+	}
+
+	rows, err := importer.Read(importer.Format(input.Format), bytes.NewReader(c.Body()))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).
+			JSON(
+				presenter.ErrorResponse{
+					Title:  "Unable to parse body",
+					Detail: err.Error(),
+				},
+			)
+	}
+
+	for _, row := range rows {
+		h.validateRow(c, row)
+	}
+
+	if input.Async {
+		return h.enqueue(c, rows, input.Parallelism)
+	}
+
+	resp := presenter.NewImportResponse(h.service.Import(c.UserContext(), rows, input.Parallelism))
+
+	if resp.Failed > 0 {
+		log.Warn(c.UserContext(), "transaction import finished with failed rows")
+	}
+
+	return c.JSON(resp)
+}
+
+func (h *importHandler) enqueue(c *fiber.Ctx, rows []*entity.ImportRow, parallelism int) error {
+	j, err := h.jobService.Enqueue(
+		c.UserContext(),
+		worker.JobTypeImport,
+		worker.ImportPayload{Rows: rows, Parallelism: parallelism},
+		currentClientID(c),
+	)
+	if err != nil {
+		log.Error(c.UserContext(), "unable to enqueue transaction import", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			presenter.ErrorResponse{Title: "Error while enqueuing the import"},
+		)
+	}
+
+	c.Location(fmt.Sprintf("/jobs/%d", j.ID))
+
+	return c.Status(fiber.StatusAccepted).JSON(presenter.NewJobResponse(j))
+}
+
+// validateRow apply the same rules of POST /transactions to the parsed rows
+func (h *importHandler) validateRow(c *fiber.Ctx, row *entity.ImportRow) {
+	if len(row.Errors) > 0 {
+		return
+	}
+
+	errs := validator.ValidateStruct(presenter.TransactionRequest{
+		AccountID:       row.AccountID,
+		OperationTypeID: row.OperationTypeID,
+		Amount:          row.Amount,
+		Currency:        row.Currency,
+		CardID:          row.CardID,
+		EventDate:       row.EventDate,
+	})
+	for _, err := range errs {
+		row.Errors = append(row.Errors, err.Detail)
+	}
+
+	if len(row.Errors) == 0 && !canAccessAccount(c, row.AccountID) {
+		row.Errors = append(row.Errors, "the account does not belong to the client")
+	}
+}
+
+func importFormat(contentType string) string {
+	switch {
+	case strings.HasPrefix(contentType, "text/csv"):
+		return string(importer.FormatCSV)
+	case strings.HasPrefix(contentType, "application/x-ndjson"),
+		strings.HasPrefix(contentType, "application/jsonl"):
+		return string(importer.FormatJSONL)
+	}
+
+	return ""
+}
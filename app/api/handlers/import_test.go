@@ -0,0 +1,299 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/app/worker"
+	"github.com/brunomdev/digital-account/domain/importer"
+	"github.com/brunomdev/digital-account/domain/importer/mock_importer"
+	"github.com/brunomdev/digital-account/domain/job"
+	"github.com/brunomdev/digital-account/domain/job/mock_job"
+	"github.com/brunomdev/digital-account/entity"
+	testHelper "github.com/brunomdev/digital-account/pkg/tests"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/steinfletcher/apitest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_importHandler_Import(t *testing.T) {
+	csvBody := "account_id,operation_type_id,amount\n1,1,-50\n2,4,100\n1,1,0\n"
+	createdAt := time.Date(2022, 3, 24, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		svcArgs     func(ctrl *gomock.Controller) importer.Service
+		jobArgs     func(ctrl *gomock.Controller) job.Service
+		client      *entity.Client
+		contentType string
+		query       map[string]string
+		body        string
+		wantStatus  int
+		wantHeaders map[string]string
+		wantBody    func() ([]byte, error)
+	}{
+		{
+			name: "Error missing format",
+			svcArgs: func(ctrl *gomock.Controller) importer.Service {
+				return mock_importer.NewMockService(ctrl)
+			},
+			contentType: "text/plain",
+			body:        csvBody,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal([]presenter.ErrorResponse{
+					{
+						Source: "Format",
+						Detail: "Format is a required field",
+					},
+				})
+			},
+		},
+		{
+			name: "Error validation parallelism",
+			svcArgs: func(ctrl *gomock.Controller) importer.Service {
+				return mock_importer.NewMockService(ctrl)
+			},
+			contentType: "text/csv",
+			query:       map[string]string{"parallelism": "64"},
+			body:        csvBody,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal([]presenter.ErrorResponse{
+					{
+						Source: "Parallelism",
+						Detail: "Parallelism must be 32 or less",
+					},
+				})
+			},
+		},
+		{
+			name: "Error malformed file",
+			svcArgs: func(ctrl *gomock.Controller) importer.Service {
+				return mock_importer.NewMockService(ctrl)
+			},
+			contentType: "text/csv",
+			body:        "account_id,amount\n1,-50\n",
+			wantStatus:  http.StatusBadRequest,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Unable to parse body",
+					Detail: "missing the operation_type_id column on the CSV header",
+				})
+			},
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) importer.Service {
+				svc := mock_importer.NewMockService(ctrl)
+				svc.EXPECT().Import(gomock.Any(), []*entity.ImportRow{
+					{Line: 2, AccountID: 1, OperationTypeID: 1, Amount: -50},
+					{
+						Line:            3,
+						AccountID:       2,
+						OperationTypeID: 4,
+						Amount:          100,
+						Errors:          []string{"the account does not belong to the client"},
+					},
+					{Line: 4, AccountID: 1, OperationTypeID: 1, Errors: []string{"Amount is a required field"}},
+				}, 2).Return([]*entity.ImportResult{
+					{Line: 2, AccountID: 1, Outcome: "approved", TransactionID: 10},
+					{
+						Line:      3,
+						AccountID: 2,
+						Outcome:   entity.ImportOutcomeInvalid,
+						Errors:    []string{"the account does not belong to the client"},
+					},
+					{Line: 4, AccountID: 1, Outcome: entity.ImportOutcomeInvalid, Errors: []string{"Amount is a required field"}},
+				})
+
+				return svc
+			},
+			client:      &entity.Client{ID: 1, Role: entity.RoleCustomer, AccountID: 1},
+			contentType: "text/csv",
+			query:       map[string]string{"parallelism": "2"},
+			body:        csvBody,
+			wantStatus:  http.StatusOK,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ImportResponse{
+					Total:    3,
+					Approved: 1,
+					Invalid:  2,
+					Results: []presenter.ImportResultResponse{
+						{Line: 2, AccountID: 1, Outcome: "approved", TransactionID: 10},
+						{
+							Line:      3,
+							AccountID: 2,
+							Outcome:   "invalid",
+							Errors:    []string{"the account does not belong to the client"},
+						},
+						{Line: 4, AccountID: 1, Outcome: "invalid", Errors: []string{"Amount is a required field"}},
+					},
+				})
+			},
+		},
+		{
+			name: "Success async",
+			svcArgs: func(ctrl *gomock.Controller) importer.Service {
+				return mock_importer.NewMockService(ctrl)
+			},
+			jobArgs: func(ctrl *gomock.Controller) job.Service {
+				svc := mock_job.NewMockService(ctrl)
+				svc.EXPECT().Enqueue(gomock.Any(), "transaction_import", gomock.Any(), 1).
+					DoAndReturn(func(_ context.Context, jobType string, payload interface{}, clientID int) (*entity.Job, error) {
+						assert.Equal(t, worker.ImportPayload{
+							Rows: []*entity.ImportRow{
+								{Line: 2, AccountID: 1, OperationTypeID: 1, Amount: -50},
+							},
+							Parallelism: 4,
+						}, payload)
+
+						return &entity.Job{
+							ID:          7,
+							Type:        jobType,
+							Status:      entity.JobStatusQueued,
+							MaxAttempts: 3,
+							ClientID:    clientID,
+							CreatedAt:   createdAt,
+						}, nil
+					})
+
+				return svc
+			},
+			client:      &entity.Client{ID: 1, Role: entity.RoleCustomer, AccountID: 1},
+			contentType: "text/csv",
+			query:       map[string]string{"async": "true"},
+			body:        "account_id,operation_type_id,amount\n1,1,-50\n",
+			wantStatus:  http.StatusAccepted,
+			wantHeaders: map[string]string{"Location": "/jobs/7"},
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.JobResponse{
+					ID:          7,
+					Type:        "transaction_import",
+					Status:      "queued",
+					MaxAttempts: 3,
+					CreatedAt:   createdAt,
+				})
+			},
+		},
+		{
+			name: "Success csv optional columns",
+			svcArgs: func(ctrl *gomock.Controller) importer.Service {
+				svc := mock_importer.NewMockService(ctrl)
+				svc.EXPECT().Import(gomock.Any(), []*entity.ImportRow{
+					{
+						Line:            2,
+						AccountID:       1,
+						OperationTypeID: 1,
+						Amount:          -10,
+						Currency:        "USD",
+						CardID:          7,
+						EventDate:       time.Date(2022, 3, 28, 12, 0, 0, 0, time.UTC),
+					},
+					{
+						Line:            3,
+						AccountID:       1,
+						OperationTypeID: 1,
+						Amount:          -10,
+						CardID:          -1,
+						Errors:          []string{"CardID must be 1 or greater"},
+					},
+				}, 4).Return([]*entity.ImportResult{
+					{Line: 2, AccountID: 1, Outcome: "approved", TransactionID: 10},
+					{
+						Line:      3,
+						AccountID: 1,
+						Outcome:   entity.ImportOutcomeInvalid,
+						Errors:    []string{"CardID must be 1 or greater"},
+					},
+				})
+
+				return svc
+			},
+			contentType: "text/csv",
+			body:        "account_id,operation_type_id,amount,currency,card_id,event_date\n1,1,-10,USD,7,2022-03-28T12:00:00Z\n1,1,-10,,-1,\n",
+			wantStatus:  http.StatusOK,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ImportResponse{
+					Total:    2,
+					Approved: 1,
+					Invalid:  1,
+					Results: []presenter.ImportResultResponse{
+						{Line: 2, AccountID: 1, Outcome: "approved", TransactionID: 10},
+						{
+							Line:      3,
+							AccountID: 1,
+							Outcome:   "invalid",
+							Errors:    []string{"CardID must be 1 or greater"},
+						},
+					},
+				})
+			},
+		},
+		{
+			name: "Success jsonl by query",
+			svcArgs: func(ctrl *gomock.Controller) importer.Service {
+				svc := mock_importer.NewMockService(ctrl)
+				svc.EXPECT().Import(gomock.Any(), []*entity.ImportRow{
+					{Line: 1, AccountID: 1, OperationTypeID: 4, Amount: 25},
+				}, 4).Return([]*entity.ImportResult{
+					{Line: 1, AccountID: 1, Outcome: "declined", Reason: "INSUFFICIENT_CREDIT_LIMIT"},
+				})
+
+				return svc
+			},
+			contentType: "application/octet-stream",
+			query:       map[string]string{"format": "jsonl"},
+			body:        `{"account_id":1,"operation_type_id":4,"amount":25}`,
+			wantStatus:  http.StatusOK,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ImportResponse{
+					Total:    1,
+					Declined: 1,
+					Results: []presenter.ImportResultResponse{
+						{Line: 1, AccountID: 1, Outcome: "declined", Reason: "INSUFFICIENT_CREDIT_LIMIT"},
+					},
+				})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := fiber.New()
+
+			useClient(app, tc.client)
+
+			jobSvc := job.Service(mock_job.NewMockService(ctrl))
+			if tc.jobArgs != nil {
+				jobSvc = tc.jobArgs(ctrl)
+			}
+
+			handler := NewImportHandler(tc.svcArgs(ctrl), jobSvc, 4)
+
+			app.Post("/transactions/import", handler.Import)
+
+			wantBody, err := tc.wantBody()
+			assert.NoError(t, err)
+
+			apitest.New().
+				HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+				Post("/transactions/import").
+				ContentType(tc.contentType).
+				QueryParams(tc.query).
+				Body(tc.body).
+				Expect(t).
+				Status(tc.wantStatus).
+				Headers(tc.wantHeaders).
+				Body(string(wantBody)).
+				End()
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/job"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/infra/log"
+	validator "github.com/brunomdev/digital-account/pkg/validate"
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+)
+
+type JobHandler interface {
+	Get(c *fiber.Ctx) error
+}
+
+type jobHandler struct {
+	service job.Service
+}
+
+func NewJobHandler(service job.Service) JobHandler {
+	return &jobHandler{
+		service: service,
+	}
+}
+
+// Get the status, progress and result of a job, a job of another customer is reported as not found
+func (h *jobHandler) Get(c *fiber.Ctx) error {
+	var input struct {
+		ID int `validate:"required,min=1"`
+	}
+
+	input.ID, _ = c.ParamsInt("id")
+
+	errs := validator.ValidateStruct(input)
+	if errs != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(errs)
+	}
+
+	j, err := h.service.GetByID(c.UserContext(), input.ID)
+	if err == nil && !canAccessJob(c, j) {
+		err = errors.Wrap(entity.ErrNotFound, "job")
+	}
+	if errors.Is(err, entity.ErrNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(
+			presenter.ErrorResponse{Title: "Job not found", Detail: err.Error()},
+		)
+	}
+	if err != nil {
+		log.Error(c.UserContext(), "unable to get job", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			presenter.ErrorResponse{Title: "Error while getting Job"},
+		)
+	}
+
+	return c.JSON(presenter.NewJobResponse(j))
+}
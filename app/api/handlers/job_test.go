@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/job"
+	"github.com/brunomdev/digital-account/domain/job/mock_job"
+	"github.com/brunomdev/digital-account/entity"
+	testHelper "github.com/brunomdev/digital-account/pkg/tests"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/steinfletcher/apitest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_jobHandler_Get(t *testing.T) {
+	createdAt := time.Date(2022, 3, 24, 10, 0, 0, 0, time.UTC)
+	succeeded := &entity.Job{
+		ID:          7,
+		Type:        "transaction_import",
+		Status:      entity.JobStatusSucceeded,
+		Progress:    100,
+		Attempts:    1,
+		MaxAttempts: 3,
+		Result:      []byte(`{"total":1}`),
+		ClientID:    1,
+		CreatedAt:   createdAt,
+	}
+
+	testCases := []struct {
+		name       string
+		svcArgs    func(ctrl *gomock.Controller) job.Service
+		client     *entity.Client
+		wantStatus int
+		wantBody   func() ([]byte, error)
+	}{
+		{
+			name: "Error not found",
+			svcArgs: func(ctrl *gomock.Controller) job.Service {
+				svc := mock_job.NewMockService(ctrl)
+				svc.EXPECT().GetByID(gomock.Any(), 7).Return(nil, errors.Wrap(entity.ErrNotFound, "job"))
+
+				return svc
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{Title: "Job not found", Detail: "job: not found"})
+			},
+		},
+		{
+			name: "Error job of another customer",
+			svcArgs: func(ctrl *gomock.Controller) job.Service {
+				svc := mock_job.NewMockService(ctrl)
+				svc.EXPECT().GetByID(gomock.Any(), 7).Return(succeeded, nil)
+
+				return svc
+			},
+			client:     &entity.Client{ID: 2, Role: entity.RoleCustomer, AccountID: 2},
+			wantStatus: http.StatusNotFound,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{Title: "Job not found", Detail: "job: not found"})
+			},
+		},
+		{
+			name: "Error database",
+			svcArgs: func(ctrl *gomock.Controller) job.Service {
+				svc := mock_job.NewMockService(ctrl)
+				svc.EXPECT().GetByID(gomock.Any(), 7).Return(nil, errors.New("database error"))
+
+				return svc
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{Title: "Error while getting Job"})
+			},
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) job.Service {
+				svc := mock_job.NewMockService(ctrl)
+				svc.EXPECT().GetByID(gomock.Any(), 7).Return(succeeded, nil)
+
+				return svc
+			},
+			client:     &entity.Client{ID: 1, Role: entity.RoleCustomer, AccountID: 1},
+			wantStatus: http.StatusOK,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(map[string]interface{}{
+					"id":           7,
+					"type":         "transaction_import",
+					"status":       "succeeded",
+					"progress":     100,
+					"attempts":     1,
+					"max_attempts": 3,
+					"result":       map[string]int{"total": 1},
+					"created_at":   createdAt,
+				})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := fiber.New()
+
+			useClient(app, tc.client)
+
+			handler := NewJobHandler(tc.svcArgs(ctrl))
+
+			app.Get("/jobs/:id", handler.Get)
+
+			wantBody, err := tc.wantBody()
+			assert.NoError(t, err)
+
+			apitest.New().
+				HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+				Get("/jobs/7").
+				Expect(t).
+				Status(tc.wantStatus).
+				Body(string(wantBody)).
+				End()
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/operationtype"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/infra/log"
+	validator "github.com/brunomdev/digital-account/pkg/validate"
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+)
+
+type OperationTypeHandler interface {
+	UpdateRules(c *fiber.Ctx) error
+}
+
+type operationTypeHandler struct {
+	service operationtype.Service
+}
+
+func NewOperationTypeHandler(service operationtype.Service) OperationTypeHandler {
+	return &operationTypeHandler{
+		service: service,
+	}
+}
+
+// UpdateRules replace the sub-limit, the fee and the daily caps of the debits of the operation type
+func (h *operationTypeHandler) UpdateRules(c *fiber.Ctx) error {
+	var input struct {
+		ID             int     `json:"-" validate:"required,min=1"`
+		LimitRate      float64 `json:"limit_rate" validate:"min=0,max=1"`
+		Fee            float64 `json:"fee" validate:"min=0"`
+		FeeRate        float64 `json:"fee_rate" validate:"min=0,max=1"`
+		DailyMaxCount  int     `json:"daily_max_count" validate:"min=0"`
+		DailyMaxAmount float64 `json:"daily_max_amount" validate:"min=0"`
+	}
+
+	err := c.BodyParser(&input)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			presenter.ErrorResponse{Title: "Unable to parse body", Detail: err.Error()},
+		)
+	}
+
+	input.ID, _ = c.ParamsInt("id")
+
+	errs := validator.ValidateStruct(input)
+	if errs != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(errs)
+	}
+
+	opType, err := h.service.UpdateRules(c.UserContext(), input.ID, entity.OperationTypeRules{
+		LimitRate:      input.LimitRate,
+		Fee:            input.Fee,
+		FeeRate:        input.FeeRate,
+		DailyMaxCount:  input.DailyMaxCount,
+		DailyMaxAmount: input.DailyMaxAmount,
+	})
+	if errors.Is(err, entity.ErrNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(
+			presenter.ErrorResponse{Title: "Operation type not found", Detail: err.Error()},
+		)
+	}
+	if errors.Is(err, entity.ErrSystemOperationType) || errors.Is(err, entity.ErrInvalidRules) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(
+			presenter.ErrorResponse{Title: "Operation type rules not allowed", Detail: err.Error()},
+		)
+	}
+	if err != nil {
+		log.Error(c.UserContext(), "unable to update operation type rules", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			presenter.ErrorResponse{Title: "Error while updating Operation type rules"},
+		)
+	}
+
+	return c.JSON(presenter.NewOperationTypeResponse(opType))
+}
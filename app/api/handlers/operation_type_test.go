@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/operationtype"
+	"github.com/brunomdev/digital-account/domain/operationtype/mock_operationtype"
+	"github.com/brunomdev/digital-account/entity"
+	testHelper "github.com/brunomdev/digital-account/pkg/tests"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/steinfletcher/apitest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func Test_operationTypeHandler_UpdateRules(t *testing.T) {
+	rules := entity.OperationTypeRules{LimitRate: 0.3, Fee: 5, FeeRate: 0.01, DailyMaxCount: 3, DailyMaxAmount: 500}
+	reqBody := []byte(`{"limit_rate": 0.3, "fee": 5, "fee_rate": 0.01, "daily_max_count": 3, "daily_max_amount": 500}`)
+
+	testCases := []struct {
+		name       string
+		svcArgs    func(ctrl *gomock.Controller) operationtype.Service
+		id         int
+		reqBody    []byte
+		wantStatus int
+		wantBody   func() ([]byte, error)
+	}{
+		{
+			name: "Error bodyParser",
+			svcArgs: func(ctrl *gomock.Controller) operationtype.Service {
+				return mock_operationtype.NewMockService(ctrl)
+			},
+			id:         3,
+			reqBody:    []byte(`{"fee": 5,}`),
+			wantStatus: http.StatusBadRequest,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Unable to parse body",
+					Detail: "invalid character '}' looking for beginning of value",
+				})
+			},
+		},
+		{
+			name: "Error validation",
+			svcArgs: func(ctrl *gomock.Controller) operationtype.Service {
+				return mock_operationtype.NewMockService(ctrl)
+			},
+			id:         3,
+			reqBody:    []byte(`{"limit_rate": 1.5}`),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal([]presenter.ErrorResponse{
+					{
+						Source: "LimitRate",
+						Detail: "LimitRate must be 1 or less",
+					},
+				})
+			},
+		},
+		{
+			name: "Error not found",
+			svcArgs: func(ctrl *gomock.Controller) operationtype.Service {
+				svc := mock_operationtype.NewMockService(ctrl)
+
+				svc.EXPECT().UpdateRules(gomock.Any(), 99, rules).Return(nil, errors.Wrap(entity.ErrNotFound, "operation type"))
+
+				return svc
+			},
+			id:         99,
+			reqBody:    reqBody,
+			wantStatus: http.StatusNotFound,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Operation type not found",
+					Detail: "operation type: not found",
+				})
+			},
+		},
+		{
+			name: "Error system operation type",
+			svcArgs: func(ctrl *gomock.Controller) operationtype.Service {
+				svc := mock_operationtype.NewMockService(ctrl)
+
+				svc.EXPECT().UpdateRules(gomock.Any(), 8, rules).Return(nil, entity.ErrSystemOperationType)
+
+				return svc
+			},
+			id:         8,
+			reqBody:    reqBody,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Operation type rules not allowed",
+					Detail: "operation type reserved to the system",
+				})
+			},
+		},
+		{
+			name: "Error service",
+			svcArgs: func(ctrl *gomock.Controller) operationtype.Service {
+				svc := mock_operationtype.NewMockService(ctrl)
+
+				svc.EXPECT().UpdateRules(gomock.Any(), 3, rules).Return(nil, errors.New("error"))
+
+				return svc
+			},
+			id:         3,
+			reqBody:    reqBody,
+			wantStatus: http.StatusInternalServerError,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{Title: "Error while updating Operation type rules"})
+			},
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) operationtype.Service {
+				svc := mock_operationtype.NewMockService(ctrl)
+
+				svc.EXPECT().UpdateRules(gomock.Any(), 3, rules).
+					Return(&entity.OperationType{ID: 3, Description: "SAQUE", Rules: rules}, nil)
+
+				return svc
+			},
+			id:         3,
+			reqBody:    reqBody,
+			wantStatus: http.StatusOK,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.OperationTypeResponse{
+					ID:          3,
+					Description: "SAQUE",
+					Rules: presenter.OperationTypeRulesResponse{
+						LimitRate:      0.3,
+						Fee:            5,
+						FeeRate:        0.01,
+						DailyMaxCount:  3,
+						DailyMaxAmount: 500,
+					},
+				})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := fiber.New()
+
+			handler := NewOperationTypeHandler(tc.svcArgs(ctrl))
+
+			app.Put("/operation-types/:id/rules", handler.UpdateRules)
+
+			wantBody, err := tc.wantBody()
+			assert.NoError(t, err)
+
+			apitest.New().
+				HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+				Putf("/operation-types/%d/rules", tc.id).
+				Body(string(tc.reqBody)).
+				Header(fiber.HeaderContentType, fiber.MIMEApplicationJSON).
+				Expect(t).
+				Status(tc.wantStatus).
+				Body(string(wantBody)).
+				End()
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/schedule"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/infra/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+)
+
+type ScheduleHandler interface {
+	List(c *fiber.Ctx) error
+	Run(c *fiber.Ctx) error
+}
+
+type scheduleHandler struct {
+	service schedule.Service
+}
+
+func NewScheduleHandler(service schedule.Service) ScheduleHandler {
+	return &scheduleHandler{
+		service: service,
+	}
+}
+
+// List the scheduled tasks with their next run and the status of their last run
+func (h *scheduleHandler) List(c *fiber.Ctx) error {
+	schedules, err := h.service.List(c.UserContext())
+	if err != nil {
+		log.Error(c.UserContext(), "unable to list schedules", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			presenter.ErrorResponse{Title: "Error while listing Schedules"},
+		)
+	}
+
+	resp := make([]presenter.ScheduleResponse, 0, len(schedules))
+	for _, s := range schedules {
+		resp = append(resp, presenter.NewScheduleResponse(s))
+	}
+
+	return c.JSON(resp)
+}
+
+// Run enqueue a run of the task now, the run is followed on the returned job
+func (h *scheduleHandler) Run(c *fiber.Ctx) error {
+	j, err := h.service.Trigger(c.UserContext(), c.Params("name"))
+	if errors.Is(err, entity.ErrNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(
+			presenter.ErrorResponse{Title: "Schedule not found", Detail: err.Error()},
+		)
+	}
+	if errors.Is(err, entity.ErrAlreadyRunning) {
+		return c.Status(fiber.StatusConflict).JSON(
+			presenter.ErrorResponse{Title: "Schedule already running", Detail: "the last run did not finish yet"},
+		)
+	}
+	if err != nil {
+		log.Error(c.UserContext(), "unable to run schedule", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			presenter.ErrorResponse{Title: "Error while running Schedule"},
+		)
+	}
+
+	c.Location(fmt.Sprintf("/jobs/%d", j.ID))
+
+	return c.Status(fiber.StatusAccepted).JSON(presenter.NewJobResponse(j))
+}
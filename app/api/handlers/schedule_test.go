@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/schedule"
+	"github.com/brunomdev/digital-account/domain/schedule/mock_schedule"
+	"github.com/brunomdev/digital-account/entity"
+	testHelper "github.com/brunomdev/digital-account/pkg/tests"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/steinfletcher/apitest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_scheduleHandler_List(t *testing.T) {
+	nextRunAt := time.Date(2022, 3, 26, 3, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2022, 3, 25, 3, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		svcArgs    func(ctrl *gomock.Controller) schedule.Service
+		wantStatus int
+		wantBody   func() ([]byte, error)
+	}{
+		{
+			name: "Error database",
+			svcArgs: func(ctrl *gomock.Controller) schedule.Service {
+				svc := mock_schedule.NewMockService(ctrl)
+				svc.EXPECT().List(gomock.Any()).Return(nil, errors.New("database error"))
+
+				return svc
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{Title: "Error while listing Schedules"})
+			},
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) schedule.Service {
+				svc := mock_schedule.NewMockService(ctrl)
+				svc.EXPECT().List(gomock.Any()).Return([]*entity.Schedule{
+					{
+						Name:      "cleanup",
+						Spec:      "0 3 * * *",
+						NextRunAt: nextRunAt,
+						LastRun: &entity.Job{
+							ID:          3,
+							Type:        "scheduled_cleanup",
+							Status:      entity.JobStatusSucceeded,
+							Progress:    100,
+							Attempts:    1,
+							MaxAttempts: 3,
+							CreatedAt:   createdAt,
+						},
+					},
+					{Name: "manual"},
+				}, nil)
+
+				return svc
+			},
+			wantStatus: http.StatusOK,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal([]map[string]interface{}{
+					{
+						"name":        "cleanup",
+						"schedule":    "0 3 * * *",
+						"next_run_at": nextRunAt,
+						"last_run": map[string]interface{}{
+							"id":           3,
+							"type":         "scheduled_cleanup",
+							"status":       "succeeded",
+							"progress":     100,
+							"attempts":     1,
+							"max_attempts": 3,
+							"created_at":   createdAt,
+						},
+					},
+					{"name": "manual"},
+				})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := fiber.New()
+
+			handler := NewScheduleHandler(tc.svcArgs(ctrl))
+
+			app.Get("/admin/schedules", handler.List)
+
+			wantBody, err := tc.wantBody()
+			assert.NoError(t, err)
+
+			apitest.New().
+				HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+				Get("/admin/schedules").
+				Expect(t).
+				Status(tc.wantStatus).
+				Body(string(wantBody)).
+				End()
+		})
+	}
+}
+
+func Test_scheduleHandler_Run(t *testing.T) {
+	createdAt := time.Date(2022, 3, 25, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		svcArgs      func(ctrl *gomock.Controller) schedule.Service
+		wantStatus   int
+		wantLocation string
+		wantBody     func() ([]byte, error)
+	}{
+		{
+			name: "Error not found",
+			svcArgs: func(ctrl *gomock.Controller) schedule.Service {
+				svc := mock_schedule.NewMockService(ctrl)
+				svc.EXPECT().Trigger(gomock.Any(), "cleanup").Return(nil, errors.Wrap(entity.ErrNotFound, "schedule"))
+
+				return svc
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{Title: "Schedule not found", Detail: "schedule: not found"})
+			},
+		},
+		{
+			name: "Error already running",
+			svcArgs: func(ctrl *gomock.Controller) schedule.Service {
+				svc := mock_schedule.NewMockService(ctrl)
+				svc.EXPECT().Trigger(gomock.Any(), "cleanup").Return(nil, entity.ErrAlreadyRunning)
+
+				return svc
+			},
+			wantStatus: http.StatusConflict,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Schedule already running",
+					Detail: "the last run did not finish yet",
+				})
+			},
+		},
+		{
+			name: "Error database",
+			svcArgs: func(ctrl *gomock.Controller) schedule.Service {
+				svc := mock_schedule.NewMockService(ctrl)
+				svc.EXPECT().Trigger(gomock.Any(), "cleanup").Return(nil, errors.New("database error"))
+
+				return svc
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{Title: "Error while running Schedule"})
+			},
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) schedule.Service {
+				svc := mock_schedule.NewMockService(ctrl)
+				svc.EXPECT().Trigger(gomock.Any(), "cleanup").Return(&entity.Job{
+					ID:          4,
+					Type:        "scheduled_cleanup",
+					Status:      entity.JobStatusQueued,
+					MaxAttempts: 3,
+					CreatedAt:   createdAt,
+				}, nil)
+
+				return svc
+			},
+			wantStatus:   http.StatusAccepted,
+			wantLocation: "/jobs/4",
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(map[string]interface{}{
+					"id":           4,
+					"type":         "scheduled_cleanup",
+					"status":       "queued",
+					"progress":     0,
+					"attempts":     0,
+					"max_attempts": 3,
+					"created_at":   createdAt,
+				})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := fiber.New()
+
+			handler := NewScheduleHandler(tc.svcArgs(ctrl))
+
+			app.Post("/admin/schedules/:name/run", handler.Run)
+
+			wantBody, err := tc.wantBody()
+			assert.NoError(t, err)
+
+			test := apitest.New().
+				HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+				Post("/admin/schedules/cleanup/run").
+				Expect(t).
+				Status(tc.wantStatus).
+				Body(string(wantBody))
+
+			if tc.wantLocation != "" {
+				test = test.Header("Location", tc.wantLocation)
+			}
+
+			test.End()
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"bufio"
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/statement"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/infra/log"
+	validator "github.com/brunomdev/digital-account/pkg/validate"
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"time"
+)
+
+const statementDateLayout = "2006-01-02"
+
+type StatementHandler interface {
+	Get(c *fiber.Ctx) error
+}
+
+type statementHandler struct {
+	service statement.Service
+}
+
+func NewStatementHandler(service statement.Service) StatementHandler {
+	return &statementHandler{
+		service: service,
+	}
+}
+
+// Get stream the statement of the account, from and to are inclusive dates in UTC, by default the current month
+func (h *statementHandler) Get(c *fiber.Ctx) error {
+	var input struct {
+		AccountID int    `query:"-" validate:"required,min=1"`
+		From      string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+		To        string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+		Format    string `query:"format" validate:"oneof=csv ofx json txt"`
+	}
+
+	input.Format = "json"
+
+	err := c.QueryParser(&input)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).
+			JSON(
+				presenter.ErrorResponse{
+					Title:  "Unable to parse query",
+					Detail: err.Error(),
+				},
+			)
+	}
+
+	input.AccountID, _ = c.ParamsInt("id")
+
+	errs := validator.ValidateStruct(input)
+	if errs != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(errs)
+	}
+
+	if !canAccessAccount(c, input.AccountID) {
+		return forbiddenAccount(c)
+	}
+
+	// the service defaults the missing dates on its clock
+	var from, to time.Time
+	if input.From != "" {
+		from, _ = time.Parse(statementDateLayout, input.From)
+	}
+	if input.To != "" {
+		to, _ = time.Parse(statementDateLayout, input.To)
+		to = to.AddDate(0, 0, 1)
+	}
+
+	st, err := h.service.Get(c.UserContext(), input.AccountID, from, to)
+	if err != nil {
+		log.Error(c.UserContext(), "unable to get statement", err)
+
+		return h.errResponse(c, err)
+	}
+
+	format := presenter.StatementFormats[input.Format]
+
+	c.Attachment(presenter.StatementFilename(st, format))
+	c.Set(fiber.HeaderContentType, format.ContentType)
+
+	// the handler returns before the body is written and c is released, so the writer only keeps the user context,
+	// its values are copied out of the fiber buffers by the middlewares (request id, actor, logger and clock offset)
+	ctx := c.UserContext()
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		writer := format.NewWriter(w)
+
+		err := writer.Header(st)
+		if err == nil {
+			err = h.service.EachLine(ctx, st, func(line *entity.StatementLine) error {
+				return writer.Line(line)
+			})
+		}
+		if err == nil {
+			err = writer.Footer(st)
+		}
+		if err == nil {
+			err = w.Flush()
+		}
+
+		if err != nil {
+			log.Error(ctx, "unable to stream statement", err)
+		}
+	})
+
+	return nil
+}
+
+func (h *statementHandler) errResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, entity.ErrNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(
+			presenter.ErrorResponse{Title: "Resource not found", Detail: err.Error()},
+		)
+	case errors.Is(err, entity.ErrInvalidPeriod):
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(
+			presenter.ErrorResponse{Title: "Invalid period", Detail: "from must not be after to"},
+		)
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(
+		presenter.ErrorResponse{Title: "Error while getting Statement"},
+	)
+}
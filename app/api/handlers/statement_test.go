@@ -0,0 +1,323 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/statement"
+	"github.com/brunomdev/digital-account/domain/statement/mock_statement"
+	"github.com/brunomdev/digital-account/entity"
+	testHelper "github.com/brunomdev/digital-account/pkg/tests"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/steinfletcher/apitest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_statementHandler_Get(t *testing.T) {
+	from := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+	eventDate := time.Date(2022, 3, 17, 17, 0, 0, 0, time.UTC)
+	adjustedAt := time.Date(2022, 3, 20, 9, 30, 0, 0, time.UTC)
+
+	st := &entity.Statement{
+		Account:                     &entity.Account{ID: 1, DocumentNumber: "12345678900"},
+		From:                        from,
+		To:                          to,
+		OpeningAvailableCreditLimit: 100,
+		ClosingAvailableCreditLimit: 200,
+		GeneratedAt:                 time.Date(2022, 4, 2, 8, 0, 0, 0, time.UTC),
+	}
+	lines := []*entity.StatementLine{
+		{
+			Transaction:          &entity.Transaction{ID: 1, AccountID: 1, OperationTypeID: 1, Amount: -50, EventDate: eventDate},
+			OperationType:        "COMPRA A VISTA",
+			Amount:               -50,
+			AvailableCreditLimit: 50,
+		},
+		{
+			Transaction:          &entity.Transaction{ID: 2, AccountID: 1, OperationTypeID: 4, Amount: 100, EventDate: eventDate},
+			OperationType:        "PAGAMENTO",
+			Amount:               100,
+			AvailableCreditLimit: 150,
+		},
+		{
+			Adjustment:           &entity.CreditLimitAdjustment{ID: 1, AccountID: 1, Amount: 50, CreatedAt: adjustedAt},
+			OperationType:        "AJUSTE DE LIMITE",
+			Amount:               50,
+			AvailableCreditLimit: 200,
+		},
+	}
+
+	streamed := func(ctrl *gomock.Controller) statement.Service {
+		svc := mock_statement.NewMockService(ctrl)
+		svc.EXPECT().Get(gomock.Any(), 1, from, to).Return(st, nil)
+		svc.EXPECT().EachLine(gomock.Any(), st, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *entity.Statement, fn func(*entity.StatementLine) error) error {
+				for _, line := range lines {
+					if err := fn(line); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+
+		return svc
+	}
+	period := map[string]string{"from": "2022-03-01", "to": "2022-03-31"}
+
+	testCases := []struct {
+		name            string
+		svcArgs         func(ctrl *gomock.Controller) statement.Service
+		client          *entity.Client
+		query           map[string]string
+		wantStatus      int
+		wantContentType string
+		wantFilename    string
+		wantBody        func() ([]byte, error)
+	}{
+		{
+			name: "Error validation",
+			svcArgs: func(ctrl *gomock.Controller) statement.Service {
+				return mock_statement.NewMockService(ctrl)
+			},
+			query:      map[string]string{"format": "pdf"},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal([]presenter.ErrorResponse{
+					{
+						Source: "Format",
+						Detail: "Format must be one of [csv ofx json txt]",
+					},
+				})
+			},
+		},
+		{
+			name: "Error account of another customer",
+			svcArgs: func(ctrl *gomock.Controller) statement.Service {
+				return mock_statement.NewMockService(ctrl)
+			},
+			client:     &entity.Client{ID: 1, Role: entity.RoleCustomer, AccountID: 2},
+			wantStatus: http.StatusForbidden,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Forbidden",
+					Detail: "the account does not belong to the client",
+				})
+			},
+		},
+		{
+			name: "Error account not found",
+			svcArgs: func(ctrl *gomock.Controller) statement.Service {
+				svc := mock_statement.NewMockService(ctrl)
+				svc.EXPECT().Get(gomock.Any(), 1, from, to).Return(nil, errors.Wrap(entity.ErrNotFound, "account"))
+
+				return svc
+			},
+			query:      period,
+			wantStatus: http.StatusNotFound,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Resource not found",
+					Detail: "account: not found",
+				})
+			},
+		},
+		{
+			name: "Error invalid period",
+			svcArgs: func(ctrl *gomock.Controller) statement.Service {
+				svc := mock_statement.NewMockService(ctrl)
+				svc.EXPECT().Get(gomock.Any(), 1, to, from.AddDate(0, 0, 1)).Return(nil, entity.ErrInvalidPeriod)
+
+				return svc
+			},
+			query:      map[string]string{"from": "2022-04-01", "to": "2022-03-01"},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Invalid period",
+					Detail: "from must not be after to",
+				})
+			},
+		},
+		{
+			name:            "Success json",
+			svcArgs:         streamed,
+			query:           period,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantFilename:    "statement-1-2022-03-01-2022-03-31.json",
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(map[string]interface{}{
+					"account_id":                     1,
+					"document_number":                "12345678900",
+					"from":                           "2022-03-01",
+					"to":                             "2022-03-31",
+					"opening_available_credit_limit": 100,
+					"closing_available_credit_limit": 200,
+					"transactions": []presenter.StatementLineResponse{
+						{
+							TransactionID:        1,
+							OperationTypeID:      1,
+							OperationType:        "COMPRA A VISTA",
+							Amount:               -50,
+							AvailableCreditLimit: 50,
+							EventDate:            eventDate,
+						},
+						{
+							TransactionID:        2,
+							OperationTypeID:      4,
+							OperationType:        "PAGAMENTO",
+							Amount:               100,
+							AvailableCreditLimit: 150,
+							EventDate:            eventDate,
+						},
+						{
+							AdjustmentID:         1,
+							OperationType:        "AJUSTE DE LIMITE",
+							Amount:               50,
+							AvailableCreditLimit: 200,
+							EventDate:            adjustedAt,
+						},
+					},
+				})
+			},
+		},
+		{
+			name:    "Success csv",
+			svcArgs: streamed,
+			query: map[string]string{
+				"from":   "2022-03-01",
+				"to":     "2022-03-31",
+				"format": "csv",
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantFilename:    "statement-1-2022-03-01-2022-03-31.csv",
+			wantBody: func() ([]byte, error) {
+				return []byte(`date,transaction_id,operation_type,amount,available_credit_limit
+2022-03-01,,OPENING AVAILABLE CREDIT LIMIT,,100.00
+2022-03-17 17:00:00,1,COMPRA A VISTA,-50.00,50.00
+2022-03-17 17:00:00,2,PAGAMENTO,100.00,150.00
+2022-03-20 09:30:00,,AJUSTE DE LIMITE,50.00,200.00
+2022-03-31,,CLOSING AVAILABLE CREDIT LIMIT,,200.00
+`), nil
+			},
+		},
+		{
+			name: "Success default period",
+			svcArgs: func(ctrl *gomock.Controller) statement.Service {
+				svc := mock_statement.NewMockService(ctrl)
+				// the service defaults the missing dates on its clock
+				svc.EXPECT().Get(gomock.Any(), 1, time.Time{}, time.Time{}).Return(st, nil)
+				svc.EXPECT().EachLine(gomock.Any(), st, gomock.Any()).Return(nil)
+
+				return svc
+			},
+			query:           map[string]string{"format": "csv"},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantFilename:    "statement-1-2022-03-01-2022-03-31.csv",
+			wantBody: func() ([]byte, error) {
+				return []byte(`date,transaction_id,operation_type,amount,available_credit_limit
+2022-03-01,,OPENING AVAILABLE CREDIT LIMIT,,100.00
+2022-03-31,,CLOSING AVAILABLE CREDIT LIMIT,,200.00
+`), nil
+			},
+		},
+		{
+			name:    "Success txt",
+			svcArgs: streamed,
+			query: map[string]string{
+				"from":   "2022-03-01",
+				"to":     "2022-03-31",
+				"format": "txt",
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/plain; charset=utf-8",
+			wantFilename:    "statement-1-2022-03-01-2022-03-31.txt",
+			wantBody: func() ([]byte, error) {
+				return []byte(`STATEMENT - ACCOUNT 1 - DOCUMENT 12345678900
+PERIOD 2022-03-01 TO 2022-03-31
+
+DATE                 DESCRIPTION                           AMOUNT     AVAILABLE
+2022-03-01           OPENING AVAILABLE CREDIT LIMIT                      100.00
+2022-03-17 17:00:00  COMPRA A VISTA                        -50.00         50.00
+2022-03-17 17:00:00  PAGAMENTO                             100.00        150.00
+2022-03-20 09:30:00  AJUSTE DE LIMITE                       50.00        200.00
+2022-03-31           CLOSING AVAILABLE CREDIT LIMIT                      200.00
+`), nil
+			},
+		},
+		{
+			name:    "Success ofx",
+			svcArgs: streamed,
+			query: map[string]string{
+				"from":   "2022-03-01",
+				"to":     "2022-03-31",
+				"format": "ofx",
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/x-ofx",
+			wantFilename:    "statement-1-2022-03-01-2022-03-31.ofx",
+			wantBody: func() ([]byte, error) {
+				return []byte(`<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS><DTSERVER>20220402080000</DTSERVER><LANGUAGE>POR</LANGUAGE></SONRS></SIGNONMSGSRSV1>
+<CREDITCARDMSGSRSV1><CCSTMTTRNRS><TRNUID>0</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<CCSTMTRS><CURDEF>BRL</CURDEF><CCACCTFROM><ACCTID>1</ACCTID></CCACCTFROM>
+<BANKTRANLIST><DTSTART>20220301000000</DTSTART><DTEND>20220401000000</DTEND>
+<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20220317170000</DTPOSTED><TRNAMT>-50.00</TRNAMT><FITID>1</FITID><NAME>COMPRA A VISTA</NAME></STMTTRN>
+<STMTTRN><TRNTYPE>CREDIT</TRNTYPE><DTPOSTED>20220317170000</DTPOSTED><TRNAMT>100.00</TRNAMT><FITID>2</FITID><NAME>PAGAMENTO</NAME></STMTTRN>
+<STMTTRN><TRNTYPE>CREDIT</TRNTYPE><DTPOSTED>20220320093000</DTPOSTED><TRNAMT>50.00</TRNAMT><FITID>A1</FITID><NAME>AJUSTE DE LIMITE</NAME></STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL><BALAMT>200.00</BALAMT><DTASOF>20220401000000</DTASOF></LEDGERBAL>
+<AVAILBAL><BALAMT>200.00</BALAMT><DTASOF>20220401000000</DTASOF></AVAILBAL>
+</CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1>
+</OFX>
+`), nil
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := fiber.New()
+
+			useClient(app, tc.client)
+
+			handler := NewStatementHandler(tc.svcArgs(ctrl))
+
+			app.Get("/accounts/:id/statement", handler.Get)
+
+			wantBody, err := tc.wantBody()
+			assert.NoError(t, err)
+
+			result := apitest.New().
+				HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+				Get("/accounts/1/statement").
+				QueryParams(tc.query).
+				Expect(t).
+				Status(tc.wantStatus).
+				Body(string(wantBody)).
+				End()
+
+			if tc.wantFilename != "" {
+				assert.Equal(t, tc.wantContentType, result.Response.Header.Get(fiber.HeaderContentType))
+				assert.Equal(
+					t,
+					`attachment; filename="`+tc.wantFilename+`"`,
+					result.Response.Header.Get(fiber.HeaderContentDisposition),
+				)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 type TransactionHandler interface {
 	Create(c *fiber.Ctx) error
+	ListAttempts(c *fiber.Ctx) error
 }
 
 type transactionHandler struct {
@@ -25,11 +26,7 @@ func NewTransactionHandler(service transaction.Service) TransactionHandler {
 }
 
 func (h *transactionHandler) Create(c *fiber.Ctx) error {
-	var input struct {
-		AccountID       int     `json:"account_id" validate:"required,min=1"`
-		OperationTypeID int     `json:"operation_type_id" validate:"required,min=1"`
-		Amount          float64 `json:"amount" validate:"required"`
-	}
+	var input presenter.TransactionRequest
 
 	err := c.BodyParser(&input)
 	if err != nil {
@@ -47,24 +44,83 @@ func (h *transactionHandler) Create(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusUnprocessableEntity).JSON(errs)
 	}
 
-	txn, err := h.service.Create(c.Context(), input.AccountID, input.OperationTypeID, input.Amount)
+	if !canAccessAccount(c, input.AccountID) {
+		return forbiddenAccount(c)
+	}
+
+	txn, err := h.service.Create(c.UserContext(), entity.TransactionInput{
+		AccountID:       input.AccountID,
+		OperationTypeID: input.OperationTypeID,
+		Amount:          input.Amount,
+		Currency:        input.Currency,
+		CardID:          input.CardID,
+		EventDate:       input.EventDate,
+	})
 	if err != nil {
-		log.Error(c.Context(), "unable to create transaction", err)
+		log.Error(c.UserContext(), "unable to create transaction", err)
 
 		errStatus, errResponse := h.formatErrResponse(err)
 
 		return c.Status(errStatus).JSON(errResponse)
 	}
 
-	resp := presenter.TransactionResponse{
-		ID:              txn.ID,
-		AccountID:       txn.AccountID,
-		OperationTypeID: txn.OperationTypeID,
-		Amount:          txn.Amount,
-		EventDate:       txn.EventDate,
+	return c.Status(fiber.StatusCreated).JSON(presenter.NewTransactionResponse(txn))
+}
+
+func (h *transactionHandler) ListAttempts(c *fiber.Ctx) error {
+	var input struct {
+		AccountID int `query:"-" validate:"required,min=1"`
+		Limit     int `query:"limit" validate:"min=1,max=500"`
+	}
+
+	input.Limit = 50
+
+	err := c.QueryParser(&input)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).
+			JSON(
+				presenter.ErrorResponse{
+					Title:  "Unable to parse query",
+					Detail: err.Error(),
+				},
+			)
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(resp)
+	input.AccountID, _ = c.ParamsInt("id")
+
+	errs := validator.ValidateStruct(input)
+	if errs != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(errs)
+	}
+
+	if !canAccessAccount(c, input.AccountID) {
+		return forbiddenAccount(c)
+	}
+
+	attempts, err := h.service.ListAttempts(c.UserContext(), input.AccountID, input.Limit)
+	if err != nil {
+		log.Error(c.UserContext(), "unable to list transaction attempts", err)
+
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			presenter.ErrorResponse{Title: "Error while listing Transaction attempts"},
+		)
+	}
+
+	resp := make([]presenter.TransactionAttemptResponse, 0, len(attempts))
+	for _, attempt := range attempts {
+		resp = append(resp, presenter.TransactionAttemptResponse{
+			ID:              attempt.ID,
+			AccountID:       attempt.AccountID,
+			OperationTypeID: attempt.OperationTypeID,
+			Amount:          attempt.Amount,
+			Outcome:         string(attempt.Outcome),
+			DeclineReason:   attempt.DeclineReason,
+			TransactionID:   attempt.TransactionID,
+			CreatedAt:       attempt.CreatedAt,
+		})
+	}
+
+	return c.JSON(resp)
 }
 
 func (h *transactionHandler) formatErrResponse(err error) (int, presenter.ErrorResponse) {
@@ -86,6 +142,46 @@ func (h *transactionHandler) formatErrResponse(err error) (int, presenter.ErrorR
 		errStatus = fiber.StatusBadRequest
 		errResponse.Title = "Insufficient Available Credit Limit"
 		errResponse.Detail = err.Error()
+	case errors.Is(err, entity.ErrTransactionDeclined):
+		errStatus = fiber.StatusUnprocessableEntity
+		errResponse.Title = "Transaction declined"
+		errResponse.Detail = err.Error()
+	case errors.Is(err, entity.ErrVelocityLimitExceeded):
+		errStatus = fiber.StatusTooManyRequests
+		errResponse.Title = "Account Velocity Limit Exceeded"
+		errResponse.Detail = err.Error()
+	case errors.Is(err, entity.ErrOperationLimitExceeded):
+		errStatus = fiber.StatusBadRequest
+		errResponse.Title = "Operation Type Limit Exceeded"
+		errResponse.Detail = err.Error()
+	case errors.Is(err, entity.ErrDailyLimitExceeded):
+		errStatus = fiber.StatusTooManyRequests
+		errResponse.Title = "Operation Type Daily Limit Exceeded"
+		errResponse.Detail = err.Error()
+	case errors.Is(err, entity.ErrSystemOperationType):
+		errStatus = fiber.StatusUnprocessableEntity
+		errResponse.Title = "Operation type not allowed"
+		errResponse.Detail = err.Error()
+	case errors.Is(err, entity.ErrCurrencyNotSupported):
+		errStatus = fiber.StatusUnprocessableEntity
+		errResponse.Title = "Currency not supported"
+		errResponse.Detail = err.Error()
+	case errors.Is(err, entity.ErrCardNotActive):
+		errStatus = fiber.StatusUnprocessableEntity
+		errResponse.Title = "Card not active"
+		errResponse.Detail = err.Error()
+	case errors.Is(err, entity.ErrCardNotAllowed):
+		errStatus = fiber.StatusUnprocessableEntity
+		errResponse.Title = "Card not allowed"
+		errResponse.Detail = err.Error()
+	case errors.Is(err, entity.ErrCardLimitExceeded):
+		errStatus = fiber.StatusBadRequest
+		errResponse.Title = "Card Limit Exceeded"
+		errResponse.Detail = err.Error()
+	case errors.Is(err, entity.ErrInvalidEventDate):
+		errStatus = fiber.StatusUnprocessableEntity
+		errResponse.Title = "Invalid event date"
+		errResponse.Detail = err.Error()
 	}
 
 	return errStatus, errResponse
@@ -22,6 +22,7 @@ func Test_transactionHandler_Create(t *testing.T) {
 	testCases := []struct {
 		name       string
 		svcArgs    func(ctrl *gomock.Controller) transaction.Service
+		client     *entity.Client
 		reqBody    []byte
 		wantStatus int
 		wantBody   func() ([]byte, error)
@@ -60,12 +61,27 @@ func Test_transactionHandler_Create(t *testing.T) {
 				})
 			},
 		},
+		{
+			name: "Error account of another customer",
+			svcArgs: func(ctrl *gomock.Controller) transaction.Service {
+				return mock_transaction.NewMockService(ctrl)
+			},
+			client:     &entity.Client{ID: 1, Role: entity.RoleCustomer, AccountID: 2},
+			reqBody:    []byte(`{"account_id": 1, "operation_type_id": 1, "amount": 123.45}`),
+			wantStatus: http.StatusForbidden,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Forbidden",
+					Detail: "the account does not belong to the client",
+				})
+			},
+		},
 		{
 			name: "Error service not found",
 			svcArgs: func(ctrl *gomock.Controller) transaction.Service {
 				svc := mock_transaction.NewMockService(ctrl)
 
-				svc.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				svc.EXPECT().Create(gomock.Any(), gomock.Any()).
 					Return(nil, errors.Wrap(entity.ErrNotFound, "account"))
 
 				return svc
@@ -84,7 +100,7 @@ func Test_transactionHandler_Create(t *testing.T) {
 			svcArgs: func(ctrl *gomock.Controller) transaction.Service {
 				svc := mock_transaction.NewMockService(ctrl)
 
-				svc.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				svc.EXPECT().Create(gomock.Any(), gomock.Any()).
 					Return(nil, entity.ErrInvalidAmount)
 
 				return svc
@@ -103,7 +119,7 @@ func Test_transactionHandler_Create(t *testing.T) {
 			svcArgs: func(ctrl *gomock.Controller) transaction.Service {
 				svc := mock_transaction.NewMockService(ctrl)
 
-				svc.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				svc.EXPECT().Create(gomock.Any(), gomock.Any()).
 					Return(nil, entity.ErrInsufficientCreditLimit)
 
 				return svc
@@ -117,12 +133,153 @@ func Test_transactionHandler_Create(t *testing.T) {
 				})
 			},
 		},
+		{
+			name: "Error service transaction declined",
+			svcArgs: func(ctrl *gomock.Controller) transaction.Service {
+				svc := mock_transaction.NewMockService(ctrl)
+
+				svc.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Return(nil, &entity.DeclineError{ReasonCodes: []string{"WITHDRAWAL_ON_NEW_ACCOUNT"}})
+
+				return svc
+			},
+			reqBody:    []byte(`{"account_id": 1, "operation_type_id": 3, "amount": 123.45}`),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Transaction declined",
+					Detail: "transaction declined: WITHDRAWAL_ON_NEW_ACCOUNT",
+				})
+			},
+		},
+		{
+			name: "Error service velocity limit exceeded",
+			svcArgs: func(ctrl *gomock.Controller) transaction.Service {
+				svc := mock_transaction.NewMockService(ctrl)
+
+				svc.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Return(nil, entity.ErrVelocityLimitExceeded)
+
+				return svc
+			},
+			reqBody:    []byte(`{"account_id": 1, "operation_type_id": 1, "amount": 123.45}`),
+			wantStatus: http.StatusTooManyRequests,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Account Velocity Limit Exceeded",
+					Detail: "account velocity limit exceeded",
+				})
+			},
+		},
+		{
+			name: "Error service operation type daily limit exceeded",
+			svcArgs: func(ctrl *gomock.Controller) transaction.Service {
+				svc := mock_transaction.NewMockService(ctrl)
+
+				svc.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Return(nil, entity.ErrDailyLimitExceeded)
+
+				return svc
+			},
+			reqBody:    []byte(`{"account_id": 1, "operation_type_id": 3, "amount": 123.45}`),
+			wantStatus: http.StatusTooManyRequests,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Operation Type Daily Limit Exceeded",
+					Detail: "operation type daily limit exceeded",
+				})
+			},
+		},
+		{
+			name: "Error service system operation type",
+			svcArgs: func(ctrl *gomock.Controller) transaction.Service {
+				svc := mock_transaction.NewMockService(ctrl)
+
+				svc.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Return(nil, entity.ErrSystemOperationType)
+
+				return svc
+			},
+			reqBody:    []byte(`{"account_id": 1, "operation_type_id": 5, "amount": 123.45}`),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Operation type not allowed",
+					Detail: "operation type reserved to the system",
+				})
+			},
+		},
+		{
+			name: "Error service currency not supported",
+			svcArgs: func(ctrl *gomock.Controller) transaction.Service {
+				svc := mock_transaction.NewMockService(ctrl)
+
+				svc.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Return(nil, errors.Wrap(entity.ErrCurrencyNotSupported, "JPY"))
+
+				return svc
+			},
+			reqBody:    []byte(`{"account_id": 1, "operation_type_id": 1, "amount": -10, "currency": "JPY"}`),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Currency not supported",
+					Detail: "JPY: currency not supported",
+				})
+			},
+		},
+		{
+			name: "Error service card limit exceeded",
+			svcArgs: func(ctrl *gomock.Controller) transaction.Service {
+				svc := mock_transaction.NewMockService(ctrl)
+
+				svc.EXPECT().Create(gomock.Any(), entity.TransactionInput{
+					AccountID:       1,
+					OperationTypeID: 1,
+					Amount:          -10,
+					CardID:          7,
+				}).Return(nil, entity.ErrCardLimitExceeded)
+
+				return svc
+			},
+			reqBody:    []byte(`{"account_id": 1, "operation_type_id": 1, "amount": -10, "card_id": 7}`),
+			wantStatus: http.StatusBadRequest,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Card Limit Exceeded",
+					Detail: "card limit exceeded",
+				})
+			},
+		},
+		{
+			name: "Error service invalid event date",
+			svcArgs: func(ctrl *gomock.Controller) transaction.Service {
+				svc := mock_transaction.NewMockService(ctrl)
+
+				svc.EXPECT().Create(gomock.Any(), entity.TransactionInput{
+					AccountID:       1,
+					OperationTypeID: 1,
+					Amount:          -10,
+					EventDate:       time.Date(2021, 1, 10, 12, 0, 0, 0, time.UTC),
+				}).Return(nil, entity.ErrInvalidEventDate)
+
+				return svc
+			},
+			reqBody:    []byte(`{"account_id": 1, "operation_type_id": 1, "amount": -10, "event_date": "2021-01-10T12:00:00Z"}`),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Invalid event date",
+					Detail: "event date out of the allowed window",
+				})
+			},
+		},
 		{
 			name: "Error service generic error",
 			svcArgs: func(ctrl *gomock.Controller) transaction.Service {
 				svc := mock_transaction.NewMockService(ctrl)
 
-				svc.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				svc.EXPECT().Create(gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("error"))
 
 				return svc
@@ -140,13 +297,13 @@ func Test_transactionHandler_Create(t *testing.T) {
 			svcArgs: func(ctrl *gomock.Controller) transaction.Service {
 				svc := mock_transaction.NewMockService(ctrl)
 
-				svc.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, accountID, operationTypeID int, amount float64) (*entity.Transaction, error) {
+				svc.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, input entity.TransactionInput) (*entity.Transaction, error) {
 						return &entity.Transaction{
 							ID:              1,
-							AccountID:       accountID,
-							OperationTypeID: operationTypeID,
-							Amount:          amount,
+							AccountID:       input.AccountID,
+							OperationTypeID: input.OperationTypeID,
+							Amount:          input.Amount,
 							EventDate:       time.Time{},
 						}, nil
 					})
@@ -165,6 +322,76 @@ func Test_transactionHandler_Create(t *testing.T) {
 				})
 			},
 		},
+		{
+			name: "Success backdated",
+			svcArgs: func(ctrl *gomock.Controller) transaction.Service {
+				svc := mock_transaction.NewMockService(ctrl)
+
+				svc.EXPECT().Create(gomock.Any(), entity.TransactionInput{
+					AccountID:       1,
+					OperationTypeID: 1,
+					Amount:          -10,
+					EventDate:       time.Date(2022, 3, 28, 9, 0, 0, 0, time.FixedZone("", -3*60*60)),
+				}).Return(&entity.Transaction{
+					ID:              1,
+					AccountID:       1,
+					OperationTypeID: 1,
+					Amount:          -10,
+					EventDate:       time.Date(2022, 3, 28, 12, 0, 0, 0, time.UTC),
+					CreatedAt:       time.Date(2022, 3, 30, 10, 0, 0, 0, time.UTC),
+				}, nil)
+
+				return svc
+			},
+			reqBody:    []byte(`{"account_id": 1, "operation_type_id": 1, "amount": -10, "event_date": "2022-03-28T09:00:00-03:00"}`),
+			wantStatus: http.StatusCreated,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.TransactionResponse{
+					ID:              1,
+					AccountID:       1,
+					OperationTypeID: 1,
+					Amount:          -10,
+					EventDate:       time.Date(2022, 3, 28, 12, 0, 0, 0, time.UTC),
+					CreatedAt:       time.Date(2022, 3, 30, 10, 0, 0, 0, time.UTC),
+				})
+			},
+		},
+		{
+			name: "Success foreign currency",
+			svcArgs: func(ctrl *gomock.Controller) transaction.Service {
+				svc := mock_transaction.NewMockService(ctrl)
+
+				svc.EXPECT().Create(gomock.Any(), entity.TransactionInput{
+					AccountID:       1,
+					OperationTypeID: 1,
+					Amount:          -10,
+					Currency:        "USD",
+				}).Return(&entity.Transaction{
+					ID:              1,
+					AccountID:       1,
+					OperationTypeID: 1,
+					Amount:          -52,
+					Exchange:        &entity.Exchange{Currency: "USD", OriginalAmount: -10, Rate: 5.2},
+				}, nil)
+
+				return svc
+			},
+			reqBody:    []byte(`{"account_id": 1, "operation_type_id": 1, "amount": -10, "currency": "USD"}`),
+			wantStatus: http.StatusCreated,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.TransactionResponse{
+					ID:              1,
+					AccountID:       1,
+					OperationTypeID: 1,
+					Amount:          -52,
+					Exchange: &presenter.TransactionExchangeResponse{
+						Currency:       "USD",
+						OriginalAmount: -10,
+						Rate:           5.2,
+					},
+				})
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -174,6 +401,8 @@ func Test_transactionHandler_Create(t *testing.T) {
 
 			app := fiber.New()
 
+			useClient(app, tc.client)
+
 			handler := NewTransactionHandler(tc.svcArgs(ctrl))
 
 			app.Post("/transactions", handler.Create)
@@ -193,3 +422,130 @@ func Test_transactionHandler_Create(t *testing.T) {
 		})
 	}
 }
+
+func Test_transactionHandler_ListAttempts(t *testing.T) {
+	createdAt := time.Date(2022, 3, 23, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		svcArgs    func(ctrl *gomock.Controller) transaction.Service
+		client     *entity.Client
+		path       string
+		query      map[string]string
+		wantStatus int
+		wantBody   func() ([]byte, error)
+	}{
+		{
+			name: "Error validation",
+			svcArgs: func(ctrl *gomock.Controller) transaction.Service {
+				return mock_transaction.NewMockService(ctrl)
+			},
+			path:       "/accounts/1/transaction-attempts",
+			query:      map[string]string{"limit": "1000"},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal([]presenter.ErrorResponse{
+					{
+						Source: "Limit",
+						Detail: "Limit must be 500 or less",
+					},
+				})
+			},
+		},
+		{
+			name: "Error account of another customer",
+			svcArgs: func(ctrl *gomock.Controller) transaction.Service {
+				return mock_transaction.NewMockService(ctrl)
+			},
+			client:     &entity.Client{ID: 1, Role: entity.RoleCustomer, AccountID: 2},
+			path:       "/accounts/1/transaction-attempts",
+			wantStatus: http.StatusForbidden,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Forbidden",
+					Detail: "the account does not belong to the client",
+				})
+			},
+		},
+		{
+			name: "Error service",
+			svcArgs: func(ctrl *gomock.Controller) transaction.Service {
+				svc := mock_transaction.NewMockService(ctrl)
+
+				svc.EXPECT().ListAttempts(gomock.Any(), 1, 50).Return(nil, errors.New("error"))
+
+				return svc
+			},
+			path:       "/accounts/1/transaction-attempts",
+			wantStatus: http.StatusInternalServerError,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{Title: "Error while listing Transaction attempts"})
+			},
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) transaction.Service {
+				svc := mock_transaction.NewMockService(ctrl)
+
+				svc.EXPECT().ListAttempts(gomock.Any(), 1, 10).
+					Return([]*entity.TransactionAttempt{
+						{
+							ID:              2,
+							AccountID:       1,
+							OperationTypeID: 1,
+							Amount:          -5000,
+							Outcome:         entity.AttemptOutcomeDeclined,
+							DeclineReason:   "INSUFFICIENT_CREDIT_LIMIT",
+							CreatedAt:       createdAt,
+						},
+					}, nil)
+
+				return svc
+			},
+			client:     &entity.Client{ID: 1, Role: entity.RoleCustomer, AccountID: 1},
+			path:       "/accounts/1/transaction-attempts",
+			query:      map[string]string{"limit": "10"},
+			wantStatus: http.StatusOK,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal([]presenter.TransactionAttemptResponse{
+					{
+						ID:              2,
+						AccountID:       1,
+						OperationTypeID: 1,
+						Amount:          -5000,
+						Outcome:         "declined",
+						DeclineReason:   "INSUFFICIENT_CREDIT_LIMIT",
+						CreatedAt:       createdAt,
+					},
+				})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := fiber.New()
+
+			useClient(app, tc.client)
+
+			handler := NewTransactionHandler(tc.svcArgs(ctrl))
+
+			app.Get("/accounts/:id/transaction-attempts", handler.ListAttempts)
+
+			wantBody, err := tc.wantBody()
+			assert.NoError(t, err)
+
+			apitest.New().
+				HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+				Get(tc.path).
+				QueryParams(tc.query).
+				Expect(t).
+				Status(tc.wantStatus).
+				Body(string(wantBody)).
+				End()
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"fmt"
+	"github.com/brunomdev/digital-account/infra/log"
+	"github.com/gofiber/fiber/v2"
+	"math/rand"
+	"time"
+)
+
+// NewAccessLog create middleware to log the requests once handled, with the route template, the status, the latency
+// and the bytes sent. Only a sampleRatio of the requests is logged, from 0 to 1, but the server errors and the requests
+// slower than slowThreshold are always logged as warnings. A zero slowThreshold disables the slow request warnings.
+func NewAccessLog(sampleRatio float64, slowThreshold time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		err := c.Next()
+
+		latency := time.Since(start)
+		status := statusCode(c, err)
+		slow := slowThreshold > 0 && latency > slowThreshold
+
+		if !slow && status < fiber.StatusInternalServerError && rand.Float64() >= sampleRatio {
+			return err
+		}
+
+		msg := fmt.Sprintf("%s %s %d", c.Method(), c.Route().Path, status)
+		event := log.Event{
+			"method":     c.Method(),
+			"route":      c.Route().Path,
+			"status":     status,
+			"latency_ms": float64(latency.Microseconds()) / 1000,
+			"bytes":      len(c.Response().Body()),
+			"ip":         c.IP(),
+			"request_id": c.GetRespHeader(fiber.HeaderXRequestID),
+			"user_agent": c.Get(fiber.HeaderUserAgent),
+		}
+
+		switch {
+		case slow:
+			log.Warn(c.UserContext(), "slow request "+msg, event)
+		case status >= fiber.StatusInternalServerError:
+			log.Warn(c.UserContext(), msg, event)
+		default:
+			log.Info(c.UserContext(), msg, event)
+		}
+
+		return err
+	}
+}
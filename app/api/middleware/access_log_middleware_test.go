@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"github.com/brunomdev/digital-account/infra/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewAccessLog(t *testing.T) {
+	testCases := []struct {
+		name          string
+		sampleRatio   float64
+		slowThreshold time.Duration
+		path          string
+		wantLevel     zapcore.Level
+		wantMessage   string
+		wantStatus    int
+	}{
+		{
+			name:        "Sampled",
+			sampleRatio: 1,
+			path:        "/accounts/1",
+			wantLevel:   zapcore.InfoLevel,
+			wantMessage: "GET /accounts/:id 200",
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "Not sampled",
+			sampleRatio: 0,
+			path:        "/accounts/1",
+		},
+		{
+			name:        "Client error not sampled",
+			sampleRatio: 0,
+			path:        "/accounts/0",
+		},
+		{
+			name:        "Server error",
+			sampleRatio: 0,
+			path:        "/accounts/500",
+			wantLevel:   zapcore.WarnLevel,
+			wantMessage: "GET /accounts/:id 500",
+			wantStatus:  http.StatusInternalServerError,
+		},
+		{
+			name:          "Slow request",
+			sampleRatio:   0,
+			slowThreshold: time.Nanosecond,
+			path:          "/accounts/1",
+			wantLevel:     zapcore.WarnLevel,
+			wantMessage:   "slow request GET /accounts/:id 200",
+			wantStatus:    http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+
+			zapLogger := log.ZapLogger
+			log.ZapLogger = zap.New(core)
+			t.Cleanup(func() {
+				log.ZapLogger = zapLogger
+			})
+
+			app := fiber.New()
+			app.Use(
+				requestid.New(requestid.Config{Generator: func() string { return "request-1" }}),
+				NewAccessLog(tc.sampleRatio, tc.slowThreshold),
+			)
+			app.Get("/accounts/:id", func(c *fiber.Ctx) error {
+				switch c.Params("id") {
+				case "0":
+					return fiber.ErrNotFound
+				case "500":
+					return fiber.ErrInternalServerError
+				}
+
+				return c.SendString("ok")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			req.Header.Set(fiber.HeaderUserAgent, "test-agent")
+
+			_, err := app.Test(req, -1)
+			require.NoError(t, err)
+
+			entries := logs.All()
+			if tc.wantMessage == "" {
+				assert.Empty(t, entries)
+				return
+			}
+
+			require.Len(t, entries, 1)
+			assert.Equal(t, tc.wantLevel, entries[0].Level)
+			assert.Equal(t, tc.wantMessage, entries[0].Message)
+
+			event, ok := entries[0].ContextMap()["event"].(log.Event)
+			require.True(t, ok)
+			assert.Equal(t, "GET", event["method"])
+			assert.Equal(t, "/accounts/:id", event["route"])
+			assert.Equal(t, tc.wantStatus, event["status"])
+			assert.Equal(t, "request-1", event["request_id"])
+			assert.Equal(t, "test-agent", event["user_agent"])
+			assert.Equal(t, "0.0.0.0", event["ip"])
+			assert.Contains(t, event, "latency_ms")
+			assert.Contains(t, event, "bytes")
+		})
+	}
+}
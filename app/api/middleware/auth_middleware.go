@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"fmt"
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/audit"
+	"github.com/brunomdev/digital-account/domain/client"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/infra/log"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/pkg/errors"
+)
+
+// HeaderAPIKey header used by the API clients to authenticate
+const HeaderAPIKey = "X-Api-Key"
+
+// anonymousClient is used when the authentication is disabled, it has access to everything, the limits included
+var anonymousClient = &entity.Client{
+	Name: "anonymous",
+	Role: entity.RoleRisk,
+	Scopes: []entity.Scope{
+		entity.ScopeAccountsRead,
+		entity.ScopeAccountsWrite,
+		entity.ScopeCustomersRead,
+		entity.ScopeCustomersWrite,
+		entity.ScopeCardsRead,
+		entity.ScopeCardsWrite,
+		entity.ScopeTransactionsWrite,
+		entity.ScopeLimitsAdmin,
+		entity.ScopeSchedulesAdmin,
+		entity.ScopeAuditRead,
+		entity.ScopeTimeTravelAdmin,
+	},
+}
+
+// NewAuth create middleware to authenticate the API client by the X-Api-Key header,
+// requests already authenticated by a parent group are not authenticated again. With timeTravel the authenticated
+// requests go through NewTimeTravel
+func NewAuth(service client.Service, enabled, timeTravel bool) fiber.Handler {
+	travel := NewTimeTravel(timeTravel)
+
+	return func(c *fiber.Ctx) error {
+		if _, ok := c.Locals(client.ClientKeyType).(*entity.Client); ok {
+			return c.Next()
+		}
+
+		if !enabled {
+			setClient(c, anonymousClient)
+
+			return travel(c)
+		}
+
+		cl, err := service.Authenticate(c.UserContext(), c.Get(HeaderAPIKey))
+		if errors.Is(err, entity.ErrUnauthorized) {
+			return c.Status(fiber.StatusUnauthorized).JSON(
+				presenter.ErrorResponse{Title: "Unauthorized", Detail: "missing or invalid API key"},
+			)
+		}
+		if err != nil {
+			log.Error(c.UserContext(), "unable to authenticate client", err)
+
+			return c.Status(fiber.StatusInternalServerError).JSON(
+				presenter.ErrorResponse{Title: "Error while authenticating client"},
+			)
+		}
+
+		setClient(c, cl)
+
+		return travel(c)
+	}
+}
+
+// setClient keep the authenticated client on the request, the changes it makes are audited under its name. The request
+// ID is copied, fiber reuses the buffer of the header once the request is done
+func setClient(c *fiber.Ctx, cl *entity.Client) {
+	c.Locals(client.ClientKeyType, cl)
+	c.SetUserContext(audit.WithActor(c.UserContext(), cl.Name, utils.CopyString(c.GetRespHeader(fiber.HeaderXRequestID))))
+}
+
+// RequireScope create middleware to allow only clients granted with all the given scopes
+func RequireScope(scopes ...entity.Scope) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		cl, ok := c.Locals(client.ClientKeyType).(*entity.Client)
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(
+				presenter.ErrorResponse{Title: "Unauthorized", Detail: "missing or invalid API key"},
+			)
+		}
+
+		for _, scope := range scopes {
+			if !cl.HasScope(scope) {
+				return c.Status(fiber.StatusForbidden).JSON(
+					presenter.ErrorResponse{Title: "Forbidden", Detail: fmt.Sprintf("missing scope %s", scope)},
+				)
+			}
+		}
+
+		return c.Next()
+	}
+}
+
+// RequireRole create middleware to allow only clients with one of the given roles
+func RequireRole(roles ...entity.Role) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		cl, ok := c.Locals(client.ClientKeyType).(*entity.Client)
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(
+				presenter.ErrorResponse{Title: "Unauthorized", Detail: "missing or invalid API key"},
+			)
+		}
+
+		for _, role := range roles {
+			if cl.Role == role {
+				return c.Next()
+			}
+		}
+
+		return c.Status(fiber.StatusForbidden).JSON(
+			presenter.ErrorResponse{Title: "Forbidden", Detail: fmt.Sprintf("role %s not allowed", cl.Role)},
+		)
+	}
+}
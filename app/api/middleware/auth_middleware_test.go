@@ -0,0 +1,282 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/audit"
+	"github.com/brunomdev/digital-account/domain/client"
+	"github.com/brunomdev/digital-account/domain/client/mock_client"
+	"github.com/brunomdev/digital-account/entity"
+	testHelper "github.com/brunomdev/digital-account/pkg/tests"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/steinfletcher/apitest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestNewAuth(t *testing.T) {
+	testCases := []struct {
+		name       string
+		svcArgs    func(ctrl *gomock.Controller) client.Service
+		enabled    bool
+		apiKey     string
+		scopes     []entity.Scope
+		wantStatus int
+		wantBody   func() ([]byte, error)
+	}{
+		{
+			name: "Disabled",
+			svcArgs: func(ctrl *gomock.Controller) client.Service {
+				return mock_client.NewMockService(ctrl)
+			},
+			enabled:    false,
+			scopes:     []entity.Scope{entity.ScopeLimitsAdmin},
+			wantStatus: http.StatusOK,
+			wantBody: func() ([]byte, error) {
+				return []byte("ok"), nil
+			},
+		},
+		{
+			name: "Error invalid key",
+			svcArgs: func(ctrl *gomock.Controller) client.Service {
+				svc := mock_client.NewMockService(ctrl)
+
+				svc.EXPECT().Authenticate(gomock.Any(), "invalid").Return(nil, entity.ErrUnauthorized)
+
+				return svc
+			},
+			enabled:    true,
+			apiKey:     "invalid",
+			wantStatus: http.StatusUnauthorized,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Unauthorized",
+					Detail: "missing or invalid API key",
+				})
+			},
+		},
+		{
+			name: "Error service",
+			svcArgs: func(ctrl *gomock.Controller) client.Service {
+				svc := mock_client.NewMockService(ctrl)
+
+				svc.EXPECT().Authenticate(gomock.Any(), "secret").Return(nil, errors.New("error"))
+
+				return svc
+			},
+			enabled:    true,
+			apiKey:     "secret",
+			wantStatus: http.StatusInternalServerError,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{Title: "Error while authenticating client"})
+			},
+		},
+		{
+			name: "Error missing scope",
+			svcArgs: func(ctrl *gomock.Controller) client.Service {
+				svc := mock_client.NewMockService(ctrl)
+
+				svc.EXPECT().Authenticate(gomock.Any(), "secret").
+					Return(&entity.Client{ID: 1, Role: entity.RoleBackOffice, Scopes: []entity.Scope{entity.ScopeAccountsRead}}, nil)
+
+				return svc
+			},
+			enabled:    true,
+			apiKey:     "secret",
+			scopes:     []entity.Scope{entity.ScopeAccountsRead, entity.ScopeLimitsAdmin},
+			wantStatus: http.StatusForbidden,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Forbidden",
+					Detail: "missing scope limits:admin",
+				})
+			},
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) client.Service {
+				svc := mock_client.NewMockService(ctrl)
+
+				svc.EXPECT().Authenticate(gomock.Any(), "secret").
+					Return(&entity.Client{ID: 1, Role: entity.RoleRisk, Scopes: []entity.Scope{entity.ScopeLimitsAdmin}}, nil)
+
+				return svc
+			},
+			enabled:    true,
+			apiKey:     "secret",
+			scopes:     []entity.Scope{entity.ScopeLimitsAdmin},
+			wantStatus: http.StatusOK,
+			wantBody: func() ([]byte, error) {
+				return []byte("ok"), nil
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			app := fiber.New()
+
+			app.Get("/resource", NewAuth(tc.svcArgs(ctrl), tc.enabled, false), RequireScope(tc.scopes...), func(c *fiber.Ctx) error {
+				return c.SendString("ok")
+			})
+
+			wantBody, err := tc.wantBody()
+			assert.NoError(t, err)
+
+			apitest.New().
+				HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+				Get("/resource").
+				Header(HeaderAPIKey, tc.apiKey).
+				Expect(t).
+				Status(tc.wantStatus).
+				Body(string(wantBody)).
+				End()
+		})
+	}
+}
+
+func TestNewAuth_actor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mock_client.NewMockService(ctrl)
+	svc.EXPECT().Authenticate(gomock.Any(), "secret").Return(&entity.Client{ID: 1, Name: "backoffice"}, nil)
+
+	app := fiber.New()
+
+	app.Use(requestid.New(requestid.Config{Generator: func() string { return "request-1" }}))
+	app.Get("/resource", NewAuth(svc, true, false), func(c *fiber.Ctx) error {
+		name, requestID := audit.ActorFrom(c.UserContext())
+
+		return c.SendString(name + " " + requestID)
+	})
+
+	apitest.New().
+		HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+		Get("/resource").
+		Header(HeaderAPIKey, "secret").
+		Expect(t).
+		Status(http.StatusOK).
+		Body("backoffice request-1").
+		End()
+}
+
+func TestNewAuth_actorOutlivesRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mock_client.NewMockService(ctrl)
+	svc.EXPECT().Authenticate(gomock.Any(), "secret").Return(&entity.Client{ID: 1, Name: "backoffice"}, nil).Times(2)
+
+	requestIDs := []string{"request-1", "request-2"}
+	generated := 0
+
+	app := fiber.New()
+
+	var ctxs []context.Context
+
+	app.Use(requestid.New(requestid.Config{Generator: func() string {
+		generated++
+
+		return requestIDs[generated-1]
+	}}))
+	app.Get("/resource", NewAuth(svc, true, false), func(c *fiber.Ctx) error {
+		ctxs = append(ctxs, c.UserContext())
+
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	for range requestIDs {
+		apitest.New().
+			HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+			Get("/resource").
+			Header(HeaderAPIKey, "secret").
+			Expect(t).
+			Status(http.StatusOK).
+			End()
+	}
+
+	for i, ctx := range ctxs {
+		_, requestID := audit.ActorFrom(ctx)
+		assert.Equal(t, requestIDs[i], requestID)
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	testCases := []struct {
+		name       string
+		client     *entity.Client
+		wantStatus int
+		wantBody   func() ([]byte, error)
+	}{
+		{
+			name:       "Error without client",
+			wantStatus: http.StatusUnauthorized,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Unauthorized",
+					Detail: "missing or invalid API key",
+				})
+			},
+		},
+		{
+			name: "Error role not allowed",
+			client: &entity.Client{
+				ID:     1,
+				Role:   entity.RoleBackOffice,
+				Scopes: []entity.Scope{entity.ScopeLimitsAdmin},
+			},
+			wantStatus: http.StatusForbidden,
+			wantBody: func() ([]byte, error) {
+				return json.Marshal(presenter.ErrorResponse{
+					Title:  "Forbidden",
+					Detail: "role backoffice not allowed",
+				})
+			},
+		},
+		{
+			name:       "Success",
+			client:     &entity.Client{ID: 1, Role: entity.RoleRisk},
+			wantStatus: http.StatusOK,
+			wantBody: func() ([]byte, error) {
+				return []byte("ok"), nil
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+
+			if tc.client != nil {
+				app.Use(func(c *fiber.Ctx) error {
+					c.Locals(client.ClientKeyType, tc.client)
+					return c.Next()
+				})
+			}
+
+			app.Get("/resource", RequireRole(entity.RoleRisk), func(c *fiber.Ctx) error {
+				return c.SendString("ok")
+			})
+
+			wantBody, err := tc.wantBody()
+			assert.NoError(t, err)
+
+			apitest.New().
+				HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+				Get("/resource").
+				Expect(t).
+				Status(tc.wantStatus).
+				Body(string(wantBody)).
+				End()
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	appConfig "github.com/brunomdev/digital-account/config"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/compress"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -8,14 +9,23 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"github.com/newrelic/go-agent/v3/newrelic"
 	"github.com/nobuyo/nrfiber"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // FiberMiddleware provide Fiber's built-in middlewares.
 // See: https://docs.gofiber.io/api/middleware
-func FiberMiddleware(a *fiber.App, newRelic *newrelic.Application, appDebug bool) {
+func FiberMiddleware(
+	a *fiber.App,
+	newRelic *newrelic.Application,
+	registerer prometheus.Registerer,
+	cfg *appConfig.Config,
+) {
 	a.Use(
 		recover.New(),
 		requestid.New(),
+		NewMetrics(registerer),
+		NewTracing(),
+		NewAccessLog(cfg.AccessLogSampleRatio, cfg.AccessLogSlowThreshold),
 		cors.New(),
 		compress.New(compress.Config{
 			Level: compress.LevelBestSpeed,
@@ -23,6 +33,7 @@ func FiberMiddleware(a *fiber.App, newRelic *newrelic.Application, appDebug bool
 		nrfiber.New(nrfiber.Config{
 			NewRelicApp: newRelic,
 		}),
-		NewLog(appDebug),
+		NewLog(cfg.LogBodyFields),
+		NewRateLimiter(cfg.RateLimitMax, cfg.RateLimitExpiration),
 	)
 }
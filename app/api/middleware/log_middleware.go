@@ -1,29 +1,84 @@
 package middleware
 
 import (
-	"fmt"
+	"encoding/json"
 	"github.com/brunomdev/digital-account/infra/log"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
-// NewLog create middleware to add requestId to log context and logging the request
-func NewLog(appDebug bool) fiber.Handler {
+// NewLog create middleware to add requestId to log context, the requests are logged by NewAccessLog. The JSON request
+// and response bodies are logged on debug level with only the bodyFields, none are logged without fields.
+func NewLog(bodyFields []string) fiber.Handler {
+	allowed := make(map[string]struct{}, len(bodyFields))
+	for _, field := range bodyFields {
+		allowed[field] = struct{}{}
+	}
+
 	return func(c *fiber.Ctx) error {
-		c.Locals(log.LoggerKeyType,
-			log.WithContext(c.Context()).With(
-				zap.String("X-Request-ID", c.GetRespHeader(fiber.HeaderXRequestID)),
-			),
+		// the logger outlives the request on the streamed responses, the header is copied out of the fiber buffer
+		logger := log.WithContext(c.Context()).With(
+			zap.String("X-Request-ID", utils.CopyString(c.GetRespHeader(fiber.HeaderXRequestID))),
 		)
 
-		if appDebug {
-			log.Info(
-				c.Context(),
-				fmt.Sprintf("%s %s %d", c.Method(), c.Path(), c.Response().StatusCode()),
-			)
+		c.Locals(log.LoggerKeyType, logger)
+		c.SetUserContext(log.NewContext(c.UserContext(), logger))
+
+		if len(allowed) == 0 {
+			return errors.Wrap(c.Next(), "NewLog")
+		}
+
+		if body, ok := filterBody(c.Body(), allowed); ok {
+			log.Debug(c.UserContext(), "request body", log.Event{"body": body})
+		}
+
+		err := c.Next()
+
+		if body, ok := filterBody(c.Response().Body(), allowed); ok {
+			log.Debug(c.UserContext(), "response body", log.Event{"body": body})
+		}
+
+		return errors.Wrap(err, "NewLog")
+	}
+}
+
+// filterBody decode the JSON body keeping only the allowed fields at any depth, false when the body is not JSON
+func filterBody(body []byte, allowed map[string]struct{}) (interface{}, bool) {
+	if len(body) == 0 {
+		return nil, false
+	}
+
+	var decoded interface{}
+
+	err := json.Unmarshal(body, &decoded)
+	if err != nil {
+		return nil, false
+	}
+
+	return filterValue(decoded, allowed), true
+}
+
+func filterValue(value interface{}, allowed map[string]struct{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		filtered := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if _, ok := allowed[key]; ok {
+				filtered[key] = filterValue(item, allowed)
+			}
+		}
+
+		return filtered
+	case []interface{}:
+		filtered := make([]interface{}, len(v))
+		for i, item := range v {
+			filtered[i] = filterValue(item, allowed)
 		}
 
-		return errors.Wrap(c.Next(), "NewLog")
+		return filtered
+	default:
+		return value
 	}
 }
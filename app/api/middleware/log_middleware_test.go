@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"fmt"
+	"github.com/brunomdev/digital-account/infra/log"
+	testHelper "github.com/brunomdev/digital-account/pkg/tests"
+	"github.com/gofiber/fiber/v2"
+	"github.com/steinfletcher/apitest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"net/http"
+	"testing"
+)
+
+func TestNewLog_bodies(t *testing.T) {
+	testCases := []struct {
+		name       string
+		bodyFields []string
+		want       map[string]string
+	}{
+		{
+			name:       "Without fields",
+			bodyFields: nil,
+			want:       map[string]string{},
+		},
+		{
+			name:       "Allowed fields",
+			bodyFields: []string{"account_id", "amount", "exchange", "currency"},
+			want: map[string]string{
+				"request body":  "map[body:map[account_id:1 amount:-50]]",
+				"response body": "map[body:map[account_id:1 amount:-50 exchange:map[currency:USD]]]",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+
+			zapLogger := log.ZapLogger
+			log.ZapLogger = zap.New(core)
+			t.Cleanup(func() {
+				log.ZapLogger = zapLogger
+			})
+
+			app := fiber.New()
+
+			app.Post("/transactions", NewLog(tc.bodyFields), func(c *fiber.Ctx) error {
+				return c.Status(fiber.StatusCreated).
+					SendString(`{"id":1,"account_id":1,"amount":-50,"exchange":{"currency":"USD","rate":5.2}}`)
+			})
+
+			apitest.New().
+				HandlerFunc(testHelper.FiberToHandlerFunc(app)).
+				Post("/transactions").
+				JSON(`{"account_id":1,"amount":-50,"document_number":"12345678900"}`).
+				Expect(t).
+				Status(http.StatusCreated).
+				End()
+
+			got := map[string]string{}
+			for _, entry := range logs.All() {
+				got[entry.Message] = fmt.Sprint(entry.ContextMap()["event"])
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+				t.Errorf("logged bodies = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"strconv"
+	"time"
+)
+
+// NewMetrics create middleware to record the rate, errors and duration of the requests by route template
+func NewMetrics(registerer prometheus.Registerer) fiber.Handler {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "digital_account",
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "digital_account",
+		Name:      "http_request_duration_seconds",
+		Help:      "Duration of the HTTP requests by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	registerer.MustRegister(requests, duration)
+
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		err := c.Next()
+
+		// the method is copied, fiber reuses its buffer once the request is done and the labels outlive it
+		labels := []string{utils.CopyString(c.Method()), c.Route().Path, strconv.Itoa(statusCode(c, err))}
+
+		requests.WithLabelValues(labels...).Inc()
+		duration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
+
+// statusCode returns the status sent to the client, including the ones from errors handled by the error handler
+func statusCode(c *fiber.Ctx, err error) int {
+	if err == nil {
+		return c.Response().StatusCode()
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+
+	return fiber.StatusInternalServerError
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewMetrics(t *testing.T) {
+	testCases := []struct {
+		name     string
+		requests []string
+		want     string
+	}{
+		{
+			name:     "Route template",
+			requests: []string{"/accounts/1", "/accounts/2"},
+			want: `
+# HELP digital_account_http_requests_total Number of HTTP requests by method, route and status.
+# TYPE digital_account_http_requests_total counter
+digital_account_http_requests_total{method="GET",route="/accounts/:id",status="200"} 2
+`,
+		},
+		{
+			name:     "Error status",
+			requests: []string{"/accounts/1", "/accounts/0"},
+			want: `
+# HELP digital_account_http_requests_total Number of HTTP requests by method, route and status.
+# TYPE digital_account_http_requests_total counter
+digital_account_http_requests_total{method="GET",route="/accounts/:id",status="200"} 1
+digital_account_http_requests_total{method="GET",route="/accounts/:id",status="404"} 1
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry := prometheus.NewRegistry()
+
+			app := fiber.New()
+			app.Use(NewMetrics(registry))
+			app.Get("/accounts/:id", func(c *fiber.Ctx) error {
+				if c.Params("id") == "0" {
+					return fiber.ErrNotFound
+				}
+
+				return c.SendString("ok")
+			})
+
+			for _, path := range tc.requests {
+				_, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil), -1)
+				assert.NoError(t, err)
+			}
+
+			err := testutil.GatherAndCompare(registry, strings.NewReader(tc.want), "digital_account_http_requests_total")
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewMetrics_methods(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	app := fiber.New()
+	app.Use(NewMetrics(registry))
+	app.Get("/accounts/:id", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+	app.Post("/transactions", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/accounts/1", nil),
+		httptest.NewRequest(http.MethodPost, "/transactions", nil),
+		httptest.NewRequest(http.MethodGet, "/accounts/2", nil),
+	} {
+		_, err := app.Test(req, -1)
+		assert.NoError(t, err)
+	}
+
+	want := `
+# HELP digital_account_http_requests_total Number of HTTP requests by method, route and status.
+# TYPE digital_account_http_requests_total counter
+digital_account_http_requests_total{method="GET",route="/accounts/:id",status="200"} 2
+digital_account_http_requests_total{method="POST",route="/transactions",status="201"} 1
+`
+
+	err := testutil.GatherAndCompare(registry, strings.NewReader(want), "digital_account_http_requests_total")
+	assert.NoError(t, err)
+}
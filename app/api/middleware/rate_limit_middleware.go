@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/client"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"strconv"
+	"time"
+)
+
+// NewRateLimiter create middleware to limit the requests of each IP to max requests by expiration, it runs before the
+// authentication so the unverified API key is not part of the key. A max lower than 1 disables the limiter
+func NewRateLimiter(max int, expiration time.Duration) fiber.Handler {
+	return newLimiter(max, expiration, func(c *fiber.Ctx) string {
+		return c.IP()
+	})
+}
+
+// NewClientRateLimiter create middleware to limit the requests of each authenticated client to max requests by
+// expiration, it must run after the authentication and falls back to the IP for the anonymous client. A max lower
+// than 1 disables the limiter
+func NewClientRateLimiter(max int, expiration time.Duration) fiber.Handler {
+	return newLimiter(max, expiration, func(c *fiber.Ctx) string {
+		cl, ok := c.Locals(client.ClientKeyType).(*entity.Client)
+		if !ok || cl.ID == 0 {
+			return "ip|" + c.IP()
+		}
+
+		return "client|" + strconv.Itoa(cl.ID)
+	})
+}
+
+func newLimiter(max int, expiration time.Duration, keyGenerator func(c *fiber.Ctx) string) fiber.Handler {
+	return limiter.New(limiter.Config{
+		Next: func(c *fiber.Ctx) bool {
+			return max < 1
+		},
+		Max:          max,
+		Expiration:   expiration,
+		KeyGenerator: keyGenerator,
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(
+				presenter.ErrorResponse{Title: "Too many requests", Detail: "rate limit exceeded, retry later"},
+			)
+		},
+	})
+}
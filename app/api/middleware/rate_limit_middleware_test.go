@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"github.com/brunomdev/digital-account/domain/client"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestNewRateLimiter(t *testing.T) {
+	testCases := []struct {
+		name       string
+		max        int
+		requests   []string
+		wantStatus []int
+	}{
+		{
+			name:       "Disabled",
+			max:        0,
+			requests:   []string{"key-a", "key-a", "key-a"},
+			wantStatus: []int{http.StatusOK, http.StatusOK, http.StatusOK},
+		},
+		{
+			name:       "Limit by IP whatever the key",
+			max:        2,
+			requests:   []string{"key-a", "key-b", "key-c"},
+			wantStatus: []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+
+			app.Get("/resource", NewRateLimiter(tc.max, time.Minute), func(c *fiber.Ctx) error {
+				return c.SendString("ok")
+			})
+
+			for i, key := range tc.requests {
+				req := httptest.NewRequest(http.MethodGet, "/resource", nil)
+				req.Header.Set(HeaderAPIKey, key)
+
+				resp, err := app.Test(req, -1)
+				assert.NoError(t, err)
+				assert.Equalf(t, tc.wantStatus[i], resp.StatusCode, "request %d", i)
+			}
+		})
+	}
+}
+
+func TestNewClientRateLimiter(t *testing.T) {
+	testCases := []struct {
+		name       string
+		max        int
+		requests   []int
+		wantStatus []int
+	}{
+		{
+			name:       "Disabled",
+			max:        0,
+			requests:   []int{1, 1, 1},
+			wantStatus: []int{http.StatusOK, http.StatusOK, http.StatusOK},
+		},
+		{
+			name:       "Limit by client",
+			max:        2,
+			requests:   []int{1, 1, 2, 1},
+			wantStatus: []int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+
+			app.Use(func(c *fiber.Ctx) error {
+				id, _ := strconv.Atoi(c.Get("X-Client-ID"))
+				c.Locals(client.ClientKeyType, &entity.Client{ID: id})
+
+				return c.Next()
+			})
+			app.Get("/resource", NewClientRateLimiter(tc.max, time.Minute), func(c *fiber.Ctx) error {
+				return c.SendString("ok")
+			})
+
+			for i, clientID := range tc.requests {
+				req := httptest.NewRequest(http.MethodGet, "/resource", nil)
+				req.Header.Set("X-Client-ID", strconv.Itoa(clientID))
+
+				resp, err := app.Test(req, -1)
+				assert.NoError(t, err)
+				assert.Equalf(t, tc.wantStatus[i], resp.StatusCode, "request %d", i)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"fmt"
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/client"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/pkg/clock"
+	"github.com/gofiber/fiber/v2"
+	"time"
+)
+
+// HeaderTimeTravel header with the RFC 3339 time a request runs at, only accepted when the time travel is enabled
+const HeaderTimeTravel = "X-Debug-Time"
+
+// NewTimeTravel create middleware to run the request at the time of the X-Debug-Time header, the clocks of the
+// services keep running from that time until the end of the request. It runs after the authentication, see NewAuth,
+// and only the clients granted the time_travel:admin scope may send the header. Disabled the header is ignored
+func NewTimeTravel(enabled bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		value := c.Get(HeaderTimeTravel)
+		if !enabled || value == "" {
+			return c.Next()
+		}
+
+		cl, ok := c.Locals(client.ClientKeyType).(*entity.Client)
+		if !ok || !cl.HasScope(entity.ScopeTimeTravelAdmin) {
+			return c.Status(fiber.StatusForbidden).JSON(
+				presenter.ErrorResponse{
+					Title:  "Forbidden",
+					Detail: fmt.Sprintf("missing scope %s", entity.ScopeTimeTravelAdmin),
+				},
+			)
+		}
+
+		at, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(
+				presenter.ErrorResponse{Title: "Invalid " + HeaderTimeTravel + " header", Detail: err.Error()},
+			)
+		}
+
+		c.SetUserContext(clock.WithOffset(c.UserContext(), time.Until(at)))
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"github.com/brunomdev/digital-account/domain/client"
+	"github.com/brunomdev/digital-account/domain/client/mock_client"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/pkg/clock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewTimeTravel(t *testing.T) {
+	admin := &entity.Client{ID: 1, Role: entity.RoleBackOffice, Scopes: []entity.Scope{entity.ScopeTimeTravelAdmin}}
+
+	testCases := []struct {
+		name       string
+		enabled    bool
+		client     *entity.Client
+		header     string
+		wantStatus int
+		wantOffset time.Duration
+	}{
+		{
+			name:       "Disabled",
+			client:     admin,
+			header:     time.Now().Add(-48 * time.Hour).Format(time.RFC3339),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Without header",
+			enabled:    true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Error without client",
+			enabled:    true,
+			header:     time.Now().Add(-48 * time.Hour).Format(time.RFC3339),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "Error missing scope",
+			enabled:    true,
+			client:     &entity.Client{ID: 2, Role: entity.RoleRisk, Scopes: []entity.Scope{entity.ScopeLimitsAdmin}},
+			header:     time.Now().Add(-48 * time.Hour).Format(time.RFC3339),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "Invalid header",
+			enabled:    true,
+			client:     admin,
+			header:     "2022-03-28",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Travel to the past",
+			enabled:    true,
+			client:     admin,
+			header:     time.Now().Add(-48 * time.Hour).Format(time.RFC3339),
+			wantStatus: http.StatusOK,
+			wantOffset: -48 * time.Hour,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var offset time.Duration
+
+			app := fiber.New()
+			if tc.client != nil {
+				app.Use(func(c *fiber.Ctx) error {
+					c.Locals(client.ClientKeyType, tc.client)
+					return c.Next()
+				})
+			}
+			app.Use(NewTimeTravel(tc.enabled))
+			app.Get("/", func(c *fiber.Ctx) error {
+				offset = clock.Offset(c.UserContext())
+
+				return c.SendStatus(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.header != "" {
+				req.Header.Set(HeaderTimeTravel, tc.header)
+			}
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, resp.StatusCode)
+			assert.InDelta(t, tc.wantOffset.Seconds(), offset.Seconds(), 2)
+		})
+	}
+}
+
+func TestNewAuth_timeTravel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mock_client.NewMockService(ctrl)
+	svc.EXPECT().Authenticate(gomock.Any(), "secret").
+		Return(&entity.Client{ID: 1, Scopes: []entity.Scope{entity.ScopeTimeTravelAdmin}}, nil)
+
+	var offset time.Duration
+
+	app := fiber.New()
+	app.Get("/", NewAuth(svc, true, true), func(c *fiber.Ctx) error {
+		offset = clock.Offset(c.UserContext())
+
+		return c.SendStatus(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(HeaderAPIKey, "secret")
+	req.Header.Set(HeaderTimeTravel, time.Now().Add(-48*time.Hour).Format(time.RFC3339))
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.InDelta(t, (-48 * time.Hour).Seconds(), offset.Seconds(), 2)
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewTracing create middleware to start a span per request, continuing the W3C traceparent sent by the caller
+func NewTracing() fiber.Handler {
+	tracer := otel.Tracer("github.com/brunomdev/digital-account/app/api")
+
+	return func(c *fiber.Ctx) error {
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), headerCarrier{header: &c.Request().Header})
+
+		// the span is exported after the request is done, when fiber already reused the buffer of the method
+		method := utils.CopyString(c.Method())
+
+		ctx, span := tracer.Start(
+			ctx,
+			method+" "+c.Path(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPMethodKey.String(method),
+				semconv.HTTPTargetKey.String(string(c.Request().RequestURI())),
+				semconv.HTTPUserAgentKey.String(string(c.Request().Header.UserAgent())),
+				semconv.HTTPClientIPKey.String(c.IP()),
+			),
+		)
+		defer span.End()
+
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		status := statusCode(c, err)
+
+		span.SetName(method + " " + c.Route().Path)
+		span.SetAttributes(
+			semconv.HTTPRouteKey.String(c.Route().Path),
+			semconv.HTTPStatusCodeKey.Int(status),
+		)
+		span.SetStatus(semconv.SpanStatusFromHTTPStatusCode(status))
+
+		if err != nil {
+			span.RecordError(err)
+		}
+
+		return err
+	}
+}
+
+// headerCarrier adapts the fasthttp request header to the OpenTelemetry propagators
+type headerCarrier struct {
+	header *fasthttp.RequestHeader
+}
+
+func (h headerCarrier) Get(key string) string {
+	return string(h.header.Peek(key))
+}
+
+func (h headerCarrier) Set(key, value string) {
+	h.header.Set(key, value)
+}
+
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, h.header.Len())
+	h.header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+
+	return keys
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewTracing(t *testing.T) {
+	testCases := []struct {
+		name        string
+		path        string
+		traceparent string
+		wantTraceID string
+		wantStatus  int
+	}{
+		{
+			name:       "New trace",
+			path:       "/accounts/1",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "Continue the caller trace",
+			path:        "/accounts/1",
+			traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:       "Error status",
+			path:       "/accounts/0",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+			otel.SetTextMapPropagator(propagation.TraceContext{})
+
+			var handlerSpan trace.SpanContext
+
+			app := fiber.New()
+			app.Use(NewTracing())
+			app.Get("/accounts/:id", func(c *fiber.Ctx) error {
+				handlerSpan = trace.SpanContextFromContext(c.UserContext())
+
+				if c.Params("id") == "0" {
+					return fiber.ErrNotFound
+				}
+
+				return c.SendString("ok")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.traceparent != "" {
+				req.Header.Set("traceparent", tc.traceparent)
+			}
+
+			_, err := app.Test(req, -1)
+			assert.NoError(t, err)
+
+			spans := recorder.Ended()
+			if !assert.Len(t, spans, 1) {
+				return
+			}
+
+			span := spans[0]
+			assert.Equal(t, "GET /accounts/:id", span.Name())
+			assert.Equal(t, span.SpanContext(), handlerSpan)
+			assert.Contains(t, span.Attributes(), semconv.HTTPStatusCodeKey.Int(tc.wantStatus))
+
+			if tc.wantTraceID != "" {
+				assert.Equal(t, tc.wantTraceID, span.SpanContext().TraceID().String())
+				assert.True(t, span.Parent().IsRemote())
+			}
+		})
+	}
+}
+
+func TestNewTracing_methods(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	app := fiber.New()
+	app.Use(NewTracing())
+	app.Get("/accounts/:id", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+	app.Post("/transactions", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/accounts/1", nil),
+		httptest.NewRequest(http.MethodPost, "/transactions", nil),
+	} {
+		_, err := app.Test(req, -1)
+		assert.NoError(t, err)
+	}
+
+	spans := recorder.Ended()
+	if !assert.Len(t, spans, 2) {
+		return
+	}
+
+	assert.Equal(t, "GET /accounts/:id", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), semconv.HTTPMethodKey.String(http.MethodGet))
+	assert.Equal(t, "POST /transactions", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), semconv.HTTPMethodKey.String(http.MethodPost))
+}
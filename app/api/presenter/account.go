@@ -4,4 +4,6 @@ type AccountResponse struct {
 	ID                   int     `json:"account_id"`
 	DocumentNumber       string  `json:"document_number"`
 	AvailableCreditLimit float64 `json:"available_credit_limit"`
+	// CustomerID is the holder of the account, absent on the accounts created without a customer
+	CustomerID int `json:"customer_id,omitempty"`
 }
@@ -0,0 +1,59 @@
+package presenter
+
+import (
+	"encoding/json"
+	"github.com/brunomdev/digital-account/entity"
+	"time"
+)
+
+type AuditEntryResponse struct {
+	ID        int             `json:"id"`
+	Actor     string          `json:"actor"`
+	RequestID string          `json:"request_id,omitempty"`
+	Action    string          `json:"action"`
+	Entity    string          `json:"entity"`
+	EntityID  int             `json:"entity_id"`
+	Before    json.RawMessage `json:"before,omitempty"`
+	After     json.RawMessage `json:"after,omitempty"`
+	PrevHash  string          `json:"prev_hash"`
+	Hash      string          `json:"hash"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func NewAuditEntryResponse(e *entity.AuditEntry) AuditEntryResponse {
+	resp := AuditEntryResponse{
+		ID:        e.ID,
+		Actor:     e.Actor,
+		RequestID: e.RequestID,
+		Action:    string(e.Action),
+		Entity:    string(e.Entity),
+		EntityID:  e.EntityID,
+		PrevHash:  e.PrevHash,
+		Hash:      e.Hash,
+		CreatedAt: e.CreatedAt,
+	}
+
+	if e.Before != "" {
+		resp.Before = json.RawMessage(e.Before)
+	}
+
+	if e.After != "" {
+		resp.After = json.RawMessage(e.After)
+	}
+
+	return resp
+}
+
+type AuditVerificationResponse struct {
+	Valid         bool `json:"valid"`
+	Entries       int  `json:"entries"`
+	BrokenEntryID int  `json:"broken_entry_id,omitempty"`
+}
+
+func NewAuditVerificationResponse(v *entity.AuditVerification) AuditVerificationResponse {
+	return AuditVerificationResponse{
+		Valid:         v.Valid(),
+		Entries:       v.Entries,
+		BrokenEntryID: v.BrokenEntryID,
+	}
+}
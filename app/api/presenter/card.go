@@ -0,0 +1,43 @@
+package presenter
+
+import (
+	"github.com/brunomdev/digital-account/entity"
+	"time"
+)
+
+// CardRequest is the body of POST /accounts/:id/cards, a zero limit issue the card without a monthly limit
+type CardRequest struct {
+	Type  entity.CardType `json:"type" validate:"required,oneof=virtual physical"`
+	Limit float64         `json:"limit" validate:"min=0"`
+}
+
+type CardResponse struct {
+	ID          int               `json:"card_id"`
+	AccountID   int               `json:"account_id"`
+	Type        entity.CardType   `json:"type"`
+	MaskedPAN   string            `json:"masked_pan"`
+	Token       string            `json:"token"`
+	ExpiryMonth int               `json:"expiry_month"`
+	ExpiryYear  int               `json:"expiry_year"`
+	Status      entity.CardStatus `json:"status"`
+	Limit       float64           `json:"limit"`
+	// ReplacesID is the card replaced by this one, absent on the cards issued directly
+	ReplacesID int       `json:"replaces_card_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func NewCardResponse(c *entity.Card) CardResponse {
+	return CardResponse{
+		ID:          c.ID,
+		AccountID:   c.AccountID,
+		Type:        c.Type,
+		MaskedPAN:   c.MaskedPAN,
+		Token:       c.Token,
+		ExpiryMonth: c.ExpiryMonth,
+		ExpiryYear:  c.ExpiryYear,
+		Status:      c.Status,
+		Limit:       c.Limit,
+		ReplacesID:  c.ReplacesID,
+		CreatedAt:   c.CreatedAt,
+	}
+}
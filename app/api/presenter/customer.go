@@ -0,0 +1,82 @@
+package presenter
+
+import (
+	"github.com/brunomdev/digital-account/entity"
+	"time"
+)
+
+// CustomerRequest is the body of POST /customers and PUT /customers/:id, a person has name and birth date and a
+// company has the company name
+type CustomerRequest struct {
+	Name        string         `json:"name" validate:"max=255"`
+	BirthDate   string         `json:"birth_date" validate:"omitempty,datetime=2006-01-02"`
+	CompanyName string         `json:"company_name" validate:"max=255"`
+	Email       string         `json:"email" validate:"required,email,max=255"`
+	Phone       string         `json:"phone" validate:"max=32"`
+	Address     AddressPayload `json:"address"`
+}
+
+type AddressPayload struct {
+	Street     string `json:"street" validate:"max=255"`
+	Number     string `json:"number" validate:"max=32"`
+	Complement string `json:"complement" validate:"max=255"`
+	City       string `json:"city" validate:"max=255"`
+	State      string `json:"state" validate:"max=255"`
+	PostalCode string `json:"postal_code" validate:"max=32"`
+	Country    string `json:"country" validate:"omitempty,iso3166_1_alpha2"`
+}
+
+type CustomerResponse struct {
+	ID          int            `json:"customer_id"`
+	Name        string         `json:"name,omitempty"`
+	BirthDate   string         `json:"birth_date,omitempty"`
+	CompanyName string         `json:"company_name,omitempty"`
+	Email       string         `json:"email"`
+	Phone       string         `json:"phone,omitempty"`
+	Address     AddressPayload `json:"address"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// Customer convert the request to the entity with the given id, zero on the creation
+func (r CustomerRequest) Customer(id int) *entity.Customer {
+	return &entity.Customer{
+		ID:          id,
+		Name:        r.Name,
+		BirthDate:   r.BirthDate,
+		CompanyName: r.CompanyName,
+		Email:       r.Email,
+		Phone:       r.Phone,
+		Address: entity.Address{
+			Street:     r.Address.Street,
+			Number:     r.Address.Number,
+			Complement: r.Address.Complement,
+			City:       r.Address.City,
+			State:      r.Address.State,
+			PostalCode: r.Address.PostalCode,
+			Country:    r.Address.Country,
+		},
+	}
+}
+
+func NewCustomerResponse(c *entity.Customer) CustomerResponse {
+	return CustomerResponse{
+		ID:          c.ID,
+		Name:        c.Name,
+		BirthDate:   c.BirthDate,
+		CompanyName: c.CompanyName,
+		Email:       c.Email,
+		Phone:       c.Phone,
+		Address: AddressPayload{
+			Street:     c.Address.Street,
+			Number:     c.Address.Number,
+			Complement: c.Address.Complement,
+			City:       c.Address.City,
+			State:      c.Address.State,
+			PostalCode: c.Address.PostalCode,
+			Country:    c.Address.Country,
+		},
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
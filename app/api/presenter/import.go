@@ -0,0 +1,53 @@
+package presenter
+
+import "github.com/brunomdev/digital-account/entity"
+
+type ImportResponse struct {
+	Total    int                    `json:"total"`
+	Approved int                    `json:"approved"`
+	Declined int                    `json:"declined"`
+	Failed   int                    `json:"failed"`
+	Invalid  int                    `json:"invalid"`
+	Results  []ImportResultResponse `json:"results"`
+}
+
+type ImportResultResponse struct {
+	Line          int      `json:"line"`
+	AccountID     int      `json:"account_id"`
+	Outcome       string   `json:"outcome"`
+	TransactionID int      `json:"transaction_id,omitempty"`
+	Reason        string   `json:"reason,omitempty"`
+	Errors        []string `json:"errors,omitempty"`
+}
+
+// NewImportResponse count the outcomes of the import results
+func NewImportResponse(results []*entity.ImportResult) ImportResponse {
+	resp := ImportResponse{
+		Total:   len(results),
+		Results: make([]ImportResultResponse, 0, len(results)),
+	}
+
+	for _, result := range results {
+		switch result.Outcome {
+		case entity.ImportOutcome(entity.AttemptOutcomeApproved):
+			resp.Approved++
+		case entity.ImportOutcome(entity.AttemptOutcomeDeclined):
+			resp.Declined++
+		case entity.ImportOutcome(entity.AttemptOutcomeFailed):
+			resp.Failed++
+		case entity.ImportOutcomeInvalid:
+			resp.Invalid++
+		}
+
+		resp.Results = append(resp.Results, ImportResultResponse{
+			Line:          result.Line,
+			AccountID:     result.AccountID,
+			Outcome:       string(result.Outcome),
+			TransactionID: result.TransactionID,
+			Reason:        result.Reason,
+			Errors:        result.Errors,
+		})
+	}
+
+	return resp
+}
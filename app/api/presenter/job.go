@@ -0,0 +1,38 @@
+package presenter
+
+import (
+	"encoding/json"
+	"github.com/brunomdev/digital-account/entity"
+	"time"
+)
+
+type JobResponse struct {
+	ID          int             `json:"id"`
+	Type        string          `json:"type"`
+	Status      string          `json:"status"`
+	Progress    int             `json:"progress"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	Result      json.RawMessage `json:"result,omitempty"`
+	Error       string          `json:"error,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+func NewJobResponse(j *entity.Job) JobResponse {
+	resp := JobResponse{
+		ID:          j.ID,
+		Type:        j.Type,
+		Status:      string(j.Status),
+		Progress:    j.Progress,
+		Attempts:    j.Attempts,
+		MaxAttempts: j.MaxAttempts,
+		Error:       j.Error,
+		CreatedAt:   j.CreatedAt,
+	}
+
+	if len(j.Result) > 0 {
+		resp.Result = j.Result
+	}
+
+	return resp
+}
@@ -0,0 +1,31 @@
+package presenter
+
+import "github.com/brunomdev/digital-account/entity"
+
+type OperationTypeResponse struct {
+	ID          int                        `json:"operation_type_id"`
+	Description string                     `json:"description"`
+	Rules       OperationTypeRulesResponse `json:"rules"`
+}
+
+type OperationTypeRulesResponse struct {
+	LimitRate      float64 `json:"limit_rate"`
+	Fee            float64 `json:"fee"`
+	FeeRate        float64 `json:"fee_rate"`
+	DailyMaxCount  int     `json:"daily_max_count"`
+	DailyMaxAmount float64 `json:"daily_max_amount"`
+}
+
+func NewOperationTypeResponse(opType *entity.OperationType) OperationTypeResponse {
+	return OperationTypeResponse{
+		ID:          opType.ID,
+		Description: opType.Description,
+		Rules: OperationTypeRulesResponse{
+			LimitRate:      opType.Rules.LimitRate,
+			Fee:            opType.Rules.Fee,
+			FeeRate:        opType.Rules.FeeRate,
+			DailyMaxCount:  opType.Rules.DailyMaxCount,
+			DailyMaxAmount: opType.Rules.DailyMaxAmount,
+		},
+	}
+}
@@ -0,0 +1,127 @@
+package presenter
+
+import (
+	"github.com/brunomdev/digital-account/entity"
+	"time"
+)
+
+type ScheduleResponse struct {
+	Name      string       `json:"name"`
+	Schedule  string       `json:"schedule,omitempty"`
+	NextRunAt *time.Time   `json:"next_run_at,omitempty"`
+	LastRun   *JobResponse `json:"last_run,omitempty"`
+}
+
+func NewScheduleResponse(s *entity.Schedule) ScheduleResponse {
+	resp := ScheduleResponse{
+		Name:     s.Name,
+		Schedule: s.Spec,
+	}
+
+	if !s.NextRunAt.IsZero() {
+		resp.NextRunAt = &s.NextRunAt
+	}
+
+	if s.LastRun != nil {
+		lastRun := NewJobResponse(s.LastRun)
+		resp.LastRun = &lastRun
+	}
+
+	return resp
+}
+
+// CleanupResponse is the result of the cleanup task
+type CleanupResponse struct {
+	Before   time.Time `json:"before"`
+	Attempts int       `json:"attempts"`
+	Jobs     int       `json:"jobs"`
+}
+
+// ReconciliationResponse is the result of the limit reconciliation task
+type ReconciliationResponse struct {
+	Accounts  int                  `json:"accounts"`
+	Baselined int                  `json:"baselined"`
+	Skipped   int                  `json:"skipped"`
+	Drifts    []LimitDriftResponse `json:"drifts"`
+}
+
+type LimitDriftResponse struct {
+	AccountID int     `json:"account_id"`
+	Expected  float64 `json:"expected"`
+	Actual    float64 `json:"actual"`
+}
+
+func NewReconciliationResponse(r *entity.Reconciliation) ReconciliationResponse {
+	resp := ReconciliationResponse{
+		Accounts:  r.Accounts,
+		Baselined: r.Baselined,
+		Skipped:   r.Skipped,
+		Drifts:    make([]LimitDriftResponse, 0, len(r.Drifts)),
+	}
+
+	for _, drift := range r.Drifts {
+		resp.Drifts = append(resp.Drifts, LimitDriftResponse{
+			AccountID: drift.AccountID,
+			Expected:  drift.Expected,
+			Actual:    drift.Actual,
+		})
+	}
+
+	return resp
+}
+
+// ChargeRunResponse is the result of the charges task
+type ChargeRunResponse struct {
+	Date     time.Time `json:"date"`
+	Accounts int       `json:"accounts"`
+	Charges  int       `json:"charges"`
+	Amount   float64   `json:"amount"`
+	Failed   int       `json:"failed"`
+}
+
+func NewChargeRunResponse(r *entity.ChargeRun) ChargeRunResponse {
+	return ChargeRunResponse{
+		Date:     r.Date,
+		Accounts: r.Accounts,
+		Charges:  r.Charges,
+		Amount:   r.Amount,
+		Failed:   r.Failed,
+	}
+}
+
+// AttemptReportResponse is the result of the transaction report task
+type AttemptReportResponse struct {
+	From        time.Time                `json:"from"`
+	To          time.Time                `json:"to"`
+	Total       int                      `json:"total"`
+	TotalAmount float64                  `json:"total_amount"`
+	Summaries   []AttemptSummaryResponse `json:"summaries"`
+}
+
+type AttemptSummaryResponse struct {
+	OperationTypeID int     `json:"operation_type_id"`
+	Outcome         string  `json:"outcome"`
+	Count           int     `json:"count"`
+	Amount          float64 `json:"amount"`
+}
+
+func NewAttemptReportResponse(from, to time.Time, summaries []*entity.AttemptSummary) AttemptReportResponse {
+	resp := AttemptReportResponse{
+		From:      from,
+		To:        to,
+		Summaries: make([]AttemptSummaryResponse, 0, len(summaries)),
+	}
+
+	for _, summary := range summaries {
+		resp.Total += summary.Count
+		resp.TotalAmount += summary.Amount
+		resp.Summaries = append(resp.Summaries, AttemptSummaryResponse{
+			OperationTypeID: summary.OperationTypeID,
+			Outcome:         string(summary.Outcome),
+			Count:           summary.Count,
+			Amount:          summary.Amount,
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,343 @@
+package presenter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/brunomdev/digital-account/entity"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	dateLayout     = "2006-01-02"
+	dateTimeLayout = "2006-01-02 15:04:05"
+	ofxDateLayout  = "20060102150405"
+)
+
+// StatementWriter encode a statement while its lines are streamed
+type StatementWriter interface {
+	Header(statement *entity.Statement) error
+	Line(line *entity.StatementLine) error
+	Footer(statement *entity.Statement) error
+}
+
+// StatementFormat is the encoding of a statement export
+type StatementFormat struct {
+	ContentType string
+	Extension   string
+	NewWriter   func(w io.Writer) StatementWriter
+}
+
+// StatementFormats available on the statement export by the format query param
+var StatementFormats = map[string]StatementFormat{
+	"csv": {
+		ContentType: "text/csv; charset=utf-8",
+		Extension:   "csv",
+		NewWriter: func(w io.Writer) StatementWriter {
+			return &csvStatementWriter{w: csv.NewWriter(w)}
+		},
+	},
+	"ofx": {
+		ContentType: "application/x-ofx",
+		Extension:   "ofx",
+		NewWriter: func(w io.Writer) StatementWriter {
+			return &ofxStatementWriter{w: w}
+		},
+	},
+	"json": {
+		ContentType: "application/json",
+		Extension:   "json",
+		NewWriter: func(w io.Writer) StatementWriter {
+			return &jsonStatementWriter{w: w}
+		},
+	},
+	"txt": {
+		ContentType: "text/plain; charset=utf-8",
+		Extension:   "txt",
+		NewWriter: func(w io.Writer) StatementWriter {
+			return &textStatementWriter{w: w}
+		},
+	},
+}
+
+// StatementFilename of the export, with the last day of the period since the end is exclusive
+func StatementFilename(statement *entity.Statement, format StatementFormat) string {
+	from, to := statementPeriod(statement)
+
+	return fmt.Sprintf("statement-%d-%s-%s.%s", statement.Account.ID, from, to, format.Extension)
+}
+
+func statementPeriod(statement *entity.Statement) (string, string) {
+	return statement.From.Format(dateLayout), statement.To.Add(-time.Nanosecond).Format(dateLayout)
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+type csvStatementWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvStatementWriter) Header(statement *entity.Statement) error {
+	from, _ := statementPeriod(statement)
+
+	return c.write(
+		[]string{"date", "transaction_id", "operation_type", "amount", "available_credit_limit"},
+		[]string{from, "", "OPENING AVAILABLE CREDIT LIMIT", "", formatAmount(statement.OpeningAvailableCreditLimit)},
+	)
+}
+
+func (c *csvStatementWriter) Line(line *entity.StatementLine) error {
+	transactionID := ""
+	if line.Transaction != nil {
+		transactionID = strconv.Itoa(line.Transaction.ID)
+	}
+
+	return c.write([]string{
+		line.Date().UTC().Format(dateTimeLayout),
+		transactionID,
+		line.OperationType,
+		formatAmount(line.Amount),
+		formatAmount(line.AvailableCreditLimit),
+	})
+}
+
+func (c *csvStatementWriter) Footer(statement *entity.Statement) error {
+	_, to := statementPeriod(statement)
+
+	return c.write(
+		[]string{to, "", "CLOSING AVAILABLE CREDIT LIMIT", "", formatAmount(statement.ClosingAvailableCreditLimit)},
+	)
+}
+
+func (c *csvStatementWriter) write(records ...[]string) error {
+	for _, record := range records {
+		err := c.w.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.w.Flush()
+
+	return c.w.Error()
+}
+
+type StatementResponse struct {
+	AccountID                   int     `json:"account_id"`
+	DocumentNumber              string  `json:"document_number"`
+	From                        string  `json:"from"`
+	To                          string  `json:"to"`
+	OpeningAvailableCreditLimit float64 `json:"opening_available_credit_limit"`
+	ClosingAvailableCreditLimit float64 `json:"closing_available_credit_limit"`
+}
+
+// StatementLineResponse is a transaction or, with the adjustment_id, a change of the credit limit
+type StatementLineResponse struct {
+	TransactionID        int       `json:"transaction_id,omitempty"`
+	AdjustmentID         int       `json:"adjustment_id,omitempty"`
+	OperationTypeID      int       `json:"operation_type_id,omitempty"`
+	OperationType        string    `json:"operation_type"`
+	Amount               float64   `json:"amount"`
+	AvailableCreditLimit float64   `json:"available_credit_limit"`
+	EventDate            time.Time `json:"event_date"`
+}
+
+// jsonStatementWriter write the StatementResponse fields followed by the transactions array
+type jsonStatementWriter struct {
+	w     io.Writer
+	lines int
+}
+
+func (j *jsonStatementWriter) Header(statement *entity.Statement) error {
+	from, to := statementPeriod(statement)
+
+	header, err := json.Marshal(StatementResponse{
+		AccountID:                   statement.Account.ID,
+		DocumentNumber:              statement.Account.DocumentNumber,
+		From:                        from,
+		To:                          to,
+		OpeningAvailableCreditLimit: statement.OpeningAvailableCreditLimit,
+		ClosingAvailableCreditLimit: statement.ClosingAvailableCreditLimit,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(j.w, `%s,"transactions":[`, header[:len(header)-1])
+
+	return err
+}
+
+func (j *jsonStatementWriter) Line(line *entity.StatementLine) error {
+	response := StatementLineResponse{
+		OperationType:        line.OperationType,
+		Amount:               line.Amount,
+		AvailableCreditLimit: line.AvailableCreditLimit,
+		EventDate:            line.Date(),
+	}
+	if line.Adjustment != nil {
+		response.AdjustmentID = line.Adjustment.ID
+	} else {
+		response.TransactionID = line.Transaction.ID
+		response.OperationTypeID = line.Transaction.OperationTypeID
+	}
+
+	item, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	if j.lines > 0 {
+		_, err = io.WriteString(j.w, ",")
+		if err != nil {
+			return err
+		}
+	}
+	j.lines++
+
+	_, err = j.w.Write(item)
+
+	return err
+}
+
+func (j *jsonStatementWriter) Footer(_ *entity.Statement) error {
+	_, err := io.WriteString(j.w, "]}")
+
+	return err
+}
+
+// ofxStatementWriter write an OFX 2.2 credit card statement, the balances are the available credit limit
+type ofxStatementWriter struct {
+	w io.Writer
+}
+
+func (o *ofxStatementWriter) Header(statement *entity.Statement) error {
+	_, err := fmt.Fprintf(o.w, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS><DTSERVER>%s</DTSERVER><LANGUAGE>POR</LANGUAGE></SONRS></SIGNONMSGSRSV1>
+<CREDITCARDMSGSRSV1><CCSTMTTRNRS><TRNUID>0</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<CCSTMTRS><CURDEF>BRL</CURDEF><CCACCTFROM><ACCTID>%d</ACCTID></CCACCTFROM>
+<BANKTRANLIST><DTSTART>%s</DTSTART><DTEND>%s</DTEND>
+`,
+		statement.GeneratedAt.UTC().Format(ofxDateLayout),
+		statement.Account.ID,
+		statement.From.UTC().Format(ofxDateLayout),
+		statement.To.UTC().Format(ofxDateLayout),
+	)
+
+	return err
+}
+
+func (o *ofxStatementWriter) Line(line *entity.StatementLine) error {
+	trnType := "DEBIT"
+	if line.Amount > 0 {
+		trnType = "CREDIT"
+	}
+
+	// the adjustments have their own ids, the prefix keeps them apart from the transactions
+	var fitID string
+	if line.Adjustment != nil {
+		fitID = "A" + strconv.Itoa(line.Adjustment.ID)
+	} else {
+		fitID = strconv.Itoa(line.Transaction.ID)
+	}
+
+	_, err := fmt.Fprintf(
+		o.w,
+		"<STMTTRN><TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><TRNAMT>%s</TRNAMT><FITID>%s</FITID><NAME>",
+		trnType,
+		line.Date().UTC().Format(ofxDateLayout),
+		formatAmount(line.Amount),
+		fitID,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = xml.EscapeText(o.w, []byte(line.OperationType))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(o.w, "</NAME></STMTTRN>\n")
+
+	return err
+}
+
+func (o *ofxStatementWriter) Footer(statement *entity.Statement) error {
+	closing := formatAmount(statement.ClosingAvailableCreditLimit)
+	asOf := statement.To.UTC().Format(ofxDateLayout)
+
+	_, err := fmt.Fprintf(o.w, `</BANKTRANLIST>
+<LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL>
+<AVAILBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></AVAILBAL>
+</CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1>
+</OFX>
+`, closing, asOf, closing, asOf)
+
+	return err
+}
+
+// textStatementWriter write a fixed width statement to be printed or read on a terminal
+type textStatementWriter struct {
+	w io.Writer
+}
+
+const textStatementRow = "%-19s  %-30s  %12s  %12s\n"
+
+func (t *textStatementWriter) Header(statement *entity.Statement) error {
+	from, to := statementPeriod(statement)
+
+	_, err := fmt.Fprintf(
+		t.w,
+		"STATEMENT - ACCOUNT %d - DOCUMENT %s\nPERIOD %s TO %s\n\n"+textStatementRow+textStatementRow,
+		statement.Account.ID,
+		statement.Account.DocumentNumber,
+		from,
+		to,
+		"DATE",
+		"DESCRIPTION",
+		"AMOUNT",
+		"AVAILABLE",
+		from,
+		"OPENING AVAILABLE CREDIT LIMIT",
+		"",
+		formatAmount(statement.OpeningAvailableCreditLimit),
+	)
+
+	return err
+}
+
+func (t *textStatementWriter) Line(line *entity.StatementLine) error {
+	_, err := fmt.Fprintf(
+		t.w,
+		textStatementRow,
+		line.Date().UTC().Format(dateTimeLayout),
+		line.OperationType,
+		formatAmount(line.Amount),
+		formatAmount(line.AvailableCreditLimit),
+	)
+
+	return err
+}
+
+func (t *textStatementWriter) Footer(statement *entity.Statement) error {
+	_, to := statementPeriod(statement)
+
+	_, err := fmt.Fprintf(
+		t.w,
+		textStatementRow,
+		to,
+		"CLOSING AVAILABLE CREDIT LIMIT",
+		"",
+		formatAmount(statement.ClosingAvailableCreditLimit),
+	)
+
+	return err
+}
@@ -1,6 +1,22 @@
 package presenter
 
-import "time"
+import (
+	"github.com/brunomdev/digital-account/entity"
+	"time"
+)
+
+// TransactionRequest is the body of POST /transactions, also validated on each row of the imports
+type TransactionRequest struct {
+	AccountID       int `json:"account_id" validate:"required,min=1"`
+	OperationTypeID int `json:"operation_type_id" validate:"required,min=1"`
+	// Amount is in the currency, or in the account currency when the currency is empty
+	Amount   float64 `json:"amount" validate:"required"`
+	Currency string  `json:"currency" validate:"omitempty,iso4217"`
+	// CardID is the card of the account the purchase or the withdrawal is made with
+	CardID int `json:"card_id" validate:"omitempty,min=1"`
+	// EventDate backdate the transaction inside the allowed window, the creation time when omitted
+	EventDate time.Time `json:"event_date"`
+}
 
 type TransactionResponse struct {
 	ID              int       `json:"id"`
@@ -8,4 +24,36 @@ type TransactionResponse struct {
 	OperationTypeID int       `json:"operation_type_id"`
 	Amount          float64   `json:"amount"`
 	EventDate       time.Time `json:"event_date"`
+	CardID          int       `json:"card_id,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	// Exchange is only present on the transactions made in a foreign currency
+	Exchange *TransactionExchangeResponse `json:"exchange,omitempty"`
+}
+
+type TransactionExchangeResponse struct {
+	Currency       string  `json:"currency"`
+	OriginalAmount float64 `json:"original_amount"`
+	Rate           float64 `json:"rate"`
+}
+
+func NewTransactionResponse(txn *entity.Transaction) TransactionResponse {
+	resp := TransactionResponse{
+		ID:              txn.ID,
+		AccountID:       txn.AccountID,
+		OperationTypeID: txn.OperationTypeID,
+		Amount:          txn.Amount,
+		EventDate:       txn.EventDate,
+		CardID:          txn.CardID,
+		CreatedAt:       txn.CreatedAt,
+	}
+
+	if txn.Exchange != nil {
+		resp.Exchange = &TransactionExchangeResponse{
+			Currency:       txn.Exchange.Currency,
+			OriginalAmount: txn.Exchange.OriginalAmount,
+			Rate:           txn.Exchange.Rate,
+		}
+	}
+
+	return resp
 }
@@ -0,0 +1,14 @@
+package presenter
+
+import "time"
+
+type TransactionAttemptResponse struct {
+	ID              int       `json:"id"`
+	AccountID       int       `json:"account_id"`
+	OperationTypeID int       `json:"operation_type_id"`
+	Amount          float64   `json:"amount"`
+	Outcome         string    `json:"outcome"`
+	DeclineReason   string    `json:"decline_reason,omitempty"`
+	TransactionID   int       `json:"transaction_id,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
@@ -2,11 +2,38 @@ package api
 
 import (
 	"github.com/brunomdev/digital-account/app/api/handlers"
+	"github.com/brunomdev/digital-account/app/api/middleware"
 	"github.com/brunomdev/digital-account/app/api/routes"
 )
 
 func (s *Server) router() {
+	auth := middleware.NewAuth(s.service.Client, s.cfg.AuthEnabled, s.cfg.TimeTravelEnabled)
+	transactionLimiter := middleware.NewClientRateLimiter(s.cfg.RateLimitTransactionsMax, s.cfg.RateLimitTransactionsExpiration)
+
+	accountHandler := handlers.NewAccountHandler(s.service.Account)
+	customerHandler := handlers.NewCustomerHandler(s.service.Customer)
+	cardHandler := handlers.NewCardHandler(s.service.Card)
+	transactionHandler := handlers.NewTransactionHandler(s.service.Transaction)
+	healthHandler := handlers.NewHealthHandler(s.service.Health)
+	statementHandler := handlers.NewStatementHandler(s.service.Statement)
+	importHandler := handlers.NewImportHandler(s.service.Importer, s.service.Job, s.cfg.ImportParallelism)
+	jobHandler := handlers.NewJobHandler(s.service.Job)
+	scheduleHandler := handlers.NewScheduleHandler(s.service.Schedule)
+	operationTypeHandler := handlers.NewOperationTypeHandler(s.service.OperationType)
+	auditHandler := handlers.NewAuditHandler(s.service.Audit)
+
 	routes.DocRoutes(s.httpServer)
-	routes.AccountRoutes(s.httpServer, handlers.NewAccountHandler(s.service.Account))
-	routes.TransactionRoutes(s.httpServer, handlers.NewTransactionHandler(s.service.Transaction))
+	routes.HealthRoutes(s.httpServer, healthHandler)
+	routes.MetricsRoutes(s.httpServer, s.registry)
+	routes.AccountRoutes(s.httpServer, accountHandler, auth)
+	routes.CustomerRoutes(s.httpServer, customerHandler, auth)
+	routes.CardRoutes(s.httpServer, cardHandler, auth)
+	routes.ImportRoutes(s.httpServer, importHandler, auth)
+	routes.TransactionRoutes(s.httpServer, transactionHandler, auth, transactionLimiter)
+	routes.TransactionAttemptRoutes(s.httpServer, transactionHandler, auth)
+	routes.StatementRoutes(s.httpServer, statementHandler, auth)
+	routes.JobRoutes(s.httpServer, jobHandler, auth)
+	routes.ScheduleRoutes(s.httpServer, scheduleHandler, auth)
+	routes.OperationTypeRoutes(s.httpServer, operationTypeHandler, auth)
+	routes.AuditRoutes(s.httpServer, auditHandler, auth)
 }
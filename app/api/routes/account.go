@@ -2,11 +2,15 @@ package routes
 
 import (
 	"github.com/brunomdev/digital-account/app/api/handlers"
+	"github.com/brunomdev/digital-account/app/api/middleware"
+	"github.com/brunomdev/digital-account/entity"
 	"github.com/gofiber/fiber/v2"
 )
 
-func AccountRoutes(route *fiber.App, handler handlers.AccountHandler) {
-	routes := route.Group("/accounts")
-	routes.Post("/", handler.Create)
-	routes.Get("/:id", handler.Get)
+func AccountRoutes(route *fiber.App, handler handlers.AccountHandler, auth fiber.Handler) {
+	routes := route.Group("/accounts", auth)
+	routes.Post("/", middleware.RequireScope(entity.ScopeAccountsWrite), handler.Create)
+	routes.Get("/:id", middleware.RequireScope(entity.ScopeAccountsRead), handler.Get)
+	routes.Patch("/:id/credit-limit", middleware.RequireScope(entity.ScopeLimitsAdmin),
+		middleware.RequireRole(entity.RoleRisk), handler.UpdateCreditLimit)
 }
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/brunomdev/digital-account/app/api/handlers"
+	"github.com/brunomdev/digital-account/app/api/middleware"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/gofiber/fiber/v2"
+)
+
+func AuditRoutes(route *fiber.App, handler handlers.AuditHandler, auth fiber.Handler) {
+	routes := route.Group("/audit", auth)
+	routes.Get("/", middleware.RequireScope(entity.ScopeAuditRead), handler.List)
+	routes.Get("/verify", middleware.RequireScope(entity.ScopeAuditRead), handler.Verify)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/brunomdev/digital-account/app/api/handlers"
+	"github.com/brunomdev/digital-account/app/api/middleware"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/gofiber/fiber/v2"
+)
+
+func CardRoutes(route *fiber.App, handler handlers.CardHandler, auth fiber.Handler) {
+	route.Group("/accounts/:id/cards", auth).
+		Post("/", middleware.RequireScope(entity.ScopeCardsWrite), handler.Issue)
+
+	routes := route.Group("/cards", auth)
+	routes.Get("/:id", middleware.RequireScope(entity.ScopeCardsRead), handler.Get)
+	routes.Post("/:id/block", middleware.RequireScope(entity.ScopeCardsWrite), handler.Block)
+	routes.Post("/:id/replace", middleware.RequireScope(entity.ScopeCardsWrite), handler.Replace)
+}
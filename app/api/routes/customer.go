@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/brunomdev/digital-account/app/api/handlers"
+	"github.com/brunomdev/digital-account/app/api/middleware"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/gofiber/fiber/v2"
+)
+
+func CustomerRoutes(route *fiber.App, handler handlers.CustomerHandler, auth fiber.Handler) {
+	routes := route.Group("/customers", auth)
+	routes.Post("/", middleware.RequireScope(entity.ScopeCustomersWrite), handler.Create)
+	routes.Get("/:id", middleware.RequireScope(entity.ScopeCustomersRead), handler.Get)
+	routes.Put("/:id", middleware.RequireScope(entity.ScopeCustomersWrite), handler.Update)
+	routes.Post("/:id/accounts", middleware.RequireScope(entity.ScopeAccountsWrite), handler.OpenAccount)
+}
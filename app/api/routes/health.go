@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"github.com/brunomdev/digital-account/app/api/handlers"
+	"github.com/gofiber/fiber/v2"
+)
+
+func HealthRoutes(route *fiber.App, handler handlers.HealthHandler) {
+	route.Get("/healthz", handler.Live)
+	route.Get("/readyz", handler.Ready)
+}
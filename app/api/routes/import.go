@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/brunomdev/digital-account/app/api/handlers"
+	"github.com/brunomdev/digital-account/app/api/middleware"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/gofiber/fiber/v2"
+)
+
+func ImportRoutes(route *fiber.App, handler handlers.ImportHandler, auth fiber.Handler) {
+	routes := route.Group("/transactions/import", auth)
+	routes.Post("/", middleware.RequireScope(entity.ScopeTransactionsWrite), handler.Import)
+}
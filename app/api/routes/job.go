@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/brunomdev/digital-account/app/api/handlers"
+	"github.com/brunomdev/digital-account/app/api/middleware"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/gofiber/fiber/v2"
+)
+
+func JobRoutes(route *fiber.App, handler handlers.JobHandler, auth fiber.Handler) {
+	routes := route.Group("/jobs", auth)
+	// the jobs are enqueued by the imports, so reading them takes the same scope
+	routes.Get("/:id", middleware.RequireScope(entity.ScopeTransactionsWrite), handler.Get)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+)
+
+func MetricsRoutes(route *fiber.App, gatherer prometheus.Gatherer) {
+	handler := fasthttpadaptor.NewFastHTTPHandler(promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
+
+	route.Get("/metrics", func(c *fiber.Ctx) error {
+		handler(c.Context())
+		return nil
+	})
+}
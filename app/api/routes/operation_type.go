@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/brunomdev/digital-account/app/api/handlers"
+	"github.com/brunomdev/digital-account/app/api/middleware"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/gofiber/fiber/v2"
+)
+
+func OperationTypeRoutes(route *fiber.App, handler handlers.OperationTypeHandler, auth fiber.Handler) {
+	routes := route.Group("/operation-types", auth)
+	routes.Put("/:id/rules", middleware.RequireScope(entity.ScopeLimitsAdmin),
+		middleware.RequireRole(entity.RoleRisk), handler.UpdateRules)
+}
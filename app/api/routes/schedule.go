@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/brunomdev/digital-account/app/api/handlers"
+	"github.com/brunomdev/digital-account/app/api/middleware"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/gofiber/fiber/v2"
+)
+
+func ScheduleRoutes(route *fiber.App, handler handlers.ScheduleHandler, auth fiber.Handler) {
+	routes := route.Group("/admin/schedules", auth, middleware.RequireScope(entity.ScopeSchedulesAdmin))
+	routes.Get("/", handler.List)
+	routes.Post("/:name/run", handler.Run)
+}
@@ -2,10 +2,12 @@ package routes
 
 import (
 	"github.com/brunomdev/digital-account/app/api/handlers"
+	"github.com/brunomdev/digital-account/app/api/middleware"
+	"github.com/brunomdev/digital-account/entity"
 	"github.com/gofiber/fiber/v2"
 )
 
-func TransactionRoutes(route *fiber.App, handler handlers.TransactionHandler) {
-	routes := route.Group("/transactions")
-	routes.Post("/", handler.Create)
+func TransactionRoutes(route *fiber.App, handler handlers.TransactionHandler, auth, limiter fiber.Handler) {
+	routes := route.Group("/transactions", auth)
+	routes.Post("/", middleware.RequireScope(entity.ScopeTransactionsWrite), limiter, handler.Create)
 }
@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/brunomdev/digital-account/app/api/handlers"
+	"github.com/brunomdev/digital-account/app/api/middleware"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TransactionAttemptRoutes(route *fiber.App, handler handlers.TransactionHandler, auth fiber.Handler) {
+	routes := route.Group("/accounts/:id/transaction-attempts", auth)
+	routes.Get("/", middleware.RequireScope(entity.ScopeAccountsRead), handler.ListAttempts)
+}
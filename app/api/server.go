@@ -7,13 +7,16 @@ import (
 	"github.com/brunomdev/digital-account/domain"
 	"github.com/gofiber/fiber/v2"
 	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/prometheus/client_golang/prometheus"
 	"log"
 	"net/http"
+	"time"
 )
 
 type Server struct {
 	cfg        *appConfig.Config
 	newRelic   *newrelic.Application
+	registry   *prometheus.Registry
 	httpServer *fiber.App
 	service    *domain.Service
 }
@@ -29,7 +32,11 @@ func NewServer(options ...func(server *Server) error) (*Server, error) {
 
 	app := fiber.New()
 
-	middleware.FiberMiddleware(app, server.newRelic, server.cfg.AppDebug)
+	if server.registry == nil {
+		server.registry = prometheus.NewRegistry()
+	}
+
+	middleware.FiberMiddleware(app, server.newRelic, server.registry, server.cfg)
 
 	server.httpServer = app
 
@@ -65,6 +72,20 @@ func WithNewRelic(nr *newrelic.Application) func(server *Server) error {
 	}
 }
 
+func WithRegistry(registry *prometheus.Registry) func(server *Server) error {
+	return func(server *Server) error {
+		server.registry = registry
+		return nil
+	}
+}
+
+// Drain fail the readiness probe and wait the delay, so the load balancer stops sending requests before closing
+func (s *Server) Drain(delay time.Duration) {
+	s.service.Health.Drain()
+
+	time.Sleep(delay)
+}
+
 func (s *Server) Close() error {
 	return s.httpServer.Shutdown()
 }
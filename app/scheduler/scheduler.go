@@ -0,0 +1,299 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/brunomdev/digital-account/app/worker"
+	"github.com/brunomdev/digital-account/domain/job"
+	"github.com/brunomdev/digital-account/domain/schedule"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/infra/log"
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+	"sync"
+	"time"
+)
+
+// jobTypePrefix is prepended to the task name to build the type of its jobs
+const jobTypePrefix = "scheduled_"
+
+// Task is the work of a schedule, scheduledAt is the tick that triggered the run and the result is stored as the
+// job result
+type Task func(ctx context.Context, scheduledAt time.Time) (interface{}, error)
+
+// Payload is the job payload of a scheduled run
+type Payload struct {
+	ScheduledAt time.Time `json:"scheduled_at"`
+	Manual      bool      `json:"manual"`
+}
+
+type definition struct {
+	name     string
+	spec     string
+	schedule cron.Schedule
+	task     Task
+	next     time.Time
+}
+
+// Scheduler enqueue the registered tasks on the jobs queue following their cron expressions. Every instance runs a
+// scheduler, a named lock shared on the database makes sure each tick is enqueued once and the workers run a task
+// once at a time.
+type Scheduler struct {
+	pool        *worker.Pool
+	jobService  job.Service
+	locker      schedule.Locker
+	definitions []*definition
+	now         func() time.Time
+	mu          sync.Mutex
+	wg          sync.WaitGroup
+}
+
+type Option func(s *Scheduler)
+
+func NewScheduler(pool *worker.Pool, jobService job.Service, locker schedule.Locker, options ...Option) *Scheduler {
+	s := &Scheduler{
+		pool:       pool,
+		jobService: jobService,
+		locker:     locker,
+		now:        time.Now,
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
+}
+
+// WithClock replace the current time, used by the tests
+func WithClock(now func() time.Time) Option {
+	return func(s *Scheduler) {
+		s.now = now
+	}
+}
+
+// Register a task with a standard cron expression evaluated in UTC, an empty spec is only run by Trigger. Must be
+// called before the pool and the scheduler are started.
+func (s *Scheduler) Register(name, spec string, task Task) error {
+	d := &definition{name: name, spec: spec, task: task}
+
+	if spec != "" {
+		parsed, err := cron.ParseStandard(spec)
+		if err != nil {
+			return errors.Wrapf(err, "schedule %s", name)
+		}
+
+		d.schedule = parsed
+		d.next = parsed.Next(s.now().UTC())
+	}
+
+	s.definitions = append(s.definitions, d)
+	s.pool.Register(jobTypePrefix+name, s.handler(d))
+
+	return nil
+}
+
+// Start enqueue the due tasks until ctx is done, the ticks missed while no instance was running are not caught up
+func (s *Scheduler) Start(ctx context.Context) {
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		s.loop(ctx)
+	}()
+}
+
+// Wait for the scheduler to stop after the context of Start is done
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context) {
+	for {
+		next, ok := s.nextTick()
+		if !ok {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(next.Sub(s.now())):
+		}
+
+		s.Tick(ctx)
+	}
+}
+
+func (s *Scheduler) nextTick() (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var next time.Time
+	for _, d := range s.definitions {
+		if d.schedule != nil && (next.IsZero() || d.next.Before(next)) {
+			next = d.next
+		}
+	}
+
+	return next, !next.IsZero()
+}
+
+// Tick enqueue every task due at the current time and move them to their next run
+func (s *Scheduler) Tick(ctx context.Context) {
+	now := s.now().UTC()
+
+	s.mu.Lock()
+	due := make(map[*definition]time.Time)
+	for _, d := range s.definitions {
+		if d.schedule != nil && !d.next.After(now) {
+			due[d] = d.next
+			d.next = d.schedule.Next(now)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, d := range s.definitions {
+		scheduledAt, ok := due[d]
+		if !ok {
+			continue
+		}
+
+		err := s.enqueueTick(ctx, d, scheduledAt)
+		if err != nil {
+			log.Error(ctx, "unable to enqueue scheduled task", err, log.Event{"schedule": d.name})
+		}
+	}
+}
+
+// enqueueTick enqueue the run of a tick unless another instance already did or the last run is still pending
+func (s *Scheduler) enqueueTick(ctx context.Context, d *definition, scheduledAt time.Time) error {
+	release, err := s.locker.TryLock(ctx, "schedule:"+d.name)
+	if errors.Is(err, entity.ErrLocked) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	latest, err := s.latest(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	if latest != nil {
+		if !latest.Finished() {
+			log.Warn(ctx, "scheduled task skipped, the last run did not finish", log.Event{
+				"schedule": d.name,
+				"job_id":   latest.ID,
+			})
+
+			return nil
+		}
+
+		var payload Payload
+		if json.Unmarshal(latest.Payload, &payload) == nil && !payload.ScheduledAt.Before(scheduledAt) {
+			return nil
+		}
+	}
+
+	_, err = s.jobService.Enqueue(ctx, jobTypePrefix+d.name, Payload{ScheduledAt: scheduledAt}, 0)
+
+	return err
+}
+
+func (s *Scheduler) latest(ctx context.Context, d *definition) (*entity.Job, error) {
+	latest, err := s.jobService.GetLatestByType(ctx, jobTypePrefix+d.name)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, nil
+	}
+
+	return latest, err
+}
+
+func (s *Scheduler) definition(name string) (*definition, bool) {
+	for _, d := range s.definitions {
+		if d.name == name {
+			return d, true
+		}
+	}
+
+	return nil, false
+}
+
+// List the registered tasks with their next run and the job of their last run
+func (s *Scheduler) List(ctx context.Context) ([]*entity.Schedule, error) {
+	schedules := make([]*entity.Schedule, 0, len(s.definitions))
+	for _, d := range s.definitions {
+		latest, err := s.latest(ctx, d)
+		if err != nil {
+			return nil, errors.Wrap(err, "List")
+		}
+
+		s.mu.Lock()
+		next := d.next
+		s.mu.Unlock()
+
+		schedules = append(schedules, &entity.Schedule{
+			Name:      d.name,
+			Spec:      d.spec,
+			NextRunAt: next,
+			LastRun:   latest,
+		})
+	}
+
+	return schedules, nil
+}
+
+// Trigger enqueue a manual run of the task now
+func (s *Scheduler) Trigger(ctx context.Context, name string) (*entity.Job, error) {
+	d, ok := s.definition(name)
+	if !ok {
+		return nil, errors.Wrap(entity.ErrNotFound, "schedule")
+	}
+
+	release, err := s.locker.TryLock(ctx, "schedule:"+d.name)
+	if errors.Is(err, entity.ErrLocked) {
+		return nil, entity.ErrAlreadyRunning
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Trigger")
+	}
+	defer release()
+
+	latest, err := s.latest(ctx, d)
+	if err != nil {
+		return nil, errors.Wrap(err, "Trigger")
+	}
+
+	if latest != nil && !latest.Finished() {
+		return nil, entity.ErrAlreadyRunning
+	}
+
+	return s.jobService.Enqueue(ctx, jobTypePrefix+d.name, Payload{ScheduledAt: s.now().UTC(), Manual: true}, 0)
+}
+
+// handler run the task of a job holding the run lock, so a task is never run by two workers at the same time
+func (s *Scheduler) handler(d *definition) job.Handler {
+	return func(ctx context.Context, j *entity.Job, progress func(percent int)) (interface{}, error) {
+		var payload Payload
+
+		err := json.Unmarshal(j.Payload, &payload)
+		if err != nil {
+			return nil, job.Permanent(errors.Wrap(err, "ScheduleHandler"))
+		}
+
+		release, err := s.locker.TryLock(ctx, "schedule:"+d.name+":run")
+		if errors.Is(err, entity.ErrLocked) {
+			return nil, job.Permanent(entity.ErrAlreadyRunning)
+		}
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+
+		return d.task(ctx, payload.ScheduledAt)
+	}
+}
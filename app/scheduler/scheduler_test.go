@@ -0,0 +1,272 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/brunomdev/digital-account/app/worker"
+	"github.com/brunomdev/digital-account/domain/job"
+	"github.com/brunomdev/digital-account/domain/job/mock_job"
+	"github.com/brunomdev/digital-account/domain/schedule/mock_schedule"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type mocks struct {
+	jobService *mock_job.MockService
+	locker     *mock_schedule.MockLocker
+}
+
+func newTestScheduler(ctrl *gomock.Controller, now time.Time, task Task) (*Scheduler, mocks) {
+	m := mocks{
+		jobService: mock_job.NewMockService(ctrl),
+		locker:     mock_schedule.NewMockLocker(ctrl),
+	}
+
+	if task == nil {
+		task = func(ctx context.Context, scheduledAt time.Time) (interface{}, error) {
+			return nil, nil
+		}
+	}
+
+	// registered an hour before now, so the hourly task is due at now
+	current := now.Add(-time.Hour)
+	s := NewScheduler(worker.NewPool(mock_job.NewMockRepository(ctrl)), m.jobService, m.locker, WithClock(func() time.Time {
+		return current
+	}))
+
+	if err := s.Register("hourly", "0 * * * *", task); err != nil {
+		panic(err)
+	}
+	if err := s.Register("manual", "", task); err != nil {
+		panic(err)
+	}
+
+	current = now
+
+	return s, m
+}
+
+func payload(t *testing.T, p Payload) []byte {
+	b, err := json.Marshal(p)
+	require.NoError(t, err)
+
+	return b
+}
+
+func TestScheduler_Tick(t *testing.T) {
+	now := time.Date(2022, 3, 25, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name   string
+		expect func(m mocks)
+	}{
+		{
+			name: "Enqueued",
+			expect: func(m mocks) {
+				m.locker.EXPECT().TryLock(gomock.Any(), "schedule:hourly").Return(func() {}, nil)
+				m.jobService.EXPECT().GetLatestByType(gomock.Any(), "scheduled_hourly").
+					Return(nil, errors.Wrap(entity.ErrNotFound, "job"))
+				m.jobService.EXPECT().Enqueue(gomock.Any(), "scheduled_hourly", Payload{ScheduledAt: now}, 0).
+					Return(&entity.Job{ID: 1}, nil)
+			},
+		},
+		{
+			name: "Skipped locked by another instance",
+			expect: func(m mocks) {
+				m.locker.EXPECT().TryLock(gomock.Any(), "schedule:hourly").Return(nil, entity.ErrLocked)
+			},
+		},
+		{
+			name: "Skipped already enqueued by another instance",
+			expect: func(m mocks) {
+				m.locker.EXPECT().TryLock(gomock.Any(), "schedule:hourly").Return(func() {}, nil)
+				m.jobService.EXPECT().GetLatestByType(gomock.Any(), "scheduled_hourly").Return(&entity.Job{
+					ID:      1,
+					Status:  entity.JobStatusSucceeded,
+					Payload: []byte(`{"scheduled_at":"2022-03-25T10:00:00Z","manual":false}`),
+				}, nil)
+			},
+		},
+		{
+			name: "Skipped last run not finished",
+			expect: func(m mocks) {
+				m.locker.EXPECT().TryLock(gomock.Any(), "schedule:hourly").Return(func() {}, nil)
+				m.jobService.EXPECT().GetLatestByType(gomock.Any(), "scheduled_hourly").Return(&entity.Job{
+					ID:      1,
+					Status:  entity.JobStatusRunning,
+					Payload: []byte(`{"scheduled_at":"2022-03-25T09:00:00Z","manual":false}`),
+				}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s, m := newTestScheduler(ctrl, now, nil)
+			tc.expect(m)
+
+			s.Tick(context.TODO())
+
+			next, ok := s.nextTick()
+			assert.True(t, ok)
+			assert.Equal(t, now.Add(time.Hour), next)
+		})
+	}
+}
+
+func TestScheduler_Trigger(t *testing.T) {
+	now := time.Date(2022, 3, 25, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		task    string
+		expect  func(m mocks)
+		want    *entity.Job
+		wantErr error
+	}{
+		{
+			name:    "Error unknown task",
+			task:    "missing",
+			expect:  func(m mocks) {},
+			wantErr: entity.ErrNotFound,
+		},
+		{
+			name: "Error locked",
+			task: "manual",
+			expect: func(m mocks) {
+				m.locker.EXPECT().TryLock(gomock.Any(), "schedule:manual").Return(nil, entity.ErrLocked)
+			},
+			wantErr: entity.ErrAlreadyRunning,
+		},
+		{
+			name: "Error last run not finished",
+			task: "manual",
+			expect: func(m mocks) {
+				m.locker.EXPECT().TryLock(gomock.Any(), "schedule:manual").Return(func() {}, nil)
+				m.jobService.EXPECT().GetLatestByType(gomock.Any(), "scheduled_manual").
+					Return(&entity.Job{ID: 1, Status: entity.JobStatusQueued}, nil)
+			},
+			wantErr: entity.ErrAlreadyRunning,
+		},
+		{
+			name: "Success",
+			task: "manual",
+			expect: func(m mocks) {
+				m.locker.EXPECT().TryLock(gomock.Any(), "schedule:manual").Return(func() {}, nil)
+				m.jobService.EXPECT().GetLatestByType(gomock.Any(), "scheduled_manual").
+					Return(&entity.Job{ID: 1, Status: entity.JobStatusFailed}, nil)
+				m.jobService.EXPECT().Enqueue(gomock.Any(), "scheduled_manual", Payload{ScheduledAt: now, Manual: true}, 0).
+					Return(&entity.Job{ID: 2, Type: "scheduled_manual", Status: entity.JobStatusQueued}, nil)
+			},
+			want: &entity.Job{ID: 2, Type: "scheduled_manual", Status: entity.JobStatusQueued},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s, m := newTestScheduler(ctrl, now, nil)
+			tc.expect(m)
+
+			got, err := s.Trigger(context.TODO(), tc.task)
+			if tc.wantErr != nil {
+				assert.True(t, errors.Is(err, tc.wantErr), "Trigger() error = %v, want %v", err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestScheduler_List(t *testing.T) {
+	now := time.Date(2022, 3, 25, 10, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, m := newTestScheduler(ctrl, now, nil)
+
+	lastRun := &entity.Job{ID: 1, Type: "scheduled_hourly", Status: entity.JobStatusSucceeded}
+	m.jobService.EXPECT().GetLatestByType(gomock.Any(), "scheduled_hourly").Return(lastRun, nil)
+	m.jobService.EXPECT().GetLatestByType(gomock.Any(), "scheduled_manual").
+		Return(nil, errors.Wrap(entity.ErrNotFound, "job"))
+
+	got, err := s.List(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.Schedule{
+		{Name: "hourly", Spec: "0 * * * *", NextRunAt: now, LastRun: lastRun},
+		{Name: "manual"},
+	}, got)
+}
+
+func TestScheduler_Register(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := NewScheduler(worker.NewPool(mock_job.NewMockRepository(ctrl)), nil, nil)
+
+	assert.Error(t, s.Register("invalid", "every day", nil))
+}
+
+func TestScheduler_handler(t *testing.T) {
+	now := time.Date(2022, 3, 25, 10, 0, 0, 0, time.UTC)
+	scheduledAt := now.Add(-time.Hour)
+	j := &entity.Job{ID: 1, Type: "scheduled_hourly", Payload: payload(t, Payload{ScheduledAt: scheduledAt})}
+
+	t.Run("Success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		released := false
+		s, m := newTestScheduler(ctrl, now, func(ctx context.Context, at time.Time) (interface{}, error) {
+			assert.Equal(t, scheduledAt, at)
+
+			return map[string]int{"deleted": 2}, nil
+		})
+		m.locker.EXPECT().TryLock(gomock.Any(), "schedule:hourly:run").Return(func() {
+			released = true
+		}, nil)
+
+		d, _ := s.definition("hourly")
+		got, err := s.handler(d)(context.TODO(), j, func(int) {})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"deleted": 2}, got)
+		assert.True(t, released)
+	})
+
+	t.Run("Error already running", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		s, m := newTestScheduler(ctrl, now, nil)
+		m.locker.EXPECT().TryLock(gomock.Any(), "schedule:hourly:run").Return(nil, entity.ErrLocked)
+
+		d, _ := s.definition("hourly")
+		_, err := s.handler(d)(context.TODO(), j, func(int) {})
+		assert.True(t, job.IsPermanent(err))
+		assert.True(t, errors.Is(err, entity.ErrAlreadyRunning))
+	})
+
+	t.Run("Error invalid payload", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		s, _ := newTestScheduler(ctrl, now, nil)
+
+		d, _ := s.definition("hourly")
+		_, err := s.handler(d)(context.TODO(), &entity.Job{ID: 1, Payload: []byte(`[]`)}, func(int) {})
+		assert.True(t, job.IsPermanent(err))
+	})
+}
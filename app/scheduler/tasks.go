@@ -0,0 +1,79 @@
+package scheduler
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/charge"
+	"github.com/brunomdev/digital-account/domain/job"
+	"github.com/brunomdev/digital-account/domain/reconciliation"
+	"github.com/brunomdev/digital-account/domain/transaction"
+	"time"
+)
+
+// Names of the tasks registered by the service
+const (
+	TaskCharges             = "charges"
+	TaskCleanup             = "cleanup"
+	TaskLimitReconciliation = "limit_reconciliation"
+	TaskTransactionReport   = "transaction_report"
+)
+
+// NewCleanupTask delete the transaction attempts and the finished jobs older than the retention
+func NewCleanupTask(transactionService transaction.Service, jobService job.Service, retention time.Duration) Task {
+	return func(ctx context.Context, scheduledAt time.Time) (interface{}, error) {
+		before := scheduledAt.UTC().Add(-retention)
+
+		attempts, err := transactionService.PurgeAttempts(ctx, before)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs, err := jobService.Purge(ctx, before)
+		if err != nil {
+			return nil, err
+		}
+
+		return presenter.CleanupResponse{Before: before, Attempts: attempts, Jobs: jobs}, nil
+	}
+}
+
+// NewReconciliationTask report the accounts whose available credit limit does not match their transactions
+func NewReconciliationTask(service reconciliation.Service) Task {
+	return func(ctx context.Context, scheduledAt time.Time) (interface{}, error) {
+		result, err := service.Reconcile(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return presenter.NewReconciliationResponse(result), nil
+	}
+}
+
+// NewReportTask summarize the transaction attempts of the UTC day before the run by operation type and outcome
+func NewReportTask(transactionService transaction.Service) Task {
+	return func(ctx context.Context, scheduledAt time.Time) (interface{}, error) {
+		to := scheduledAt.UTC().Truncate(24 * time.Hour)
+		from := to.AddDate(0, 0, -1)
+
+		summaries, err := transactionService.SummarizeAttempts(ctx, from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		return presenter.NewAttemptReportResponse(from, to, summaries), nil
+	}
+}
+
+// NewChargesTask accrue the interest, late fees and penalties of the UTC day before the run on every account
+func NewChargesTask(service charge.Service) Task {
+	return func(ctx context.Context, scheduledAt time.Time) (interface{}, error) {
+		day := scheduledAt.UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+
+		run, err := service.AccrueAll(ctx, day)
+		if err != nil {
+			return nil, err
+		}
+
+		return presenter.NewChargeRunResponse(run), nil
+	}
+}
@@ -0,0 +1,112 @@
+package scheduler
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/charge/mock_charge"
+	"github.com/brunomdev/digital-account/domain/job/mock_job"
+	"github.com/brunomdev/digital-account/domain/reconciliation/mock_reconciliation"
+	"github.com/brunomdev/digital-account/domain/transaction/mock_transaction"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestNewCleanupTask(t *testing.T) {
+	scheduledAt := time.Date(2022, 3, 25, 3, 0, 0, 0, time.UTC)
+	before := scheduledAt.Add(-24 * time.Hour)
+
+	t.Run("Success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		transactionSvc := mock_transaction.NewMockService(ctrl)
+		transactionSvc.EXPECT().PurgeAttempts(gomock.Any(), before).Return(3, nil)
+		jobSvc := mock_job.NewMockService(ctrl)
+		jobSvc.EXPECT().Purge(gomock.Any(), before).Return(1, nil)
+
+		got, err := NewCleanupTask(transactionSvc, jobSvc, 24*time.Hour)(context.TODO(), scheduledAt)
+		require.NoError(t, err)
+		assert.Equal(t, presenter.CleanupResponse{Before: before, Attempts: 3, Jobs: 1}, got)
+	})
+
+	t.Run("Error purging attempts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		transactionSvc := mock_transaction.NewMockService(ctrl)
+		transactionSvc.EXPECT().PurgeAttempts(gomock.Any(), before).Return(0, errors.New("database error"))
+
+		_, err := NewCleanupTask(transactionSvc, mock_job.NewMockService(ctrl), 24*time.Hour)(context.TODO(), scheduledAt)
+		assert.EqualError(t, err, "database error")
+	})
+}
+
+func TestNewReconciliationTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mock_reconciliation.NewMockService(ctrl)
+	svc.EXPECT().Reconcile(gomock.Any()).Return(&entity.Reconciliation{
+		Accounts: 2,
+		Drifts:   []entity.LimitDrift{{AccountID: 1, Expected: 50, Actual: 40}},
+	}, nil)
+
+	got, err := NewReconciliationTask(svc)(context.TODO(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, presenter.ReconciliationResponse{
+		Accounts: 2,
+		Drifts:   []presenter.LimitDriftResponse{{AccountID: 1, Expected: 50, Actual: 40}},
+	}, got)
+}
+
+func TestNewReportTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	from := time.Date(2022, 3, 24, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+
+	svc := mock_transaction.NewMockService(ctrl)
+	svc.EXPECT().SummarizeAttempts(gomock.Any(), from, to).Return([]*entity.AttemptSummary{
+		{OperationTypeID: 1, Outcome: entity.AttemptOutcomeApproved, Count: 2, Amount: 30},
+		{OperationTypeID: 1, Outcome: entity.AttemptOutcomeDeclined, Count: 1, Amount: 500},
+	}, nil)
+
+	got, err := NewReportTask(svc)(context.TODO(), time.Date(2022, 3, 25, 1, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, presenter.AttemptReportResponse{
+		From:        from,
+		To:          to,
+		Total:       3,
+		TotalAmount: 530,
+		Summaries: []presenter.AttemptSummaryResponse{
+			{OperationTypeID: 1, Outcome: "approved", Count: 2, Amount: 30},
+			{OperationTypeID: 1, Outcome: "declined", Count: 1, Amount: 500},
+		},
+	}, got)
+}
+
+func TestNewChargesTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	day := time.Date(2022, 3, 24, 0, 0, 0, 0, time.UTC)
+
+	svc := mock_charge.NewMockService(ctrl)
+	svc.EXPECT().AccrueAll(gomock.Any(), day).Return(&entity.ChargeRun{
+		Date:     day,
+		Accounts: 3,
+		Charges:  2,
+		Amount:   12.5,
+		Failed:   1,
+	}, nil)
+
+	got, err := NewChargesTask(svc)(context.TODO(), time.Date(2022, 3, 25, 2, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, presenter.ChargeRunResponse{Date: day, Accounts: 3, Charges: 2, Amount: 12.5, Failed: 1}, got)
+}
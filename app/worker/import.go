@@ -0,0 +1,60 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/importer"
+	"github.com/brunomdev/digital-account/domain/job"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+)
+
+// JobTypeImport is the job of POST /transactions/import?async=true
+const JobTypeImport = "transaction_import"
+
+// importBatchSize rows are imported between the progress updates
+const importBatchSize = 100
+
+// ImportPayload is the job payload with the rows already parsed and validated by the API
+type ImportPayload struct {
+	Rows        []*entity.ImportRow `json:"rows"`
+	Parallelism int                 `json:"parallelism"`
+}
+
+// NewImportHandler import the rows in batches, keeping the file order of each account, and store the same report of
+// the synchronous import as the result. The transactions are not idempotent, so an interrupted import is never retried.
+func NewImportHandler(service importer.Service) job.Handler {
+	return func(ctx context.Context, j *entity.Job, progress func(percent int)) (interface{}, error) {
+		if j.Attempts > 1 {
+			return nil, job.Permanent(errors.Errorf(
+				"the import was interrupted at %d%%, check the transactions created before importing the rows again",
+				j.Progress,
+			))
+		}
+
+		var payload ImportPayload
+
+		err := json.Unmarshal(j.Payload, &payload)
+		if err != nil {
+			return nil, job.Permanent(errors.Wrap(err, "ImportHandler"))
+		}
+
+		results := make([]*entity.ImportResult, 0, len(payload.Rows))
+		for start := 0; start < len(payload.Rows); start += importBatchSize {
+			if ctx.Err() != nil {
+				return nil, job.Permanent(errors.Wrapf(ctx.Err(), "import interrupted after %d rows", start))
+			}
+
+			end := start + importBatchSize
+			if end > len(payload.Rows) {
+				end = len(payload.Rows)
+			}
+
+			results = append(results, service.Import(ctx, payload.Rows[start:end], payload.Parallelism)...)
+			progress(end * 100 / len(payload.Rows))
+		}
+
+		return presenter.NewImportResponse(results), nil
+	}
+}
@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/brunomdev/digital-account/app/api/presenter"
+	"github.com/brunomdev/digital-account/domain/importer/mock_importer"
+	"github.com/brunomdev/digital-account/domain/job"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNewImportHandler(t *testing.T) {
+	rows := make([]*entity.ImportRow, 150)
+	for i := range rows {
+		rows[i] = &entity.ImportRow{Line: i + 2, AccountID: 1, OperationTypeID: 4, Amount: 10}
+	}
+
+	payload, err := json.Marshal(ImportPayload{Rows: rows, Parallelism: 2})
+	require.NoError(t, err)
+
+	t.Run("Success in batches", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		svc := mock_importer.NewMockService(ctrl)
+		for _, batch := range [][]*entity.ImportRow{rows[:100], rows[100:]} {
+			results := make([]*entity.ImportResult, 0, len(batch))
+			for _, row := range batch {
+				results = append(results, &entity.ImportResult{Line: row.Line, AccountID: 1, Outcome: "approved"})
+			}
+
+			svc.EXPECT().Import(gomock.Any(), batch, 2).Return(results)
+		}
+
+		var progress []int
+		result, err := NewImportHandler(svc)(
+			context.TODO(),
+			&entity.Job{ID: 1, Attempts: 1, Payload: payload},
+			func(percent int) {
+				progress = append(progress, percent)
+			},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []int{66, 100}, progress)
+
+		resp, ok := result.(presenter.ImportResponse)
+		require.True(t, ok)
+		assert.Equal(t, 150, resp.Total)
+		assert.Equal(t, 150, resp.Approved)
+		assert.Equal(t, 151, resp.Results[149].Line)
+	})
+
+	t.Run("Error interrupted import", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		_, err := NewImportHandler(mock_importer.NewMockService(ctrl))(
+			context.TODO(),
+			&entity.Job{ID: 1, Attempts: 2, Progress: 66, Payload: payload},
+			func(int) {},
+		)
+		assert.True(t, job.IsPermanent(err))
+		assert.EqualError(
+			t,
+			err,
+			"the import was interrupted at 66%, check the transactions created before importing the rows again",
+		)
+	})
+}
@@ -0,0 +1,253 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/brunomdev/digital-account/domain/audit"
+	"github.com/brunomdev/digital-account/domain/job"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/infra/log"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"sync"
+	"time"
+)
+
+var tracer = otel.Tracer("github.com/brunomdev/digital-account/app/worker")
+
+// Pool run the queued jobs with a fixed number of workers polling the repository
+type Pool struct {
+	repo         job.Repository
+	handlers     map[string]job.Handler
+	workers      int
+	pollInterval time.Duration
+	timeout      time.Duration
+	backoff      time.Duration
+	maxBackoff   time.Duration
+	now          func() time.Time
+	wg           sync.WaitGroup
+}
+
+type Option func(p *Pool)
+
+func NewPool(repo job.Repository, options ...Option) *Pool {
+	p := &Pool{
+		repo:         repo,
+		handlers:     make(map[string]job.Handler),
+		workers:      2,
+		pollInterval: time.Second,
+		timeout:      5 * time.Minute,
+		backoff:      5 * time.Second,
+		maxBackoff:   5 * time.Minute,
+		now:          time.Now,
+	}
+
+	for _, option := range options {
+		option(p)
+	}
+
+	return p
+}
+
+// WithWorkers set how many jobs run at the same time
+func WithWorkers(workers int) Option {
+	return func(p *Pool) {
+		p.workers = workers
+	}
+}
+
+// WithPollInterval set how long an idle worker waits before looking for a job again
+func WithPollInterval(interval time.Duration) Option {
+	return func(p *Pool) {
+		p.pollInterval = interval
+	}
+}
+
+// WithTimeout cancel the jobs running longer than timeout, it's also the lease after which another worker may claim
+// a job whose worker died
+func WithTimeout(timeout time.Duration) Option {
+	return func(p *Pool) {
+		p.timeout = timeout
+	}
+}
+
+// WithBackoff wait base before retrying a failed job, doubling on every attempt up to maxBackoff
+func WithBackoff(base, maxBackoff time.Duration) Option {
+	return func(p *Pool) {
+		p.backoff = base
+		p.maxBackoff = maxBackoff
+	}
+}
+
+// Register the handler of a job type, must be called before Start
+func (p *Pool) Register(jobType string, handler job.Handler) {
+	p.handlers[jobType] = handler
+}
+
+// Start the workers, they stop claiming jobs when ctx is done
+func (p *Pool) Start(ctx context.Context) {
+	for i := 0; i < p.workers; i++ {
+		p.wg.Add(1)
+
+		go func() {
+			defer p.wg.Done()
+
+			p.work(ctx)
+		}()
+	}
+}
+
+// Wait for the running jobs to finish after the context of Start is done
+func (p *Pool) Wait() {
+	p.wg.Wait()
+}
+
+func (p *Pool) work(ctx context.Context) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if p.RunNext(ctx) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(p.pollInterval):
+		}
+	}
+}
+
+// RunNext claim and run a single job, false when there was none to run
+func (p *Pool) RunNext(ctx context.Context) bool {
+	now := p.now().UTC()
+
+	claimed, err := p.repo.Claim(ctx, now, now.Add(p.timeout))
+	if errors.Is(err, entity.ErrNotFound) {
+		return false
+	}
+	if err != nil {
+		log.Error(ctx, "unable to claim job", err)
+
+		return false
+	}
+
+	p.run(claimed)
+
+	return true
+}
+
+// run the job detached from the workers context, so a shutdown waits for it instead of wasting the attempt. The
+// changes are audited as made by the actor who enqueued the job
+func (p *Pool) run(claimed *entity.Job) {
+	ctx, cancel := context.WithTimeout(audit.WithActor(context.Background(), claimed.Actor, claimed.RequestID), p.timeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "worker.Run", trace.WithAttributes(
+		attribute.Int("job.id", claimed.ID),
+		attribute.String("job.type", claimed.Type),
+		attribute.Int("job.attempt", claimed.Attempts),
+	))
+	defer span.End()
+
+	result, err := p.handle(ctx, claimed)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	p.finish(ctx, claimed, result, err)
+
+	err = p.repo.Update(context.Background(), claimed)
+	if err != nil {
+		log.Error(ctx, "unable to update job", err, log.Event{"job_id": claimed.ID})
+	}
+}
+
+func (p *Pool) handle(ctx context.Context, claimed *entity.Job) (result []byte, err error) {
+	handler, ok := p.handlers[claimed.Type]
+	if !ok {
+		return nil, job.Permanent(errors.Errorf("unknown job type %q", claimed.Type))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panic: %v", r)
+		}
+	}()
+
+	var mu sync.Mutex
+	progress := func(percent int) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if percent < 0 || percent > 100 || percent == claimed.Progress {
+			return
+		}
+		claimed.Progress = percent
+
+		err := p.repo.Update(ctx, claimed)
+		if err != nil {
+			log.Error(ctx, "unable to update job progress", err, log.Event{"job_id": claimed.ID})
+		}
+	}
+
+	output, err := handler(ctx, claimed, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(output)
+}
+
+// finish set the outcome of the attempt, failed jobs are queued again with backoff until the max attempts unless
+// the error is permanent
+func (p *Pool) finish(ctx context.Context, claimed *entity.Job, result []byte, err error) {
+	if err == nil {
+		claimed.Status = entity.JobStatusSucceeded
+		claimed.Progress = 100
+		claimed.Result = result
+		claimed.Error = ""
+
+		return
+	}
+
+	claimed.Error = err.Error()
+
+	if claimed.Attempts >= claimed.MaxAttempts || job.IsPermanent(err) {
+		claimed.Status = entity.JobStatusFailed
+
+		log.Error(ctx, "job failed", err, log.Event{"job_id": claimed.ID, "attempts": claimed.Attempts})
+
+		return
+	}
+
+	claimed.Status = entity.JobStatusQueued
+	claimed.RunAt = p.now().UTC().Add(p.retryDelay(claimed.Attempts))
+
+	log.Warn(ctx, "job attempt failed, retrying", log.Event{
+		"job_id":   claimed.ID,
+		"attempts": claimed.Attempts,
+		"error":    err.Error(),
+	})
+}
+
+// retryDelay double the backoff for every attempt already made
+func (p *Pool) retryDelay(attempts int) time.Duration {
+	delay := p.backoff
+	for i := 1; i < attempts && delay < p.maxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > p.maxBackoff {
+		return p.maxBackoff
+	}
+
+	return delay
+}
@@ -0,0 +1,217 @@
+package worker
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/audit"
+	"github.com/brunomdev/digital-account/domain/job"
+	"github.com/brunomdev/digital-account/domain/job/mock_job"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPool_RunNext(t *testing.T) {
+	now := time.Date(2022, 3, 24, 10, 0, 0, 0, time.UTC)
+	leaseUntil := now.Add(time.Minute)
+
+	claimed := func(attempts int) *entity.Job {
+		return &entity.Job{
+			ID:          1,
+			Type:        "test",
+			Payload:     []byte(`{}`),
+			Status:      entity.JobStatusRunning,
+			Attempts:    attempts,
+			MaxAttempts: 3,
+			Actor:       "backoffice",
+			RequestID:   "request-1",
+			RunAt:       leaseUntil,
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		repoArgs func(ctrl *gomock.Controller) job.Repository
+		handler  job.Handler
+		want     bool
+	}{
+		{
+			name: "Empty queue",
+			repoArgs: func(ctrl *gomock.Controller) job.Repository {
+				repo := mock_job.NewMockRepository(ctrl)
+				repo.EXPECT().Claim(gomock.Any(), now, leaseUntil).Return(nil, entity.ErrNotFound)
+
+				return repo
+			},
+			want: false,
+		},
+		{
+			name: "Error claim",
+			repoArgs: func(ctrl *gomock.Controller) job.Repository {
+				repo := mock_job.NewMockRepository(ctrl)
+				repo.EXPECT().Claim(gomock.Any(), now, leaseUntil).Return(nil, errors.New("database error"))
+
+				return repo
+			},
+			want: false,
+		},
+		{
+			name: "Succeeded with progress",
+			repoArgs: func(ctrl *gomock.Controller) job.Repository {
+				repo := mock_job.NewMockRepository(ctrl)
+				repo.EXPECT().Claim(gomock.Any(), now, leaseUntil).Return(claimed(1), nil)
+
+				progress := claimed(1)
+				progress.Progress = 50
+				repo.EXPECT().Update(gomock.Any(), progress).Return(nil)
+
+				succeeded := claimed(1)
+				succeeded.Status = entity.JobStatusSucceeded
+				succeeded.Progress = 100
+				succeeded.Result = []byte(`{"total":2}`)
+				repo.EXPECT().Update(gomock.Any(), succeeded).Return(nil)
+
+				return repo
+			},
+			handler: func(ctx context.Context, j *entity.Job, progress func(percent int)) (interface{}, error) {
+				// the changes of the job are audited as made by who enqueued it
+				actor, requestID := audit.ActorFrom(ctx)
+				assert.Equal(t, "backoffice", actor)
+				assert.Equal(t, "request-1", requestID)
+
+				progress(50)
+				progress(50)
+
+				return map[string]int{"total": 2}, nil
+			},
+			want: true,
+		},
+		{
+			name: "Failed attempt retried with backoff",
+			repoArgs: func(ctrl *gomock.Controller) job.Repository {
+				repo := mock_job.NewMockRepository(ctrl)
+				repo.EXPECT().Claim(gomock.Any(), now, leaseUntil).Return(claimed(2), nil)
+
+				retry := claimed(2)
+				retry.Status = entity.JobStatusQueued
+				retry.Error = "timeout"
+				retry.RunAt = now.Add(2 * time.Second)
+				repo.EXPECT().Update(gomock.Any(), retry).Return(nil)
+
+				return repo
+			},
+			handler: func(ctx context.Context, j *entity.Job, progress func(percent int)) (interface{}, error) {
+				return nil, errors.New("timeout")
+			},
+			want: true,
+		},
+		{
+			name: "Failed after the max attempts",
+			repoArgs: func(ctrl *gomock.Controller) job.Repository {
+				repo := mock_job.NewMockRepository(ctrl)
+				repo.EXPECT().Claim(gomock.Any(), now, leaseUntil).Return(claimed(3), nil)
+
+				failed := claimed(3)
+				failed.Status = entity.JobStatusFailed
+				failed.Error = "timeout"
+				repo.EXPECT().Update(gomock.Any(), failed).Return(nil)
+
+				return repo
+			},
+			handler: func(ctx context.Context, j *entity.Job, progress func(percent int)) (interface{}, error) {
+				return nil, errors.New("timeout")
+			},
+			want: true,
+		},
+		{
+			name: "Failed by a permanent error",
+			repoArgs: func(ctrl *gomock.Controller) job.Repository {
+				repo := mock_job.NewMockRepository(ctrl)
+				repo.EXPECT().Claim(gomock.Any(), now, leaseUntil).Return(claimed(1), nil)
+
+				failed := claimed(1)
+				failed.Status = entity.JobStatusFailed
+				failed.Error = "invalid payload"
+				repo.EXPECT().Update(gomock.Any(), failed).Return(nil)
+
+				return repo
+			},
+			handler: func(ctx context.Context, j *entity.Job, progress func(percent int)) (interface{}, error) {
+				return nil, job.Permanent(errors.New("invalid payload"))
+			},
+			want: true,
+		},
+		{
+			name: "Failed by a panic",
+			repoArgs: func(ctrl *gomock.Controller) job.Repository {
+				repo := mock_job.NewMockRepository(ctrl)
+				repo.EXPECT().Claim(gomock.Any(), now, leaseUntil).Return(claimed(3), nil)
+
+				failed := claimed(3)
+				failed.Status = entity.JobStatusFailed
+				failed.Error = "job panic: boom"
+				repo.EXPECT().Update(gomock.Any(), failed).Return(nil)
+
+				return repo
+			},
+			handler: func(ctx context.Context, j *entity.Job, progress func(percent int)) (interface{}, error) {
+				panic("boom")
+			},
+			want: true,
+		},
+		{
+			name: "Unknown job type",
+			repoArgs: func(ctrl *gomock.Controller) job.Repository {
+				repo := mock_job.NewMockRepository(ctrl)
+				repo.EXPECT().Claim(gomock.Any(), now, leaseUntil).Return(claimed(1), nil)
+
+				failed := claimed(1)
+				failed.Status = entity.JobStatusFailed
+				failed.Error = `unknown job type "test"`
+				repo.EXPECT().Update(gomock.Any(), failed).Return(nil)
+
+				return repo
+			},
+			want: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			p := NewPool(tc.repoArgs(ctrl), WithTimeout(time.Minute), WithBackoff(time.Second, time.Minute))
+			p.now = func() time.Time {
+				return now
+			}
+
+			if tc.handler != nil {
+				p.Register("test", tc.handler)
+			}
+
+			assert.Equal(t, tc.want, p.RunNext(context.TODO()))
+		})
+	}
+}
+
+func TestPool_retryDelay(t *testing.T) {
+	p := NewPool(nil, WithBackoff(5*time.Second, time.Minute))
+
+	testCases := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 5 * time.Second},
+		{attempts: 2, want: 10 * time.Second},
+		{attempts: 4, want: 40 * time.Second},
+		{attempts: 5, want: time.Minute},
+		{attempts: 50, want: time.Minute},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, p.retryDelay(tc.attempts), "retryDelay(%d)", tc.attempts)
+	}
+}
@@ -0,0 +1,127 @@
+// Command import send a CSV or JSONL file of transactions to POST /transactions/import and write the report
+//
+//	go run ./cmd/import -url http://localhost:8080 -api-key <key> -file transactions.csv -report report.json
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var contentTypes = map[string]string{
+	"csv":   "text/csv",
+	"jsonl": "application/x-ndjson",
+}
+
+type report struct {
+	Total    int `json:"total"`
+	Approved int `json:"approved"`
+	Declined int `json:"declined"`
+	Failed   int `json:"failed"`
+	Invalid  int `json:"invalid"`
+}
+
+func main() {
+	baseURL := flag.String("url", "http://localhost:8080", "base URL of the API")
+	apiKey := flag.String("api-key", os.Getenv("API_KEY"), "API key sent on the X-Api-Key header, by default $API_KEY")
+	file := flag.String("file", "", "CSV or JSONL file to import")
+	format := flag.String("format", "", "csv or jsonl, by default the file extension")
+	parallelism := flag.Int("parallelism", 0, "accounts imported at the same time, by default the server config")
+	reportFile := flag.String("report", "", "file to write the JSON report, by default stdout")
+	timeout := flag.Duration("timeout", 5*time.Minute, "timeout of the import request")
+	flag.Parse()
+
+	if err := run(*baseURL, *apiKey, *file, *format, *parallelism, *reportFile, *timeout); err != nil {
+		fmt.Fprintln(os.Stderr, "import:", err)
+		os.Exit(1)
+	}
+}
+
+func run(baseURL, apiKey, file, format string, parallelism int, reportFile string, timeout time.Duration) error {
+	if file == "" {
+		return fmt.Errorf("the -file flag is required")
+	}
+
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(file), ".")
+	}
+	contentType, ok := contentTypes[format]
+	if !ok {
+		return fmt.Errorf("unknown format %q, use -format csv or -format jsonl", format)
+	}
+
+	body, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{"format": {format}}
+	if parallelism > 0 {
+		query.Set("parallelism", strconv.Itoa(parallelism))
+	}
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		strings.TrimSuffix(baseURL, "/")+"/transactions/import?"+query.Encode(),
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if apiKey != "" {
+		req.Header.Set("X-Api-Key", apiKey)
+	}
+
+	resp, err := (&http.Client{Timeout: timeout}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, content)
+	}
+
+	var summary report
+	err = json.Unmarshal(content, &summary)
+	if err != nil {
+		return err
+	}
+
+	if reportFile == "" {
+		_, err = os.Stdout.Write(append(content, '\n'))
+	} else {
+		err = os.WriteFile(reportFile, content, 0o644)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(
+		os.Stderr,
+		"total %d, approved %d, declined %d, failed %d, invalid %d\n",
+		summary.Total,
+		summary.Approved,
+		summary.Declined,
+		summary.Failed,
+		summary.Invalid,
+	)
+
+	return nil
+}
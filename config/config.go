@@ -6,20 +6,72 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"time"
 )
 
 const fileConfig = ".env"
 
 type Config struct {
-	AppDebug           bool   `mapstructure:"APP_DEBUG"`
-	HTTPPort           string `mapstructure:"HTTP_PORT"`
-	DBHost             string `mapstructure:"DB_HOST"`
-	DBPort             string `mapstructure:"DB_PORT"`
-	DBDatabase         string `mapstructure:"DB_DATABASE"`
-	DBUser             string `mapstructure:"DB_USER"`
-	DBPass             string `mapstructure:"DB_PASS"`
-	NewRelicAppName    string `mapstructure:"NEW_RELIC_APP_NAME"`
-	NewRelicLicenseKey string `mapstructure:"NEW_RELIC_LICENSE_KEY"`
+	AppDebug                        bool          `mapstructure:"APP_DEBUG"`
+	LogBodyFields                   []string      `mapstructure:"LOG_BODY_FIELDS"`
+	AccessLogSampleRatio            float64       `mapstructure:"ACCESS_LOG_SAMPLE_RATIO"`
+	AccessLogSlowThreshold          time.Duration `mapstructure:"ACCESS_LOG_SLOW_THRESHOLD"`
+	AuthEnabled                     bool          `mapstructure:"AUTH_ENABLED"`
+	TimeTravelEnabled               bool          `mapstructure:"TIME_TRAVEL_ENABLED"`
+	HTTPPort                        string        `mapstructure:"HTTP_PORT"`
+	ShutdownDrainDelay              time.Duration `mapstructure:"SHUTDOWN_DRAIN_DELAY"`
+	DBDriver                        string        `mapstructure:"DB_DRIVER"`
+	DBHost                          string        `mapstructure:"DB_HOST"`
+	DBPort                          string        `mapstructure:"DB_PORT"`
+	DBDatabase                      string        `mapstructure:"DB_DATABASE"`
+	DBUser                          string        `mapstructure:"DB_USER"`
+	DBPass                          string        `mapstructure:"DB_PASS"`
+	DBSSLMode                       string        `mapstructure:"DB_SSL_MODE"`
+	DBPath                          string        `mapstructure:"DB_PATH"`
+	PIIEncryptionKey                string        `mapstructure:"PII_ENCRYPTION_KEY"`
+	MemoryAPIKey                    string        `mapstructure:"MEMORY_API_KEY"`
+	CacheEnabled                    bool          `mapstructure:"CACHE_ENABLED"`
+	CacheSize                       int           `mapstructure:"CACHE_SIZE"`
+	CacheOperationTypeTTL           time.Duration `mapstructure:"CACHE_OPERATION_TYPE_TTL"`
+	CacheAccountTTL                 time.Duration `mapstructure:"CACHE_ACCOUNT_TTL"`
+	NewRelicAppName                 string        `mapstructure:"NEW_RELIC_APP_NAME"`
+	NewRelicLicenseKey              string        `mapstructure:"NEW_RELIC_LICENSE_KEY"`
+	RateLimitMax                    int           `mapstructure:"RATE_LIMIT_MAX"`
+	RateLimitExpiration             time.Duration `mapstructure:"RATE_LIMIT_EXPIRATION"`
+	RateLimitTransactionsMax        int           `mapstructure:"RATE_LIMIT_TRANSACTIONS_MAX"`
+	RateLimitTransactionsExpiration time.Duration `mapstructure:"RATE_LIMIT_TRANSACTIONS_EXPIRATION"`
+	VelocityMaxDebits               int           `mapstructure:"VELOCITY_MAX_DEBITS"`
+	VelocityMaxAmount               float64       `mapstructure:"VELOCITY_MAX_AMOUNT"`
+	VelocityWindow                  time.Duration `mapstructure:"VELOCITY_WINDOW"`
+	RiskRulesFile                   string        `mapstructure:"RISK_RULES_FILE"`
+	AccountCurrency                 string        `mapstructure:"ACCOUNT_CURRENCY"`
+	ExchangeRatesFile               string        `mapstructure:"EXCHANGE_RATES_FILE"`
+	ExchangeSpreadRate              float64       `mapstructure:"EXCHANGE_SPREAD_RATE"`
+	ExchangeIOFRate                 float64       `mapstructure:"EXCHANGE_IOF_RATE"`
+	CardBIN                         string        `mapstructure:"CARD_BIN"`
+	CardValidityYears               int           `mapstructure:"CARD_VALIDITY_YEARS"`
+	TransactionEventDateWindow      time.Duration `mapstructure:"TRANSACTION_EVENT_DATE_WINDOW"`
+	ImportParallelism               int           `mapstructure:"IMPORT_PARALLELISM"`
+	JobsWorkers                     int           `mapstructure:"JOBS_WORKERS"`
+	JobsPollInterval                time.Duration `mapstructure:"JOBS_POLL_INTERVAL"`
+	JobsTimeout                     time.Duration `mapstructure:"JOBS_TIMEOUT"`
+	JobsMaxAttempts                 int           `mapstructure:"JOBS_MAX_ATTEMPTS"`
+	JobsBackoff                     time.Duration `mapstructure:"JOBS_BACKOFF"`
+	JobsMaxBackoff                  time.Duration `mapstructure:"JOBS_MAX_BACKOFF"`
+	ChargesMonthlyInterestRate      float64       `mapstructure:"CHARGES_MONTHLY_INTEREST_RATE"`
+	ChargesGraceDays                int           `mapstructure:"CHARGES_GRACE_DAYS"`
+	ChargesLateFee                  float64       `mapstructure:"CHARGES_LATE_FEE"`
+	ChargesPenaltyRate              float64       `mapstructure:"CHARGES_PENALTY_RATE"`
+	SchedulerEnabled                bool          `mapstructure:"SCHEDULER_ENABLED"`
+	ScheduleCharges                 string        `mapstructure:"SCHEDULE_CHARGES"`
+	ScheduleCleanup                 string        `mapstructure:"SCHEDULE_CLEANUP"`
+	ScheduleLimitReconciliation     string        `mapstructure:"SCHEDULE_LIMIT_RECONCILIATION"`
+	ScheduleTransactionReport       string        `mapstructure:"SCHEDULE_TRANSACTION_REPORT"`
+	CleanupRetention                time.Duration `mapstructure:"CLEANUP_RETENTION"`
+	TracingServiceName              string        `mapstructure:"TRACING_SERVICE_NAME"`
+	TracingSampleRatio              float64       `mapstructure:"TRACING_SAMPLE_RATIO"`
+	OTLPEndpoint                    string        `mapstructure:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OTLPInsecure                    bool          `mapstructure:"OTEL_EXPORTER_OTLP_INSECURE"`
 }
 
 // Load the config from file or env to the Config struct
@@ -28,6 +80,45 @@ func Load() (*Config, error) {
 	viper.AutomaticEnv()
 
 	viper.SetDefault("HTTP_PORT", "8080")
+	viper.SetDefault("SHUTDOWN_DRAIN_DELAY", 5*time.Second)
+	viper.SetDefault("ACCESS_LOG_SAMPLE_RATIO", 1)
+	viper.SetDefault("ACCESS_LOG_SLOW_THRESHOLD", time.Second)
+	viper.SetDefault("AUTH_ENABLED", true)
+	viper.SetDefault("TIME_TRAVEL_ENABLED", false)
+	viper.SetDefault("DB_DRIVER", "mysql")
+	viper.SetDefault("DB_SSL_MODE", "disable")
+	viper.SetDefault("DB_PATH", "digital-account.db")
+	viper.SetDefault("CACHE_ENABLED", true)
+	viper.SetDefault("CACHE_SIZE", 1000)
+	viper.SetDefault("CACHE_OPERATION_TYPE_TTL", 10*time.Minute)
+	viper.SetDefault("CACHE_ACCOUNT_TTL", 5*time.Second)
+	viper.SetDefault("RATE_LIMIT_MAX", 300)
+	viper.SetDefault("RATE_LIMIT_EXPIRATION", time.Minute)
+	viper.SetDefault("RATE_LIMIT_TRANSACTIONS_MAX", 60)
+	viper.SetDefault("RATE_LIMIT_TRANSACTIONS_EXPIRATION", time.Minute)
+	viper.SetDefault("VELOCITY_WINDOW", time.Hour)
+	viper.SetDefault("RISK_RULES_FILE", "config/risk_rules.yaml")
+	viper.SetDefault("ACCOUNT_CURRENCY", "BRL")
+	viper.SetDefault("EXCHANGE_RATES_FILE", "config/exchange_rates.yaml")
+	viper.SetDefault("CARD_BIN", "516292")
+	viper.SetDefault("CARD_VALIDITY_YEARS", 5)
+	viper.SetDefault("TRANSACTION_EVENT_DATE_WINDOW", 72*time.Hour)
+	viper.SetDefault("IMPORT_PARALLELISM", 4)
+	viper.SetDefault("JOBS_WORKERS", 2)
+	viper.SetDefault("JOBS_POLL_INTERVAL", time.Second)
+	viper.SetDefault("JOBS_TIMEOUT", 10*time.Minute)
+	viper.SetDefault("JOBS_MAX_ATTEMPTS", 3)
+	viper.SetDefault("JOBS_BACKOFF", 5*time.Second)
+	viper.SetDefault("JOBS_MAX_BACKOFF", 5*time.Minute)
+	viper.SetDefault("CHARGES_GRACE_DAYS", 10)
+	viper.SetDefault("SCHEDULER_ENABLED", true)
+	viper.SetDefault("SCHEDULE_CHARGES", "0 2 * * *")
+	viper.SetDefault("SCHEDULE_CLEANUP", "0 3 * * *")
+	viper.SetDefault("SCHEDULE_LIMIT_RECONCILIATION", "30 3 * * *")
+	viper.SetDefault("SCHEDULE_TRANSACTION_REPORT", "0 1 * * *")
+	viper.SetDefault("CLEANUP_RETENTION", 90*24*time.Hour)
+	viper.SetDefault("TRACING_SERVICE_NAME", "digital-account")
+	viper.SetDefault("TRACING_SAMPLE_RATIO", 1)
 
 	var cfg Config
 
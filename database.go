@@ -0,0 +1,160 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/brunomdev/digital-account/config"
+	"github.com/brunomdev/digital-account/domain"
+	"github.com/brunomdev/digital-account/domain/client"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/infra/encryption"
+	"github.com/brunomdev/digital-account/infra/memory"
+	"github.com/brunomdev/digital-account/infra/migration"
+	"github.com/brunomdev/digital-account/infra/mysql"
+	"github.com/brunomdev/digital-account/infra/postgres"
+	"github.com/brunomdev/digital-account/infra/sqlite"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	migrateMysql "github.com/golang-migrate/migrate/v4/database/mysql"
+	migratePostgres "github.com/golang-migrate/migrate/v4/database/postgres"
+	migrateSqlite "github.com/golang-migrate/migrate/v4/database/sqlite"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/pkg/errors"
+)
+
+const (
+	driverMySQL    = "mysql"
+	driverPostgres = "postgres"
+	driverSqlite   = "sqlite"
+	driverMemory   = "memory"
+)
+
+// memoryClient is the client of MEMORY_API_KEY, the memory backend has no api_clients table to register one
+var memoryClient = entity.Client{
+	ID:   1,
+	Name: "memory",
+	Role: entity.RoleRisk,
+	Scopes: []entity.Scope{
+		entity.ScopeAccountsRead,
+		entity.ScopeAccountsWrite,
+		entity.ScopeCustomersRead,
+		entity.ScopeCustomersWrite,
+		entity.ScopeCardsRead,
+		entity.ScopeCardsWrite,
+		entity.ScopeTransactionsWrite,
+		entity.ScopeLimitsAdmin,
+		entity.ScopeSchedulesAdmin,
+		entity.ScopeAuditRead,
+		entity.ScopeTimeTravelAdmin,
+	},
+}
+
+// storage is the backend chosen by DB_DRIVER, already migrated. db is nil for the memory backend
+type storage struct {
+	db            *sql.DB
+	repository    *domain.Repository
+	schemaVersion uint
+}
+
+func openStorage(cfg *config.Config) (*storage, error) {
+	var (
+		db               *sql.DB
+		driver           database.Driver
+		repository       *domain.Repository
+		migrationsSource string
+		err              error
+	)
+
+	// the key is checked by every backend, a deploy missing it fails on start up and not when it moves to a SQL one
+	if cfg.PIIEncryptionKey == "" {
+		return nil, errors.New("PII_ENCRYPTION_KEY is required")
+	}
+
+	cipher, err := encryption.NewCipher(cfg.PIIEncryptionKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid PII_ENCRYPTION_KEY")
+	}
+
+	switch cfg.DBDriver {
+	case driverMySQL:
+		db, err = mysql.NewDB(fmt.Sprintf(
+			"%s:%s@tcp(%s:%s)/%s?multiStatements=true&parseTime=true",
+			cfg.DBUser, cfg.DBPass, cfg.DBHost, cfg.DBPort, cfg.DBDatabase,
+		))
+		if err != nil {
+			return nil, errors.Wrap(err, "unable connect with database")
+		}
+
+		driver, err = migrateMysql.WithInstance(db, &migrateMysql.Config{})
+		repository = mysql.NewRepository(db)
+		migrationsSource = "file://migrations"
+	case driverPostgres:
+		db, err = postgres.NewDB(fmt.Sprintf(
+			"postgres://%s:%s@%s:%s/%s?sslmode=%s",
+			cfg.DBUser, cfg.DBPass, cfg.DBHost, cfg.DBPort, cfg.DBDatabase, cfg.DBSSLMode,
+		))
+		if err != nil {
+			return nil, errors.Wrap(err, "unable connect with database")
+		}
+
+		driver, err = migratePostgres.WithInstance(db, &migratePostgres.Config{})
+		repository = postgres.NewRepository(db)
+		migrationsSource = "file://migrations/postgres"
+	case driverSqlite:
+		db, err = sqlite.NewDB(cfg.DBPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable connect with database")
+		}
+
+		driver, err = migrateSqlite.WithInstance(db, &migrateSqlite.Config{})
+		repository = sqlite.NewRepository(db)
+		migrationsSource = "file://migrations/sqlite"
+	case driverMemory:
+		return openMemory(cfg)
+	default:
+		return nil, errors.Errorf("unknown database driver %q", cfg.DBDriver)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get %s driver", cfg.DBDriver)
+	}
+
+	// the personal data of the customers is encrypted at rest, the memory backend keeps nothing at rest
+	repository.Customer = encryption.NewCustomerRepository(repository.Customer, cipher)
+
+	m, err := migrate.NewWithDatabaseInstance(migrationsSource, cfg.DBDatabase, driver)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to define initiate migrate")
+	}
+
+	err = m.Up()
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return nil, errors.Wrap(err, "unable to migrate database")
+	}
+
+	schemaVersion, err := migration.LatestVersion(migrationsSource)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the latest migration version")
+	}
+
+	return &storage{
+		db:            db,
+		repository:    repository,
+		schemaVersion: schemaVersion,
+	}, nil
+}
+
+// openMemory create the memory backend, with the authentication enabled it starts with the client of MEMORY_API_KEY
+// as every route would answer 401 otherwise
+func openMemory(cfg *config.Config) (*storage, error) {
+	if !cfg.AuthEnabled {
+		return &storage{repository: memory.NewRepository(nil)}, nil
+	}
+
+	if cfg.MemoryAPIKey == "" {
+		return nil, errors.New("MEMORY_API_KEY is required by the memory backend unless AUTH_ENABLED is false")
+	}
+
+	clients := map[string]entity.Client{client.HashKey(cfg.MemoryAPIKey): memoryClient}
+
+	return &storage{repository: memory.NewRepository(clients)}, nil
+}
@@ -5,16 +5,38 @@ package account
 import (
 	"context"
 	"github.com/brunomdev/digital-account/entity"
+	"time"
 )
 
 type Service interface {
 	Create(ctx context.Context, docNumber string, availableCreditLimit float64) (*entity.Account, error)
 	Get(ctx context.Context, id int) (*entity.Account, error)
+	// UpdateCreditLimit set the available credit limit changed by a transaction
 	UpdateCreditLimit(ctx context.Context, id int, availableCreditLimit float64) (*entity.Account, error)
+	// AdjustCreditLimit set the available credit limit outside the transactions, keeping the change on the limit
+	// history read by the statements
+	AdjustCreditLimit(ctx context.Context, id int, availableCreditLimit float64) (*entity.Account, error)
 }
 
 type Repository interface {
-	Save(ctx context.Context, docNumber string, availableCreditLimit float64) (*entity.Account, error)
+	Save(ctx context.Context, docNumber string, availableCreditLimit float64, createdAt time.Time) (*entity.Account, error)
+	// SaveForCustomer save an account held by the customer
+	SaveForCustomer(
+		ctx context.Context,
+		customerID int,
+		docNumber string,
+		availableCreditLimit float64,
+		createdAt time.Time,
+	) (*entity.Account, error)
 	GetByID(ctx context.Context, id int) (*entity.Account, error)
 	Update(ctx context.Context, account *entity.Account) (*entity.Account, error)
+	// SetCreditLimit set the available credit limit of the account and append the difference to the previous limit to
+	// its history, made at the given time, in a single transaction
+	SetCreditLimit(ctx context.Context, id int, availableCreditLimit float64, at time.Time) (*entity.Account, error)
+	// SumAdjustmentsSince return the sum of the limit history of the account made at or after since
+	SumAdjustmentsSince(ctx context.Context, accountID int, since time.Time) (float64, error)
+	// ListAdjustments return the limit history of the account made from (inclusive) to (exclusive), oldest first
+	ListAdjustments(ctx context.Context, accountID int, from, to time.Time) ([]*entity.CreditLimitAdjustment, error)
+	// List the accounts with id greater than afterID, ordered by id, up to limit
+	List(ctx context.Context, afterID, limit int) ([]*entity.Account, error)
 }
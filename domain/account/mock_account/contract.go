@@ -7,6 +7,7 @@ package mock_account
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/brunomdev/digital-account/entity"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,21 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// AdjustCreditLimit mocks base method.
+func (m *MockService) AdjustCreditLimit(ctx context.Context, id int, availableCreditLimit float64) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustCreditLimit", ctx, id, availableCreditLimit)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustCreditLimit indicates an expected call of AdjustCreditLimit.
+func (mr *MockServiceMockRecorder) AdjustCreditLimit(ctx, id, availableCreditLimit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustCreditLimit", reflect.TypeOf((*MockService)(nil).AdjustCreditLimit), ctx, id, availableCreditLimit)
+}
+
 // Create mocks base method.
 func (m *MockService) Create(ctx context.Context, docNumber string, availableCreditLimit float64) (*entity.Account, error) {
 	m.ctrl.T.Helper()
@@ -118,19 +134,94 @@ func (mr *MockRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, id)
 }
 
+// List mocks base method.
+func (m *MockRepository) List(ctx context.Context, afterID, limit int) ([]*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepositoryMockRecorder) List(ctx, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx, afterID, limit)
+}
+
+// ListAdjustments mocks base method.
+func (m *MockRepository) ListAdjustments(ctx context.Context, accountID int, from, to time.Time) ([]*entity.CreditLimitAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAdjustments", ctx, accountID, from, to)
+	ret0, _ := ret[0].([]*entity.CreditLimitAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAdjustments indicates an expected call of ListAdjustments.
+func (mr *MockRepositoryMockRecorder) ListAdjustments(ctx, accountID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAdjustments", reflect.TypeOf((*MockRepository)(nil).ListAdjustments), ctx, accountID, from, to)
+}
+
 // Save mocks base method.
-func (m *MockRepository) Save(ctx context.Context, docNumber string, availableCreditLimit float64) (*entity.Account, error) {
+func (m *MockRepository) Save(ctx context.Context, docNumber string, availableCreditLimit float64, createdAt time.Time) (*entity.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Save", ctx, docNumber, availableCreditLimit)
+	ret := m.ctrl.Call(m, "Save", ctx, docNumber, availableCreditLimit, createdAt)
 	ret0, _ := ret[0].(*entity.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Save indicates an expected call of Save.
-func (mr *MockRepositoryMockRecorder) Save(ctx, docNumber, availableCreditLimit interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Save(ctx, docNumber, availableCreditLimit, createdAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRepository)(nil).Save), ctx, docNumber, availableCreditLimit, createdAt)
+}
+
+// SaveForCustomer mocks base method.
+func (m *MockRepository) SaveForCustomer(ctx context.Context, customerID int, docNumber string, availableCreditLimit float64, createdAt time.Time) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveForCustomer", ctx, customerID, docNumber, availableCreditLimit, createdAt)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveForCustomer indicates an expected call of SaveForCustomer.
+func (mr *MockRepositoryMockRecorder) SaveForCustomer(ctx, customerID, docNumber, availableCreditLimit, createdAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveForCustomer", reflect.TypeOf((*MockRepository)(nil).SaveForCustomer), ctx, customerID, docNumber, availableCreditLimit, createdAt)
+}
+
+// SetCreditLimit mocks base method.
+func (m *MockRepository) SetCreditLimit(ctx context.Context, id int, availableCreditLimit float64, at time.Time) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCreditLimit", ctx, id, availableCreditLimit, at)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCreditLimit indicates an expected call of SetCreditLimit.
+func (mr *MockRepositoryMockRecorder) SetCreditLimit(ctx, id, availableCreditLimit, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCreditLimit", reflect.TypeOf((*MockRepository)(nil).SetCreditLimit), ctx, id, availableCreditLimit, at)
+}
+
+// SumAdjustmentsSince mocks base method.
+func (m *MockRepository) SumAdjustmentsSince(ctx context.Context, accountID int, since time.Time) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumAdjustmentsSince", ctx, accountID, since)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumAdjustmentsSince indicates an expected call of SumAdjustmentsSince.
+func (mr *MockRepositoryMockRecorder) SumAdjustmentsSince(ctx, accountID, since interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRepository)(nil).Save), ctx, docNumber, availableCreditLimit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumAdjustmentsSince", reflect.TypeOf((*MockRepository)(nil).SumAdjustmentsSince), ctx, accountID, since)
 }
 
 // Update mocks base method.
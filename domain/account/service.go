@@ -2,35 +2,110 @@ package account
 
 import (
 	"context"
+	"github.com/brunomdev/digital-account/domain/audit"
 	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/infra/log"
+	"github.com/brunomdev/digital-account/pkg/clock"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/brunomdev/digital-account/domain/account")
+
 type service struct {
-	repo Repository
+	repo         Repository
+	clock        clock.Clock
+	auditService audit.Service
 }
 
-func NewService(repo Repository) Service {
+// NewService create the account service, every change of the accounts is recorded on the audit trail
+func NewService(repo Repository, clk clock.Clock, auditService audit.Service) Service {
 	return &service{
-		repo: repo,
+		repo:         repo,
+		clock:        clk,
+		auditService: auditService,
 	}
 }
 
 func (s *service) Create(ctx context.Context, docNumber string, availableCreditLimit float64) (*entity.Account, error) {
-	return s.repo.Save(ctx, docNumber, availableCreditLimit)
+	ctx, span := tracer.Start(ctx, "account.Create")
+	defer span.End()
+
+	account, err := s.repo.Save(ctx, docNumber, availableCreditLimit, s.clock.Now(ctx).UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, entity.AuditActionCreate, account.ID, nil, account)
+
+	return account, nil
 }
 
 func (s *service) Get(ctx context.Context, id int) (*entity.Account, error) {
+	ctx, span := tracer.Start(ctx, "account.Get", trace.WithAttributes(attribute.Int("account.id", id)))
+	defer span.End()
+
 	return s.repo.GetByID(ctx, id)
 }
 
 func (s *service) UpdateCreditLimit(ctx context.Context, id int, newLimit float64) (*entity.Account, error) {
+	ctx, span := tracer.Start(ctx, "account.UpdateCreditLimit", trace.WithAttributes(attribute.Int("account.id", id)))
+	defer span.End()
+
 	account, err := s.repo.GetByID(ctx, id)
 	if errors.Is(err, entity.ErrNotFound) {
 		return nil, errors.Wrap(err, "account")
 	}
+	if err != nil {
+		return nil, errors.Wrap(err, "UpdateCreditLimit")
+	}
 
+	before := *account
 	account.AvailabelCreditLimit = newLimit
 
-	return s.repo.Update(ctx, account)
+	updated, err := s.repo.Update(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, entity.AuditActionUpdateCreditLimit, id, &before, updated)
+
+	return updated, nil
+}
+
+func (s *service) AdjustCreditLimit(ctx context.Context, id int, newLimit float64) (*entity.Account, error) {
+	ctx, span := tracer.Start(ctx, "account.AdjustCreditLimit", trace.WithAttributes(attribute.Int("account.id", id)))
+	defer span.End()
+
+	before, err := s.repo.GetByID(ctx, id)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, errors.Wrap(err, "account")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "AdjustCreditLimit")
+	}
+
+	updated, err := s.repo.SetCreditLimit(ctx, id, newLimit, s.clock.Now(ctx).UTC())
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, errors.Wrap(err, "account")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "AdjustCreditLimit")
+	}
+
+	s.audit(ctx, entity.AuditActionUpdateCreditLimit, id, before, updated)
+
+	return updated, nil
+}
+
+// audit record the change of the account on the audit trail. The change is already committed and undoing it could
+// overwrite a change made meanwhile, so a failure is logged instead of failing the request
+func (s *service) audit(ctx context.Context, action entity.AuditAction, id int, before, after interface{}) {
+	err := s.auditService.Record(ctx, action, entity.AuditEntityAccount, id, before, after)
+	if err != nil {
+		log.Error(ctx, "unable to audit account", err, log.Event{"account_id": id, "action": action})
+		trace.SpanFromContext(ctx).RecordError(err)
+	}
 }
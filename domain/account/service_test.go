@@ -3,22 +3,28 @@ package account
 import (
 	"context"
 	"github.com/brunomdev/digital-account/domain/account/mock_account"
+	"github.com/brunomdev/digital-account/domain/audit"
+	"github.com/brunomdev/digital-account/domain/audit/mock_audit"
 	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/pkg/clock"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	"testing"
+	"time"
 )
 
+var now = time.Date(2022, 3, 30, 10, 0, 0, 0, time.UTC)
+
 func Test_service_Create(t *testing.T) {
 	type args struct {
-		ctx                  context.Context
 		docNumber            string
 		availableCreditLimit float64
 	}
 	testCases := []struct {
 		name    string
 		svcArgs func(ctrl *gomock.Controller) Repository
+		audit   func(ctrl *gomock.Controller) audit.Service
 		args    args
 		want    *entity.Account
 		wantErr bool
@@ -28,7 +34,7 @@ func Test_service_Create(t *testing.T) {
 			svcArgs: func(ctrl *gomock.Controller) Repository {
 				repo := mock_account.NewMockRepository(ctrl)
 
-				repo.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+				repo.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
 
 				return repo
 			},
@@ -38,28 +44,60 @@ func Test_service_Create(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Error audit is only logged",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_account.NewMockRepository(ctrl)
+
+				repo.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any(), now).Return(&entity.Account{ID: 1}, nil)
+
+				return repo
+			},
+			audit: func(ctrl *gomock.Controller) audit.Service {
+				auditService := mock_audit.NewMockService(ctrl)
+				auditService.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), 1, nil, gomock.Any()).
+					Return(errors.New("database error"))
+
+				return auditService
+			},
+			args: args{
+				docNumber: "12345678900",
+			},
+			want:    &entity.Account{ID: 1},
+			wantErr: false,
+		},
 		{
 			name: "Success",
 			svcArgs: func(ctrl *gomock.Controller) Repository {
 				repo := mock_account.NewMockRepository(ctrl)
 
-				repo.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, docNumber string, availableCreditLimit float64) (*entity.Account, error) {
+				repo.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any(), now).
+					DoAndReturn(func(ctx context.Context, docNumber string, availableCreditLimit float64, createdAt time.Time) (*entity.Account, error) {
 						return &entity.Account{
 							ID:                   1,
 							DocumentNumber:       docNumber,
 							AvailabelCreditLimit: availableCreditLimit,
+							CreatedAt:            createdAt,
 						}, nil
 					})
 
 				return repo
 			},
+			audit: func(ctrl *gomock.Controller) audit.Service {
+				auditService := mock_audit.NewMockService(ctrl)
+				auditService.EXPECT().
+					Record(gomock.Any(), entity.AuditActionCreate, entity.AuditEntityAccount, 1, nil, gomock.Any()).
+					Return(nil)
+
+				return auditService
+			},
 			args: args{
 				docNumber: "12345678900",
 			},
 			want: &entity.Account{
 				ID:             1,
 				DocumentNumber: "12345678900",
+				CreatedAt:      now,
 			},
 			wantErr: false,
 		},
@@ -70,9 +108,14 @@ func Test_service_Create(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			s := NewService(tc.svcArgs(ctrl))
+			var auditService audit.Service = mock_audit.NewMockService(ctrl)
+			if tc.audit != nil {
+				auditService = tc.audit(ctrl)
+			}
+
+			s := NewService(tc.svcArgs(ctrl), clock.NewFake(now), auditService)
 
-			got, err := s.Create(tc.args.ctx, tc.args.docNumber, tc.args.availableCreditLimit)
+			got, err := s.Create(context.TODO(), tc.args.docNumber, tc.args.availableCreditLimit)
 			if (err != nil) != tc.wantErr {
 				t.Errorf("Create() error = %v, wantErr %v", err, tc.wantErr)
 				return
@@ -87,12 +130,12 @@ func Test_service_Create(t *testing.T) {
 
 func Test_service_Get(t *testing.T) {
 	type args struct {
-		ctx context.Context
-		id  int
+		id int
 	}
 	testCases := []struct {
 		name    string
 		svcArgs func(ctrl *gomock.Controller) Repository
+		audit   func(ctrl *gomock.Controller) audit.Service
 		args    args
 		want    *entity.Account
 		wantErr bool
@@ -143,9 +186,14 @@ func Test_service_Get(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			s := NewService(tc.svcArgs(ctrl))
+			var auditService audit.Service = mock_audit.NewMockService(ctrl)
+			if tc.audit != nil {
+				auditService = tc.audit(ctrl)
+			}
+
+			s := NewService(tc.svcArgs(ctrl), clock.NewFake(now), auditService)
 
-			got, err := s.Get(tc.args.ctx, tc.args.id)
+			got, err := s.Get(context.TODO(), tc.args.id)
 			if (err != nil) != tc.wantErr {
 				t.Errorf("Get() error = %v, wantErr %v", err, tc.wantErr)
 				return
@@ -160,13 +208,13 @@ func Test_service_Get(t *testing.T) {
 
 func Test_service_UpdateCreditLimit(t *testing.T) {
 	type args struct {
-		ctx                  context.Context
 		id                   int
 		availableCreditLimit float64
 	}
 	testCases := []struct {
 		name    string
 		svcArgs func(ctrl *gomock.Controller) Repository
+		audit   func(ctrl *gomock.Controller) audit.Service
 		args    args
 		want    *entity.Account
 		wantErr bool
@@ -187,6 +235,22 @@ func Test_service_UpdateCreditLimit(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Error database",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_account.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+
+				return repo
+			},
+			args: args{
+				id:                   1,
+				availableCreditLimit: 200,
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "Error update",
 			svcArgs: func(ctrl *gomock.Controller) Repository {
@@ -212,6 +276,33 @@ func Test_service_UpdateCreditLimit(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Error audit is only logged",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_account.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), gomock.Any()).
+					Return(&entity.Account{ID: 1, DocumentNumber: "12345678900", AvailabelCreditLimit: 500}, nil)
+
+				repo.EXPECT().Update(gomock.Any(), &entity.Account{ID: 1, DocumentNumber: "12345678900", AvailabelCreditLimit: 200}).
+					Return(&entity.Account{ID: 1, DocumentNumber: "12345678900", AvailabelCreditLimit: 200}, nil)
+
+				return repo
+			},
+			audit: func(ctrl *gomock.Controller) audit.Service {
+				auditService := mock_audit.NewMockService(ctrl)
+				auditService.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), 1, gomock.Any(), gomock.Any()).
+					Return(errors.New("database error"))
+
+				return auditService
+			},
+			args: args{
+				id:                   1,
+				availableCreditLimit: 200,
+			},
+			want:    &entity.Account{ID: 1, DocumentNumber: "12345678900", AvailabelCreditLimit: 200},
+			wantErr: false,
+		},
 		{
 			name: "Success",
 			svcArgs: func(ctrl *gomock.Controller) Repository {
@@ -237,6 +328,19 @@ func Test_service_UpdateCreditLimit(t *testing.T) {
 
 				return repo
 			},
+			audit: func(ctrl *gomock.Controller) audit.Service {
+				auditService := mock_audit.NewMockService(ctrl)
+				auditService.EXPECT().Record(
+					gomock.Any(),
+					entity.AuditActionUpdateCreditLimit,
+					entity.AuditEntityAccount,
+					1,
+					&entity.Account{ID: 1, DocumentNumber: "12345678900", AvailabelCreditLimit: 500},
+					&entity.Account{ID: 1, DocumentNumber: "12345678900", AvailabelCreditLimit: 200},
+				).Return(nil)
+
+				return auditService
+			},
 			args: args{
 				id:                   1,
 				availableCreditLimit: 200,
@@ -255,9 +359,14 @@ func Test_service_UpdateCreditLimit(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			s := NewService(tc.svcArgs(ctrl))
+			var auditService audit.Service = mock_audit.NewMockService(ctrl)
+			if tc.audit != nil {
+				auditService = tc.audit(ctrl)
+			}
+
+			s := NewService(tc.svcArgs(ctrl), clock.NewFake(now), auditService)
 
-			got, err := s.UpdateCreditLimit(tc.args.ctx, tc.args.id, tc.args.availableCreditLimit)
+			got, err := s.UpdateCreditLimit(context.TODO(), tc.args.id, tc.args.availableCreditLimit)
 			if (err != nil) != tc.wantErr {
 				t.Errorf("UpdateCreditLimit() error = %v, wantErr %v", err, tc.wantErr)
 				return
@@ -269,3 +378,114 @@ func Test_service_UpdateCreditLimit(t *testing.T) {
 		})
 	}
 }
+
+func Test_service_AdjustCreditLimit(t *testing.T) {
+	before := &entity.Account{ID: 1, DocumentNumber: "12345678900", AvailabelCreditLimit: 500}
+	adjusted := &entity.Account{ID: 1, DocumentNumber: "12345678900", AvailabelCreditLimit: 200}
+
+	testCases := []struct {
+		name    string
+		svcArgs func(ctrl *gomock.Controller) Repository
+		audit   func(ctrl *gomock.Controller) audit.Service
+		want    *entity.Account
+		wantErr error
+	}{
+		{
+			name: "Error not found",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_account.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, entity.ErrNotFound)
+
+				return repo
+			},
+			wantErr: entity.ErrNotFound,
+		},
+		{
+			name: "Error set credit limit",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_account.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), 1).Return(before, nil)
+				repo.EXPECT().SetCreditLimit(gomock.Any(), 1, 200.0, now).Return(nil, errors.New("database error"))
+
+				return repo
+			},
+			wantErr: errors.New("AdjustCreditLimit: database error"),
+		},
+		{
+			name: "Error audit keeps the adjustment",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_account.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), 1).Return(before, nil)
+				repo.EXPECT().SetCreditLimit(gomock.Any(), 1, 200.0, now).Return(adjusted, nil)
+
+				return repo
+			},
+			audit: func(ctrl *gomock.Controller) audit.Service {
+				auditService := mock_audit.NewMockService(ctrl)
+				auditService.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), 1, before, adjusted).
+					Return(errors.New("database error"))
+
+				return auditService
+			},
+			want: adjusted,
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_account.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), 1).Return(before, nil)
+				repo.EXPECT().SetCreditLimit(gomock.Any(), 1, 200.0, now).Return(adjusted, nil)
+
+				return repo
+			},
+			audit: func(ctrl *gomock.Controller) audit.Service {
+				auditService := mock_audit.NewMockService(ctrl)
+				auditService.EXPECT().Record(
+					gomock.Any(),
+					entity.AuditActionUpdateCreditLimit,
+					entity.AuditEntityAccount,
+					1,
+					before,
+					adjusted,
+				).Return(nil)
+
+				return auditService
+			},
+			want: adjusted,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var auditService audit.Service = mock_audit.NewMockService(ctrl)
+			if tc.audit != nil {
+				auditService = tc.audit(ctrl)
+			}
+
+			s := NewService(tc.svcArgs(ctrl), clock.NewFake(now), auditService)
+
+			got, err := s.AdjustCreditLimit(context.TODO(), 1, 200)
+			if tc.wantErr != nil {
+				if err == nil || !(errors.Is(err, tc.wantErr) || err.Error() == tc.wantErr.Error()) {
+					t.Errorf("AdjustCreditLimit() error = %v, wantErr %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("AdjustCreditLimit() unexpected error = %v", err)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("AdjustCreditLimit() got = %v, want %v, %v", got, tc.want, cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package audit
+
+import "context"
+
+// SystemActor is recorded for the changes made without a client, like the scheduled jobs
+const SystemActor = "system"
+
+type actorKeyType struct{}
+
+var actorKey = actorKeyType{}
+
+type actor struct {
+	name      string
+	requestID string
+}
+
+// WithActor set who is making the changes with the returned context and the request they came from
+func WithActor(ctx context.Context, name, requestID string) context.Context {
+	return context.WithValue(ctx, actorKey, actor{name: name, requestID: requestID})
+}
+
+// ActorFrom return the actor set by WithActor, SystemActor and no request when it was not set
+func ActorFrom(ctx context.Context) (name, requestID string) {
+	a, ok := ctx.Value(actorKey).(actor)
+	if !ok || a.name == "" {
+		return SystemActor, a.requestID
+	}
+
+	return a.name, a.requestID
+}
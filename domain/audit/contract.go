@@ -0,0 +1,33 @@
+//go:generate go run github.com/golang/mock/mockgen@v1.6.0 -source=contract.go -destination=mock_audit/contract.go
+
+package audit
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/entity"
+)
+
+type Service interface {
+	// Record append an entry of the action made on the entity by the actor of the context, the snapshots are marshalled
+	// to JSON and a nil snapshot is left empty
+	Record(
+		ctx context.Context,
+		action entity.AuditAction,
+		entityName entity.AuditEntity,
+		entityID int,
+		before, after interface{},
+	) error
+	// List the entries of the entity, newest first, up to limit
+	List(ctx context.Context, entityName entity.AuditEntity, entityID, limit int) ([]*entity.AuditEntry, error)
+	// Verify walk the whole chain checking the hash of each entry and its link to the previous one
+	Verify(ctx context.Context) (*entity.AuditVerification, error)
+}
+
+type Repository interface {
+	// Append seal the entry with the hash of the last one and save it, appends are serialized so the chain never forks
+	Append(ctx context.Context, entry *entity.AuditEntry) (*entity.AuditEntry, error)
+	// ListByEntity return the entries of the entity, newest first, up to limit
+	ListByEntity(ctx context.Context, entityName entity.AuditEntity, entityID, limit int) ([]*entity.AuditEntry, error)
+	// EachEntry stream every entry in the chain order to fn, an error of fn stops the stream and is returned
+	EachEntry(ctx context.Context, fn func(entry *entity.AuditEntry) error) error
+}
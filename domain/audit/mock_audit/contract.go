@@ -0,0 +1,147 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package mock_audit is a generated GoMock package.
+package mock_audit
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/brunomdev/digital-account/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockService) List(ctx context.Context, entityName entity.AuditEntity, entityID, limit int) ([]*entity.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, entityName, entityID, limit)
+	ret0, _ := ret[0].([]*entity.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceMockRecorder) List(ctx, entityName, entityID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx, entityName, entityID, limit)
+}
+
+// Record mocks base method.
+func (m *MockService) Record(ctx context.Context, action entity.AuditAction, entityName entity.AuditEntity, entityID int, before, after interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, action, entityName, entityID, before, after)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockServiceMockRecorder) Record(ctx, action, entityName, entityID, before, after interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockService)(nil).Record), ctx, action, entityName, entityID, before, after)
+}
+
+// Verify mocks base method.
+func (m *MockService) Verify(ctx context.Context) (*entity.AuditVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx)
+	ret0, _ := ret[0].(*entity.AuditVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockServiceMockRecorder) Verify(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockService)(nil).Verify), ctx)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockRepository) Append(ctx context.Context, entry *entity.AuditEntry) (*entity.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", ctx, entry)
+	ret0, _ := ret[0].(*entity.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockRepositoryMockRecorder) Append(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockRepository)(nil).Append), ctx, entry)
+}
+
+// EachEntry mocks base method.
+func (m *MockRepository) EachEntry(ctx context.Context, fn func(*entity.AuditEntry) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EachEntry", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EachEntry indicates an expected call of EachEntry.
+func (mr *MockRepositoryMockRecorder) EachEntry(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EachEntry", reflect.TypeOf((*MockRepository)(nil).EachEntry), ctx, fn)
+}
+
+// ListByEntity mocks base method.
+func (m *MockRepository) ListByEntity(ctx context.Context, entityName entity.AuditEntity, entityID, limit int) ([]*entity.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByEntity", ctx, entityName, entityID, limit)
+	ret0, _ := ret[0].([]*entity.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByEntity indicates an expected call of ListByEntity.
+func (mr *MockRepositoryMockRecorder) ListByEntity(ctx, entityName, entityID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByEntity", reflect.TypeOf((*MockRepository)(nil).ListByEntity), ctx, entityName, entityID, limit)
+}
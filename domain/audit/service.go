@@ -0,0 +1,150 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/pkg/clock"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+var tracer = otel.Tracer("github.com/brunomdev/digital-account/domain/audit")
+
+// errChainBroken stop the walk of the chain on the first broken entry
+var errChainBroken = errors.New("audit chain broken")
+
+type service struct {
+	repo  Repository
+	clock clock.Clock
+}
+
+func NewService(repo Repository, clk clock.Clock) Service {
+	return &service{
+		repo:  repo,
+		clock: clk,
+	}
+}
+
+func (s *service) Record(
+	ctx context.Context,
+	action entity.AuditAction,
+	entityName entity.AuditEntity,
+	entityID int,
+	before, after interface{},
+) error {
+	ctx, span := tracer.Start(ctx, "audit.Record", trace.WithAttributes(
+		attribute.String("audit.action", string(action)),
+		attribute.String("audit.entity", string(entityName)),
+		attribute.Int("audit.entity_id", entityID),
+	))
+	defer span.End()
+
+	beforeState, err := snapshot(before)
+	if err != nil {
+		return errors.Wrap(err, "Record")
+	}
+
+	afterState, err := snapshot(after)
+	if err != nil {
+		return errors.Wrap(err, "Record")
+	}
+
+	actorName, requestID := ActorFrom(ctx)
+
+	_, err = s.repo.Append(ctx, &entity.AuditEntry{
+		Actor:     actorName,
+		RequestID: requestID,
+		Action:    action,
+		Entity:    entityName,
+		EntityID:  entityID,
+		Before:    beforeState,
+		After:     afterState,
+		// the databases keep the time in seconds, the hash must match what is read back. The time travel offset is
+		// dropped so a request can't date its entries
+		CreatedAt: s.clock.Now(clock.WithOffset(ctx, 0)).UTC().Truncate(time.Second),
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return errors.Wrap(err, "Record")
+	}
+
+	return nil
+}
+
+func (s *service) List(
+	ctx context.Context,
+	entityName entity.AuditEntity,
+	entityID, limit int,
+) ([]*entity.AuditEntry, error) {
+	ctx, span := tracer.Start(ctx, "audit.List", trace.WithAttributes(
+		attribute.String("audit.entity", string(entityName)),
+		attribute.Int("audit.entity_id", entityID),
+	))
+	defer span.End()
+
+	entries, err := s.repo.ListByEntity(ctx, entityName, entityID, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, errors.Wrap(err, "List")
+	}
+
+	return entries, nil
+}
+
+// Verify stop at the first broken entry, the ones after it are chained to a hash that can't be trusted anymore
+func (s *service) Verify(ctx context.Context) (*entity.AuditVerification, error) {
+	ctx, span := tracer.Start(ctx, "audit.Verify")
+	defer span.End()
+
+	var (
+		verification entity.AuditVerification
+		prevHash     string
+	)
+
+	err := s.repo.EachEntry(ctx, func(entry *entity.AuditEntry) error {
+		verification.Entries++
+
+		if !entry.Verify(prevHash) {
+			verification.BrokenEntryID = entry.ID
+
+			return errChainBroken
+		}
+
+		prevHash = entry.Hash
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errChainBroken) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, errors.Wrap(err, "Verify")
+	}
+
+	span.SetAttributes(attribute.Bool("audit.valid", verification.Valid()))
+
+	return &verification, nil
+}
+
+// snapshot marshal the state of the entity, nil is no state
+func snapshot(state interface{}) (string, error) {
+	if state == nil {
+		return "", nil
+	}
+
+	content, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
@@ -0,0 +1,281 @@
+package audit
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/audit/mock_audit"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/pkg/clock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2022, 3, 31, 10, 0, 0, 500, time.UTC)
+
+func Test_service_Record(t *testing.T) {
+	testCases := []struct {
+		name    string
+		ctx     context.Context
+		svcArgs func(ctrl *gomock.Controller) Repository
+		before  interface{}
+		after   interface{}
+		wantErr bool
+	}{
+		{
+			name: "Error snapshot",
+			ctx:  context.TODO(),
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				return mock_audit.NewMockRepository(ctrl)
+			},
+			after:   func() {},
+			wantErr: true,
+		},
+		{
+			name: "Error database",
+			ctx:  context.TODO(),
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_audit.NewMockRepository(ctrl)
+				repo.EXPECT().Append(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+
+				return repo
+			},
+			after:   &entity.Account{ID: 1},
+			wantErr: true,
+		},
+		{
+			name: "Success without actor",
+			ctx:  context.TODO(),
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_audit.NewMockRepository(ctrl)
+				repo.EXPECT().Append(gomock.Any(), &entity.AuditEntry{
+					Actor:     SystemActor,
+					Action:    entity.AuditActionCreate,
+					Entity:    entity.AuditEntityAccount,
+					EntityID:  1,
+					After:     `{"ID":1,"DocumentNumber":"","AvailabelCreditLimit":0,"CustomerID":0,"CreatedAt":"0001-01-01T00:00:00Z"}`,
+					CreatedAt: now.Truncate(time.Second),
+				}).Return(&entity.AuditEntry{ID: 1}, nil)
+
+				return repo
+			},
+			after:   &entity.Account{ID: 1},
+			wantErr: false,
+		},
+		{
+			name: "Success with actor",
+			ctx:  WithActor(context.TODO(), "backoffice", "request-1"),
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_audit.NewMockRepository(ctrl)
+				repo.EXPECT().Append(gomock.Any(), &entity.AuditEntry{
+					Actor:     "backoffice",
+					RequestID: "request-1",
+					Action:    entity.AuditActionCreate,
+					Entity:    entity.AuditEntityAccount,
+					EntityID:  1,
+					Before:    `{"limit":100}`,
+					After:     `{"limit":50}`,
+					CreatedAt: now.Truncate(time.Second),
+				}).Return(&entity.AuditEntry{ID: 1}, nil)
+
+				return repo
+			},
+			before:  map[string]int{"limit": 100},
+			after:   map[string]int{"limit": 50},
+			wantErr: false,
+		},
+		{
+			name: "Success ignoring the time travel",
+			ctx:  clock.WithOffset(context.TODO(), -48*time.Hour),
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_audit.NewMockRepository(ctrl)
+				repo.EXPECT().Append(gomock.Any(), &entity.AuditEntry{
+					Actor:     SystemActor,
+					Action:    entity.AuditActionCreate,
+					Entity:    entity.AuditEntityAccount,
+					EntityID:  1,
+					After:     `{"limit":50}`,
+					CreatedAt: now.Truncate(time.Second),
+				}).Return(&entity.AuditEntry{ID: 1}, nil)
+
+				return repo
+			},
+			after:   map[string]int{"limit": 50},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := NewService(tc.svcArgs(ctrl), clock.NewFake(now))
+
+			err := s.Record(tc.ctx, entity.AuditActionCreate, entity.AuditEntityAccount, 1, tc.before, tc.after)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Record() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func Test_service_List(t *testing.T) {
+	testCases := []struct {
+		name    string
+		svcArgs func(ctrl *gomock.Controller) Repository
+		want    []*entity.AuditEntry
+		wantErr bool
+	}{
+		{
+			name: "Error database",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_audit.NewMockRepository(ctrl)
+				repo.EXPECT().ListByEntity(gomock.Any(), entity.AuditEntityAccount, 1, 10).
+					Return(nil, errors.New("database error"))
+
+				return repo
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_audit.NewMockRepository(ctrl)
+				repo.EXPECT().ListByEntity(gomock.Any(), entity.AuditEntityAccount, 1, 10).
+					Return([]*entity.AuditEntry{{ID: 2}, {ID: 1}}, nil)
+
+				return repo
+			},
+			want:    []*entity.AuditEntry{{ID: 2}, {ID: 1}},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := NewService(tc.svcArgs(ctrl), clock.NewFake(now))
+
+			got, err := s.List(context.TODO(), entity.AuditEntityAccount, 1, 10)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("List() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("List() got = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func Test_service_Verify(t *testing.T) {
+	// chain seal three entries of the credit limit of account 1 as the repositories do
+	chain := func() []*entity.AuditEntry {
+		entries := []*entity.AuditEntry{
+			{ID: 1, Actor: "system", Action: entity.AuditActionCreate, After: `{"limit":100}`},
+			{ID: 2, Actor: "risk", Action: entity.AuditActionUpdateCreditLimit, Before: `{"limit":100}`, After: `{"limit":50}`},
+			{ID: 3, Actor: "risk", Action: entity.AuditActionUpdateCreditLimit, Before: `{"limit":50}`, After: `{"limit":80}`},
+		}
+
+		var prevHash string
+		for _, entry := range entries {
+			entry.Entity = entity.AuditEntityAccount
+			entry.EntityID = 1
+			entry.CreatedAt = now.Truncate(time.Second)
+			entry.Seal(prevHash)
+
+			prevHash = entry.Hash
+		}
+
+		return entries
+	}
+	stream := func(entries []*entity.AuditEntry) func(context.Context, func(*entity.AuditEntry) error) error {
+		return func(_ context.Context, fn func(*entity.AuditEntry) error) error {
+			for _, entry := range entries {
+				if err := fn(entry); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		svcArgs func(ctrl *gomock.Controller) Repository
+		want    *entity.AuditVerification
+		wantErr bool
+	}{
+		{
+			name: "Error database",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_audit.NewMockRepository(ctrl)
+				repo.EXPECT().EachEntry(gomock.Any(), gomock.Any()).Return(errors.New("database error"))
+
+				return repo
+			},
+			wantErr: true,
+		},
+		{
+			name: "Success modified entry",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				entries := chain()
+				entries[1].After = `{"limit":5000}`
+
+				repo := mock_audit.NewMockRepository(ctrl)
+				repo.EXPECT().EachEntry(gomock.Any(), gomock.Any()).DoAndReturn(stream(entries))
+
+				return repo
+			},
+			want: &entity.AuditVerification{Entries: 2, BrokenEntryID: 2},
+		},
+		{
+			name: "Success removed entry",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				entries := chain()
+
+				repo := mock_audit.NewMockRepository(ctrl)
+				repo.EXPECT().EachEntry(gomock.Any(), gomock.Any()).
+					DoAndReturn(stream([]*entity.AuditEntry{entries[0], entries[2]}))
+
+				return repo
+			},
+			want: &entity.AuditVerification{Entries: 2, BrokenEntryID: 3},
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_audit.NewMockRepository(ctrl)
+				repo.EXPECT().EachEntry(gomock.Any(), gomock.Any()).DoAndReturn(stream(chain()))
+
+				return repo
+			},
+			want: &entity.AuditVerification{Entries: 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := NewService(tc.svcArgs(ctrl), clock.NewFake(now))
+
+			got, err := s.Verify(context.TODO())
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Verify() got = %v, want %v, %v", got, tc.want, cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+//go:generate go run github.com/golang/mock/mockgen@v1.6.0 -source=contract.go -destination=mock_card/contract.go
+
+package card
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/entity"
+)
+
+type Service interface {
+	// Issue a new active card to the account, a zero limit is unlimited
+	Issue(ctx context.Context, accountID int, cardType entity.CardType, limit float64) (*entity.Card, error)
+	Get(ctx context.Context, id int) (*entity.Card, error)
+	// Block the card, blocking a blocked card has no effect
+	Block(ctx context.Context, id int) (*entity.Card, error)
+	// Replace issue a new card with the type and the limit of the card, which can't be used anymore
+	Replace(ctx context.Context, id int) (*entity.Card, error)
+}
+
+type Repository interface {
+	Save(ctx context.Context, card *entity.Card) (*entity.Card, error)
+	GetByID(ctx context.Context, id int) (*entity.Card, error)
+	UpdateStatus(ctx context.Context, id int, status entity.CardStatus) error
+}
+
+// Issuing is the configuration of the issued cards
+type Issuing struct {
+	// BIN is the first six digits of the PANs
+	BIN string
+	// ValidityYears of the cards, the card expires on the end of the month
+	ValidityYears int
+}
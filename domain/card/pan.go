@@ -0,0 +1,61 @@
+package card
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"strings"
+)
+
+const panLength = 16
+
+// newPAN generate a random PAN starting with the BIN and ending with the Luhn check digit
+func newPAN(bin string) (string, error) {
+	digits := []byte(bin)
+
+	for len(digits) < panLength-1 {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+
+		digits = append(digits, byte('0'+n.Int64()))
+	}
+
+	return string(append(digits, luhnCheckDigit(digits))), nil
+}
+
+// luhnCheckDigit is the digit that makes the digits followed by it pass the Luhn check
+func luhnCheckDigit(digits []byte) byte {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+
+		// doubled from the rightmost digit, since the check digit goes after it
+		if (len(digits)-1-i)%2 == 0 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+
+		sum += d
+	}
+
+	return byte('0' + (10-sum%10)%10)
+}
+
+// maskPAN keep the first six and the last four digits of the PAN
+func maskPAN(pan string) string {
+	return pan[:6] + strings.Repeat("*", len(pan)-10) + pan[len(pan)-4:]
+}
+
+// newToken generate the random token that identifies the card in place of the PAN
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return "tok_" + hex.EncodeToString(b), nil
+}
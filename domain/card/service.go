@@ -0,0 +1,172 @@
+package card
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/account"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/pkg/clock"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/brunomdev/digital-account/domain/card")
+
+type service struct {
+	repo           Repository
+	accountService account.Service
+	clock          clock.Clock
+	issuing        Issuing
+}
+
+func NewService(repo Repository, accountService account.Service, clk clock.Clock, issuing Issuing) Service {
+	return &service{
+		repo:           repo,
+		accountService: accountService,
+		clock:          clk,
+		issuing:        issuing,
+	}
+}
+
+func (s *service) Issue(ctx context.Context, accountID int, cardType entity.CardType, limit float64) (*entity.Card, error) {
+	ctx, span := tracer.Start(ctx, "card.Issue", trace.WithAttributes(
+		attribute.Int("account.id", accountID),
+		attribute.String("card.type", string(cardType)),
+	))
+	defer span.End()
+
+	if !entity.IsValidCardType(cardType) || limit < 0 {
+		return nil, entity.ErrInvalidCard
+	}
+
+	_, err := s.accountService.Get(ctx, accountID)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, errors.Wrap(err, "account")
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, errors.Wrap(err, "Issue")
+	}
+
+	issued, err := s.issue(ctx, &entity.Card{AccountID: accountID, Type: cardType, Limit: limit})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, errors.Wrap(err, "Issue")
+	}
+
+	return issued, nil
+}
+
+func (s *service) Get(ctx context.Context, id int) (*entity.Card, error) {
+	ctx, span := tracer.Start(ctx, "card.Get", trace.WithAttributes(attribute.Int("card.id", id)))
+	defer span.End()
+
+	c, err := s.repo.GetByID(ctx, id)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, errors.Wrap(err, "card")
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, errors.Wrap(err, "Get")
+	}
+
+	return c, nil
+}
+
+func (s *service) Block(ctx context.Context, id int) (*entity.Card, error) {
+	ctx, span := tracer.Start(ctx, "card.Block", trace.WithAttributes(attribute.Int("card.id", id)))
+	defer span.End()
+
+	c, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.Status {
+	case entity.CardStatusBlocked:
+		return c, nil
+	case entity.CardStatusReplaced:
+		return nil, entity.ErrCardNotActive
+	}
+
+	err = s.repo.UpdateStatus(ctx, id, entity.CardStatusBlocked)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, errors.Wrap(err, "Block")
+	}
+
+	c.Status = entity.CardStatusBlocked
+
+	return c, nil
+}
+
+func (s *service) Replace(ctx context.Context, id int) (*entity.Card, error) {
+	ctx, span := tracer.Start(ctx, "card.Replace", trace.WithAttributes(attribute.Int("card.id", id)))
+	defer span.End()
+
+	c, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Status == entity.CardStatusReplaced {
+		return nil, entity.ErrCardNotActive
+	}
+
+	// the card is replaced first, a failure afterwards leaves the account without a card instead of with two
+	err = s.repo.UpdateStatus(ctx, id, entity.CardStatusReplaced)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, errors.Wrap(err, "Replace")
+	}
+
+	replacement, err := s.issue(ctx, &entity.Card{
+		AccountID:  c.AccountID,
+		Type:       c.Type,
+		Limit:      c.Limit,
+		ReplacesID: c.ID,
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, errors.Wrap(err, "Replace")
+	}
+
+	return replacement, nil
+}
+
+// issue generate the PAN, the token and the expiry of the card and save it active
+func (s *service) issue(ctx context.Context, c *entity.Card) (*entity.Card, error) {
+	pan, err := newPAN(s.issuing.BIN)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+
+	expiry := s.clock.Now(ctx).UTC().AddDate(s.issuing.ValidityYears, 0, 0)
+
+	c.MaskedPAN = maskPAN(pan)
+	c.Token = token
+	c.ExpiryMonth = int(expiry.Month())
+	c.ExpiryYear = expiry.Year()
+	c.Status = entity.CardStatusActive
+
+	return s.repo.Save(ctx, c)
+}
@@ -0,0 +1,348 @@
+package card
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/account"
+	"github.com/brunomdev/digital-account/domain/account/mock_account"
+	"github.com/brunomdev/digital-account/domain/card/mock_card"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/pkg/clock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var issuing = Issuing{BIN: "516292", ValidityYears: 5}
+
+var now = time.Date(2022, 3, 30, 10, 0, 0, 0, time.UTC)
+
+func newCard(status entity.CardStatus) *entity.Card {
+	return &entity.Card{
+		ID:          1,
+		AccountID:   1,
+		Type:        entity.CardTypeVirtual,
+		MaskedPAN:   "516292******1234",
+		Token:       "tok_0123456789abcdef0123456789abcdef",
+		ExpiryMonth: 3,
+		ExpiryYear:  2027,
+		Status:      status,
+		Limit:       500,
+	}
+}
+
+// saveIssued return the card given to Save with the ID of the next card
+func saveIssued(ctx context.Context, c *entity.Card) (*entity.Card, error) {
+	saved := *c
+	saved.ID = 2
+	return &saved, nil
+}
+
+func Test_service_Issue(t *testing.T) {
+	testCases := []struct {
+		name           string
+		repo           func(ctrl *gomock.Controller) Repository
+		accountService func(ctrl *gomock.Controller) account.Service
+		cardType       entity.CardType
+		limit          float64
+		wantErr        error
+	}{
+		{
+			name: "Error invalid type",
+			repo: func(ctrl *gomock.Controller) Repository {
+				return mock_card.NewMockRepository(ctrl)
+			},
+			accountService: func(ctrl *gomock.Controller) account.Service {
+				return mock_account.NewMockService(ctrl)
+			},
+			cardType: "prepaid",
+			wantErr:  entity.ErrInvalidCard,
+		},
+		{
+			name: "Error negative limit",
+			repo: func(ctrl *gomock.Controller) Repository {
+				return mock_card.NewMockRepository(ctrl)
+			},
+			accountService: func(ctrl *gomock.Controller) account.Service {
+				return mock_account.NewMockService(ctrl)
+			},
+			cardType: entity.CardTypeVirtual,
+			limit:    -1,
+			wantErr:  entity.ErrInvalidCard,
+		},
+		{
+			name: "Error account not found",
+			repo: func(ctrl *gomock.Controller) Repository {
+				return mock_card.NewMockRepository(ctrl)
+			},
+			accountService: func(ctrl *gomock.Controller) account.Service {
+				accountService := mock_account.NewMockService(ctrl)
+
+				accountService.EXPECT().Get(gomock.Any(), 1).Return(nil, entity.ErrNotFound)
+
+				return accountService
+			},
+			cardType: entity.CardTypePhysical,
+			wantErr:  errors.Wrap(entity.ErrNotFound, "account"),
+		},
+		{
+			name: "Error database",
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_card.NewMockRepository(ctrl)
+
+				repo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+
+				return repo
+			},
+			accountService: func(ctrl *gomock.Controller) account.Service {
+				accountService := mock_account.NewMockService(ctrl)
+
+				accountService.EXPECT().Get(gomock.Any(), 1).Return(&entity.Account{ID: 1}, nil)
+
+				return accountService
+			},
+			cardType: entity.CardTypePhysical,
+			wantErr:  errors.New("Issue: database error"),
+		},
+		{
+			name: "Success",
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_card.NewMockRepository(ctrl)
+
+				repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(saveIssued)
+
+				return repo
+			},
+			accountService: func(ctrl *gomock.Controller) account.Service {
+				accountService := mock_account.NewMockService(ctrl)
+
+				accountService.EXPECT().Get(gomock.Any(), 1).Return(&entity.Account{ID: 1}, nil)
+
+				return accountService
+			},
+			cardType: entity.CardTypeVirtual,
+			limit:    500,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := NewService(tc.repo(ctrl), tc.accountService(ctrl), clock.NewFake(now), issuing)
+
+			// the card issued on the traveled date expires five years after it
+			ctx := clock.WithOffset(context.TODO(), 24*time.Hour*365)
+
+			got, err := s.Issue(ctx, 1, tc.cardType, tc.limit)
+			if (err != nil) != (tc.wantErr != nil) || (err != nil && err.Error() != tc.wantErr.Error()) {
+				t.Errorf("Issue() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.ExpiryYear != 2028 || got.ExpiryMonth != 3 {
+				t.Errorf("Issue() expiry = %02d/%d, want 03/2028", got.ExpiryMonth, got.ExpiryYear)
+			}
+
+			if !strings.HasPrefix(got.MaskedPAN, issuing.BIN+"******") || len(got.MaskedPAN) != panLength {
+				t.Errorf("Issue() masked PAN = %v", got.MaskedPAN)
+			}
+
+			if got.Status != entity.CardStatusActive || got.Limit != tc.limit || got.Type != tc.cardType {
+				t.Errorf("Issue() got = %v", got)
+			}
+		})
+	}
+}
+
+func Test_service_Block(t *testing.T) {
+	testCases := []struct {
+		name    string
+		repo    func(ctrl *gomock.Controller) Repository
+		want    *entity.Card
+		wantErr error
+	}{
+		{
+			name: "Error not found",
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_card.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, entity.ErrNotFound)
+
+				return repo
+			},
+			wantErr: errors.Wrap(entity.ErrNotFound, "card"),
+		},
+		{
+			name: "Error replaced",
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_card.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), 1).Return(newCard(entity.CardStatusReplaced), nil)
+
+				return repo
+			},
+			wantErr: entity.ErrCardNotActive,
+		},
+		{
+			name: "Success already blocked",
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_card.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), 1).Return(newCard(entity.CardStatusBlocked), nil)
+
+				return repo
+			},
+			want: newCard(entity.CardStatusBlocked),
+		},
+		{
+			name: "Success",
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_card.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), 1).Return(newCard(entity.CardStatusActive), nil)
+				repo.EXPECT().UpdateStatus(gomock.Any(), 1, entity.CardStatusBlocked).Return(nil)
+
+				return repo
+			},
+			want: newCard(entity.CardStatusBlocked),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := NewService(tc.repo(ctrl), mock_account.NewMockService(ctrl), clock.NewFake(now), issuing)
+
+			got, err := s.Block(context.TODO(), 1)
+			if (err != nil) != (tc.wantErr != nil) || (err != nil && err.Error() != tc.wantErr.Error()) {
+				t.Errorf("Block() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Block() got = %v, want %v, %v", got, tc.want, cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
+
+func Test_service_Replace(t *testing.T) {
+	testCases := []struct {
+		name    string
+		repo    func(ctrl *gomock.Controller) Repository
+		wantErr error
+	}{
+		{
+			name: "Error replaced",
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_card.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), 1).Return(newCard(entity.CardStatusReplaced), nil)
+
+				return repo
+			},
+			wantErr: entity.ErrCardNotActive,
+		},
+		{
+			name: "Error database",
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_card.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), 1).Return(newCard(entity.CardStatusActive), nil)
+				repo.EXPECT().UpdateStatus(gomock.Any(), 1, entity.CardStatusReplaced).Return(errors.New("database error"))
+
+				return repo
+			},
+			wantErr: errors.New("Replace: database error"),
+		},
+		{
+			name: "Success blocked card",
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_card.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), 1).Return(newCard(entity.CardStatusBlocked), nil)
+				gomock.InOrder(
+					repo.EXPECT().UpdateStatus(gomock.Any(), 1, entity.CardStatusReplaced).Return(nil),
+					repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(saveIssued),
+				)
+
+				return repo
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := NewService(tc.repo(ctrl), mock_account.NewMockService(ctrl), clock.NewFake(now), issuing)
+
+			got, err := s.Replace(context.TODO(), 1)
+			if (err != nil) != (tc.wantErr != nil) || (err != nil && err.Error() != tc.wantErr.Error()) {
+				t.Errorf("Replace() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			old := newCard(entity.CardStatusBlocked)
+			if got.ID != 2 || got.ReplacesID != old.ID || got.AccountID != old.AccountID || got.Limit != old.Limit ||
+				got.Type != old.Type || got.Status != entity.CardStatusActive || got.Token == old.Token {
+				t.Errorf("Replace() got = %v", got)
+			}
+		})
+	}
+}
+
+func Test_newPAN(t *testing.T) {
+	pan, err := newPAN(issuing.BIN)
+	if err != nil {
+		t.Fatalf("newPAN() error = %v", err)
+	}
+
+	if len(pan) != panLength || !strings.HasPrefix(pan, issuing.BIN) {
+		t.Fatalf("newPAN() got = %v", pan)
+	}
+
+	if got := luhnCheckDigit([]byte(pan[:panLength-1])); got != pan[panLength-1] {
+		t.Errorf("newPAN() check digit = %c, want %c", pan[panLength-1], got)
+	}
+}
+
+func Test_luhnCheckDigit(t *testing.T) {
+	testCases := []struct {
+		digits string
+		want   byte
+	}{
+		{digits: "7992739871", want: '3'},
+		{digits: "411111111111111", want: '1'},
+		{digits: "555555555555444", want: '4'},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.digits, func(t *testing.T) {
+			if got := luhnCheckDigit([]byte(tc.digits)); got != tc.want {
+				t.Errorf("luhnCheckDigit() got = %c, want %c", got, tc.want)
+			}
+		})
+	}
+}
+
+func Test_maskPAN(t *testing.T) {
+	if got := maskPAN("5162921234567890"); got != "516292******7890" {
+		t.Errorf("maskPAN() got = %v", got)
+	}
+}
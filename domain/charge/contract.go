@@ -0,0 +1,27 @@
+//go:generate go run github.com/golang/mock/mockgen@v1.6.0 -source=contract.go -destination=mock_charge/contract.go
+
+package charge
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/entity"
+	"time"
+)
+
+type Service interface {
+	// Accrue post the interest and the late fees of the day on the account, the charges already posted for the day
+	// are not posted again
+	Accrue(ctx context.Context, accountID int, day time.Time) ([]*entity.Charge, error)
+	// AccrueAll accrue the charges of the day on every account, an account failing doesn't stop the others
+	AccrueAll(ctx context.Context, day time.Time) (*entity.ChargeRun, error)
+}
+
+type Repository interface {
+	// Reserve save the charge before its transaction is posted, entity.ErrAlreadyCharged when the account already has
+	// a charge of the type on the day
+	Reserve(ctx context.Context, charge *entity.Charge) (*entity.Charge, error)
+	// SetTransactionID link the reserved charge to its posted transaction
+	SetTransactionID(ctx context.Context, id, transactionID int) error
+	// Delete release the reservation of a charge whose transaction was not posted
+	Delete(ctx context.Context, id int) error
+}
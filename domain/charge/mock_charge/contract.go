@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package mock_charge is a generated GoMock package.
+package mock_charge
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/brunomdev/digital-account/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Accrue mocks base method.
+func (m *MockService) Accrue(ctx context.Context, accountID int, day time.Time) ([]*entity.Charge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accrue", ctx, accountID, day)
+	ret0, _ := ret[0].([]*entity.Charge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accrue indicates an expected call of Accrue.
+func (mr *MockServiceMockRecorder) Accrue(ctx, accountID, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accrue", reflect.TypeOf((*MockService)(nil).Accrue), ctx, accountID, day)
+}
+
+// AccrueAll mocks base method.
+func (m *MockService) AccrueAll(ctx context.Context, day time.Time) (*entity.ChargeRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccrueAll", ctx, day)
+	ret0, _ := ret[0].(*entity.ChargeRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccrueAll indicates an expected call of AccrueAll.
+func (mr *MockServiceMockRecorder) AccrueAll(ctx, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccrueAll", reflect.TypeOf((*MockService)(nil).AccrueAll), ctx, day)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id)
+}
+
+// Reserve mocks base method.
+func (m *MockRepository) Reserve(ctx context.Context, charge *entity.Charge) (*entity.Charge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, charge)
+	ret0, _ := ret[0].(*entity.Charge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockRepositoryMockRecorder) Reserve(ctx, charge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockRepository)(nil).Reserve), ctx, charge)
+}
+
+// SetTransactionID mocks base method.
+func (m *MockRepository) SetTransactionID(ctx context.Context, id, transactionID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTransactionID", ctx, id, transactionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTransactionID indicates an expected call of SetTransactionID.
+func (mr *MockRepositoryMockRecorder) SetTransactionID(ctx, id, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTransactionID", reflect.TypeOf((*MockRepository)(nil).SetTransactionID), ctx, id, transactionID)
+}
@@ -0,0 +1,227 @@
+package charge
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/account"
+	"github.com/brunomdev/digital-account/domain/transaction"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"math"
+	"time"
+)
+
+var tracer = otel.Tracer("github.com/brunomdev/digital-account/domain/charge")
+
+const (
+	pageSize = 100
+	// daysInMonth prorates the monthly interest rate by day
+	daysInMonth = 30
+)
+
+// Config of the charges, zero values disable each charge
+type Config struct {
+	// MonthlyInterestRate is the interest on the overdue balance, 0.12 is 12% a month
+	MonthlyInterestRate float64
+	// GraceDays is how many days a debit is free of interest
+	GraceDays int
+	// LateFee is the fixed fee charged when the balance becomes overdue
+	LateFee float64
+	// PenaltyRate is the penalty on the overdue balance charged when it becomes overdue, 0.02 is 2%
+	PenaltyRate float64
+}
+
+type service struct {
+	repo               Repository
+	accountRepo        account.Repository
+	transactionRepo    transaction.Repository
+	transactionService transaction.Service
+	cfg                Config
+}
+
+func NewService(
+	repo Repository,
+	accountRepo account.Repository,
+	transactionRepo transaction.Repository,
+	transactionService transaction.Service,
+	cfg Config,
+) Service {
+	return &service{
+		repo:               repo,
+		accountRepo:        accountRepo,
+		transactionRepo:    transactionRepo,
+		transactionService: transactionService,
+		cfg:                cfg,
+	}
+}
+
+func (s *service) Accrue(ctx context.Context, accountID int, day time.Time) ([]*entity.Charge, error) {
+	ctx, span := tracer.Start(ctx, "charge.Accrue", trace.WithAttributes(attribute.Int("account.id", accountID)))
+	defer span.End()
+
+	charges, err := s.accrue(ctx, accountID, truncateDay(day))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, errors.Wrap(err, "Accrue")
+	}
+
+	return charges, nil
+}
+
+func (s *service) AccrueAll(ctx context.Context, day time.Time) (*entity.ChargeRun, error) {
+	ctx, span := tracer.Start(ctx, "charge.AccrueAll")
+	defer span.End()
+
+	run := &entity.ChargeRun{Date: truncateDay(day)}
+
+	afterID := 0
+	for {
+		accounts, err := s.accountRepo.List(ctx, afterID, pageSize)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			return nil, errors.Wrap(err, "AccrueAll")
+		}
+
+		for _, acc := range accounts {
+			run.Accounts++
+			afterID = acc.ID
+
+			charges, err := s.accrue(ctx, acc.ID, run.Date)
+			if err != nil {
+				span.RecordError(errors.Wrapf(err, "account %d", acc.ID))
+				run.Failed++
+
+				continue
+			}
+
+			for _, c := range charges {
+				run.Charges++
+				run.Amount += c.Amount
+			}
+		}
+
+		if len(accounts) < pageSize {
+			break
+		}
+	}
+
+	run.Amount = round(run.Amount)
+
+	span.SetAttributes(
+		attribute.Int("charge.accounts", run.Accounts),
+		attribute.Int("charge.charges", run.Charges),
+		attribute.Int("charge.failed", run.Failed),
+	)
+
+	return run, nil
+}
+
+// accrue compute the charges of the day from the overdue balance, the debits made before the grace period not yet
+// covered by the payments. The late fee and the penalty are charged on the first overdue day only.
+func (s *service) accrue(ctx context.Context, accountID int, day time.Time) ([]*entity.Charge, error) {
+	overdue, err := s.overdueBalance(ctx, accountID, day)
+	if err != nil {
+		return nil, err
+	}
+
+	if overdue <= 0 {
+		return []*entity.Charge{}, nil
+	}
+
+	candidates := []*entity.Charge{
+		{Type: entity.ChargeTypeInterest, Amount: round(overdue * s.cfg.MonthlyInterestRate / daysInMonth)},
+	}
+
+	previous, err := s.overdueBalance(ctx, accountID, day.AddDate(0, 0, -1))
+	if err != nil {
+		return nil, err
+	}
+
+	if previous <= 0 {
+		candidates = append(
+			candidates,
+			&entity.Charge{Type: entity.ChargeTypeLateFee, Amount: round(s.cfg.LateFee)},
+			&entity.Charge{Type: entity.ChargeTypePenalty, Amount: round(overdue * s.cfg.PenaltyRate)},
+		)
+	}
+
+	charges := make([]*entity.Charge, 0, len(candidates))
+	for _, c := range candidates {
+		if c.Amount <= 0 {
+			continue
+		}
+
+		c.AccountID = accountID
+		c.Date = day
+
+		posted, err := s.post(ctx, c)
+		if errors.Is(err, entity.ErrAlreadyCharged) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		charges = append(charges, posted)
+	}
+
+	return charges, nil
+}
+
+// overdueBalance is the balance past the grace period at the end of the day
+func (s *service) overdueBalance(ctx context.Context, accountID int, day time.Time) (float64, error) {
+	debits, _, err := s.transactionRepo.SumBalanceBefore(ctx, accountID, day.AddDate(0, 0, 1-s.cfg.GraceDays))
+	if err != nil {
+		return 0, err
+	}
+
+	_, payments, err := s.transactionRepo.SumBalanceBefore(ctx, accountID, day.AddDate(0, 0, 1))
+	if err != nil {
+		return 0, err
+	}
+
+	return round(debits - payments), nil
+}
+
+// post reserve the charge of the day before posting its transaction, so a charge is never posted twice even when
+// runs overlap. The reservation is released when the transaction fails.
+func (s *service) post(ctx context.Context, c *entity.Charge) (*entity.Charge, error) {
+	reserved, err := s.repo.Reserve(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	txn, err := s.transactionService.Charge(ctx, c.AccountID, c.Type.OperationTypeID(), c.Amount)
+	if err != nil {
+		errDel := s.repo.Delete(ctx, reserved.ID)
+		if errDel != nil {
+			return nil, errors.Wrap(errDel, "release charge")
+		}
+
+		return nil, err
+	}
+
+	reserved.TransactionID = txn.ID
+
+	err = s.repo.SetTransactionID(ctx, reserved.ID, txn.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return reserved, nil
+}
+
+func truncateDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,229 @@
+package charge
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/account/mock_account"
+	"github.com/brunomdev/digital-account/domain/charge/mock_charge"
+	"github.com/brunomdev/digital-account/domain/transaction/mock_transaction"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"testing"
+	"time"
+)
+
+var testConfig = Config{MonthlyInterestRate: 0.15, GraceDays: 10, LateFee: 10, PenaltyRate: 0.02}
+
+type mocks struct {
+	repo               *mock_charge.MockRepository
+	accountRepo        *mock_account.MockRepository
+	transactionRepo    *mock_transaction.MockRepository
+	transactionService *mock_transaction.MockService
+}
+
+func newMocks(ctrl *gomock.Controller) mocks {
+	return mocks{
+		repo:               mock_charge.NewMockRepository(ctrl),
+		accountRepo:        mock_account.NewMockRepository(ctrl),
+		transactionRepo:    mock_transaction.NewMockRepository(ctrl),
+		transactionService: mock_transaction.NewMockService(ctrl),
+	}
+}
+
+// expectBalance expect the sums of the overdue balance of the day, debits made before the grace period and payments
+// made until the end of the day
+func expectBalance(m mocks, day time.Time, debits, payments float64) {
+	m.transactionRepo.EXPECT().SumBalanceBefore(gomock.Any(), 1, day.AddDate(0, 0, -9)).Return(debits, 0.0, nil)
+	m.transactionRepo.EXPECT().SumBalanceBefore(gomock.Any(), 1, day.AddDate(0, 0, 1)).Return(debits, payments, nil)
+}
+
+func expectPost(m mocks, c entity.Charge, id, transactionID int) {
+	reserved := c
+	reserved.ID = id
+
+	m.repo.EXPECT().Reserve(gomock.Any(), &c).Return(&reserved, nil)
+	m.transactionService.EXPECT().Charge(gomock.Any(), 1, c.Type.OperationTypeID(), c.Amount).
+		Return(&entity.Transaction{ID: transactionID}, nil)
+	m.repo.EXPECT().SetTransactionID(gomock.Any(), id, transactionID).Return(nil)
+}
+
+func Test_service_Accrue(t *testing.T) {
+	day := time.Date(2022, 3, 25, 0, 0, 0, 0, time.UTC)
+	previous := day.AddDate(0, 0, -1)
+
+	interest := entity.Charge{AccountID: 1, Type: entity.ChargeTypeInterest, Date: day, Amount: 1.5}
+	lateFee := entity.Charge{AccountID: 1, Type: entity.ChargeTypeLateFee, Date: day, Amount: 10}
+	penalty := entity.Charge{AccountID: 1, Type: entity.ChargeTypePenalty, Date: day, Amount: 6}
+
+	posted := func(c entity.Charge, id, transactionID int) *entity.Charge {
+		c.ID = id
+		c.TransactionID = transactionID
+
+		return &c
+	}
+
+	testCases := []struct {
+		name    string
+		expect  func(m mocks)
+		want    []*entity.Charge
+		wantErr bool
+	}{
+		{
+			name: "Error database",
+			expect: func(m mocks) {
+				m.transactionRepo.EXPECT().SumBalanceBefore(gomock.Any(), 1, gomock.Any()).
+					Return(0.0, 0.0, errors.New("database error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Success balance within the grace period",
+			expect: func(m mocks) {
+				expectBalance(m, day, 0, 0)
+			},
+			want:    []*entity.Charge{},
+			wantErr: false,
+		},
+		{
+			name: "Success balance paid",
+			expect: func(m mocks) {
+				expectBalance(m, day, 300, 300)
+			},
+			want:    []*entity.Charge{},
+			wantErr: false,
+		},
+		{
+			name: "Success first overdue day",
+			expect: func(m mocks) {
+				expectBalance(m, day, 300, 0)
+				expectBalance(m, previous, 0, 0)
+				expectPost(m, interest, 1, 11)
+				expectPost(m, lateFee, 2, 12)
+				expectPost(m, penalty, 3, 13)
+			},
+			want: []*entity.Charge{
+				posted(interest, 1, 11),
+				posted(lateFee, 2, 12),
+				posted(penalty, 3, 13),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Success interest only after the first overdue day",
+			expect: func(m mocks) {
+				expectBalance(m, day, 400, 100)
+				expectBalance(m, previous, 400, 100)
+				expectPost(m, interest, 4, 14)
+			},
+			want:    []*entity.Charge{posted(interest, 4, 14)},
+			wantErr: false,
+		},
+		{
+			name: "Success already charged on the day",
+			expect: func(m mocks) {
+				expectBalance(m, day, 300, 0)
+				expectBalance(m, previous, 300, 0)
+				m.repo.EXPECT().Reserve(gomock.Any(), &interest).Return(nil, entity.ErrAlreadyCharged)
+			},
+			want:    []*entity.Charge{},
+			wantErr: false,
+		},
+		{
+			name: "Error transaction releases the reservation",
+			expect: func(m mocks) {
+				expectBalance(m, day, 300, 0)
+				expectBalance(m, previous, 300, 0)
+
+				reserved := interest
+				reserved.ID = 5
+				m.repo.EXPECT().Reserve(gomock.Any(), &interest).Return(&reserved, nil)
+				m.transactionService.EXPECT().Charge(gomock.Any(), 1, entity.OperationTypeJuros, 1.5).
+					Return(nil, errors.New("database error"))
+				m.repo.EXPECT().Delete(gomock.Any(), 5).Return(nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newMocks(ctrl)
+			tc.expect(m)
+
+			s := NewService(m.repo, m.accountRepo, m.transactionRepo, m.transactionService, testConfig)
+
+			got, err := s.Accrue(context.TODO(), 1, day.Add(10*time.Hour))
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Accrue() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Accrue() got = %v, want %v, %v", got, tc.want, cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
+
+func Test_service_AccrueAll(t *testing.T) {
+	day := time.Date(2022, 3, 25, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		expect  func(m mocks)
+		want    *entity.ChargeRun
+		wantErr bool
+	}{
+		{
+			name: "Error listing accounts",
+			expect: func(m mocks) {
+				m.accountRepo.EXPECT().List(gomock.Any(), 0, pageSize).Return(nil, errors.New("database error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Success with a failed account",
+			expect: func(m mocks) {
+				m.accountRepo.EXPECT().List(gomock.Any(), 0, pageSize).Return([]*entity.Account{{ID: 1}, {ID: 2}}, nil)
+
+				expectBalance(m, day, 400, 100)
+				expectBalance(m, day.AddDate(0, 0, -1), 400, 100)
+				expectPost(m, entity.Charge{AccountID: 1, Type: entity.ChargeTypeInterest, Date: day, Amount: 1.5}, 1, 11)
+
+				m.transactionRepo.EXPECT().SumBalanceBefore(gomock.Any(), 2, gomock.Any()).
+					Return(0.0, 0.0, errors.New("database error"))
+			},
+			want:    &entity.ChargeRun{Date: day, Accounts: 2, Charges: 1, Amount: 1.5, Failed: 1},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newMocks(ctrl)
+			tc.expect(m)
+
+			s := NewService(m.repo, m.accountRepo, m.transactionRepo, m.transactionService, testConfig)
+
+			got, err := s.AccrueAll(context.TODO(), day)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("AccrueAll() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("AccrueAll() got = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
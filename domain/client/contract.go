@@ -0,0 +1,19 @@
+//go:generate go run github.com/golang/mock/mockgen@v1.6.0 -source=contract.go -destination=mock_client/contract.go
+
+package client
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/entity"
+)
+
+// ClientKeyType constant for the authenticated client stored in the request context
+const ClientKeyType = "client_key"
+
+type Service interface {
+	Authenticate(ctx context.Context, apiKey string) (*entity.Client, error)
+}
+
+type Repository interface {
+	GetByKeyHash(ctx context.Context, keyHash string) (*entity.Client, error)
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+)
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+func (s *service) Authenticate(ctx context.Context, apiKey string) (*entity.Client, error) {
+	if apiKey == "" {
+		return nil, entity.ErrUnauthorized
+	}
+
+	cl, err := s.repo.GetByKeyHash(ctx, HashKey(apiKey))
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, entity.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Authenticate")
+	}
+
+	return cl, nil
+}
+
+// HashKey returns the SHA-256 hex digest used to store API keys
+func HashKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/client/mock_client"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"testing"
+)
+
+func Test_service_Authenticate(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		apiKey string
+	}
+	testCases := []struct {
+		name    string
+		svcArgs func(ctrl *gomock.Controller) Repository
+		args    args
+		want    *entity.Client
+		wantErr error
+	}{
+		{
+			name: "Error empty key",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				return mock_client.NewMockRepository(ctrl)
+			},
+			args:    args{apiKey: ""},
+			want:    nil,
+			wantErr: entity.ErrUnauthorized,
+		},
+		{
+			name: "Error unknown key",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_client.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByKeyHash(gomock.Any(), HashKey("unknown")).Return(nil, entity.ErrNotFound)
+
+				return repo
+			},
+			args:    args{apiKey: "unknown"},
+			want:    nil,
+			wantErr: entity.ErrUnauthorized,
+		},
+		{
+			name: "Error database",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_client.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByKeyHash(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+
+				return repo
+			},
+			args:    args{apiKey: "secret"},
+			want:    nil,
+			wantErr: errors.New("Authenticate: database error"),
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_client.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByKeyHash(gomock.Any(), HashKey("secret")).
+					Return(&entity.Client{
+						ID:        1,
+						Name:      "mobile app",
+						Role:      entity.RoleCustomer,
+						Scopes:    []entity.Scope{entity.ScopeAccountsRead},
+						AccountID: 1,
+					}, nil)
+
+				return repo
+			},
+			args: args{apiKey: "secret"},
+			want: &entity.Client{
+				ID:        1,
+				Name:      "mobile app",
+				Role:      entity.RoleCustomer,
+				Scopes:    []entity.Scope{entity.ScopeAccountsRead},
+				AccountID: 1,
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := NewService(tc.svcArgs(ctrl))
+
+			got, err := s.Authenticate(tc.args.ctx, tc.args.apiKey)
+			if (err != nil) != (tc.wantErr != nil) || (err != nil && err.Error() != tc.wantErr.Error()) {
+				t.Errorf("Authenticate() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Authenticate() got = %v, want %v, %v", got, tc.want, cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+//go:generate go run github.com/golang/mock/mockgen@v1.6.0 -source=contract.go -destination=mock_customer/contract.go
+
+package customer
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/entity"
+)
+
+type Service interface {
+	Create(ctx context.Context, customer *entity.Customer) (*entity.Customer, error)
+	Get(ctx context.Context, id int) (*entity.Customer, error)
+	// Update replace the profile of the customer
+	Update(ctx context.Context, customer *entity.Customer) (*entity.Customer, error)
+	// OpenAccount create an account held by the customer
+	OpenAccount(ctx context.Context, customerID int, docNumber string, availableCreditLimit float64) (*entity.Account, error)
+}
+
+type Repository interface {
+	Save(ctx context.Context, customer *entity.Customer) (*entity.Customer, error)
+	GetByID(ctx context.Context, id int) (*entity.Customer, error)
+	Update(ctx context.Context, customer *entity.Customer) (*entity.Customer, error)
+}
@@ -0,0 +1,131 @@
+package customer
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/account"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/pkg/clock"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/brunomdev/digital-account/domain/customer")
+
+type service struct {
+	repo        Repository
+	accountRepo account.Repository
+	clock       clock.Clock
+}
+
+func NewService(repo Repository, accountRepo account.Repository, clk clock.Clock) Service {
+	return &service{
+		repo:        repo,
+		accountRepo: accountRepo,
+		clock:       clk,
+	}
+}
+
+func (s *service) Create(ctx context.Context, customer *entity.Customer) (*entity.Customer, error) {
+	ctx, span := tracer.Start(ctx, "customer.Create")
+	defer span.End()
+
+	err := customer.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.Save(ctx, customer)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, errors.Wrap(err, "Create")
+	}
+
+	return created, nil
+}
+
+func (s *service) Get(ctx context.Context, id int) (*entity.Customer, error) {
+	ctx, span := tracer.Start(ctx, "customer.Get", trace.WithAttributes(attribute.Int("customer.id", id)))
+	defer span.End()
+
+	customer, err := s.repo.GetByID(ctx, id)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, errors.Wrap(err, "customer")
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, errors.Wrap(err, "Get")
+	}
+
+	return customer, nil
+}
+
+func (s *service) Update(ctx context.Context, customer *entity.Customer) (*entity.Customer, error) {
+	ctx, span := tracer.Start(ctx, "customer.Update", trace.WithAttributes(attribute.Int("customer.id", customer.ID)))
+	defer span.End()
+
+	err := customer.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.repo.GetByID(ctx, customer.ID)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, errors.Wrap(err, "customer")
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, errors.Wrap(err, "Update")
+	}
+
+	customer.CreatedAt = current.CreatedAt
+
+	updated, err := s.repo.Update(ctx, customer)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, errors.Wrap(err, "Update")
+	}
+
+	return updated, nil
+}
+
+func (s *service) OpenAccount(
+	ctx context.Context,
+	customerID int,
+	docNumber string,
+	availableCreditLimit float64,
+) (*entity.Account, error) {
+	ctx, span := tracer.Start(ctx, "customer.OpenAccount", trace.WithAttributes(attribute.Int("customer.id", customerID)))
+	defer span.End()
+
+	_, err := s.repo.GetByID(ctx, customerID)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, errors.Wrap(err, "customer")
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, errors.Wrap(err, "OpenAccount")
+	}
+
+	acc, err := s.accountRepo.SaveForCustomer(ctx, customerID, docNumber, availableCreditLimit, s.clock.Now(ctx).UTC())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, errors.Wrap(err, "OpenAccount")
+	}
+
+	return acc, nil
+}
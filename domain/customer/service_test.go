@@ -0,0 +1,275 @@
+package customer
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/account"
+	"github.com/brunomdev/digital-account/domain/account/mock_account"
+	"github.com/brunomdev/digital-account/domain/customer/mock_customer"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/pkg/clock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2022, 3, 30, 10, 0, 0, 0, time.UTC)
+
+func newPerson() *entity.Customer {
+	return &entity.Customer{
+		Name:      "Maria Silva",
+		BirthDate: "1990-01-31",
+		Email:     "maria@example.com",
+	}
+}
+
+func Test_service_Create(t *testing.T) {
+	testCases := []struct {
+		name     string
+		repo     func(ctrl *gomock.Controller) Repository
+		customer func() *entity.Customer
+		want     *entity.Customer
+		wantErr  error
+	}{
+		{
+			name: "Error person and company",
+			repo: func(ctrl *gomock.Controller) Repository {
+				return mock_customer.NewMockRepository(ctrl)
+			},
+			customer: func() *entity.Customer {
+				c := newPerson()
+				c.CompanyName = "Silva LTDA"
+				return c
+			},
+			wantErr: entity.ErrInvalidCustomer,
+		},
+		{
+			name: "Error person without birth date",
+			repo: func(ctrl *gomock.Controller) Repository {
+				return mock_customer.NewMockRepository(ctrl)
+			},
+			customer: func() *entity.Customer {
+				c := newPerson()
+				c.BirthDate = ""
+				return c
+			},
+			wantErr: entity.ErrInvalidCustomer,
+		},
+		{
+			name: "Error invalid birth date",
+			repo: func(ctrl *gomock.Controller) Repository {
+				return mock_customer.NewMockRepository(ctrl)
+			},
+			customer: func() *entity.Customer {
+				c := newPerson()
+				c.BirthDate = "31/01/1990"
+				return c
+			},
+			wantErr: entity.ErrInvalidCustomer,
+		},
+		{
+			name: "Error without email",
+			repo: func(ctrl *gomock.Controller) Repository {
+				return mock_customer.NewMockRepository(ctrl)
+			},
+			customer: func() *entity.Customer {
+				c := newPerson()
+				c.Email = ""
+				return c
+			},
+			wantErr: entity.ErrInvalidCustomer,
+		},
+		{
+			name: "Error database",
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_customer.NewMockRepository(ctrl)
+
+				repo.EXPECT().Save(gomock.Any(), newPerson()).Return(nil, errors.New("database error"))
+
+				return repo
+			},
+			customer: newPerson,
+			wantErr:  errors.New("Create: database error"),
+		},
+		{
+			name: "Success company",
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_customer.NewMockRepository(ctrl)
+
+				repo.EXPECT().Save(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, c *entity.Customer) (*entity.Customer, error) {
+						saved := *c
+						saved.ID = 1
+						return &saved, nil
+					})
+
+				return repo
+			},
+			customer: func() *entity.Customer {
+				return &entity.Customer{CompanyName: "Silva LTDA", Email: "contato@silva.com.br"}
+			},
+			want: &entity.Customer{ID: 1, CompanyName: "Silva LTDA", Email: "contato@silva.com.br"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := NewService(tc.repo(ctrl), mock_account.NewMockRepository(ctrl), clock.NewFake(now))
+
+			got, err := s.Create(context.TODO(), tc.customer())
+			if (err != nil) != (tc.wantErr != nil) || (err != nil && err.Error() != tc.wantErr.Error()) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Create() got = %v, want %v, %v", got, tc.want, cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
+
+func Test_service_Update(t *testing.T) {
+	createdAt := time.Date(2022, 3, 28, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		repo    func(ctrl *gomock.Controller) Repository
+		want    *entity.Customer
+		wantErr error
+	}{
+		{
+			name: "Error not found",
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_customer.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, entity.ErrNotFound)
+
+				return repo
+			},
+			wantErr: errors.Wrap(entity.ErrNotFound, "customer"),
+		},
+		{
+			name: "Success keeps the creation time",
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_customer.NewMockRepository(ctrl)
+
+				current := newPerson()
+				current.ID = 1
+				current.CreatedAt = createdAt
+
+				repo.EXPECT().GetByID(gomock.Any(), 1).Return(current, nil)
+				repo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, c *entity.Customer) (*entity.Customer, error) {
+						return c, nil
+					})
+
+				return repo
+			},
+			want: &entity.Customer{
+				ID:        1,
+				Name:      "Maria Silva",
+				BirthDate: "1990-01-31",
+				Email:     "maria.silva@example.com",
+				CreatedAt: createdAt,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := NewService(tc.repo(ctrl), mock_account.NewMockRepository(ctrl), clock.NewFake(now))
+
+			c := newPerson()
+			c.ID = 1
+			c.Email = "maria.silva@example.com"
+
+			got, err := s.Update(context.TODO(), c)
+			if (err != nil) != (tc.wantErr != nil) || (err != nil && err.Error() != tc.wantErr.Error()) {
+				t.Errorf("Update() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Update() got = %v, want %v, %v", got, tc.want, cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
+
+func Test_service_OpenAccount(t *testing.T) {
+	testCases := []struct {
+		name    string
+		repos   func(ctrl *gomock.Controller) (Repository, account.Repository)
+		want    *entity.Account
+		wantErr error
+	}{
+		{
+			name: "Error customer not found",
+			repos: func(ctrl *gomock.Controller) (Repository, account.Repository) {
+				repo := mock_customer.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, entity.ErrNotFound)
+
+				return repo, mock_account.NewMockRepository(ctrl)
+			},
+			wantErr: errors.Wrap(entity.ErrNotFound, "customer"),
+		},
+		{
+			name: "Error database",
+			repos: func(ctrl *gomock.Controller) (Repository, account.Repository) {
+				repo := mock_customer.NewMockRepository(ctrl)
+				accountRepo := mock_account.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), 1).Return(&entity.Customer{ID: 1}, nil)
+				accountRepo.EXPECT().SaveForCustomer(gomock.Any(), 1, "12345678900", 100.0, now).
+					Return(nil, errors.New("database error"))
+
+				return repo, accountRepo
+			},
+			wantErr: errors.New("OpenAccount: database error"),
+		},
+		{
+			name: "Success",
+			repos: func(ctrl *gomock.Controller) (Repository, account.Repository) {
+				repo := mock_customer.NewMockRepository(ctrl)
+				accountRepo := mock_account.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), 1).Return(&entity.Customer{ID: 1}, nil)
+				accountRepo.EXPECT().SaveForCustomer(gomock.Any(), 1, "12345678900", 100.0, now).
+					Return(&entity.Account{ID: 2, DocumentNumber: "12345678900", AvailabelCreditLimit: 100, CustomerID: 1}, nil)
+
+				return repo, accountRepo
+			},
+			want: &entity.Account{ID: 2, DocumentNumber: "12345678900", AvailabelCreditLimit: 100, CustomerID: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, accountRepo := tc.repos(ctrl)
+
+			s := NewService(repo, accountRepo, clock.NewFake(now))
+
+			got, err := s.OpenAccount(context.TODO(), 1, "12345678900", 100)
+			if (err != nil) != (tc.wantErr != nil) || (err != nil && err.Error() != tc.wantErr.Error()) {
+				t.Errorf("OpenAccount() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("OpenAccount() got = %v, want %v, %v", got, tc.want, cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
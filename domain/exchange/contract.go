@@ -0,0 +1,11 @@
+//go:generate go run github.com/golang/mock/mockgen@v1.6.0 -source=contract.go -destination=mock_exchange/contract.go
+
+package exchange
+
+import "context"
+
+// RateProvider give the exchange rates between currencies
+type RateProvider interface {
+	// Rate is the price of a unit of from in to, entity.ErrCurrencyNotSupported when a currency has no rate
+	Rate(ctx context.Context, from, to string) (float64, error)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package mock_exchange is a generated GoMock package.
+package mock_exchange
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRateProvider is a mock of RateProvider interface.
+type MockRateProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateProviderMockRecorder
+}
+
+// MockRateProviderMockRecorder is the mock recorder for MockRateProvider.
+type MockRateProviderMockRecorder struct {
+	mock *MockRateProvider
+}
+
+// NewMockRateProvider creates a new mock instance.
+func NewMockRateProvider(ctrl *gomock.Controller) *MockRateProvider {
+	mock := &MockRateProvider{ctrl: ctrl}
+	mock.recorder = &MockRateProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateProvider) EXPECT() *MockRateProviderMockRecorder {
+	return m.recorder
+}
+
+// Rate mocks base method.
+func (m *MockRateProvider) Rate(ctx context.Context, from, to string) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rate", ctx, from, to)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rate indicates an expected call of Rate.
+func (mr *MockRateProviderMockRecorder) Rate(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rate", reflect.TypeOf((*MockRateProvider)(nil).Rate), ctx, from, to)
+}
@@ -0,0 +1,73 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"os"
+	"strings"
+)
+
+type ratesFile struct {
+	Base  string             `yaml:"base"`
+	Rates map[string]float64 `yaml:"rates"`
+}
+
+// fileRates are fixed rates of each currency in a base currency, read from a local file
+type fileRates struct {
+	rates map[string]float64
+}
+
+// LoadRates read the rates from the YAML file, an empty path returns no provider
+func LoadRates(path string) (RateProvider, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "LoadRates")
+	}
+
+	return ParseRates(content)
+}
+
+// ParseRates build the provider from the YAML content, each rate is the price of a unit of the currency in the base
+// currency
+func ParseRates(content []byte) (RateProvider, error) {
+	var file ratesFile
+	if err := yaml.UnmarshalStrict(content, &file); err != nil {
+		return nil, errors.Wrap(err, "ParseRates")
+	}
+
+	if len(file.Base) != 3 {
+		return nil, errors.New("ParseRates: base must be an ISO 4217 code")
+	}
+
+	rates := map[string]float64{strings.ToUpper(file.Base): 1}
+	for currency, rate := range file.Rates {
+		if len(currency) != 3 || rate <= 0 {
+			return nil, fmt.Errorf("ParseRates: invalid rate of %s", currency)
+		}
+
+		rates[strings.ToUpper(currency)] = rate
+	}
+
+	return &fileRates{rates: rates}, nil
+}
+
+func (r *fileRates) Rate(_ context.Context, from, to string) (float64, error) {
+	fromRate, ok := r.rates[strings.ToUpper(from)]
+	if !ok {
+		return 0, errors.Wrap(entity.ErrCurrencyNotSupported, from)
+	}
+
+	toRate, ok := r.rates[strings.ToUpper(to)]
+	if !ok {
+		return 0, errors.Wrap(entity.ErrCurrencyNotSupported, to)
+	}
+
+	return fromRate / toRate, nil
+}
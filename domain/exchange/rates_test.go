@@ -0,0 +1,68 @@
+package exchange
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+	"testing"
+)
+
+func TestParseRates(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{name: "Error yaml", content: "base: [", wantErr: true},
+		{name: "Error unknown field", content: "base: BRL\nspread: 0.04", wantErr: true},
+		{name: "Error missing base", content: "rates:\n  USD: 5", wantErr: true},
+		{name: "Error invalid currency", content: "base: BRL\nrates:\n  DOLLAR: 5", wantErr: true},
+		{name: "Error invalid rate", content: "base: BRL\nrates:\n  USD: 0", wantErr: true},
+		{name: "Success", content: "base: BRL\nrates:\n  USD: 5\n  EUR: 5.5", wantErr: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseRates([]byte(tc.content))
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ParseRates() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func Test_fileRates_Rate(t *testing.T) {
+	provider, err := ParseRates([]byte("base: BRL\nrates:\n  USD: 5\n  EUR: 5.5"))
+	if err != nil {
+		t.Fatalf("ParseRates() error = %v", err)
+	}
+
+	testCases := []struct {
+		name    string
+		from    string
+		to      string
+		want    float64
+		wantErr error
+	}{
+		{name: "Error from not supported", from: "GBP", to: "BRL", wantErr: entity.ErrCurrencyNotSupported},
+		{name: "Error to not supported", from: "USD", to: "GBP", wantErr: entity.ErrCurrencyNotSupported},
+		{name: "Success to base", from: "USD", to: "BRL", want: 5},
+		{name: "Success from base", from: "BRL", to: "USD", want: 0.2},
+		{name: "Success cross rate", from: "eur", to: "usd", want: 1.1},
+		{name: "Success same currency", from: "BRL", to: "BRL", want: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := provider.Rate(context.TODO(), tc.from, tc.to)
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("Rate() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if got != tc.want {
+				t.Errorf("Rate() got = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
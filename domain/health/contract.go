@@ -0,0 +1,18 @@
+//go:generate go run github.com/golang/mock/mockgen@v1.6.0 -source=contract.go -destination=mock_health/contract.go
+
+package health
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/entity"
+)
+
+type Service interface {
+	Ready(ctx context.Context) (bool, []*entity.HealthCheck)
+	Drain()
+}
+
+type Repository interface {
+	Ping(ctx context.Context) error
+	SchemaVersion(ctx context.Context) (version uint, dirty bool, err error)
+}
@@ -0,0 +1,86 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"github.com/brunomdev/digital-account/entity"
+	"sync/atomic"
+)
+
+type service struct {
+	repo          Repository
+	schemaVersion uint
+	draining      int32
+}
+
+// NewService create the readiness checks, schemaVersion is the version of the latest migration known by the instance.
+// A newer schema keeps the instance ready, the migrations are applied by the new instances of a rolling deploy first
+func NewService(repo Repository, schemaVersion uint) Service {
+	return &service{
+		repo:          repo,
+		schemaVersion: schemaVersion,
+	}
+}
+
+func (s *service) Ready(ctx context.Context) (bool, []*entity.HealthCheck) {
+	checks := []*entity.HealthCheck{
+		s.checkDraining(),
+		s.checkDatabase(ctx),
+		s.checkSchema(ctx),
+	}
+
+	for _, check := range checks {
+		if check.Status != entity.HealthStatusUp {
+			return false, checks
+		}
+	}
+
+	return true, checks
+}
+
+// Drain fail the readiness from now on, so the instance stops receiving new requests before shutting down
+func (s *service) Drain() {
+	atomic.StoreInt32(&s.draining, 1)
+}
+
+func (s *service) checkDraining() *entity.HealthCheck {
+	if atomic.LoadInt32(&s.draining) == 1 {
+		return &entity.HealthCheck{Name: "draining", Status: entity.HealthStatusDown, Detail: "shutting down"}
+	}
+
+	return &entity.HealthCheck{Name: "draining", Status: entity.HealthStatusUp}
+}
+
+func (s *service) checkDatabase(ctx context.Context) *entity.HealthCheck {
+	err := s.repo.Ping(ctx)
+	if err != nil {
+		return &entity.HealthCheck{Name: "database", Status: entity.HealthStatusDown, Detail: err.Error()}
+	}
+
+	return &entity.HealthCheck{Name: "database", Status: entity.HealthStatusUp}
+}
+
+func (s *service) checkSchema(ctx context.Context) *entity.HealthCheck {
+	version, dirty, err := s.repo.SchemaVersion(ctx)
+	if err != nil {
+		return &entity.HealthCheck{Name: "schema", Status: entity.HealthStatusDown, Detail: err.Error()}
+	}
+
+	if dirty {
+		return &entity.HealthCheck{
+			Name:   "schema",
+			Status: entity.HealthStatusDown,
+			Detail: fmt.Sprintf("migration %d is dirty", version),
+		}
+	}
+
+	if version < s.schemaVersion {
+		return &entity.HealthCheck{
+			Name:   "schema",
+			Status: entity.HealthStatusDown,
+			Detail: fmt.Sprintf("schema version %d, expected %d or newer", version, s.schemaVersion),
+		}
+	}
+
+	return &entity.HealthCheck{Name: "schema", Status: entity.HealthStatusUp}
+}
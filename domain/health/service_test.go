@@ -0,0 +1,145 @@
+package health
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/health/mock_health"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"testing"
+)
+
+func Test_service_Ready(t *testing.T) {
+	testCases := []struct {
+		name       string
+		svcArgs    func(ctrl *gomock.Controller) Repository
+		drain      bool
+		want       bool
+		wantChecks []*entity.HealthCheck
+	}{
+		{
+			name: "Error database",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_health.NewMockRepository(ctrl)
+				repo.EXPECT().Ping(gomock.Any()).Return(errors.New("connection refused"))
+				repo.EXPECT().SchemaVersion(gomock.Any()).Return(uint(0), false, errors.New("connection refused"))
+
+				return repo
+			},
+			want: false,
+			wantChecks: []*entity.HealthCheck{
+				{Name: "draining", Status: entity.HealthStatusUp},
+				{Name: "database", Status: entity.HealthStatusDown, Detail: "connection refused"},
+				{Name: "schema", Status: entity.HealthStatusDown, Detail: "connection refused"},
+			},
+		},
+		{
+			name: "Error dirty schema",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_health.NewMockRepository(ctrl)
+				repo.EXPECT().Ping(gomock.Any()).Return(nil)
+				repo.EXPECT().SchemaVersion(gomock.Any()).Return(uint(20220323100000), true, nil)
+
+				return repo
+			},
+			want: false,
+			wantChecks: []*entity.HealthCheck{
+				{Name: "draining", Status: entity.HealthStatusUp},
+				{Name: "database", Status: entity.HealthStatusUp},
+				{Name: "schema", Status: entity.HealthStatusDown, Detail: "migration 20220323100000 is dirty"},
+			},
+		},
+		{
+			name: "Error outdated schema",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_health.NewMockRepository(ctrl)
+				repo.EXPECT().Ping(gomock.Any()).Return(nil)
+				repo.EXPECT().SchemaVersion(gomock.Any()).Return(uint(20220322100000), false, nil)
+
+				return repo
+			},
+			want: false,
+			wantChecks: []*entity.HealthCheck{
+				{Name: "draining", Status: entity.HealthStatusUp},
+				{Name: "database", Status: entity.HealthStatusUp},
+				{
+					Name:   "schema",
+					Status: entity.HealthStatusDown,
+					Detail: "schema version 20220322100000, expected 20220323100000 or newer",
+				},
+			},
+		},
+		{
+			name: "Error draining",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_health.NewMockRepository(ctrl)
+				repo.EXPECT().Ping(gomock.Any()).Return(nil)
+				repo.EXPECT().SchemaVersion(gomock.Any()).Return(uint(20220323100000), false, nil)
+
+				return repo
+			},
+			drain: true,
+			want:  false,
+			wantChecks: []*entity.HealthCheck{
+				{Name: "draining", Status: entity.HealthStatusDown, Detail: "shutting down"},
+				{Name: "database", Status: entity.HealthStatusUp},
+				{Name: "schema", Status: entity.HealthStatusUp},
+			},
+		},
+		{
+			name: "Success newer schema",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_health.NewMockRepository(ctrl)
+				repo.EXPECT().Ping(gomock.Any()).Return(nil)
+				repo.EXPECT().SchemaVersion(gomock.Any()).Return(uint(20220324100000), false, nil)
+
+				return repo
+			},
+			want: true,
+			wantChecks: []*entity.HealthCheck{
+				{Name: "draining", Status: entity.HealthStatusUp},
+				{Name: "database", Status: entity.HealthStatusUp},
+				{Name: "schema", Status: entity.HealthStatusUp},
+			},
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_health.NewMockRepository(ctrl)
+				repo.EXPECT().Ping(gomock.Any()).Return(nil)
+				repo.EXPECT().SchemaVersion(gomock.Any()).Return(uint(20220323100000), false, nil)
+
+				return repo
+			},
+			want: true,
+			wantChecks: []*entity.HealthCheck{
+				{Name: "draining", Status: entity.HealthStatusUp},
+				{Name: "database", Status: entity.HealthStatusUp},
+				{Name: "schema", Status: entity.HealthStatusUp},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := NewService(tc.svcArgs(ctrl), 20220323100000)
+
+			if tc.drain {
+				s.Drain()
+			}
+
+			got, gotChecks := s.Ready(context.TODO())
+			if got != tc.want {
+				t.Errorf("Ready() got = %v, want %v", got, tc.want)
+			}
+
+			if !cmp.Equal(gotChecks, tc.wantChecks) {
+				t.Errorf("Ready() checks = %v, want %v, %v", gotChecks, tc.wantChecks, cmp.Diff(gotChecks, tc.wantChecks))
+			}
+		})
+	}
+}
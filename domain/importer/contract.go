@@ -0,0 +1,14 @@
+//go:generate go run github.com/golang/mock/mockgen@v1.6.0 -source=contract.go -destination=mock_importer/contract.go
+
+package importer
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/entity"
+)
+
+type Service interface {
+	// Import create the transactions of the rows, the accounts run in parallel up to the parallelism and the rows of
+	// an account run in the file order. The results have the same order as the rows
+	Import(ctx context.Context, rows []*entity.ImportRow, parallelism int) []*entity.ImportResult
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package mock_importer is a generated GoMock package.
+package mock_importer
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/brunomdev/digital-account/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Import mocks base method.
+func (m *MockService) Import(ctx context.Context, rows []*entity.ImportRow, parallelism int) []*entity.ImportResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, rows, parallelism)
+	ret0, _ := ret[0].([]*entity.ImportResult)
+	return ret0
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockServiceMockRecorder) Import(ctx, rows, parallelism interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockService)(nil).Import), ctx, rows, parallelism)
+}
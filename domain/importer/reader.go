@@ -0,0 +1,166 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+// csvColumns are the required columns of the CSV header, in any order. The currency, card_id and event_date columns
+// are optional, like their fields on POST /transactions
+var csvColumns = []string{"account_id", "operation_type_id", "amount"}
+
+// Read decode the rows of an import file, a row that can't be parsed is returned with its Errors while a malformed
+// file, e.g. a CSV without the header, fails the whole read
+func Read(format Format, r io.Reader) ([]*entity.ImportRow, error) {
+	switch format {
+	case FormatCSV:
+		return readCSV(r)
+	case FormatJSONL:
+		return readJSONL(r)
+	}
+
+	return nil, errors.Errorf("unknown import format %q", format)
+}
+
+func readCSV(r io.Reader) ([]*entity.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("missing the CSV header")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "readCSV")
+	}
+
+	positions := make(map[string]int, len(csvColumns))
+	for i, column := range header {
+		positions[strings.TrimSpace(column)] = i
+	}
+
+	for _, column := range csvColumns {
+		if _, ok := positions[column]; !ok {
+			return nil, errors.Errorf("missing the %s column on the CSV header", column)
+		}
+	}
+
+	rows := make([]*entity.ImportRow, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "readCSV")
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := &entity.ImportRow{Line: line}
+
+		field := func(column string) string {
+			position, ok := positions[column]
+			if !ok || position >= len(record) {
+				return ""
+			}
+
+			return strings.TrimSpace(record[position])
+		}
+
+		row.AccountID = parseInt(row, "account_id", field("account_id"))
+		row.OperationTypeID = parseInt(row, "operation_type_id", field("operation_type_id"))
+
+		amount, err := strconv.ParseFloat(field("amount"), 64)
+		if err != nil {
+			row.Errors = append(row.Errors, "amount must be a number")
+		}
+		row.Amount = amount
+
+		row.Currency = field("currency")
+
+		if cardID := field("card_id"); cardID != "" {
+			row.CardID = parseInt(row, "card_id", cardID)
+		}
+
+		if eventDate := field("event_date"); eventDate != "" {
+			row.EventDate, err = time.Parse(time.RFC3339, eventDate)
+			if err != nil {
+				row.Errors = append(row.Errors, "event_date must be a RFC 3339 date and time")
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func parseInt(row *entity.ImportRow, column, value string) int {
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		row.Errors = append(row.Errors, fmt.Sprintf("%s must be an integer", column))
+	}
+
+	return parsed
+}
+
+func readJSONL(r io.Reader) ([]*entity.ImportRow, error) {
+	scanner := bufio.NewScanner(r)
+
+	rows := make([]*entity.ImportRow, 0)
+	for line := 1; scanner.Scan(); line++ {
+		content := bytes.TrimSpace(scanner.Bytes())
+		if len(content) == 0 {
+			continue
+		}
+
+		var input struct {
+			AccountID       int       `json:"account_id"`
+			OperationTypeID int       `json:"operation_type_id"`
+			Amount          float64   `json:"amount"`
+			Currency        string    `json:"currency"`
+			CardID          int       `json:"card_id"`
+			EventDate       time.Time `json:"event_date"`
+		}
+
+		row := &entity.ImportRow{Line: line}
+
+		err := json.Unmarshal(content, &input)
+		if err != nil {
+			row.Errors = append(row.Errors, err.Error())
+		}
+
+		row.AccountID = input.AccountID
+		row.OperationTypeID = input.OperationTypeID
+		row.Amount = input.Amount
+		row.Currency = input.Currency
+		row.CardID = input.CardID
+		row.EventDate = input.EventDate
+
+		rows = append(rows, row)
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, errors.Wrap(err, "readJSONL")
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,125 @@
+package importer
+
+import (
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/google/go-cmp/cmp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRead(t *testing.T) {
+	testCases := []struct {
+		name    string
+		format  Format
+		content string
+		want    []*entity.ImportRow
+		wantErr bool
+	}{
+		{
+			name:    "Error unknown format",
+			format:  "xml",
+			wantErr: true,
+		},
+		{
+			name:    "Error CSV without header",
+			format:  FormatCSV,
+			content: "",
+			wantErr: true,
+		},
+		{
+			name:    "Error CSV missing column",
+			format:  FormatCSV,
+			content: "account_id,amount\n1,-50\n",
+			wantErr: true,
+		},
+		{
+			name:   "CSV",
+			format: FormatCSV,
+			content: `amount,account_id,operation_type_id,description
+-50.5,1,1,first
+100, 2, 4
+abc,x,1
+`,
+			want: []*entity.ImportRow{
+				{Line: 2, AccountID: 1, OperationTypeID: 1, Amount: -50.5},
+				{Line: 3, AccountID: 2, OperationTypeID: 4, Amount: 100},
+				{Line: 4, OperationTypeID: 1, Errors: []string{"account_id must be an integer", "amount must be a number"}},
+			},
+		},
+		{
+			name:   "CSV optional columns",
+			format: FormatCSV,
+			content: `account_id,operation_type_id,amount,currency,card_id,event_date
+1,1,-10,USD,7,2022-03-28T12:00:00Z
+1,1,-50.5,,,
+1,1,-20,,x,2022-03-28
+`,
+			want: []*entity.ImportRow{
+				{
+					Line:            2,
+					AccountID:       1,
+					OperationTypeID: 1,
+					Amount:          -10,
+					Currency:        "USD",
+					CardID:          7,
+					EventDate:       time.Date(2022, 3, 28, 12, 0, 0, 0, time.UTC),
+				},
+				{Line: 3, AccountID: 1, OperationTypeID: 1, Amount: -50.5},
+				{
+					Line:            4,
+					AccountID:       1,
+					OperationTypeID: 1,
+					Amount:          -20,
+					Errors: []string{
+						"card_id must be an integer",
+						"event_date must be a RFC 3339 date and time",
+					},
+				},
+			},
+		},
+		{
+			name:   "JSONL",
+			format: FormatJSONL,
+			content: `{"account_id": 1, "operation_type_id": 1, "amount": -50.5}
+
+{"account_id": 2, "operation_type_id": 4, "amount": 100}
+{"account_id": 1, "operation_type_id": 1, "amount": -10, "currency": "USD", "card_id": 7, "event_date": "2022-03-28T12:00:00Z"}
+{"account_id": "1"}
+`,
+			want: []*entity.ImportRow{
+				{Line: 1, AccountID: 1, OperationTypeID: 1, Amount: -50.5},
+				{Line: 3, AccountID: 2, OperationTypeID: 4, Amount: 100},
+				{
+					Line:            4,
+					AccountID:       1,
+					OperationTypeID: 1,
+					Amount:          -10,
+					Currency:        "USD",
+					CardID:          7,
+					EventDate:       time.Date(2022, 3, 28, 12, 0, 0, 0, time.UTC),
+				},
+				{
+					Line: 5,
+					Errors: []string{
+						"json: cannot unmarshal string into Go struct field .account_id of type int",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Read(tc.format, strings.NewReader(tc.content))
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Read() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Read() got = %v, want %v, %v", got, tc.want, cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package importer
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/transaction"
+	"github.com/brunomdev/digital-account/entity"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"sync"
+)
+
+var tracer = otel.Tracer("github.com/brunomdev/digital-account/domain/importer")
+
+type service struct {
+	transactionService transaction.Service
+}
+
+func NewService(transactionService transaction.Service) Service {
+	return &service{
+		transactionService: transactionService,
+	}
+}
+
+func (s *service) Import(ctx context.Context, rows []*entity.ImportRow, parallelism int) []*entity.ImportResult {
+	ctx, span := tracer.Start(ctx, "importer.Import", trace.WithAttributes(
+		attribute.Int("import.rows", len(rows)),
+		attribute.Int("import.parallelism", parallelism),
+	))
+	defer span.End()
+
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	results := make([]*entity.ImportResult, len(rows))
+
+	// the indexes of the rows of each account, in the file order
+	var accounts [][]int
+	accountIndex := make(map[int]int)
+	for i, row := range rows {
+		if len(row.Errors) > 0 {
+			results[i] = &entity.ImportResult{
+				Line:      row.Line,
+				AccountID: row.AccountID,
+				Outcome:   entity.ImportOutcomeInvalid,
+				Errors:    row.Errors,
+			}
+			continue
+		}
+
+		idx, ok := accountIndex[row.AccountID]
+		if !ok {
+			idx = len(accounts)
+			accountIndex[row.AccountID] = idx
+			accounts = append(accounts, nil)
+		}
+		accounts[idx] = append(accounts[idx], i)
+	}
+
+	queue := make(chan []int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < parallelism && w < len(accounts); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for indexes := range queue {
+				for _, i := range indexes {
+					results[i] = s.importRow(ctx, rows[i])
+				}
+			}
+		}()
+	}
+
+	for _, indexes := range accounts {
+		queue <- indexes
+	}
+	close(queue)
+
+	wg.Wait()
+
+	return results
+}
+
+func (s *service) importRow(ctx context.Context, row *entity.ImportRow) *entity.ImportResult {
+	result := &entity.ImportResult{
+		Line:      row.Line,
+		AccountID: row.AccountID,
+		Outcome:   entity.ImportOutcome(entity.AttemptOutcomeApproved),
+	}
+
+	txn, err := s.transactionService.Create(ctx, entity.TransactionInput{
+		AccountID:       row.AccountID,
+		OperationTypeID: row.OperationTypeID,
+		Amount:          row.Amount,
+		Currency:        row.Currency,
+		CardID:          row.CardID,
+		EventDate:       row.EventDate,
+	})
+	if err != nil {
+		outcome, reason := transaction.DeclineReason(err)
+		result.Outcome = entity.ImportOutcome(outcome)
+		result.Reason = reason
+
+		return result
+	}
+
+	result.TransactionID = txn.ID
+
+	return result
+}
@@ -0,0 +1,104 @@
+package importer
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/transaction/mock_transaction"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_service_Import(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	eventDate := time.Date(2022, 3, 28, 12, 0, 0, 0, time.UTC)
+
+	rows := []*entity.ImportRow{
+		{
+			Line:            2,
+			AccountID:       1,
+			OperationTypeID: 1,
+			Amount:          -10,
+			Currency:        "USD",
+			CardID:          7,
+			EventDate:       eventDate,
+		},
+		{Line: 3, AccountID: 2, OperationTypeID: 1, Amount: -20},
+		{Line: 4, AccountID: 1, OperationTypeID: 1, Amount: -5000},
+		{Line: 5, AccountID: 3, Errors: []string{"OperationTypeID is a required field"}},
+		{Line: 6, AccountID: 2, OperationTypeID: 4, Amount: 20},
+		{Line: 7, AccountID: 1, OperationTypeID: 4, Amount: 10},
+		{Line: 8, AccountID: 3, OperationTypeID: 1, Amount: -1},
+	}
+
+	var (
+		mu     sync.Mutex
+		nextID int
+		order  = make(map[int][]float64)
+	)
+
+	svc := mock_transaction.NewMockService(ctrl)
+	svc.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, input entity.TransactionInput) (*entity.Transaction, error) {
+			accountID, operationTypeID, amount := input.AccountID, input.OperationTypeID, input.Amount
+
+			if amount == -10 && (input.Currency != "USD" || input.CardID != 7 || !input.EventDate.Equal(eventDate)) {
+				t.Errorf("Import() input = %v, want the currency, card and event date of the row", input)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			order[accountID] = append(order[accountID], amount)
+
+			switch amount {
+			case -5000:
+				return nil, entity.ErrInsufficientCreditLimit
+			case -1:
+				return nil, errors.New("error")
+			}
+
+			nextID++
+			return &entity.Transaction{ID: nextID, AccountID: accountID, OperationTypeID: operationTypeID, Amount: amount}, nil
+		}).
+		Times(6)
+
+	got := NewService(svc).Import(context.TODO(), rows, 3)
+
+	wantOrder := map[int][]float64{
+		1: {-10, -5000, 10},
+		2: {-20, 20},
+		3: {-1},
+	}
+	if !cmp.Equal(order, wantOrder) {
+		t.Errorf("Import() order = %v, want %v", order, wantOrder)
+	}
+
+	approved := entity.ImportOutcome(entity.AttemptOutcomeApproved)
+	want := []*entity.ImportResult{
+		{Line: 2, AccountID: 1, Outcome: approved},
+		{Line: 3, AccountID: 2, Outcome: approved},
+		{Line: 4, AccountID: 1, Outcome: entity.ImportOutcome(entity.AttemptOutcomeDeclined), Reason: "INSUFFICIENT_CREDIT_LIMIT"},
+		{Line: 5, AccountID: 3, Outcome: entity.ImportOutcomeInvalid, Errors: []string{"OperationTypeID is a required field"}},
+		{Line: 6, AccountID: 2, Outcome: approved},
+		{Line: 7, AccountID: 1, Outcome: approved},
+		{Line: 8, AccountID: 3, Outcome: entity.ImportOutcome(entity.AttemptOutcomeFailed), Reason: "INTERNAL_ERROR"},
+	}
+
+	// the transaction ids depend on the scheduling of the accounts
+	for _, result := range got {
+		if result.Outcome == approved && result.TransactionID < 1 {
+			t.Errorf("Import() line %d without transaction id", result.Line)
+		}
+		result.TransactionID = 0
+	}
+
+	if !cmp.Equal(got, want) {
+		t.Errorf("Import() got = %v, want %v, %v", got, want, cmp.Diff(got, want))
+	}
+}
@@ -0,0 +1,33 @@
+//go:generate go run github.com/golang/mock/mockgen@v1.6.0 -source=contract.go -destination=mock_job/contract.go
+
+package job
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/entity"
+	"time"
+)
+
+type Service interface {
+	Enqueue(ctx context.Context, jobType string, payload interface{}, clientID int) (*entity.Job, error)
+	GetByID(ctx context.Context, id int) (*entity.Job, error)
+	// GetLatestByType return the last enqueued job of the type, entity.ErrNotFound when there is none
+	GetLatestByType(ctx context.Context, jobType string) (*entity.Job, error)
+	// Purge delete the finished jobs created before the time, returning how many were deleted
+	Purge(ctx context.Context, before time.Time) (int, error)
+}
+
+type Repository interface {
+	Save(ctx context.Context, job *entity.Job) (*entity.Job, error)
+	GetByID(ctx context.Context, id int) (*entity.Job, error)
+	// Claim mark the oldest job due at now, queued or with an expired lease, as running until leaseUntil and return it
+	// with the attempt counted, entity.ErrNotFound when there is none. Concurrent workers never claim the same job.
+	Claim(ctx context.Context, now, leaseUntil time.Time) (*entity.Job, error)
+	// Update persist the status, progress, result, error and run_at of the job
+	Update(ctx context.Context, job *entity.Job) error
+	GetLatestByType(ctx context.Context, jobType string) (*entity.Job, error)
+	DeleteFinishedBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// Handler run a job of a type, the returned result is stored as JSON and progress persists the percentage done
+type Handler func(ctx context.Context, job *entity.Job, progress func(percent int)) (interface{}, error)
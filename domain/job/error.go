@@ -0,0 +1,28 @@
+package job
+
+import "github.com/pkg/errors"
+
+// PermanentError fail the job without retrying it
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent wrap an error that retrying the job would not fix
+func Permanent(err error) error {
+	return &PermanentError{Err: err}
+}
+
+// IsPermanent check if the error, or any error it wraps, is permanent
+func IsPermanent(err error) bool {
+	var permanentErr *PermanentError
+
+	return errors.As(err, &permanentErr)
+}
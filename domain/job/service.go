@@ -0,0 +1,110 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/brunomdev/digital-account/domain/audit"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/pkg/clock"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+var tracer = otel.Tracer("github.com/brunomdev/digital-account/domain/job")
+
+type service struct {
+	repo        Repository
+	clock       clock.Clock
+	maxAttempts int
+}
+
+// NewService enqueue the jobs run by the Pool, each job is tried up to maxAttempts times
+func NewService(repo Repository, clk clock.Clock, maxAttempts int) Service {
+	return &service{
+		repo:        repo,
+		clock:       clk,
+		maxAttempts: maxAttempts,
+	}
+}
+
+func (s *service) Enqueue(ctx context.Context, jobType string, payload interface{}, clientID int) (*entity.Job, error) {
+	ctx, span := tracer.Start(ctx, "job.Enqueue", trace.WithAttributes(attribute.String("job.type", jobType)))
+	defer span.End()
+
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "Enqueue")
+	}
+
+	// the workers claim the jobs on the system time, a run_at on the traveled time would hold or hurry the job
+	runAt := s.clock.Now(clock.WithOffset(ctx, 0)).UTC()
+
+	// the job runs detached from the request, it keeps the actor so its changes aren't audited as the system's
+	actor, requestID := audit.ActorFrom(ctx)
+
+	job, err := s.repo.Save(ctx, &entity.Job{
+		Type:        jobType,
+		Payload:     content,
+		Status:      entity.JobStatusQueued,
+		MaxAttempts: s.maxAttempts,
+		ClientID:    clientID,
+		Actor:       actor,
+		RequestID:   requestID,
+		RunAt:       runAt,
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, errors.Wrap(err, "Enqueue")
+	}
+
+	span.SetAttributes(attribute.Int("job.id", job.ID))
+
+	return job, nil
+}
+
+func (s *service) GetByID(ctx context.Context, id int) (*entity.Job, error) {
+	ctx, span := tracer.Start(ctx, "job.GetByID", trace.WithAttributes(attribute.Int("job.id", id)))
+	defer span.End()
+
+	job, err := s.repo.GetByID(ctx, id)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, errors.Wrap(err, "job")
+	}
+
+	return job, err
+}
+
+func (s *service) GetLatestByType(ctx context.Context, jobType string) (*entity.Job, error) {
+	ctx, span := tracer.Start(ctx, "job.GetLatestByType", trace.WithAttributes(attribute.String("job.type", jobType)))
+	defer span.End()
+
+	job, err := s.repo.GetLatestByType(ctx, jobType)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, errors.Wrap(err, "job")
+	}
+
+	return job, err
+}
+
+func (s *service) Purge(ctx context.Context, before time.Time) (int, error) {
+	ctx, span := tracer.Start(ctx, "job.Purge")
+	defer span.End()
+
+	deleted, err := s.repo.DeleteFinishedBefore(ctx, before)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return 0, errors.Wrap(err, "Purge")
+	}
+
+	span.SetAttributes(attribute.Int("job.deleted", deleted))
+
+	return deleted, nil
+}
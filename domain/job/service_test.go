@@ -0,0 +1,267 @@
+package job
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/audit"
+	"github.com/brunomdev/digital-account/domain/job/mock_job"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/pkg/clock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2022, 3, 30, 10, 0, 0, 0, time.UTC)
+
+func Test_service_Enqueue(t *testing.T) {
+	testCases := []struct {
+		name    string
+		svcArgs func(ctrl *gomock.Controller) Repository
+		payload interface{}
+		want    *entity.Job
+		wantErr bool
+	}{
+		{
+			name: "Error payload",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				return mock_job.NewMockRepository(ctrl)
+			},
+			payload: func() {},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Error database",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_job.NewMockRepository(ctrl)
+				repo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+
+				return repo
+			},
+			payload: map[string]int{"rows": 1},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_job.NewMockRepository(ctrl)
+				repo.EXPECT().Save(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, j *entity.Job) (*entity.Job, error) {
+						if !j.RunAt.Equal(now) {
+							t.Errorf("Enqueue() run_at = %v, want %v", j.RunAt, now)
+						}
+
+						saved := *j
+						saved.ID = 1
+
+						return &saved, nil
+					})
+
+				return repo
+			},
+			payload: map[string]int{"rows": 1},
+			want: &entity.Job{
+				ID:          1,
+				Type:        "test",
+				Payload:     []byte(`{"rows":1}`),
+				Status:      entity.JobStatusQueued,
+				MaxAttempts: 3,
+				ClientID:    2,
+				Actor:       "backoffice",
+				RequestID:   "request-1",
+				RunAt:       now,
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := NewService(tc.svcArgs(ctrl), clock.NewFake(now), 3)
+
+			// the workers claim on the system time, the travel must not delay the job, and the actor is kept for them
+			ctx := clock.WithOffset(audit.WithActor(context.TODO(), "backoffice", "request-1"), 48*time.Hour)
+
+			got, err := s.Enqueue(ctx, "test", tc.payload, 2)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Enqueue() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Enqueue() got = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func Test_service_GetByID(t *testing.T) {
+	testCases := []struct {
+		name       string
+		svcArgs    func(ctrl *gomock.Controller) Repository
+		want       *entity.Job
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name: "Error not found",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_job.NewMockRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, entity.ErrNotFound)
+
+				return repo
+			},
+			want:       nil,
+			wantErr:    true,
+			wantErrMsg: "job: not found",
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_job.NewMockRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), 1).Return(&entity.Job{ID: 1, Status: entity.JobStatusRunning}, nil)
+
+				return repo
+			},
+			want:    &entity.Job{ID: 1, Status: entity.JobStatusRunning},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := NewService(tc.svcArgs(ctrl), clock.NewFake(now), 3)
+
+			got, err := s.GetByID(context.TODO(), 1)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("GetByID() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if err != nil && err.Error() != tc.wantErrMsg {
+				t.Errorf("GetByID() error = %v, want %v", err, tc.wantErrMsg)
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("GetByID() got = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func Test_service_GetLatestByType(t *testing.T) {
+	testCases := []struct {
+		name       string
+		svcArgs    func(ctrl *gomock.Controller) Repository
+		want       *entity.Job
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name: "Error not found",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_job.NewMockRepository(ctrl)
+				repo.EXPECT().GetLatestByType(gomock.Any(), "test").Return(nil, entity.ErrNotFound)
+
+				return repo
+			},
+			want:       nil,
+			wantErr:    true,
+			wantErrMsg: "job: not found",
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_job.NewMockRepository(ctrl)
+				repo.EXPECT().GetLatestByType(gomock.Any(), "test").Return(&entity.Job{ID: 2, Type: "test"}, nil)
+
+				return repo
+			},
+			want:    &entity.Job{ID: 2, Type: "test"},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := NewService(tc.svcArgs(ctrl), clock.NewFake(now), 3)
+
+			got, err := s.GetLatestByType(context.TODO(), "test")
+			if (err != nil) != tc.wantErr {
+				t.Errorf("GetLatestByType() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if err != nil && err.Error() != tc.wantErrMsg {
+				t.Errorf("GetLatestByType() error = %v, want %v", err, tc.wantErrMsg)
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("GetLatestByType() got = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func Test_service_Purge(t *testing.T) {
+	before := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		svcArgs func(ctrl *gomock.Controller) Repository
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Error database",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_job.NewMockRepository(ctrl)
+				repo.EXPECT().DeleteFinishedBefore(gomock.Any(), before).Return(0, errors.New("database error"))
+
+				return repo
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_job.NewMockRepository(ctrl)
+				repo.EXPECT().DeleteFinishedBefore(gomock.Any(), before).Return(7, nil)
+
+				return repo
+			},
+			want:    7,
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := NewService(tc.svcArgs(ctrl), clock.NewFake(now), 3)
+
+			got, err := s.Purge(context.TODO(), before)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Purge() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if got != tc.want {
+				t.Errorf("Purge() got = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
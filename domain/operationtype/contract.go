@@ -9,8 +9,11 @@ import (
 
 type Service interface {
 	Get(ctx context.Context, id int) (*entity.OperationType, error)
+	// UpdateRules replace the rules applied to the debits of the operation type
+	UpdateRules(ctx context.Context, id int, rules entity.OperationTypeRules) (*entity.OperationType, error)
 }
 
 type Repository interface {
 	GetByID(ctx context.Context, id int) (*entity.OperationType, error)
+	UpdateRules(ctx context.Context, id int, rules entity.OperationTypeRules) error
 }
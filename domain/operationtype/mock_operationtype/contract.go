@@ -50,6 +50,21 @@ func (mr *MockServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockService)(nil).Get), ctx, id)
 }
 
+// UpdateRules mocks base method.
+func (m *MockService) UpdateRules(ctx context.Context, id int, rules entity.OperationTypeRules) (*entity.OperationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRules", ctx, id, rules)
+	ret0, _ := ret[0].(*entity.OperationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRules indicates an expected call of UpdateRules.
+func (mr *MockServiceMockRecorder) UpdateRules(ctx, id, rules interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRules", reflect.TypeOf((*MockService)(nil).UpdateRules), ctx, id, rules)
+}
+
 // MockRepository is a mock of Repository interface.
 type MockRepository struct {
 	ctrl     *gomock.Controller
@@ -87,3 +102,17 @@ func (mr *MockRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, id)
 }
+
+// UpdateRules mocks base method.
+func (m *MockRepository) UpdateRules(ctx context.Context, id int, rules entity.OperationTypeRules) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRules", ctx, id, rules)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRules indicates an expected call of UpdateRules.
+func (mr *MockRepositoryMockRecorder) UpdateRules(ctx, id, rules interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRules", reflect.TypeOf((*MockRepository)(nil).UpdateRules), ctx, id, rules)
+}
@@ -2,19 +2,84 @@ package operationtype
 
 import (
 	"context"
+	"github.com/brunomdev/digital-account/domain/audit"
 	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/infra/log"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/brunomdev/digital-account/domain/operationtype")
+
 type service struct {
-	repo Repository
+	repo         Repository
+	auditService audit.Service
 }
 
-func NewService(repo Repository) Service {
+// NewService create the operation type service, every change of the rules is recorded on the audit trail
+func NewService(repo Repository, auditService audit.Service) Service {
 	return &service{
-		repo: repo,
+		repo:         repo,
+		auditService: auditService,
 	}
 }
 
 func (s *service) Get(ctx context.Context, id int) (*entity.OperationType, error) {
+	ctx, span := tracer.Start(ctx, "operationtype.Get", trace.WithAttributes(attribute.Int("operation_type.id", id)))
+	defer span.End()
+
 	return s.repo.GetByID(ctx, id)
 }
+
+func (s *service) UpdateRules(ctx context.Context, id int, rules entity.OperationTypeRules) (*entity.OperationType, error) {
+	ctx, span := tracer.Start(ctx, "operationtype.UpdateRules", trace.WithAttributes(attribute.Int("operation_type.id", id)))
+	defer span.End()
+
+	if entity.IsSystemOperationType(id) {
+		return nil, entity.ErrSystemOperationType
+	}
+
+	// payments credit the account, the rules only apply to debits
+	if id == entity.OperationTypePagamento {
+		return nil, entity.ErrInvalidRules
+	}
+
+	err := rules.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	opType, err := s.repo.GetByID(ctx, id)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, errors.Wrap(err, "operation type")
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, errors.Wrap(err, "UpdateRules")
+	}
+
+	err = s.repo.UpdateRules(ctx, id, rules)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, errors.Wrap(err, "UpdateRules")
+	}
+
+	before := *opType
+	opType.Rules = rules
+
+	err = s.auditService.Record(ctx, entity.AuditActionUpdateRules, entity.AuditEntityOperationType, id, &before, opType)
+	if err != nil {
+		// the rules are already changed and restoring the snapshot could overwrite a change made meanwhile
+		log.Error(ctx, "unable to audit operation type rules", err, log.Event{"operation_type_id": id})
+		span.RecordError(err)
+	}
+
+	return opType, nil
+}
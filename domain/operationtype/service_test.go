@@ -2,6 +2,8 @@ package operationtype
 
 import (
 	"context"
+	"github.com/brunomdev/digital-account/domain/audit"
+	"github.com/brunomdev/digital-account/domain/audit/mock_audit"
 	"github.com/brunomdev/digital-account/domain/operationtype/mock_operationtype"
 	"github.com/brunomdev/digital-account/entity"
 	"github.com/golang/mock/gomock"
@@ -12,8 +14,7 @@ import (
 
 func Test_service_Get(t *testing.T) {
 	type args struct {
-		ctx context.Context
-		id  int
+		id int
 	}
 	testCases := []struct {
 		name    string
@@ -68,9 +69,9 @@ func Test_service_Get(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			s := NewService(tc.svcArgs(ctrl))
+			s := NewService(tc.svcArgs(ctrl), mock_audit.NewMockService(ctrl))
 
-			got, err := s.Get(tc.args.ctx, tc.args.id)
+			got, err := s.Get(context.TODO(), tc.args.id)
 			if (err != nil) != tc.wantErr {
 				t.Errorf("Get() error = %v, wantErr %v", err, tc.wantErr)
 				return
@@ -82,3 +83,152 @@ func Test_service_Get(t *testing.T) {
 		})
 	}
 }
+
+func Test_service_UpdateRules(t *testing.T) {
+	rules := entity.OperationTypeRules{LimitRate: 0.3, Fee: 5, DailyMaxCount: 3}
+
+	testCases := []struct {
+		name       string
+		svcArgs    func(ctrl *gomock.Controller) Repository
+		audit      func(ctrl *gomock.Controller) audit.Service
+		id         int
+		rules      entity.OperationTypeRules
+		want       *entity.OperationType
+		wantErrMsg string
+	}{
+		{
+			name: "Error system operation type",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				return mock_operationtype.NewMockRepository(ctrl)
+			},
+			id:         entity.OperationTypeTarifa,
+			rules:      rules,
+			want:       nil,
+			wantErrMsg: "operation type reserved to the system",
+		},
+		{
+			name: "Error payment",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				return mock_operationtype.NewMockRepository(ctrl)
+			},
+			id:         entity.OperationTypePagamento,
+			rules:      rules,
+			want:       nil,
+			wantErrMsg: "invalid operation type rules",
+		},
+		{
+			name: "Error invalid rate",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				return mock_operationtype.NewMockRepository(ctrl)
+			},
+			id:         entity.OperationTypeSaque,
+			rules:      entity.OperationTypeRules{LimitRate: 1.5},
+			want:       nil,
+			wantErrMsg: "invalid operation type rules",
+		},
+		{
+			name: "Error not found",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_operationtype.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), 99).Return(nil, entity.ErrNotFound)
+
+				return repo
+			},
+			id:         99,
+			rules:      rules,
+			want:       nil,
+			wantErrMsg: "operation type: not found",
+		},
+		{
+			name: "Error database",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_operationtype.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), entity.OperationTypeSaque).
+					Return(&entity.OperationType{ID: entity.OperationTypeSaque, Description: "SAQUE"}, nil)
+				repo.EXPECT().UpdateRules(gomock.Any(), entity.OperationTypeSaque, rules).Return(errors.New("database error"))
+
+				return repo
+			},
+			id:         entity.OperationTypeSaque,
+			rules:      rules,
+			want:       nil,
+			wantErrMsg: "UpdateRules: database error",
+		},
+		{
+			name: "Error audit is only logged",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_operationtype.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), entity.OperationTypeSaque).
+					Return(&entity.OperationType{ID: entity.OperationTypeSaque, Description: "SAQUE"}, nil)
+				repo.EXPECT().UpdateRules(gomock.Any(), entity.OperationTypeSaque, rules).Return(nil)
+
+				return repo
+			},
+			audit: func(ctrl *gomock.Controller) audit.Service {
+				auditService := mock_audit.NewMockService(ctrl)
+				auditService.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("database error"))
+
+				return auditService
+			},
+			id:    entity.OperationTypeSaque,
+			rules: rules,
+			want:  &entity.OperationType{ID: entity.OperationTypeSaque, Description: "SAQUE", Rules: rules},
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) Repository {
+				repo := mock_operationtype.NewMockRepository(ctrl)
+
+				repo.EXPECT().GetByID(gomock.Any(), entity.OperationTypeSaque).
+					Return(&entity.OperationType{ID: entity.OperationTypeSaque, Description: "SAQUE"}, nil)
+				repo.EXPECT().UpdateRules(gomock.Any(), entity.OperationTypeSaque, rules).Return(nil)
+
+				return repo
+			},
+			audit: func(ctrl *gomock.Controller) audit.Service {
+				auditService := mock_audit.NewMockService(ctrl)
+				auditService.EXPECT().Record(
+					gomock.Any(),
+					entity.AuditActionUpdateRules,
+					entity.AuditEntityOperationType,
+					entity.OperationTypeSaque,
+					&entity.OperationType{ID: entity.OperationTypeSaque, Description: "SAQUE"},
+					&entity.OperationType{ID: entity.OperationTypeSaque, Description: "SAQUE", Rules: rules},
+				).Return(nil)
+
+				return auditService
+			},
+			id:    entity.OperationTypeSaque,
+			rules: rules,
+			want:  &entity.OperationType{ID: entity.OperationTypeSaque, Description: "SAQUE", Rules: rules},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var auditService audit.Service = mock_audit.NewMockService(ctrl)
+			if tc.audit != nil {
+				auditService = tc.audit(ctrl)
+			}
+
+			s := NewService(tc.svcArgs(ctrl), auditService)
+
+			got, err := s.UpdateRules(context.TODO(), tc.id, tc.rules)
+			if (err != nil) != (tc.wantErrMsg != "") || (err != nil && err.Error() != tc.wantErrMsg) {
+				t.Errorf("UpdateRules() error = %v, wantErr %v", err, tc.wantErrMsg)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("UpdateRules() got = %v, want %v, %v", got, tc.want, cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+//go:generate go run github.com/golang/mock/mockgen@v1.6.0 -source=contract.go -destination=mock_reconciliation/contract.go
+
+package reconciliation
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/entity"
+)
+
+type Service interface {
+	Reconcile(ctx context.Context) (*entity.Reconciliation, error)
+}
+
+type Repository interface {
+	GetByAccountID(ctx context.Context, accountID int) (*entity.LimitCheckpoint, error)
+	// Save insert or replace the checkpoint of the account
+	Save(ctx context.Context, checkpoint *entity.LimitCheckpoint) error
+}
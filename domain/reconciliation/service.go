@@ -0,0 +1,132 @@
+package reconciliation
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/account"
+	"github.com/brunomdev/digital-account/domain/transaction"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"math"
+)
+
+var tracer = otel.Tracer("github.com/brunomdev/digital-account/domain/reconciliation")
+
+const (
+	pageSize = 100
+	// tolerance ignores the rounding of the amounts, stored with 2 decimals
+	tolerance = 0.005
+)
+
+type service struct {
+	repo            Repository
+	accountRepo     account.Repository
+	transactionRepo transaction.Repository
+}
+
+// NewService check the available credit limit of the accounts against their transactions. The account repository
+// must not be cached, a stale limit would be reported as a drift.
+func NewService(repo Repository, accountRepo account.Repository, transactionRepo transaction.Repository) Service {
+	return &service{
+		repo:            repo,
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+	}
+}
+
+func (s *service) Reconcile(ctx context.Context) (*entity.Reconciliation, error) {
+	ctx, span := tracer.Start(ctx, "reconciliation.Reconcile")
+	defer span.End()
+
+	result := &entity.Reconciliation{Drifts: []entity.LimitDrift{}}
+
+	afterID := 0
+	for {
+		accounts, err := s.accountRepo.List(ctx, afterID, pageSize)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			return nil, errors.Wrap(err, "Reconcile")
+		}
+
+		for _, acc := range accounts {
+			err = s.reconcile(ctx, acc.ID, result)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+
+				return nil, errors.Wrapf(err, "Reconcile account %d", acc.ID)
+			}
+
+			afterID = acc.ID
+		}
+
+		if len(accounts) < pageSize {
+			break
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("reconciliation.accounts", result.Accounts),
+		attribute.Int("reconciliation.drifts", len(result.Drifts)),
+	)
+
+	return result, nil
+}
+
+// reconcile compare the limit of the account with its checkpoint plus the transactions saved after it, then move
+// the checkpoint to the current limit so each drift is reported once. The account is skipped when a transaction is
+// saved while it is read.
+func (s *service) reconcile(ctx context.Context, accountID int, result *entity.Reconciliation) error {
+	result.Accounts++
+
+	checkpoint, err := s.repo.GetByAccountID(ctx, accountID)
+	if err != nil && !errors.Is(err, entity.ErrNotFound) {
+		return err
+	}
+
+	var afterID int
+	if checkpoint != nil {
+		afterID = checkpoint.TransactionID
+	}
+
+	changes, lastID, err := s.transactionRepo.SumLimitChangesAfter(ctx, accountID, afterID)
+	if err != nil {
+		return err
+	}
+
+	acc, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	_, lastIDAfterRead, err := s.transactionRepo.SumLimitChangesAfter(ctx, accountID, afterID)
+	if err != nil {
+		return err
+	}
+
+	if lastIDAfterRead != lastID {
+		result.Skipped++
+
+		return nil
+	}
+
+	if checkpoint == nil {
+		result.Baselined++
+	} else if expected := checkpoint.AvailableCreditLimit + changes; math.Abs(expected-acc.AvailabelCreditLimit) > tolerance {
+		result.Drifts = append(result.Drifts, entity.LimitDrift{
+			AccountID: accountID,
+			Expected:  math.Round(expected*100) / 100,
+			Actual:    acc.AvailabelCreditLimit,
+		})
+	}
+
+	return s.repo.Save(ctx, &entity.LimitCheckpoint{
+		AccountID:            accountID,
+		AvailableCreditLimit: acc.AvailabelCreditLimit,
+		TransactionID:        lastID,
+	})
+}
@@ -0,0 +1,129 @@
+package reconciliation
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/account/mock_account"
+	"github.com/brunomdev/digital-account/domain/reconciliation/mock_reconciliation"
+	"github.com/brunomdev/digital-account/domain/transaction/mock_transaction"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"testing"
+)
+
+func Test_service_Reconcile(t *testing.T) {
+	type mocks struct {
+		repo            *mock_reconciliation.MockRepository
+		accountRepo     *mock_account.MockRepository
+		transactionRepo *mock_transaction.MockRepository
+	}
+
+	listAccount := func(m mocks, limit float64) {
+		m.accountRepo.EXPECT().List(gomock.Any(), 0, pageSize).Return([]*entity.Account{{ID: 1}}, nil)
+		m.accountRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&entity.Account{ID: 1, AvailabelCreditLimit: limit}, nil)
+	}
+
+	testCases := []struct {
+		name    string
+		expect  func(m mocks)
+		want    *entity.Reconciliation
+		wantErr bool
+	}{
+		{
+			name: "Error listing accounts",
+			expect: func(m mocks) {
+				m.accountRepo.EXPECT().List(gomock.Any(), 0, pageSize).Return(nil, errors.New("database error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Error checkpoint",
+			expect: func(m mocks) {
+				m.accountRepo.EXPECT().List(gomock.Any(), 0, pageSize).Return([]*entity.Account{{ID: 1}}, nil)
+				m.repo.EXPECT().GetByAccountID(gomock.Any(), 1).Return(nil, errors.New("database error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Success baseline of an account without checkpoint",
+			expect: func(m mocks) {
+				listAccount(m, 80)
+				m.repo.EXPECT().GetByAccountID(gomock.Any(), 1).Return(nil, entity.ErrNotFound)
+				m.transactionRepo.EXPECT().SumLimitChangesAfter(gomock.Any(), 1, 0).Return(-20.0, 2, nil).Times(2)
+				m.repo.EXPECT().Save(gomock.Any(), &entity.LimitCheckpoint{AccountID: 1, AvailableCreditLimit: 80, TransactionID: 2})
+			},
+			want:    &entity.Reconciliation{Accounts: 1, Baselined: 1, Drifts: []entity.LimitDrift{}},
+			wantErr: false,
+		},
+		{
+			name: "Success limit matching the transactions",
+			expect: func(m mocks) {
+				listAccount(m, 50)
+				m.repo.EXPECT().GetByAccountID(gomock.Any(), 1).
+					Return(&entity.LimitCheckpoint{AccountID: 1, AvailableCreditLimit: 80, TransactionID: 2}, nil)
+				m.transactionRepo.EXPECT().SumLimitChangesAfter(gomock.Any(), 1, 2).Return(-30.0, 3, nil).Times(2)
+				m.repo.EXPECT().Save(gomock.Any(), &entity.LimitCheckpoint{AccountID: 1, AvailableCreditLimit: 50, TransactionID: 3})
+			},
+			want:    &entity.Reconciliation{Accounts: 1, Drifts: []entity.LimitDrift{}},
+			wantErr: false,
+		},
+		{
+			name: "Success drift reported",
+			expect: func(m mocks) {
+				listAccount(m, 40)
+				m.repo.EXPECT().GetByAccountID(gomock.Any(), 1).
+					Return(&entity.LimitCheckpoint{AccountID: 1, AvailableCreditLimit: 80, TransactionID: 2}, nil)
+				m.transactionRepo.EXPECT().SumLimitChangesAfter(gomock.Any(), 1, 2).Return(-30.0, 3, nil).Times(2)
+				m.repo.EXPECT().Save(gomock.Any(), &entity.LimitCheckpoint{AccountID: 1, AvailableCreditLimit: 40, TransactionID: 3})
+			},
+			want: &entity.Reconciliation{
+				Accounts: 1,
+				Drifts:   []entity.LimitDrift{{AccountID: 1, Expected: 50, Actual: 40}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Success account skipped while transacting",
+			expect: func(m mocks) {
+				listAccount(m, 40)
+				m.repo.EXPECT().GetByAccountID(gomock.Any(), 1).
+					Return(&entity.LimitCheckpoint{AccountID: 1, AvailableCreditLimit: 80, TransactionID: 2}, nil)
+				gomock.InOrder(
+					m.transactionRepo.EXPECT().SumLimitChangesAfter(gomock.Any(), 1, 2).Return(-30.0, 3, nil),
+					m.transactionRepo.EXPECT().SumLimitChangesAfter(gomock.Any(), 1, 2).Return(-40.0, 4, nil),
+				)
+			},
+			want:    &entity.Reconciliation{Accounts: 1, Skipped: 1, Drifts: []entity.LimitDrift{}},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := mocks{
+				repo:            mock_reconciliation.NewMockRepository(ctrl),
+				accountRepo:     mock_account.NewMockRepository(ctrl),
+				transactionRepo: mock_transaction.NewMockRepository(ctrl),
+			}
+			tc.expect(m)
+
+			s := NewService(m.repo, m.accountRepo, m.transactionRepo)
+
+			got, err := s.Reconcile(context.TODO())
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Reconcile() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Reconcile() got = %v, want %v, %v", got, tc.want, cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
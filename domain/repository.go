@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"github.com/brunomdev/digital-account/domain/account"
+	"github.com/brunomdev/digital-account/domain/audit"
+	"github.com/brunomdev/digital-account/domain/card"
+	"github.com/brunomdev/digital-account/domain/charge"
+	"github.com/brunomdev/digital-account/domain/client"
+	"github.com/brunomdev/digital-account/domain/customer"
+	"github.com/brunomdev/digital-account/domain/health"
+	"github.com/brunomdev/digital-account/domain/job"
+	"github.com/brunomdev/digital-account/domain/operationtype"
+	"github.com/brunomdev/digital-account/domain/reconciliation"
+	"github.com/brunomdev/digital-account/domain/risk"
+	"github.com/brunomdev/digital-account/domain/schedule"
+	"github.com/brunomdev/digital-account/domain/transaction"
+)
+
+// Repository groups the repositories of a storage backend
+type Repository struct {
+	Account            account.Repository
+	Audit              audit.Repository
+	Card               card.Repository
+	Charge             charge.Repository
+	Client             client.Repository
+	Customer           customer.Repository
+	Health             health.Repository
+	Job                job.Repository
+	LimitCheckpoint    reconciliation.Repository
+	OperationType      operationtype.Repository
+	RiskDecision       risk.Repository
+	Transaction        transaction.Repository
+	TransactionAttempt transaction.AttemptRepository
+	// Locker is shared by the instances using the same database
+	Locker schedule.Locker
+}
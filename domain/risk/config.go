@@ -0,0 +1,145 @@
+package risk
+
+import (
+	"fmt"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"os"
+	"time"
+)
+
+const (
+	ruleTypeAmountThreshold = "amount_threshold"
+	ruleTypeNewAccountBlock = "new_account_block"
+	ruleTypeTimeOfDay       = "time_of_day"
+	ruleTypeRepeatedAmount  = "repeated_amount"
+)
+
+type rulesFile struct {
+	Rules []ruleConfig `yaml:"rules"`
+}
+
+type ruleConfig struct {
+	Type             string             `yaml:"type"`
+	ReasonCode       string             `yaml:"reason_code"`
+	Outcome          entity.RiskOutcome `yaml:"outcome"`
+	OperationTypeIDs []int              `yaml:"operation_type_ids"`
+	MaxAmount        float64            `yaml:"max_amount"`
+	MinAccountAge    time.Duration      `yaml:"min_account_age"`
+	From             string             `yaml:"from"`
+	To               string             `yaml:"to"`
+	Timezone         string             `yaml:"timezone"`
+	Window           time.Duration      `yaml:"window"`
+	MaxRepetitions   int                `yaml:"max_repetitions"`
+}
+
+// LoadRules read the rules from the YAML file, an empty path returns no rules
+func LoadRules(path string, history History) ([]Rule, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "LoadRules")
+	}
+
+	return ParseRules(content, history)
+}
+
+// ParseRules build the rules from the YAML content
+func ParseRules(content []byte, history History) ([]Rule, error) {
+	var file rulesFile
+	if err := yaml.UnmarshalStrict(content, &file); err != nil {
+		return nil, errors.Wrap(err, "ParseRules")
+	}
+
+	rules := make([]Rule, 0, len(file.Rules))
+	for i, cfg := range file.Rules {
+		rule, err := cfg.build(history)
+		if err != nil {
+			return nil, errors.Wrapf(err, "ParseRules: rule %d", i)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func (cfg ruleConfig) build(history History) (Rule, error) {
+	if cfg.ReasonCode == "" {
+		return nil, errors.New("reason_code is required")
+	}
+
+	if cfg.Outcome != entity.RiskOutcomeReview && cfg.Outcome != entity.RiskOutcomeDecline {
+		return nil, fmt.Errorf("outcome must be %s or %s", entity.RiskOutcomeReview, entity.RiskOutcomeDecline)
+	}
+
+	switch cfg.Type {
+	case ruleTypeAmountThreshold:
+		return AmountThresholdRule{
+			ReasonCode:       cfg.ReasonCode,
+			Outcome:          cfg.Outcome,
+			OperationTypeIDs: cfg.OperationTypeIDs,
+			MaxAmount:        cfg.MaxAmount,
+		}, nil
+	case ruleTypeNewAccountBlock:
+		return NewAccountBlockRule{
+			ReasonCode:       cfg.ReasonCode,
+			Outcome:          cfg.Outcome,
+			OperationTypeIDs: cfg.OperationTypeIDs,
+			MinAccountAge:    cfg.MinAccountAge,
+		}, nil
+	case ruleTypeTimeOfDay:
+		from, err := parseClock(cfg.From)
+		if err != nil {
+			return nil, errors.Wrap(err, "from")
+		}
+
+		to, err := parseClock(cfg.To)
+		if err != nil {
+			return nil, errors.Wrap(err, "to")
+		}
+
+		location, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return nil, errors.Wrap(err, "timezone")
+		}
+
+		return TimeOfDayRule{
+			ReasonCode:       cfg.ReasonCode,
+			Outcome:          cfg.Outcome,
+			OperationTypeIDs: cfg.OperationTypeIDs,
+			From:             from,
+			To:               to,
+			Location:         location,
+		}, nil
+	case ruleTypeRepeatedAmount:
+		if cfg.MaxRepetitions < 1 {
+			return nil, errors.New("max_repetitions must be greater than 0")
+		}
+
+		return RepeatedAmountRule{
+			ReasonCode:       cfg.ReasonCode,
+			Outcome:          cfg.Outcome,
+			OperationTypeIDs: cfg.OperationTypeIDs,
+			Window:           cfg.Window,
+			MaxRepetitions:   cfg.MaxRepetitions,
+			History:          history,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown rule type %q", cfg.Type)
+	}
+}
+
+// parseClock converts a HH:MM time of day to the offset from midnight
+func parseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
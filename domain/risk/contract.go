@@ -0,0 +1,42 @@
+//go:generate go run github.com/golang/mock/mockgen@v1.6.0 -source=contract.go -destination=mock_risk/contract.go
+
+package risk
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/entity"
+	"time"
+)
+
+type Service interface {
+	Evaluate(ctx context.Context, input Input) (*entity.RiskDecision, error)
+	Record(ctx context.Context, decision *entity.RiskDecision) (*entity.RiskDecision, error)
+}
+
+type Repository interface {
+	Save(ctx context.Context, decision *entity.RiskDecision) (*entity.RiskDecision, error)
+}
+
+// Rule is a single risk check evaluated before a transaction is persisted
+type Rule interface {
+	Evaluate(ctx context.Context, input Input) (Result, error)
+}
+
+// History gives the rules access to the previous transactions of the account
+type History interface {
+	CountByAmountSince(ctx context.Context, accountID, operationTypeID int, amount float64, since time.Time) (int, error)
+}
+
+// Input is the transaction being authorized
+type Input struct {
+	Account       *entity.Account
+	OperationType *entity.OperationType
+	Amount        float64
+	Time          time.Time
+}
+
+// Result of a rule, an approve outcome means the rule was not hit
+type Result struct {
+	Outcome    entity.RiskOutcome
+	ReasonCode string
+}
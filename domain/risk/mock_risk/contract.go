@@ -0,0 +1,182 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package mock_risk is a generated GoMock package.
+package mock_risk
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	risk "github.com/brunomdev/digital-account/domain/risk"
+	entity "github.com/brunomdev/digital-account/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Evaluate mocks base method.
+func (m *MockService) Evaluate(ctx context.Context, input risk.Input) (*entity.RiskDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Evaluate", ctx, input)
+	ret0, _ := ret[0].(*entity.RiskDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Evaluate indicates an expected call of Evaluate.
+func (mr *MockServiceMockRecorder) Evaluate(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evaluate", reflect.TypeOf((*MockService)(nil).Evaluate), ctx, input)
+}
+
+// Record mocks base method.
+func (m *MockService) Record(ctx context.Context, decision *entity.RiskDecision) (*entity.RiskDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, decision)
+	ret0, _ := ret[0].(*entity.RiskDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockServiceMockRecorder) Record(ctx, decision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockService)(nil).Record), ctx, decision)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Save mocks base method.
+func (m *MockRepository) Save(ctx context.Context, decision *entity.RiskDecision) (*entity.RiskDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, decision)
+	ret0, _ := ret[0].(*entity.RiskDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRepositoryMockRecorder) Save(ctx, decision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRepository)(nil).Save), ctx, decision)
+}
+
+// MockRule is a mock of Rule interface.
+type MockRule struct {
+	ctrl     *gomock.Controller
+	recorder *MockRuleMockRecorder
+}
+
+// MockRuleMockRecorder is the mock recorder for MockRule.
+type MockRuleMockRecorder struct {
+	mock *MockRule
+}
+
+// NewMockRule creates a new mock instance.
+func NewMockRule(ctrl *gomock.Controller) *MockRule {
+	mock := &MockRule{ctrl: ctrl}
+	mock.recorder = &MockRuleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRule) EXPECT() *MockRuleMockRecorder {
+	return m.recorder
+}
+
+// Evaluate mocks base method.
+func (m *MockRule) Evaluate(ctx context.Context, input risk.Input) (risk.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Evaluate", ctx, input)
+	ret0, _ := ret[0].(risk.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Evaluate indicates an expected call of Evaluate.
+func (mr *MockRuleMockRecorder) Evaluate(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evaluate", reflect.TypeOf((*MockRule)(nil).Evaluate), ctx, input)
+}
+
+// MockHistory is a mock of History interface.
+type MockHistory struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryMockRecorder
+}
+
+// MockHistoryMockRecorder is the mock recorder for MockHistory.
+type MockHistoryMockRecorder struct {
+	mock *MockHistory
+}
+
+// NewMockHistory creates a new mock instance.
+func NewMockHistory(ctrl *gomock.Controller) *MockHistory {
+	mock := &MockHistory{ctrl: ctrl}
+	mock.recorder = &MockHistoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistory) EXPECT() *MockHistoryMockRecorder {
+	return m.recorder
+}
+
+// CountByAmountSince mocks base method.
+func (m *MockHistory) CountByAmountSince(ctx context.Context, accountID, operationTypeID int, amount float64, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByAmountSince", ctx, accountID, operationTypeID, amount, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByAmountSince indicates an expected call of CountByAmountSince.
+func (mr *MockHistoryMockRecorder) CountByAmountSince(ctx, accountID, operationTypeID, amount, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByAmountSince", reflect.TypeOf((*MockHistory)(nil).CountByAmountSince), ctx, accountID, operationTypeID, amount, since)
+}
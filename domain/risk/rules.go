@@ -0,0 +1,136 @@
+package risk
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+	"math"
+	"time"
+)
+
+// AmountThresholdRule is hit when the absolute amount is greater than MaxAmount
+type AmountThresholdRule struct {
+	ReasonCode       string
+	Outcome          entity.RiskOutcome
+	OperationTypeIDs []int
+	MaxAmount        float64
+}
+
+func (r AmountThresholdRule) Evaluate(_ context.Context, input Input) (Result, error) {
+	if !appliesTo(r.OperationTypeIDs, input.OperationType.ID) || math.Abs(input.Amount) <= r.MaxAmount {
+		return approve(), nil
+	}
+
+	return Result{Outcome: r.Outcome, ReasonCode: r.ReasonCode}, nil
+}
+
+// NewAccountBlockRule is hit when the operation types are used by accounts younger than MinAccountAge
+type NewAccountBlockRule struct {
+	ReasonCode       string
+	Outcome          entity.RiskOutcome
+	OperationTypeIDs []int
+	MinAccountAge    time.Duration
+}
+
+func (r NewAccountBlockRule) Evaluate(_ context.Context, input Input) (Result, error) {
+	if !appliesTo(r.OperationTypeIDs, input.OperationType.ID) || input.Account.CreatedAt.IsZero() {
+		return approve(), nil
+	}
+
+	if input.Time.Sub(input.Account.CreatedAt) >= r.MinAccountAge {
+		return approve(), nil
+	}
+
+	return Result{Outcome: r.Outcome, ReasonCode: r.ReasonCode}, nil
+}
+
+// TimeOfDayRule is hit when the transaction happens between From and To, offsets from midnight on Location.
+// When From is after To the interval crosses midnight.
+type TimeOfDayRule struct {
+	ReasonCode       string
+	Outcome          entity.RiskOutcome
+	OperationTypeIDs []int
+	From             time.Duration
+	To               time.Duration
+	Location         *time.Location
+}
+
+func (r TimeOfDayRule) Evaluate(_ context.Context, input Input) (Result, error) {
+	if !appliesTo(r.OperationTypeIDs, input.OperationType.ID) {
+		return approve(), nil
+	}
+
+	location := r.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	t := input.Time.In(location)
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+
+	var inside bool
+	if r.From <= r.To {
+		inside = offset >= r.From && offset < r.To
+	} else {
+		inside = offset >= r.From || offset < r.To
+	}
+
+	if !inside {
+		return approve(), nil
+	}
+
+	return Result{Outcome: r.Outcome, ReasonCode: r.ReasonCode}, nil
+}
+
+// RepeatedAmountRule is hit when the account already has MaxRepetitions transactions with the same operation type
+// and amount inside the Window
+type RepeatedAmountRule struct {
+	ReasonCode       string
+	Outcome          entity.RiskOutcome
+	OperationTypeIDs []int
+	Window           time.Duration
+	MaxRepetitions   int
+	History          History
+}
+
+func (r RepeatedAmountRule) Evaluate(ctx context.Context, input Input) (Result, error) {
+	if !appliesTo(r.OperationTypeIDs, input.OperationType.ID) {
+		return approve(), nil
+	}
+
+	count, err := r.History.CountByAmountSince(
+		ctx,
+		input.Account.ID,
+		input.OperationType.ID,
+		input.Amount,
+		input.Time.Add(-r.Window),
+	)
+	if err != nil {
+		return Result{}, errors.Wrap(err, "RepeatedAmountRule")
+	}
+
+	if count < r.MaxRepetitions {
+		return approve(), nil
+	}
+
+	return Result{Outcome: r.Outcome, ReasonCode: r.ReasonCode}, nil
+}
+
+func approve() Result {
+	return Result{Outcome: entity.RiskOutcomeApprove}
+}
+
+// appliesTo check if the rule applies to the operation type, an empty list applies to every operation type
+func appliesTo(operationTypeIDs []int, operationTypeID int) bool {
+	if len(operationTypeIDs) == 0 {
+		return true
+	}
+
+	for _, id := range operationTypeIDs {
+		if id == operationTypeID {
+			return true
+		}
+	}
+
+	return false
+}
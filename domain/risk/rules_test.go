@@ -0,0 +1,252 @@
+package risk_test
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/risk"
+	"github.com/brunomdev/digital-account/domain/risk/mock_risk"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"testing"
+	"time"
+)
+
+func TestRules_Evaluate(t *testing.T) {
+	now := time.Date(2022, 3, 22, 2, 30, 0, 0, time.UTC)
+	saopaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name    string
+		rule    func(ctrl *gomock.Controller) risk.Rule
+		input   risk.Input
+		want    risk.Result
+		wantErr bool
+	}{
+		{
+			name: "Amount threshold not hit",
+			rule: func(ctrl *gomock.Controller) risk.Rule {
+				return risk.AmountThresholdRule{ReasonCode: "AMOUNT", Outcome: entity.RiskOutcomeReview, MaxAmount: 100}
+			},
+			input: risk.Input{Account: &entity.Account{ID: 1}, OperationType: &entity.OperationType{ID: 1}, Amount: -100},
+			want:  risk.Result{Outcome: entity.RiskOutcomeApprove},
+		},
+		{
+			name: "Amount threshold hit",
+			rule: func(ctrl *gomock.Controller) risk.Rule {
+				return risk.AmountThresholdRule{ReasonCode: "AMOUNT", Outcome: entity.RiskOutcomeReview, MaxAmount: 100}
+			},
+			input: risk.Input{Account: &entity.Account{ID: 1}, OperationType: &entity.OperationType{ID: 1}, Amount: -100.01},
+			want:  risk.Result{Outcome: entity.RiskOutcomeReview, ReasonCode: "AMOUNT"},
+		},
+		{
+			name: "Amount threshold other operation type",
+			rule: func(ctrl *gomock.Controller) risk.Rule {
+				return risk.AmountThresholdRule{
+					ReasonCode:       "AMOUNT",
+					Outcome:          entity.RiskOutcomeReview,
+					OperationTypeIDs: []int{3},
+					MaxAmount:        100,
+				}
+			},
+			input: risk.Input{Account: &entity.Account{ID: 1}, OperationType: &entity.OperationType{ID: 4}, Amount: 500},
+			want:  risk.Result{Outcome: entity.RiskOutcomeApprove},
+		},
+		{
+			name: "New account block hit",
+			rule: func(ctrl *gomock.Controller) risk.Rule {
+				return risk.NewAccountBlockRule{
+					ReasonCode:       "NEW_ACCOUNT",
+					Outcome:          entity.RiskOutcomeDecline,
+					OperationTypeIDs: []int{3},
+					MinAccountAge:    72 * time.Hour,
+				}
+			},
+			input: risk.Input{
+				Account:       &entity.Account{ID: 1, CreatedAt: now.Add(-24 * time.Hour)},
+				OperationType: &entity.OperationType{ID: 3},
+				Amount:        -50,
+				Time:          now,
+			},
+			want: risk.Result{Outcome: entity.RiskOutcomeDecline, ReasonCode: "NEW_ACCOUNT"},
+		},
+		{
+			name: "New account block old account",
+			rule: func(ctrl *gomock.Controller) risk.Rule {
+				return risk.NewAccountBlockRule{
+					ReasonCode:       "NEW_ACCOUNT",
+					Outcome:          entity.RiskOutcomeDecline,
+					OperationTypeIDs: []int{3},
+					MinAccountAge:    72 * time.Hour,
+				}
+			},
+			input: risk.Input{
+				Account:       &entity.Account{ID: 1, CreatedAt: now.Add(-73 * time.Hour)},
+				OperationType: &entity.OperationType{ID: 3},
+				Amount:        -50,
+				Time:          now,
+			},
+			want: risk.Result{Outcome: entity.RiskOutcomeApprove},
+		},
+		{
+			name: "Time of day crossing midnight hit",
+			rule: func(ctrl *gomock.Controller) risk.Rule {
+				return risk.TimeOfDayRule{
+					ReasonCode: "NIGHT",
+					Outcome:    entity.RiskOutcomeReview,
+					From:       23 * time.Hour,
+					To:         5 * time.Hour,
+					Location:   saopaulo,
+				}
+			},
+			input: risk.Input{
+				Account:       &entity.Account{ID: 1},
+				OperationType: &entity.OperationType{ID: 3},
+				Time:          now,
+			},
+			want: risk.Result{Outcome: entity.RiskOutcomeReview, ReasonCode: "NIGHT"},
+		},
+		{
+			name: "Time of day outside interval",
+			rule: func(ctrl *gomock.Controller) risk.Rule {
+				return risk.TimeOfDayRule{
+					ReasonCode: "NIGHT",
+					Outcome:    entity.RiskOutcomeReview,
+					From:       time.Hour,
+					To:         5 * time.Hour,
+				}
+			},
+			input: risk.Input{
+				Account:       &entity.Account{ID: 1},
+				OperationType: &entity.OperationType{ID: 3},
+				Time:          now.Add(10 * time.Hour),
+			},
+			want: risk.Result{Outcome: entity.RiskOutcomeApprove},
+		},
+		{
+			name: "Repeated amount error history",
+			rule: func(ctrl *gomock.Controller) risk.Rule {
+				history := mock_risk.NewMockHistory(ctrl)
+				history.EXPECT().CountByAmountSince(gomock.Any(), 1, 1, -10.0, now.Add(-10*time.Minute)).
+					Return(0, errors.New("database error"))
+
+				return risk.RepeatedAmountRule{
+					ReasonCode:     "REPEATED",
+					Outcome:        entity.RiskOutcomeReview,
+					Window:         10 * time.Minute,
+					MaxRepetitions: 2,
+					History:        history,
+				}
+			},
+			input: risk.Input{
+				Account:       &entity.Account{ID: 1},
+				OperationType: &entity.OperationType{ID: 1},
+				Amount:        -10,
+				Time:          now,
+			},
+			want:    risk.Result{},
+			wantErr: true,
+		},
+		{
+			name: "Repeated amount hit",
+			rule: func(ctrl *gomock.Controller) risk.Rule {
+				history := mock_risk.NewMockHistory(ctrl)
+				history.EXPECT().CountByAmountSince(gomock.Any(), 1, 1, -10.0, now.Add(-10*time.Minute)).
+					Return(2, nil)
+
+				return risk.RepeatedAmountRule{
+					ReasonCode:     "REPEATED",
+					Outcome:        entity.RiskOutcomeReview,
+					Window:         10 * time.Minute,
+					MaxRepetitions: 2,
+					History:        history,
+				}
+			},
+			input: risk.Input{
+				Account:       &entity.Account{ID: 1},
+				OperationType: &entity.OperationType{ID: 1},
+				Amount:        -10,
+				Time:          now,
+			},
+			want: risk.Result{Outcome: entity.RiskOutcomeReview, ReasonCode: "REPEATED"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			got, err := tc.rule(ctrl).Evaluate(context.TODO(), tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Evaluate() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Evaluate() got = %v, want %v, %v", got, tc.want, cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	testCases := []struct {
+		name      string
+		content   string
+		wantRules int
+		wantErr   bool
+	}{
+		{
+			name:    "Error unknown type",
+			content: "rules:\n  - type: unknown\n    reason_code: X\n    outcome: review\n",
+			wantErr: true,
+		},
+		{
+			name:    "Error invalid outcome",
+			content: "rules:\n  - type: amount_threshold\n    reason_code: X\n    outcome: approve\n",
+			wantErr: true,
+		},
+		{
+			name:    "Error unknown field",
+			content: "rules:\n  - type: amount_threshold\n    reason_code: X\n    outcome: review\n    max: 10\n",
+			wantErr: true,
+		},
+		{
+			name:    "Error invalid time of day",
+			content: "rules:\n  - type: time_of_day\n    reason_code: X\n    outcome: review\n    from: \"25:00\"\n    to: \"05:00\"\n",
+			wantErr: true,
+		},
+		{
+			name:      "Success example file",
+			content:   "",
+			wantRules: 5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				rules []risk.Rule
+				err   error
+			)
+			if tc.content == "" {
+				rules, err = risk.LoadRules("../../config/risk_rules.yaml", nil)
+			} else {
+				rules, err = risk.ParseRules([]byte(tc.content), nil)
+			}
+
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ParseRules() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if len(rules) != tc.wantRules {
+				t.Errorf("ParseRules() got %d rules, want %d", len(rules), tc.wantRules)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package risk
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+)
+
+type service struct {
+	repo  Repository
+	rules []Rule
+}
+
+func NewService(repo Repository, rules ...Rule) Service {
+	return &service{
+		repo:  repo,
+		rules: rules,
+	}
+}
+
+func (s *service) Evaluate(ctx context.Context, input Input) (*entity.RiskDecision, error) {
+	decision := &entity.RiskDecision{
+		AccountID:       input.Account.ID,
+		OperationTypeID: input.OperationType.ID,
+		Amount:          input.Amount,
+		Outcome:         entity.RiskOutcomeApprove,
+		CreatedAt:       input.Time,
+	}
+
+	for _, rule := range s.rules {
+		result, err := rule.Evaluate(ctx, input)
+		if err != nil {
+			return nil, errors.Wrap(err, "Evaluate")
+		}
+
+		if result.Outcome == "" || result.Outcome == entity.RiskOutcomeApprove {
+			continue
+		}
+
+		decision.ReasonCodes = append(decision.ReasonCodes, result.ReasonCode)
+		if result.Outcome.Severity() > decision.Outcome.Severity() {
+			decision.Outcome = result.Outcome
+		}
+	}
+
+	return decision, nil
+}
+
+func (s *service) Record(ctx context.Context, decision *entity.RiskDecision) (*entity.RiskDecision, error) {
+	return s.repo.Save(ctx, decision)
+}
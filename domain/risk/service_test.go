@@ -0,0 +1,102 @@
+package risk_test
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/risk"
+	"github.com/brunomdev/digital-account/domain/risk/mock_risk"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"testing"
+	"time"
+)
+
+func Test_service_Evaluate(t *testing.T) {
+	now := time.Date(2022, 3, 22, 10, 0, 0, 0, time.UTC)
+	input := risk.Input{
+		Account:       &entity.Account{ID: 1},
+		OperationType: &entity.OperationType{ID: entity.OperationTypeSaque},
+		Amount:        -500,
+		Time:          now,
+	}
+
+	testCases := []struct {
+		name    string
+		rules   func(ctrl *gomock.Controller) []risk.Rule
+		want    *entity.RiskDecision
+		wantErr bool
+	}{
+		{
+			name: "Error rule",
+			rules: func(ctrl *gomock.Controller) []risk.Rule {
+				rule := mock_risk.NewMockRule(ctrl)
+				rule.EXPECT().Evaluate(gomock.Any(), input).Return(risk.Result{}, errors.New("database error"))
+
+				return []risk.Rule{rule}
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Approve without rules",
+			rules: func(ctrl *gomock.Controller) []risk.Rule {
+				return nil
+			},
+			want: &entity.RiskDecision{
+				AccountID:       1,
+				OperationTypeID: entity.OperationTypeSaque,
+				Amount:          -500,
+				Outcome:         entity.RiskOutcomeApprove,
+				CreatedAt:       now,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Most severe outcome wins",
+			rules: func(ctrl *gomock.Controller) []risk.Rule {
+				review := mock_risk.NewMockRule(ctrl)
+				review.EXPECT().Evaluate(gomock.Any(), input).
+					Return(risk.Result{Outcome: entity.RiskOutcomeReview, ReasonCode: "AMOUNT"}, nil)
+
+				approve := mock_risk.NewMockRule(ctrl)
+				approve.EXPECT().Evaluate(gomock.Any(), input).
+					Return(risk.Result{Outcome: entity.RiskOutcomeApprove}, nil)
+
+				decline := mock_risk.NewMockRule(ctrl)
+				decline.EXPECT().Evaluate(gomock.Any(), input).
+					Return(risk.Result{Outcome: entity.RiskOutcomeDecline, ReasonCode: "NEW_ACCOUNT"}, nil)
+
+				return []risk.Rule{review, approve, decline}
+			},
+			want: &entity.RiskDecision{
+				AccountID:       1,
+				OperationTypeID: entity.OperationTypeSaque,
+				Amount:          -500,
+				Outcome:         entity.RiskOutcomeDecline,
+				ReasonCodes:     []string{"AMOUNT", "NEW_ACCOUNT"},
+				CreatedAt:       now,
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := risk.NewService(mock_risk.NewMockRepository(ctrl), tc.rules(ctrl)...)
+
+			got, err := s.Evaluate(context.TODO(), input)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Evaluate() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Evaluate() got = %v, want %v, %v", got, tc.want, cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+//go:generate go run github.com/golang/mock/mockgen@v1.6.0 -source=contract.go -destination=mock_schedule/contract.go
+
+package schedule
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/entity"
+)
+
+type Service interface {
+	List(ctx context.Context) ([]*entity.Schedule, error)
+	// Trigger enqueue a run of the task now, entity.ErrAlreadyRunning when the last run did not finish
+	Trigger(ctx context.Context, name string) (*entity.Job, error)
+}
+
+// Locker hold named locks shared by every instance of the service
+type Locker interface {
+	// TryLock acquire the lock without waiting, entity.ErrLocked when another holder has it. The lock is kept until
+	// release is called.
+	TryLock(ctx context.Context, name string) (release func(), err error)
+}
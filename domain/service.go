@@ -2,12 +2,30 @@ package domain
 
 import (
 	"github.com/brunomdev/digital-account/domain/account"
+	"github.com/brunomdev/digital-account/domain/audit"
+	"github.com/brunomdev/digital-account/domain/card"
+	"github.com/brunomdev/digital-account/domain/client"
+	"github.com/brunomdev/digital-account/domain/customer"
+	"github.com/brunomdev/digital-account/domain/health"
+	"github.com/brunomdev/digital-account/domain/importer"
+	"github.com/brunomdev/digital-account/domain/job"
 	"github.com/brunomdev/digital-account/domain/operationtype"
+	"github.com/brunomdev/digital-account/domain/schedule"
+	"github.com/brunomdev/digital-account/domain/statement"
 	"github.com/brunomdev/digital-account/domain/transaction"
 )
 
 type Service struct {
 	Account       account.Service
+	Audit         audit.Service
+	Card          card.Service
+	Client        client.Service
+	Customer      customer.Service
+	Health        health.Service
+	Importer      importer.Service
+	Job           job.Service
 	OperationType operationtype.Service
+	Schedule      schedule.Service
+	Statement     statement.Service
 	Transaction   transaction.Service
 }
@@ -0,0 +1,17 @@
+//go:generate go run github.com/golang/mock/mockgen@v1.6.0 -source=contract.go -destination=mock_statement/contract.go
+
+package statement
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/entity"
+	"time"
+)
+
+type Service interface {
+	// Get the statement of the account with the opening and closing available credit limit of the period, a zero
+	// from is the start of the current month and a zero to the end of the current day
+	Get(ctx context.Context, accountID int, from, to time.Time) (*entity.Statement, error)
+	// EachLine call fn with the transactions of the statement, oldest first, stopping on the first error
+	EachLine(ctx context.Context, statement *entity.Statement, fn func(line *entity.StatementLine) error) error
+}
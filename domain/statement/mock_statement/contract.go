@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package mock_statement is a generated GoMock package.
+package mock_statement
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/brunomdev/digital-account/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// EachLine mocks base method.
+func (m *MockService) EachLine(ctx context.Context, statement *entity.Statement, fn func(*entity.StatementLine) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EachLine", ctx, statement, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EachLine indicates an expected call of EachLine.
+func (mr *MockServiceMockRecorder) EachLine(ctx, statement, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EachLine", reflect.TypeOf((*MockService)(nil).EachLine), ctx, statement, fn)
+}
+
+// Get mocks base method.
+func (m *MockService) Get(ctx context.Context, accountID int, from, to time.Time) (*entity.Statement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, accountID, from, to)
+	ret0, _ := ret[0].(*entity.Statement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockServiceMockRecorder) Get(ctx, accountID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockService)(nil).Get), ctx, accountID, from, to)
+}
@@ -0,0 +1,193 @@
+package statement
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/account"
+	"github.com/brunomdev/digital-account/domain/operationtype"
+	"github.com/brunomdev/digital-account/domain/transaction"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/pkg/clock"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"math"
+	"time"
+)
+
+var tracer = otel.Tracer("github.com/brunomdev/digital-account/domain/statement")
+
+// adjustmentDescription is the description of the limit adjustments on the statement lines
+const adjustmentDescription = "AJUSTE DE LIMITE"
+
+type service struct {
+	transactionRepo transaction.Repository
+	accountRepo     account.Repository
+	opTypeService   operationtype.Service
+	clock           clock.Clock
+}
+
+// NewService create the statement service. The account repository must not be cached, a stale limit would shift the
+// opening and closing values
+func NewService(
+	transactionRepo transaction.Repository,
+	accountRepo account.Repository,
+	operationTypeService operationtype.Service,
+	clk clock.Clock,
+) Service {
+	return &service{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+		opTypeService:   operationTypeService,
+		clock:           clk,
+	}
+}
+
+// Get the statement, the available credit limit is only stored for the present, so the opening and closing values
+// are the current one reverted by the transactions and the limit adjustments after each date
+func (s *service) Get(ctx context.Context, accountID int, from, to time.Time) (*entity.Statement, error) {
+	ctx, span := tracer.Start(ctx, "statement.Get", trace.WithAttributes(attribute.Int("account.id", accountID)))
+	defer span.End()
+
+	now := s.clock.Now(ctx).UTC()
+	if from.IsZero() {
+		from = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	if to.IsZero() {
+		to = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	}
+
+	if !from.Before(to) {
+		return nil, entity.ErrInvalidPeriod
+	}
+
+	acc, err := s.accountRepo.GetByID(ctx, accountID)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, errors.Wrap(err, "account")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Get")
+	}
+
+	changedSinceFrom, err := s.limitChangesSince(ctx, accountID, from)
+	if err != nil {
+		return nil, errors.Wrap(err, "Get")
+	}
+
+	changedSinceTo, err := s.limitChangesSince(ctx, accountID, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "Get")
+	}
+
+	return &entity.Statement{
+		Account:                     acc,
+		From:                        from,
+		To:                          to,
+		OpeningAvailableCreditLimit: roundCents(acc.AvailabelCreditLimit - changedSinceFrom),
+		ClosingAvailableCreditLimit: roundCents(acc.AvailabelCreditLimit - changedSinceTo),
+		GeneratedAt:                 now,
+	}, nil
+}
+
+func (s *service) EachLine(
+	ctx context.Context,
+	statement *entity.Statement,
+	fn func(line *entity.StatementLine) error,
+) error {
+	ctx, span := tracer.Start(ctx, "statement.EachLine", trace.WithAttributes(
+		attribute.Int("account.id", statement.Account.ID),
+	))
+	defer span.End()
+
+	// the adjustments of a period are few, they are merged into the stream of transactions by date
+	adjustments, err := s.accountRepo.ListAdjustments(ctx, statement.Account.ID, statement.From, statement.To)
+	if err != nil {
+		return errors.Wrap(err, "EachLine")
+	}
+
+	descriptions := make(map[int]string)
+	availableCreditLimit := statement.OpeningAvailableCreditLimit
+
+	adjustUntil := func(date time.Time) error {
+		for len(adjustments) > 0 && !adjustments[0].CreatedAt.After(date) {
+			adjustment := adjustments[0]
+			adjustments = adjustments[1:]
+
+			availableCreditLimit = roundCents(availableCreditLimit + adjustment.Amount)
+
+			err := fn(&entity.StatementLine{
+				Adjustment:           adjustment,
+				OperationType:        adjustmentDescription,
+				Amount:               adjustment.Amount,
+				AvailableCreditLimit: availableCreditLimit,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	err = s.transactionRepo.ListByAccountID(
+		ctx,
+		statement.Account.ID,
+		statement.From,
+		statement.To,
+		func(txn *entity.Transaction) error {
+			err := adjustUntil(txn.EventDate)
+			if err != nil {
+				return err
+			}
+
+			description, ok := descriptions[txn.OperationTypeID]
+			if !ok {
+				opType, err := s.opTypeService.Get(ctx, txn.OperationTypeID)
+				if err != nil {
+					return errors.Wrap(err, "operation type")
+				}
+
+				description = opType.Description
+				descriptions[txn.OperationTypeID] = description
+			}
+
+			amount := txn.LimitChange()
+			availableCreditLimit = roundCents(availableCreditLimit + amount)
+
+			return fn(&entity.StatementLine{
+				Transaction:          txn,
+				OperationType:        description,
+				Amount:               amount,
+				AvailableCreditLimit: availableCreditLimit,
+			})
+		},
+	)
+	if err == nil {
+		err = adjustUntil(statement.To)
+	}
+	if err != nil {
+		return errors.Wrap(err, "EachLine")
+	}
+
+	return nil
+}
+
+// limitChangesSince sum the changes of the available credit limit made by the transactions and the adjustments at or
+// after since
+func (s *service) limitChangesSince(ctx context.Context, accountID int, since time.Time) (float64, error) {
+	transactions, err := s.transactionRepo.SumLimitChangesSince(ctx, accountID, since)
+	if err != nil {
+		return 0, err
+	}
+
+	adjustments, err := s.accountRepo.SumAdjustmentsSince(ctx, accountID, since)
+	if err != nil {
+		return 0, err
+	}
+
+	return transactions + adjustments, nil
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
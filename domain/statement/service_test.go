@@ -0,0 +1,331 @@
+package statement
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/account"
+	"github.com/brunomdev/digital-account/domain/account/mock_account"
+	"github.com/brunomdev/digital-account/domain/operationtype"
+	"github.com/brunomdev/digital-account/domain/operationtype/mock_operationtype"
+	"github.com/brunomdev/digital-account/domain/transaction"
+	"github.com/brunomdev/digital-account/domain/transaction/mock_transaction"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/pkg/clock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"testing"
+	"time"
+)
+
+var (
+	from = time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	to   = time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+	now  = time.Date(2022, 3, 30, 10, 0, 0, 0, time.UTC)
+)
+
+// newService build the service with the clock stopped at now
+func newService(
+	transactionRepo transaction.Repository,
+	accountRepo account.Repository,
+	operationTypeService operationtype.Service,
+) Service {
+	return NewService(transactionRepo, accountRepo, operationTypeService, clock.NewFake(now))
+}
+
+func Test_service_Get(t *testing.T) {
+	acc := &entity.Account{ID: 1, DocumentNumber: "12345678900", AvailabelCreditLimit: 80}
+
+	testCases := []struct {
+		name    string
+		svcArgs func(ctrl *gomock.Controller) (transaction.Repository, account.Repository, operationtype.Service)
+		from    time.Time
+		to      time.Time
+		want    *entity.Statement
+		wantErr error
+	}{
+		{
+			name: "Error invalid period",
+			svcArgs: func(ctrl *gomock.Controller) (transaction.Repository, account.Repository, operationtype.Service) {
+				return mock_transaction.NewMockRepository(ctrl),
+					mock_account.NewMockRepository(ctrl),
+					mock_operationtype.NewMockService(ctrl)
+			},
+			from:    to,
+			to:      from,
+			wantErr: entity.ErrInvalidPeriod,
+		},
+		{
+			name: "Error account not found",
+			svcArgs: func(ctrl *gomock.Controller) (transaction.Repository, account.Repository, operationtype.Service) {
+				accountRepo := mock_account.NewMockRepository(ctrl)
+				accountRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, entity.ErrNotFound)
+
+				return mock_transaction.NewMockRepository(ctrl), accountRepo, mock_operationtype.NewMockService(ctrl)
+			},
+			from:    from,
+			to:      to,
+			wantErr: entity.ErrNotFound,
+		},
+		{
+			name: "Error sum limit changes",
+			svcArgs: func(ctrl *gomock.Controller) (transaction.Repository, account.Repository, operationtype.Service) {
+				accountRepo := mock_account.NewMockRepository(ctrl)
+				accountRepo.EXPECT().GetByID(gomock.Any(), 1).Return(acc, nil)
+
+				repo := mock_transaction.NewMockRepository(ctrl)
+				repo.EXPECT().SumLimitChangesSince(gomock.Any(), 1, from).Return(float64(0), errors.New("error"))
+
+				return repo, accountRepo, mock_operationtype.NewMockService(ctrl)
+			},
+			from:    from,
+			to:      to,
+			wantErr: errors.New("Get: error"),
+		},
+		{
+			name: "Error sum limit adjustments",
+			svcArgs: func(ctrl *gomock.Controller) (transaction.Repository, account.Repository, operationtype.Service) {
+				accountRepo := mock_account.NewMockRepository(ctrl)
+				accountRepo.EXPECT().GetByID(gomock.Any(), 1).Return(acc, nil)
+				accountRepo.EXPECT().SumAdjustmentsSince(gomock.Any(), 1, from).Return(0.0, errors.New("error"))
+
+				repo := mock_transaction.NewMockRepository(ctrl)
+				repo.EXPECT().SumLimitChangesSince(gomock.Any(), 1, from).Return(0.0, nil)
+
+				return repo, accountRepo, mock_operationtype.NewMockService(ctrl)
+			},
+			from:    from,
+			to:      to,
+			wantErr: errors.New("Get: error"),
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) (transaction.Repository, account.Repository, operationtype.Service) {
+				accountRepo := mock_account.NewMockRepository(ctrl)
+				accountRepo.EXPECT().GetByID(gomock.Any(), 1).Return(acc, nil)
+				repo := mock_transaction.NewMockRepository(ctrl)
+				accountRepo.EXPECT().SumAdjustmentsSince(gomock.Any(), 1, from).Return(0.0, nil)
+				accountRepo.EXPECT().SumAdjustmentsSince(gomock.Any(), 1, to).Return(0.0, nil)
+
+				repo.EXPECT().SumLimitChangesSince(gomock.Any(), 1, from).Return(-30.1, nil)
+				repo.EXPECT().SumLimitChangesSince(gomock.Any(), 1, to).Return(10.0, nil)
+
+				return repo, accountRepo, mock_operationtype.NewMockService(ctrl)
+			},
+			from: from,
+			to:   to,
+			want: &entity.Statement{
+				Account:                     acc,
+				From:                        from,
+				To:                          to,
+				OpeningAvailableCreditLimit: 110.1,
+				ClosingAvailableCreditLimit: 70,
+				GeneratedAt:                 now,
+			},
+		},
+		{
+			name: "Success with limit adjustments",
+			svcArgs: func(ctrl *gomock.Controller) (transaction.Repository, account.Repository, operationtype.Service) {
+				accountRepo := mock_account.NewMockRepository(ctrl)
+				accountRepo.EXPECT().GetByID(gomock.Any(), 1).Return(acc, nil)
+				accountRepo.EXPECT().SumAdjustmentsSince(gomock.Any(), 1, from).Return(50.0, nil)
+				accountRepo.EXPECT().SumAdjustmentsSince(gomock.Any(), 1, to).Return(0.0, nil)
+
+				repo := mock_transaction.NewMockRepository(ctrl)
+				repo.EXPECT().SumLimitChangesSince(gomock.Any(), 1, from).Return(-30.1, nil)
+				repo.EXPECT().SumLimitChangesSince(gomock.Any(), 1, to).Return(10.0, nil)
+
+				return repo, accountRepo, mock_operationtype.NewMockService(ctrl)
+			},
+			from: from,
+			to:   to,
+			want: &entity.Statement{
+				Account:                     acc,
+				From:                        from,
+				To:                          to,
+				OpeningAvailableCreditLimit: 60.1,
+				ClosingAvailableCreditLimit: 70,
+				GeneratedAt:                 now,
+			},
+		},
+		{
+			name: "Success default period",
+			svcArgs: func(ctrl *gomock.Controller) (transaction.Repository, account.Repository, operationtype.Service) {
+				accountRepo := mock_account.NewMockRepository(ctrl)
+				accountRepo.EXPECT().GetByID(gomock.Any(), 1).Return(acc, nil)
+
+				repo := mock_transaction.NewMockRepository(ctrl)
+				repo.EXPECT().SumLimitChangesSince(gomock.Any(), 1, from).Return(-30.1, nil)
+				repo.EXPECT().SumLimitChangesSince(gomock.Any(), 1, time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC)).
+					Return(0.0, nil)
+				accountRepo.EXPECT().SumAdjustmentsSince(gomock.Any(), 1, gomock.Any()).Return(0.0, nil).Times(2)
+
+				return repo, accountRepo, mock_operationtype.NewMockService(ctrl)
+			},
+			want: &entity.Statement{
+				Account:                     acc,
+				From:                        from,
+				To:                          time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC),
+				OpeningAvailableCreditLimit: 110.1,
+				ClosingAvailableCreditLimit: 80,
+				GeneratedAt:                 now,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := newService(tc.svcArgs(ctrl))
+
+			got, err := s.Get(context.TODO(), 1, tc.from, tc.to)
+			if tc.wantErr != nil {
+				if err == nil || !(errors.Is(err, tc.wantErr) || err.Error() == tc.wantErr.Error()) {
+					t.Errorf("Get() error = %v, wantErr %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Get() unexpected error = %v", err)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Get() got = %v, want %v, %v", got, tc.want, cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
+
+func Test_service_EachLine(t *testing.T) {
+	statement := &entity.Statement{
+		Account:                     &entity.Account{ID: 1},
+		From:                        from,
+		To:                          to,
+		OpeningAvailableCreditLimit: 100,
+		ClosingAvailableCreditLimit: 170,
+	}
+	transactions := []*entity.Transaction{
+		{
+			ID:              1,
+			AccountID:       1,
+			OperationTypeID: entity.OperationTypeCompraAVista,
+			Amount:          -50,
+			EventDate:       from.AddDate(0, 0, 4),
+		},
+		{
+			ID:              2,
+			AccountID:       1,
+			OperationTypeID: entity.OperationTypeCompraAVista,
+			Amount:          30,
+			EventDate:       from.AddDate(0, 0, 9),
+		},
+		{
+			ID:              3,
+			AccountID:       1,
+			OperationTypeID: entity.OperationTypePagamento,
+			Amount:          100,
+			EventDate:       from.AddDate(0, 0, 19),
+		},
+	}
+	adjustments := []*entity.CreditLimitAdjustment{
+		{ID: 1, AccountID: 1, Amount: 200, CreatedAt: from.AddDate(0, 0, 9)},
+		{ID: 2, AccountID: 1, Amount: -150, CreatedAt: from.AddDate(0, 0, 24)},
+	}
+
+	testCases := []struct {
+		name    string
+		svcArgs func(ctrl *gomock.Controller) (transaction.Repository, account.Repository, operationtype.Service)
+		want    []*entity.StatementLine
+		wantErr bool
+	}{
+		{
+			name: "Error operation type",
+			svcArgs: func(ctrl *gomock.Controller) (transaction.Repository, account.Repository, operationtype.Service) {
+				repo := mock_transaction.NewMockRepository(ctrl)
+				repo.EXPECT().ListByAccountID(gomock.Any(), 1, from, to, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ int, _, _ time.Time, fn func(*entity.Transaction) error) error {
+						return fn(transactions[0])
+					})
+
+				opTypeSvc := mock_operationtype.NewMockService(ctrl)
+				opTypeSvc.EXPECT().Get(gomock.Any(), entity.OperationTypeCompraAVista).Return(nil, errors.New("error"))
+
+				accountRepo := mock_account.NewMockRepository(ctrl)
+				accountRepo.EXPECT().ListAdjustments(gomock.Any(), 1, from, to).Return(nil, nil)
+
+				return repo, accountRepo, opTypeSvc
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error list adjustments",
+			svcArgs: func(ctrl *gomock.Controller) (transaction.Repository, account.Repository, operationtype.Service) {
+				accountRepo := mock_account.NewMockRepository(ctrl)
+				accountRepo.EXPECT().ListAdjustments(gomock.Any(), 1, from, to).Return(nil, errors.New("error"))
+
+				return mock_transaction.NewMockRepository(ctrl), accountRepo, mock_operationtype.NewMockService(ctrl)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			svcArgs: func(ctrl *gomock.Controller) (transaction.Repository, account.Repository, operationtype.Service) {
+				repo := mock_transaction.NewMockRepository(ctrl)
+				repo.EXPECT().ListByAccountID(gomock.Any(), 1, from, to, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ int, _, _ time.Time, fn func(*entity.Transaction) error) error {
+						for _, txn := range transactions {
+							if err := fn(txn); err != nil {
+								return err
+							}
+						}
+						return nil
+					})
+
+				opTypeSvc := mock_operationtype.NewMockService(ctrl)
+				opTypeSvc.EXPECT().Get(gomock.Any(), entity.OperationTypeCompraAVista).
+					Return(&entity.OperationType{ID: 1, Description: "COMPRA A VISTA"}, nil).
+					Times(1)
+				opTypeSvc.EXPECT().Get(gomock.Any(), entity.OperationTypePagamento).
+					Return(&entity.OperationType{ID: 4, Description: "PAGAMENTO"}, nil).
+					Times(1)
+
+				accountRepo := mock_account.NewMockRepository(ctrl)
+				accountRepo.EXPECT().ListAdjustments(gomock.Any(), 1, from, to).Return(adjustments, nil)
+
+				return repo, accountRepo, opTypeSvc
+			},
+			want: []*entity.StatementLine{
+				{Transaction: transactions[0], OperationType: "COMPRA A VISTA", Amount: -50, AvailableCreditLimit: 50},
+				{Adjustment: adjustments[0], OperationType: "AJUSTE DE LIMITE", Amount: 200, AvailableCreditLimit: 250},
+				{Transaction: transactions[1], OperationType: "COMPRA A VISTA", Amount: -30, AvailableCreditLimit: 220},
+				{Transaction: transactions[2], OperationType: "PAGAMENTO", Amount: 100, AvailableCreditLimit: 320},
+				{Adjustment: adjustments[1], OperationType: "AJUSTE DE LIMITE", Amount: -150, AvailableCreditLimit: 170},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := newService(tc.svcArgs(ctrl))
+
+			var got []*entity.StatementLine
+			err := s.EachLine(context.TODO(), statement, func(line *entity.StatementLine) error {
+				got = append(got, line)
+				return nil
+			})
+			if (err != nil) != tc.wantErr {
+				t.Errorf("EachLine() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("EachLine() got = %v, want %v, %v", got, tc.want, cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
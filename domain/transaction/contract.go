@@ -5,13 +5,64 @@ package transaction
 import (
 	"context"
 	"github.com/brunomdev/digital-account/entity"
+	"time"
 )
 
 type Service interface {
-	Create(ctx context.Context, accountID, operationTypeID int, amount float64) (*entity.Transaction, error)
+	Create(ctx context.Context, input entity.TransactionInput) (*entity.Transaction, error)
+	// Charge post a system transaction debiting a charge from the account, the credit limit, velocity and risk
+	// checks don't apply
+	Charge(ctx context.Context, accountID, operationTypeID int, amount float64) (*entity.Transaction, error)
+	ListAttempts(ctx context.Context, accountID, limit int) ([]*entity.TransactionAttempt, error)
+	// PurgeAttempts delete the attempts created before the time, returning how many were deleted
+	PurgeAttempts(ctx context.Context, before time.Time) (int, error)
+	// SummarizeAttempts count and sum the attempts between from and to (exclusive) by operation type and outcome
+	SummarizeAttempts(ctx context.Context, from, to time.Time) ([]*entity.AttemptSummary, error)
 }
 
 type Repository interface {
+	// Save a transaction dated by the database
 	Save(ctx context.Context, accountID, operationTypeID int, amount float64) (*entity.Transaction, error)
+	// SaveDetailed save the transaction with its event date and its optional details, like the exchange of a foreign
+	// currency transaction or the card
+	SaveDetailed(ctx context.Context, txn *entity.Transaction) (*entity.Transaction, error)
+	// SaveWithLinked save the transaction as SaveDetailed and the transactions linked to it, like the fees of a debit,
+	// in a single database transaction so a debit is never saved without its fees. The linked transactions are saved
+	// on the account and the event date of the transaction, only their operation type and amount are used
+	SaveWithLinked(
+		ctx context.Context,
+		txn *entity.Transaction,
+		linked []*entity.Transaction,
+	) (*entity.Transaction, []*entity.Transaction, error)
 	GetByID(ctx context.Context, id int) (*entity.Transaction, error)
+	// SumDebitsSince count and sum the debits of the account created since the time, the limits don't follow the
+	// event date so a backdated debit can't be left out of their windows
+	SumDebitsSince(ctx context.Context, accountID int, since time.Time) (count int, amount float64, err error)
+	// SumByOperationTypeSince count and sum the transactions of the operation type of the account created since the time
+	SumByOperationTypeSince(ctx context.Context, accountID, operationTypeID int, since time.Time) (count int, amount float64, err error)
+	// SumByCardSince sum the debits made with the card created since the time
+	SumByCardSince(ctx context.Context, cardID int, since time.Time) (float64, error)
+	CountByAmountSince(ctx context.Context, accountID, operationTypeID int, amount float64, since time.Time) (int, error)
+	// SumLimitChangesSince sum the effect on the available credit limit of the transactions of the account since the time
+	SumLimitChangesSince(ctx context.Context, accountID int, since time.Time) (float64, error)
+	// SumLimitChangesAfter sum the effect on the available credit limit of the transactions of the account with id
+	// greater than afterID, lastID is the greatest id summed or afterID when there is none
+	SumLimitChangesAfter(ctx context.Context, accountID, afterID int) (amount float64, lastID int, err error)
+	// SumBalanceBefore sum the debits and the payments of the account made before the time, both positive
+	SumBalanceBefore(ctx context.Context, accountID int, before time.Time) (debits, payments float64, err error)
+	// ListByAccountID call fn with the transactions of the account between from and to (exclusive), oldest first,
+	// stopping on the first error
+	ListByAccountID(ctx context.Context, accountID int, from, to time.Time, fn func(txn *entity.Transaction) error) error
+}
+
+type AttemptRepository interface {
+	Save(ctx context.Context, attempt *entity.TransactionAttempt) (*entity.TransactionAttempt, error)
+	ListByAccountID(ctx context.Context, accountID, limit int) ([]*entity.TransactionAttempt, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int, error)
+	// Summarize group the attempts between from and to (exclusive) by operation type and outcome, ordered by both
+	Summarize(ctx context.Context, from, to time.Time) ([]*entity.AttemptSummary, error)
+}
+
+type Metrics interface {
+	ObserveAttempt(attempt *entity.TransactionAttempt)
 }
@@ -7,6 +7,7 @@ package mock_transaction
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/brunomdev/digital-account/entity"
 	gomock "github.com/golang/mock/gomock"
@@ -35,19 +36,79 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// Charge mocks base method.
+func (m *MockService) Charge(ctx context.Context, accountID, operationTypeID int, amount float64) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Charge", ctx, accountID, operationTypeID, amount)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Charge indicates an expected call of Charge.
+func (mr *MockServiceMockRecorder) Charge(ctx, accountID, operationTypeID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Charge", reflect.TypeOf((*MockService)(nil).Charge), ctx, accountID, operationTypeID, amount)
+}
+
 // Create mocks base method.
-func (m *MockService) Create(ctx context.Context, accountID, operationTypeID int, amount float64) (*entity.Transaction, error) {
+func (m *MockService) Create(ctx context.Context, input entity.TransactionInput) (*entity.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, accountID, operationTypeID, amount)
+	ret := m.ctrl.Call(m, "Create", ctx, input)
 	ret0, _ := ret[0].(*entity.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockServiceMockRecorder) Create(ctx, accountID, operationTypeID, amount interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) Create(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), ctx, input)
+}
+
+// ListAttempts mocks base method.
+func (m *MockService) ListAttempts(ctx context.Context, accountID, limit int) ([]*entity.TransactionAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttempts", ctx, accountID, limit)
+	ret0, _ := ret[0].([]*entity.TransactionAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttempts indicates an expected call of ListAttempts.
+func (mr *MockServiceMockRecorder) ListAttempts(ctx, accountID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttempts", reflect.TypeOf((*MockService)(nil).ListAttempts), ctx, accountID, limit)
+}
+
+// PurgeAttempts mocks base method.
+func (m *MockService) PurgeAttempts(ctx context.Context, before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeAttempts", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeAttempts indicates an expected call of PurgeAttempts.
+func (mr *MockServiceMockRecorder) PurgeAttempts(ctx, before interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), ctx, accountID, operationTypeID, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeAttempts", reflect.TypeOf((*MockService)(nil).PurgeAttempts), ctx, before)
+}
+
+// SummarizeAttempts mocks base method.
+func (m *MockService) SummarizeAttempts(ctx context.Context, from, to time.Time) ([]*entity.AttemptSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeAttempts", ctx, from, to)
+	ret0, _ := ret[0].([]*entity.AttemptSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummarizeAttempts indicates an expected call of SummarizeAttempts.
+func (mr *MockServiceMockRecorder) SummarizeAttempts(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeAttempts", reflect.TypeOf((*MockService)(nil).SummarizeAttempts), ctx, from, to)
 }
 
 // MockRepository is a mock of Repository interface.
@@ -73,6 +134,21 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountByAmountSince mocks base method.
+func (m *MockRepository) CountByAmountSince(ctx context.Context, accountID, operationTypeID int, amount float64, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByAmountSince", ctx, accountID, operationTypeID, amount, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByAmountSince indicates an expected call of CountByAmountSince.
+func (mr *MockRepositoryMockRecorder) CountByAmountSince(ctx, accountID, operationTypeID, amount, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByAmountSince", reflect.TypeOf((*MockRepository)(nil).CountByAmountSince), ctx, accountID, operationTypeID, amount, since)
+}
+
 // GetByID mocks base method.
 func (m *MockRepository) GetByID(ctx context.Context, id int) (*entity.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -88,6 +164,20 @@ func (mr *MockRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, id)
 }
 
+// ListByAccountID mocks base method.
+func (m *MockRepository) ListByAccountID(ctx context.Context, accountID int, from, to time.Time, fn func(*entity.Transaction) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByAccountID", ctx, accountID, from, to, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListByAccountID indicates an expected call of ListByAccountID.
+func (mr *MockRepositoryMockRecorder) ListByAccountID(ctx, accountID, from, to, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAccountID", reflect.TypeOf((*MockRepository)(nil).ListByAccountID), ctx, accountID, from, to, fn)
+}
+
 // Save mocks base method.
 func (m *MockRepository) Save(ctx context.Context, accountID, operationTypeID int, amount float64) (*entity.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -102,3 +192,246 @@ func (mr *MockRepositoryMockRecorder) Save(ctx, accountID, operationTypeID, amou
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRepository)(nil).Save), ctx, accountID, operationTypeID, amount)
 }
+
+// SaveDetailed mocks base method.
+func (m *MockRepository) SaveDetailed(ctx context.Context, txn *entity.Transaction) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDetailed", ctx, txn)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveDetailed indicates an expected call of SaveDetailed.
+func (mr *MockRepositoryMockRecorder) SaveDetailed(ctx, txn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDetailed", reflect.TypeOf((*MockRepository)(nil).SaveDetailed), ctx, txn)
+}
+
+// SaveWithLinked mocks base method.
+func (m *MockRepository) SaveWithLinked(ctx context.Context, txn *entity.Transaction, linked []*entity.Transaction) (*entity.Transaction, []*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWithLinked", ctx, txn, linked)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].([]*entity.Transaction)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SaveWithLinked indicates an expected call of SaveWithLinked.
+func (mr *MockRepositoryMockRecorder) SaveWithLinked(ctx, txn, linked interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWithLinked", reflect.TypeOf((*MockRepository)(nil).SaveWithLinked), ctx, txn, linked)
+}
+
+// SumBalanceBefore mocks base method.
+func (m *MockRepository) SumBalanceBefore(ctx context.Context, accountID int, before time.Time) (float64, float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumBalanceBefore", ctx, accountID, before)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(float64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SumBalanceBefore indicates an expected call of SumBalanceBefore.
+func (mr *MockRepositoryMockRecorder) SumBalanceBefore(ctx, accountID, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumBalanceBefore", reflect.TypeOf((*MockRepository)(nil).SumBalanceBefore), ctx, accountID, before)
+}
+
+// SumByCardSince mocks base method.
+func (m *MockRepository) SumByCardSince(ctx context.Context, cardID int, since time.Time) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumByCardSince", ctx, cardID, since)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumByCardSince indicates an expected call of SumByCardSince.
+func (mr *MockRepositoryMockRecorder) SumByCardSince(ctx, cardID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumByCardSince", reflect.TypeOf((*MockRepository)(nil).SumByCardSince), ctx, cardID, since)
+}
+
+// SumByOperationTypeSince mocks base method.
+func (m *MockRepository) SumByOperationTypeSince(ctx context.Context, accountID, operationTypeID int, since time.Time) (int, float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumByOperationTypeSince", ctx, accountID, operationTypeID, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(float64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SumByOperationTypeSince indicates an expected call of SumByOperationTypeSince.
+func (mr *MockRepositoryMockRecorder) SumByOperationTypeSince(ctx, accountID, operationTypeID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumByOperationTypeSince", reflect.TypeOf((*MockRepository)(nil).SumByOperationTypeSince), ctx, accountID, operationTypeID, since)
+}
+
+// SumDebitsSince mocks base method.
+func (m *MockRepository) SumDebitsSince(ctx context.Context, accountID int, since time.Time) (int, float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumDebitsSince", ctx, accountID, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(float64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SumDebitsSince indicates an expected call of SumDebitsSince.
+func (mr *MockRepositoryMockRecorder) SumDebitsSince(ctx, accountID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumDebitsSince", reflect.TypeOf((*MockRepository)(nil).SumDebitsSince), ctx, accountID, since)
+}
+
+// SumLimitChangesAfter mocks base method.
+func (m *MockRepository) SumLimitChangesAfter(ctx context.Context, accountID, afterID int) (float64, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumLimitChangesAfter", ctx, accountID, afterID)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SumLimitChangesAfter indicates an expected call of SumLimitChangesAfter.
+func (mr *MockRepositoryMockRecorder) SumLimitChangesAfter(ctx, accountID, afterID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumLimitChangesAfter", reflect.TypeOf((*MockRepository)(nil).SumLimitChangesAfter), ctx, accountID, afterID)
+}
+
+// SumLimitChangesSince mocks base method.
+func (m *MockRepository) SumLimitChangesSince(ctx context.Context, accountID int, since time.Time) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumLimitChangesSince", ctx, accountID, since)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumLimitChangesSince indicates an expected call of SumLimitChangesSince.
+func (mr *MockRepositoryMockRecorder) SumLimitChangesSince(ctx, accountID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumLimitChangesSince", reflect.TypeOf((*MockRepository)(nil).SumLimitChangesSince), ctx, accountID, since)
+}
+
+// MockAttemptRepository is a mock of AttemptRepository interface.
+type MockAttemptRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttemptRepositoryMockRecorder
+}
+
+// MockAttemptRepositoryMockRecorder is the mock recorder for MockAttemptRepository.
+type MockAttemptRepositoryMockRecorder struct {
+	mock *MockAttemptRepository
+}
+
+// NewMockAttemptRepository creates a new mock instance.
+func NewMockAttemptRepository(ctrl *gomock.Controller) *MockAttemptRepository {
+	mock := &MockAttemptRepository{ctrl: ctrl}
+	mock.recorder = &MockAttemptRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttemptRepository) EXPECT() *MockAttemptRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteBefore mocks base method.
+func (m *MockAttemptRepository) DeleteBefore(ctx context.Context, before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBefore", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBefore indicates an expected call of DeleteBefore.
+func (mr *MockAttemptRepositoryMockRecorder) DeleteBefore(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBefore", reflect.TypeOf((*MockAttemptRepository)(nil).DeleteBefore), ctx, before)
+}
+
+// ListByAccountID mocks base method.
+func (m *MockAttemptRepository) ListByAccountID(ctx context.Context, accountID, limit int) ([]*entity.TransactionAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByAccountID", ctx, accountID, limit)
+	ret0, _ := ret[0].([]*entity.TransactionAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByAccountID indicates an expected call of ListByAccountID.
+func (mr *MockAttemptRepositoryMockRecorder) ListByAccountID(ctx, accountID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAccountID", reflect.TypeOf((*MockAttemptRepository)(nil).ListByAccountID), ctx, accountID, limit)
+}
+
+// Save mocks base method.
+func (m *MockAttemptRepository) Save(ctx context.Context, attempt *entity.TransactionAttempt) (*entity.TransactionAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, attempt)
+	ret0, _ := ret[0].(*entity.TransactionAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockAttemptRepositoryMockRecorder) Save(ctx, attempt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAttemptRepository)(nil).Save), ctx, attempt)
+}
+
+// Summarize mocks base method.
+func (m *MockAttemptRepository) Summarize(ctx context.Context, from, to time.Time) ([]*entity.AttemptSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Summarize", ctx, from, to)
+	ret0, _ := ret[0].([]*entity.AttemptSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Summarize indicates an expected call of Summarize.
+func (mr *MockAttemptRepositoryMockRecorder) Summarize(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Summarize", reflect.TypeOf((*MockAttemptRepository)(nil).Summarize), ctx, from, to)
+}
+
+// MockMetrics is a mock of Metrics interface.
+type MockMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricsMockRecorder
+}
+
+// MockMetricsMockRecorder is the mock recorder for MockMetrics.
+type MockMetricsMockRecorder struct {
+	mock *MockMetrics
+}
+
+// NewMockMetrics creates a new mock instance.
+func NewMockMetrics(ctrl *gomock.Controller) *MockMetrics {
+	mock := &MockMetrics{ctrl: ctrl}
+	mock.recorder = &MockMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetrics) EXPECT() *MockMetricsMockRecorder {
+	return m.recorder
+}
+
+// ObserveAttempt mocks base method.
+func (m *MockMetrics) ObserveAttempt(attempt *entity.TransactionAttempt) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObserveAttempt", attempt)
+}
+
+// ObserveAttempt indicates an expected call of ObserveAttempt.
+func (mr *MockMetricsMockRecorder) ObserveAttempt(attempt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveAttempt", reflect.TypeOf((*MockMetrics)(nil).ObserveAttempt), attempt)
+}
@@ -0,0 +1,86 @@
+package transaction
+
+import (
+	"github.com/brunomdev/digital-account/domain/audit"
+	"github.com/brunomdev/digital-account/domain/card"
+	"github.com/brunomdev/digital-account/domain/exchange"
+	"github.com/brunomdev/digital-account/domain/risk"
+	"time"
+)
+
+type Option func(s *service)
+
+// VelocityLimit restricts the debits of an account inside a rolling window, zero values disable each rule
+type VelocityLimit struct {
+	MaxDebits int
+	MaxAmount float64
+	Window    time.Duration
+}
+
+// WithVelocityLimit enable the velocity rule for debits of an account
+func WithVelocityLimit(limit VelocityLimit) Option {
+	return func(s *service) {
+		s.velocityLimit = limit
+	}
+}
+
+// WithEventDateWindow accept transactions backdated up to the window, a zero window rejects any event date
+func WithEventDateWindow(window time.Duration) Option {
+	return func(s *service) {
+		s.eventDateWindow = window
+	}
+}
+
+// Exchange converts the transactions made in a foreign currency to the account currency
+type Exchange struct {
+	// Currency of the accounts, ISO 4217
+	Currency string
+	// SpreadRate is added to the rate of the provider, 0.04 is 4%
+	SpreadRate float64
+	// IOFRate is the tax on the converted amount of the foreign currency debits, posted as a transaction linked to
+	// the debit
+	IOFRate float64
+}
+
+// WithExchange accept transactions in the currencies with a rate on the provider
+func WithExchange(provider exchange.RateProvider, cfg Exchange) Option {
+	return func(s *service) {
+		s.rates = provider
+		s.exchange = cfg
+	}
+}
+
+// WithCardService accept debits made with the cards of the account, checking the status and the limit of the card
+func WithCardService(cardService card.Service) Option {
+	return func(s *service) {
+		s.cardService = cardService
+	}
+}
+
+// WithAuditService record every saved transaction on the audit trail
+func WithAuditService(auditService audit.Service) Option {
+	return func(s *service) {
+		s.auditService = auditService
+	}
+}
+
+// WithRiskService evaluate the risk rules before persisting the transactions
+func WithRiskService(riskService risk.Service) Option {
+	return func(s *service) {
+		s.riskService = riskService
+	}
+}
+
+// WithAttemptRepository persist every transaction attempt with its outcome
+func WithAttemptRepository(attemptRepo AttemptRepository) Option {
+	return func(s *service) {
+		s.attemptRepo = attemptRepo
+	}
+}
+
+// WithMetrics observe the outcome of every transaction attempt
+func WithMetrics(metrics Metrics) Option {
+	return func(s *service) {
+		s.metrics = metrics
+	}
+}
@@ -3,27 +3,191 @@ package transaction
 import (
 	"context"
 	"github.com/brunomdev/digital-account/domain/account"
+	"github.com/brunomdev/digital-account/domain/audit"
+	"github.com/brunomdev/digital-account/domain/card"
+	"github.com/brunomdev/digital-account/domain/exchange"
 	"github.com/brunomdev/digital-account/domain/operationtype"
+	"github.com/brunomdev/digital-account/domain/risk"
 	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/infra/log"
+	"github.com/brunomdev/digital-account/pkg/clock"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"math"
+	"strings"
+	"time"
 )
 
+var tracer = otel.Tracer("github.com/brunomdev/digital-account/domain/transaction")
+
 type service struct {
 	repo           Repository
 	accountService account.Service
 	opTypeService  operationtype.Service
+	velocityLimit  VelocityLimit
+	riskService    risk.Service
+	attemptRepo    AttemptRepository
+	metrics        Metrics
+	rates          exchange.RateProvider
+	exchange       Exchange
+	cardService    card.Service
+	auditService   audit.Service
+	clock          clock.Clock
+	// eventDateWindow is how far back a transaction may be dated, zero disables backdating
+	eventDateWindow time.Duration
 }
 
-func NewService(repo Repository, accountService account.Service, operationTypeService operationtype.Service) Service {
-	return &service{
+func NewService(
+	repo Repository,
+	accountService account.Service,
+	operationTypeService operationtype.Service,
+	clk clock.Clock,
+	options ...Option,
+) Service {
+	s := &service{
 		repo:           repo,
 		accountService: accountService,
 		opTypeService:  operationTypeService,
+		clock:          clk,
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
+}
+
+func (s *service) Create(ctx context.Context, input entity.TransactionInput) (*entity.Transaction, error) {
+	ctx, span := tracer.Start(ctx, "transaction.Create", trace.WithAttributes(
+		attribute.Int("account.id", input.AccountID),
+		attribute.Int("operation_type.id", input.OperationTypeID),
+		attribute.Float64("transaction.amount", input.Amount),
+		attribute.String("transaction.currency", input.Currency),
+		attribute.Int("card.id", input.CardID),
+	))
+	defer span.End()
+
+	transaction, err := s.create(ctx, input)
+
+	errAttempt := s.recordAttempt(ctx, input.AccountID, input.OperationTypeID, input.Amount, transaction, err)
+	if errAttempt != nil {
+		// the outcome is already decided, failing the request would have the client retry a debit that was applied
+		log.Error(ctx, "unable to record transaction attempt", errAttempt)
+		span.RecordError(errAttempt)
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return transaction, err
+}
+
+func (s *service) Charge(ctx context.Context, accountID, operationTypeID int, amount float64) (*entity.Transaction, error) {
+	ctx, span := tracer.Start(ctx, "transaction.Charge", trace.WithAttributes(
+		attribute.Int("account.id", accountID),
+		attribute.Int("operation_type.id", operationTypeID),
+		attribute.Float64("transaction.amount", amount),
+	))
+	defer span.End()
+
+	transaction, err := s.charge(ctx, accountID, operationTypeID, amount)
+
+	errAttempt := s.recordAttempt(ctx, accountID, operationTypeID, amount, transaction, err)
+	if errAttempt != nil {
+		// the charge is already applied, failing it would have the charge service release its reservation and the
+		// scheduler post the charge again on the next run
+		log.Error(ctx, "unable to record transaction attempt", errAttempt)
+		span.RecordError(errAttempt)
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return transaction, err
+}
+
+func (s *service) ListAttempts(ctx context.Context, accountID, limit int) ([]*entity.TransactionAttempt, error) {
+	ctx, span := tracer.Start(ctx, "transaction.ListAttempts", trace.WithAttributes(attribute.Int("account.id", accountID)))
+	defer span.End()
+
+	if s.attemptRepo == nil {
+		return []*entity.TransactionAttempt{}, nil
+	}
+
+	return s.attemptRepo.ListByAccountID(ctx, accountID, limit)
+}
+
+func (s *service) PurgeAttempts(ctx context.Context, before time.Time) (int, error) {
+	ctx, span := tracer.Start(ctx, "transaction.PurgeAttempts")
+	defer span.End()
+
+	if s.attemptRepo == nil {
+		return 0, nil
+	}
+
+	deleted, err := s.attemptRepo.DeleteBefore(ctx, before)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return 0, errors.Wrap(err, "PurgeAttempts")
+	}
+
+	span.SetAttributes(attribute.Int("transaction_attempt.deleted", deleted))
+
+	if deleted > 0 && s.auditService != nil {
+		purge := struct {
+			Before  time.Time
+			Deleted int
+		}{Before: before, Deleted: deleted}
+
+		err = s.auditService.Record(ctx, entity.AuditActionPurge, entity.AuditEntityTransactionAttempt, 0, nil, purge)
+		if err != nil {
+			// the attempts are already deleted, failing would only have the cleanup job retry a purge with nothing left
+			log.Error(ctx, "unable to audit attempts purge", err, log.Event{"deleted": deleted})
+			span.RecordError(err)
+		}
+	}
+
+	return deleted, nil
+}
+
+func (s *service) SummarizeAttempts(ctx context.Context, from, to time.Time) ([]*entity.AttemptSummary, error) {
+	ctx, span := tracer.Start(ctx, "transaction.SummarizeAttempts")
+	defer span.End()
+
+	if s.attemptRepo == nil {
+		return []*entity.AttemptSummary{}, nil
+	}
+
+	if !from.Before(to) {
+		return nil, entity.ErrInvalidPeriod
+	}
+
+	summaries, err := s.attemptRepo.Summarize(ctx, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "SummarizeAttempts")
 	}
+
+	return summaries, nil
 }
 
-func (s *service) Create(ctx context.Context, accountID, operationTypeID int, amount float64) (*entity.Transaction, error) {
+func (s *service) create(ctx context.Context, input entity.TransactionInput) (*entity.Transaction, error) {
+	accountID, operationTypeID := input.AccountID, input.OperationTypeID
+
+	eventDate, err := s.eventDate(ctx, input.EventDate)
+	if err != nil {
+		return nil, err
+	}
+
 	acc, err := s.accountService.Get(ctx, accountID)
 	if errors.Is(err, entity.ErrNotFound) {
 		return nil, errors.Wrap(err, "acc")
@@ -32,7 +196,7 @@ func (s *service) Create(ctx context.Context, accountID, operationTypeID int, am
 		return nil, errors.Wrap(err, "Create")
 	}
 
-	_, err = s.opTypeService.Get(ctx, operationTypeID)
+	opType, err := s.opTypeService.Get(ctx, operationTypeID)
 	if errors.Is(err, entity.ErrNotFound) {
 		return nil, errors.Wrap(err, "operation type")
 	}
@@ -40,34 +204,446 @@ func (s *service) Create(ctx context.Context, accountID, operationTypeID int, am
 		return nil, errors.Wrap(err, "Create")
 	}
 
-	var newLimit float64
-	if operationTypeID == 4 {
+	if entity.IsSystemOperationType(opType.ID) {
+		return nil, entity.ErrSystemOperationType
+	}
+
+	amount, fx, err := s.convert(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.CardID != 0 {
+		err = s.checkCard(ctx, input.CardID, acc.ID, operationTypeID, amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var newLimit, fee, iof float64
+	if operationTypeID == entity.OperationTypePagamento {
 		if amount < 0 {
 			return nil, entity.ErrInvalidAmount
 		}
 		newLimit = acc.AvailabelCreditLimit + amount
 	} else {
-		newLimit = acc.AvailabelCreditLimit - math.Abs(amount)
+		err = s.checkVelocity(ctx, accountID, amount)
+		if err != nil {
+			return nil, err
+		}
+
+		fee = opType.Rules.FeeOf(amount)
+
+		err = s.checkRules(ctx, acc, opType, amount)
+		if err != nil {
+			return nil, err
+		}
+
+		if fx != nil {
+			iof = round(math.Abs(amount) * s.exchange.IOFRate)
+		}
+
+		newLimit = acc.AvailabelCreditLimit - math.Abs(amount) - fee - iof
 	}
 
 	if newLimit <= 0 {
 		return nil, entity.ErrInsufficientCreditLimit
 	}
 
-	_, err = s.accountService.UpdateCreditLimit(ctx, acc.ID, newLimit)
+	decision, err := s.evaluateRisk(ctx, acc, opType, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	var fees []*entity.Transaction
+	if fee > 0 {
+		fees = append(fees, &entity.Transaction{OperationTypeID: entity.OperationTypeTarifa, Amount: -fee})
+	}
+
+	if iof > 0 {
+		fees = append(fees, &entity.Transaction{OperationTypeID: entity.OperationTypeIOF, Amount: -iof})
+	}
+
+	transaction, err := s.save(ctx, acc, &entity.Transaction{
+		AccountID:       accountID,
+		OperationTypeID: operationTypeID,
+		Amount:          amount,
+		Exchange:        fx,
+		CardID:          input.CardID,
+		EventDate:       eventDate,
+		CreatedAt:       s.clock.Now(ctx).UTC(),
+	}, newLimit, fees...)
 	if err != nil {
 		return nil, errors.Wrap(err, "Create")
 	}
 
-	transaction, err := s.repo.Save(ctx, accountID, operationTypeID, amount)
+	if decision != nil {
+		decision.TransactionID = transaction.ID
+
+		// the debit is already applied, failing the request would have the client retry it
+		_, err = s.riskService.Record(ctx, decision)
+		if err != nil {
+			log.Error(ctx, "unable to record risk decision", err, log.Event{"transaction_id": transaction.ID})
+		}
+	}
+
+	return transaction, nil
+}
+
+// charge debit a system transaction, the available credit limit may become negative
+func (s *service) charge(ctx context.Context, accountID, operationTypeID int, amount float64) (*entity.Transaction, error) {
+	if !entity.IsSystemOperationType(operationTypeID) || amount == 0 {
+		return nil, entity.ErrInvalidAmount
+	}
+
+	acc, err := s.accountService.Get(ctx, accountID)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, errors.Wrap(err, "acc")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Charge")
+	}
+
+	now := s.clock.Now(ctx).UTC()
+
+	transaction, err := s.save(ctx, acc, &entity.Transaction{
+		AccountID:       accountID,
+		OperationTypeID: operationTypeID,
+		Amount:          -math.Abs(amount),
+		EventDate:       now,
+		CreatedAt:       now,
+	}, acc.AvailabelCreditLimit-math.Abs(amount))
+	if err != nil {
+		return nil, errors.Wrap(err, "Charge")
+	}
+
+	return transaction, nil
+}
+
+// save update the available credit limit of the account and persist the transaction with its fees, linked to it and
+// already debited from newLimit. The transaction and its fees are saved all or none, the limit is restored when they
+// are not saved
+func (s *service) save(
+	ctx context.Context,
+	acc *entity.Account,
+	txn *entity.Transaction,
+	newLimit float64,
+	fees ...*entity.Transaction,
+) (*entity.Transaction, error) {
+	_, err := s.accountService.UpdateCreditLimit(ctx, acc.ID, newLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		transaction *entity.Transaction
+		savedFees   []*entity.Transaction
+	)
+	if len(fees) > 0 {
+		transaction, savedFees, err = s.repo.SaveWithLinked(ctx, txn, fees)
+	} else {
+		transaction, err = s.repo.SaveDetailed(ctx, txn)
+	}
 	if err != nil {
 		_, errUpd := s.accountService.UpdateCreditLimit(ctx, acc.ID, acc.AvailabelCreditLimit)
 		if errUpd != nil {
-			return nil, errors.Wrap(errUpd, "Create")
+			return nil, errUpd
 		}
 
-		return nil, errors.Wrap(err, "Create")
+		return nil, err
+	}
+
+	s.audit(ctx, transaction)
+	for _, fee := range savedFees {
+		s.audit(ctx, fee)
 	}
 
 	return transaction, nil
 }
+
+// audit record the saved transaction on the audit trail, when the audit service is set. The transaction is already
+// committed, so a failure is logged instead of failing the request, which the client would retry debiting it again
+func (s *service) audit(ctx context.Context, transaction *entity.Transaction) {
+	if s.auditService == nil {
+		return
+	}
+
+	err := s.auditService.Record(ctx, entity.AuditActionCreate, entity.AuditEntityTransaction, transaction.ID, nil, transaction)
+	if err != nil {
+		log.Error(ctx, "unable to audit transaction", err, log.Event{"transaction_id": transaction.ID})
+	}
+}
+
+// eventDate validate the backdated date of a transaction against the window, zero is the current time of the clock
+func (s *service) eventDate(ctx context.Context, date time.Time) (time.Time, error) {
+	now := s.clock.Now(ctx)
+	if date.IsZero() {
+		return now.UTC(), nil
+	}
+
+	if date.After(now) || date.Before(now.Add(-s.eventDateWindow)) {
+		return time.Time{}, entity.ErrInvalidEventDate
+	}
+
+	return date.UTC(), nil
+}
+
+// convert the amount of the input to the account currency with the rate of the provider plus the spread, the exchange
+// is nil when the input is already in the account currency
+func (s *service) convert(ctx context.Context, input entity.TransactionInput) (float64, *entity.Exchange, error) {
+	if input.Currency == "" || strings.EqualFold(input.Currency, s.exchange.Currency) {
+		return input.Amount, nil, nil
+	}
+
+	if s.rates == nil {
+		return 0, nil, errors.Wrap(entity.ErrCurrencyNotSupported, input.Currency)
+	}
+
+	rate, err := s.rates.Rate(ctx, input.Currency, s.exchange.Currency)
+	if errors.Is(err, entity.ErrCurrencyNotSupported) {
+		return 0, nil, err
+	}
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "convert")
+	}
+
+	rate = math.Round(rate*(1+s.exchange.SpreadRate)*1e8) / 1e8
+
+	return round(input.Amount * rate), &entity.Exchange{
+		Currency:       strings.ToUpper(input.Currency),
+		OriginalAmount: input.Amount,
+		Rate:           rate,
+	}, nil
+}
+
+// recordAttempt observe and persist the attempt with the outcome from the result of the creation
+func (s *service) recordAttempt(
+	ctx context.Context,
+	accountID, operationTypeID int,
+	amount float64,
+	transaction *entity.Transaction,
+	errCreate error,
+) error {
+	if s.attemptRepo == nil && s.metrics == nil {
+		return nil
+	}
+
+	attempt := &entity.TransactionAttempt{
+		AccountID:       accountID,
+		OperationTypeID: operationTypeID,
+		Amount:          amount,
+		Outcome:         entity.AttemptOutcomeApproved,
+	}
+
+	if transaction != nil {
+		attempt.TransactionID = transaction.ID
+	}
+
+	if errCreate != nil {
+		attempt.Outcome, attempt.DeclineReason = DeclineReason(errCreate)
+	}
+
+	if s.metrics != nil {
+		s.metrics.ObserveAttempt(attempt)
+	}
+
+	if s.attemptRepo == nil {
+		return nil
+	}
+
+	_, err := s.attemptRepo.Save(ctx, attempt)
+
+	return err
+}
+
+// DeclineReason translate the error of a creation to the attempt outcome and decline reason
+func DeclineReason(err error) (entity.AttemptOutcome, string) {
+	var declineErr *entity.DeclineError
+
+	switch {
+	case errors.As(err, &declineErr):
+		return entity.AttemptOutcomeDeclined, strings.Join(declineErr.ReasonCodes, ",")
+	case errors.Is(err, entity.ErrInsufficientCreditLimit):
+		return entity.AttemptOutcomeDeclined, "INSUFFICIENT_CREDIT_LIMIT"
+	case errors.Is(err, entity.ErrInvalidAmount):
+		return entity.AttemptOutcomeDeclined, "INVALID_AMOUNT"
+	case errors.Is(err, entity.ErrVelocityLimitExceeded):
+		return entity.AttemptOutcomeDeclined, "VELOCITY_LIMIT_EXCEEDED"
+	case errors.Is(err, entity.ErrNotFound):
+		return entity.AttemptOutcomeDeclined, "RESOURCE_NOT_FOUND"
+	case errors.Is(err, entity.ErrOperationLimitExceeded):
+		return entity.AttemptOutcomeDeclined, "OPERATION_LIMIT_EXCEEDED"
+	case errors.Is(err, entity.ErrDailyLimitExceeded):
+		return entity.AttemptOutcomeDeclined, "DAILY_LIMIT_EXCEEDED"
+	case errors.Is(err, entity.ErrSystemOperationType):
+		return entity.AttemptOutcomeDeclined, "SYSTEM_OPERATION_TYPE"
+	case errors.Is(err, entity.ErrCurrencyNotSupported):
+		return entity.AttemptOutcomeDeclined, "CURRENCY_NOT_SUPPORTED"
+	case errors.Is(err, entity.ErrCardNotActive):
+		return entity.AttemptOutcomeDeclined, "CARD_NOT_ACTIVE"
+	case errors.Is(err, entity.ErrCardNotAllowed):
+		return entity.AttemptOutcomeDeclined, "CARD_NOT_ALLOWED"
+	case errors.Is(err, entity.ErrCardLimitExceeded):
+		return entity.AttemptOutcomeDeclined, "CARD_LIMIT_EXCEEDED"
+	case errors.Is(err, entity.ErrInvalidEventDate):
+		return entity.AttemptOutcomeDeclined, "INVALID_EVENT_DATE"
+	default:
+		return entity.AttemptOutcomeFailed, "INTERNAL_ERROR"
+	}
+}
+
+// evaluateRisk run the risk rules, declined transactions have the decision recorded and return a DeclineError
+func (s *service) evaluateRisk(
+	ctx context.Context,
+	acc *entity.Account,
+	opType *entity.OperationType,
+	amount float64,
+) (*entity.RiskDecision, error) {
+	if s.riskService == nil {
+		return nil, nil
+	}
+
+	decision, err := s.riskService.Evaluate(ctx, risk.Input{
+		Account:       acc,
+		OperationType: opType,
+		Amount:        amount,
+		Time:          s.clock.Now(ctx),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "evaluateRisk")
+	}
+
+	if decision.Outcome != entity.RiskOutcomeDecline {
+		return decision, nil
+	}
+
+	_, err = s.riskService.Record(ctx, decision)
+	if err != nil {
+		return nil, errors.Wrap(err, "evaluateRisk")
+	}
+
+	return nil, &entity.DeclineError{ReasonCodes: decision.ReasonCodes}
+}
+
+// checkVelocity validates the debit against the max number and amount of debits of the account in the window
+func (s *service) checkVelocity(ctx context.Context, accountID int, amount float64) error {
+	if s.velocityLimit.MaxDebits <= 0 && s.velocityLimit.MaxAmount <= 0 {
+		return nil
+	}
+
+	count, total, err := s.repo.SumDebitsSince(ctx, accountID, s.clock.Now(ctx).Add(-s.velocityLimit.Window))
+	if err != nil {
+		return errors.Wrap(err, "checkVelocity")
+	}
+
+	if s.velocityLimit.MaxDebits > 0 && count+1 > s.velocityLimit.MaxDebits {
+		return entity.ErrVelocityLimitExceeded
+	}
+
+	if s.velocityLimit.MaxAmount > 0 && total+math.Abs(amount) > s.velocityLimit.MaxAmount {
+		return entity.ErrVelocityLimitExceeded
+	}
+
+	return nil
+}
+
+// checkRules validates the debit against the sub-limit of the operation type and the debits of the operation type made
+// by the account on the current UTC day. The fees are left out of both, as they are of the debits summed
+func (s *service) checkRules(ctx context.Context, acc *entity.Account, opType *entity.OperationType, amount float64) error {
+	rules := opType.Rules
+
+	if rules.LimitRate > 0 {
+		err := s.checkSubLimit(ctx, acc, opType, math.Abs(amount))
+		if err != nil {
+			return err
+		}
+	}
+
+	if rules.DailyMaxCount <= 0 && rules.DailyMaxAmount <= 0 {
+		return nil
+	}
+
+	count, total, err := s.repo.SumByOperationTypeSince(ctx, acc.ID, opType.ID, s.clock.Now(ctx).UTC().Truncate(24*time.Hour))
+	if err != nil {
+		return errors.Wrap(err, "checkRules")
+	}
+
+	if rules.DailyMaxCount > 0 && count+1 > rules.DailyMaxCount {
+		return entity.ErrDailyLimitExceeded
+	}
+
+	if rules.DailyMaxAmount > 0 && total+math.Abs(amount) > rules.DailyMaxAmount {
+		return entity.ErrDailyLimitExceeded
+	}
+
+	return nil
+}
+
+// checkSubLimit validates the outstanding debits of the operation type plus the new debit against the share of the
+// credit limit of the account the operation type may use. The credit limit is the available limit plus the balance,
+// and the payments of the balance are taken as paying the other operation types first
+func (s *service) checkSubLimit(ctx context.Context, acc *entity.Account, opType *entity.OperationType, debit float64) error {
+	_, debited, err := s.repo.SumByOperationTypeSince(ctx, acc.ID, opType.ID, time.Time{})
+	if err != nil {
+		return errors.Wrap(err, "checkSubLimit")
+	}
+
+	debits, payments, err := s.repo.SumBalanceBefore(ctx, acc.ID, s.clock.Now(ctx))
+	if err != nil {
+		return errors.Wrap(err, "checkSubLimit")
+	}
+
+	balance := debits - payments
+	creditLimit := acc.AvailabelCreditLimit + balance
+	outstanding := math.Min(debited, math.Max(balance, 0))
+
+	if outstanding+debit > opType.Rules.LimitRate*creditLimit {
+		return entity.ErrOperationLimitExceeded
+	}
+
+	return nil
+}
+
+// checkCard validates the card is a usable card of the account and the debit fits the limit of the card on the
+// current UTC month, payments are made without a card
+func (s *service) checkCard(ctx context.Context, cardID, accountID, operationTypeID int, amount float64) error {
+	if s.cardService == nil {
+		return errors.Wrap(entity.ErrNotFound, "card")
+	}
+
+	c, err := s.cardService.Get(ctx, cardID)
+	if errors.Is(err, entity.ErrNotFound) {
+		return err
+	}
+	if err != nil {
+		return errors.Wrap(err, "checkCard")
+	}
+
+	if c.AccountID != accountID || operationTypeID == entity.OperationTypePagamento {
+		return entity.ErrCardNotAllowed
+	}
+
+	now := s.clock.Now(ctx).UTC()
+	if !c.Usable(now) {
+		return entity.ErrCardNotActive
+	}
+
+	if c.Limit <= 0 {
+		return nil
+	}
+
+	spent, err := s.repo.SumByCardSince(ctx, c.ID, time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return errors.Wrap(err, "checkCard")
+	}
+
+	if spent+math.Abs(amount) > c.Limit {
+		return entity.ErrCardLimitExceeded
+	}
+
+	return nil
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -4,10 +4,18 @@ import (
 	"context"
 	"github.com/brunomdev/digital-account/domain/account"
 	"github.com/brunomdev/digital-account/domain/account/mock_account"
+	"github.com/brunomdev/digital-account/domain/audit/mock_audit"
+	"github.com/brunomdev/digital-account/domain/card"
+	"github.com/brunomdev/digital-account/domain/card/mock_card"
+	"github.com/brunomdev/digital-account/domain/exchange"
+	"github.com/brunomdev/digital-account/domain/exchange/mock_exchange"
 	"github.com/brunomdev/digital-account/domain/operationtype"
 	"github.com/brunomdev/digital-account/domain/operationtype/mock_operationtype"
+	"github.com/brunomdev/digital-account/domain/risk"
+	"github.com/brunomdev/digital-account/domain/risk/mock_risk"
 	"github.com/brunomdev/digital-account/domain/transaction/mock_transaction"
 	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/pkg/clock"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
@@ -15,9 +23,16 @@ import (
 	"time"
 )
 
+// now is the time of the fake clock of the services
+var now = time.Date(2022, 3, 30, 10, 0, 0, 0, time.UTC)
+
+// newTransaction return the transaction of the account 1 saved at now
+func newTransaction(operationTypeID int, amount float64) *entity.Transaction {
+	return &entity.Transaction{AccountID: 1, OperationTypeID: operationTypeID, Amount: amount, EventDate: now, CreatedAt: now}
+}
+
 func Test_service_Create(t *testing.T) {
 	type args struct {
-		ctx                        context.Context
 		accountID, operationTypeID int
 		amount                     float64
 	}
@@ -120,6 +135,29 @@ func Test_service_Create(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "Error system operation type",
+			svcArgs: func(ctrl *gomock.Controller) (Repository, account.Service, operationtype.Service) {
+				repo := mock_transaction.NewMockRepository(ctrl)
+				accountSvc := mock_account.NewMockService(ctrl)
+				opTypeSvc := mock_operationtype.NewMockService(ctrl)
+
+				accountSvc.EXPECT().Get(gomock.Any(), 1).
+					Return(&entity.Account{ID: 1, DocumentNumber: "12345678900", AvailabelCreditLimit: 100.00}, nil)
+
+				opTypeSvc.EXPECT().Get(gomock.Any(), entity.OperationTypeJuros).
+					Return(&entity.OperationType{ID: entity.OperationTypeJuros, Description: "JUROS"}, nil)
+
+				return repo, accountSvc, opTypeSvc
+			},
+			args: args{
+				accountID:       1,
+				operationTypeID: entity.OperationTypeJuros,
+				amount:          -5.00,
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "Error insuficcient available credit limit",
 			svcArgs: func(ctrl *gomock.Controller) (Repository, account.Service, operationtype.Service) {
@@ -258,7 +296,7 @@ func Test_service_Create(t *testing.T) {
 						}, nil
 					})
 
-				repo.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				repo.EXPECT().SaveDetailed(gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("error"))
 
 				accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -307,7 +345,7 @@ func Test_service_Create(t *testing.T) {
 						}, nil
 					})
 
-				repo.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				repo.EXPECT().SaveDetailed(gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("error"))
 
 				accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -362,15 +400,11 @@ func Test_service_Create(t *testing.T) {
 						}, nil
 					})
 
-				repo.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, accountID, operationTypeID int, amount float64) (*entity.Transaction, error) {
-						return &entity.Transaction{
-							ID:              1,
-							AccountID:       accountID,
-							OperationTypeID: operationTypeID,
-							Amount:          amount,
-							EventDate:       time.Time{},
-						}, nil
+				repo.EXPECT().SaveDetailed(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, txn *entity.Transaction) (*entity.Transaction, error) {
+						saved := *txn
+						saved.ID = 1
+						return &saved, nil
 					})
 
 				return repo, accountSvc, opTypeSvc
@@ -385,7 +419,8 @@ func Test_service_Create(t *testing.T) {
 				AccountID:       1,
 				OperationTypeID: 4,
 				Amount:          30.00,
-				EventDate:       time.Time{},
+				EventDate:       now,
+				CreatedAt:       now,
 			},
 			wantErr: false,
 		},
@@ -396,9 +431,15 @@ func Test_service_Create(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			s := NewService(tc.svcArgs(ctrl))
+			repo, accountSvc, opTypeSvc := tc.svcArgs(ctrl)
 
-			got, err := s.Create(tc.args.ctx, tc.args.accountID, tc.args.operationTypeID, tc.args.amount)
+			s := NewService(repo, accountSvc, opTypeSvc, clock.NewFake(now))
+
+			got, err := s.Create(context.TODO(), entity.TransactionInput{
+				AccountID:       tc.args.accountID,
+				OperationTypeID: tc.args.operationTypeID,
+				Amount:          tc.args.amount,
+			})
 			if (err != nil) != tc.wantErr {
 				t.Errorf("Create() error = %v, wantErr %v", err, tc.wantErr)
 				return
@@ -410,3 +451,1573 @@ func Test_service_Create(t *testing.T) {
 		})
 	}
 }
+
+func Test_service_Create_velocityLimit(t *testing.T) {
+	type args struct {
+		accountID, operationTypeID int
+		amount                     float64
+	}
+	testCases := []struct {
+		name    string
+		limit   VelocityLimit
+		repo    func(ctrl *gomock.Controller) Repository
+		args    args
+		wantErr error
+	}{
+		{
+			name:  "Error summing debits",
+			limit: VelocityLimit{MaxDebits: 3, Window: time.Hour},
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+
+				repo.EXPECT().SumDebitsSince(gomock.Any(), 1, gomock.Any()).Return(0, 0.0, errors.New("database error"))
+
+				return repo
+			},
+			args:    args{accountID: 1, operationTypeID: entity.OperationTypeCompraAVista, amount: 10},
+			wantErr: errors.New("checkVelocity: database error"),
+		},
+		{
+			name:  "Error max debits exceeded",
+			limit: VelocityLimit{MaxDebits: 3, Window: time.Hour},
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+
+				repo.EXPECT().SumDebitsSince(gomock.Any(), 1, gomock.Any()).Return(3, 30.0, nil)
+
+				return repo
+			},
+			args:    args{accountID: 1, operationTypeID: entity.OperationTypeCompraAVista, amount: 10},
+			wantErr: entity.ErrVelocityLimitExceeded,
+		},
+		{
+			name:  "Error max amount exceeded",
+			limit: VelocityLimit{MaxAmount: 100, Window: time.Hour},
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+
+				repo.EXPECT().SumDebitsSince(gomock.Any(), 1, gomock.Any()).Return(2, 95.0, nil)
+
+				return repo
+			},
+			args:    args{accountID: 1, operationTypeID: entity.OperationTypeSaque, amount: -10},
+			wantErr: entity.ErrVelocityLimitExceeded,
+		},
+		{
+			name:  "Success payments are not debits",
+			limit: VelocityLimit{MaxDebits: 1, Window: time.Hour},
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+
+				repo.EXPECT().SaveDetailed(gomock.Any(), newTransaction(entity.OperationTypePagamento, 10.0)).
+					Return(&entity.Transaction{ID: 1}, nil)
+
+				return repo
+			},
+			args:    args{accountID: 1, operationTypeID: entity.OperationTypePagamento, amount: 10},
+			wantErr: nil,
+		},
+		{
+			name:  "Success inside the limit",
+			limit: VelocityLimit{MaxDebits: 3, MaxAmount: 100, Window: time.Hour},
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+
+				repo.EXPECT().SumDebitsSince(gomock.Any(), 1, now.Add(-time.Hour)).Return(2, 90.0, nil)
+				repo.EXPECT().SaveDetailed(gomock.Any(), newTransaction(entity.OperationTypeCompraAVista, 10.0)).
+					Return(&entity.Transaction{ID: 1}, nil)
+
+				return repo
+			},
+			args:    args{accountID: 1, operationTypeID: entity.OperationTypeCompraAVista, amount: 10},
+			wantErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountSvc := mock_account.NewMockService(ctrl)
+			accountSvc.EXPECT().Get(gomock.Any(), tc.args.accountID).
+				Return(&entity.Account{ID: tc.args.accountID, AvailabelCreditLimit: 1000}, nil)
+			accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), tc.args.accountID, gomock.Any()).
+				Return(&entity.Account{ID: tc.args.accountID}, nil).AnyTimes()
+
+			opTypeSvc := mock_operationtype.NewMockService(ctrl)
+			opTypeSvc.EXPECT().Get(gomock.Any(), tc.args.operationTypeID).
+				Return(&entity.OperationType{ID: tc.args.operationTypeID}, nil)
+
+			s := NewService(tc.repo(ctrl), accountSvc, opTypeSvc, clock.NewFake(now), WithVelocityLimit(tc.limit))
+
+			_, err := s.Create(context.TODO(), entity.TransactionInput{
+				AccountID:       tc.args.accountID,
+				OperationTypeID: tc.args.operationTypeID,
+				Amount:          tc.args.amount,
+			})
+			if (err != nil) != (tc.wantErr != nil) || (err != nil && err.Error() != tc.wantErr.Error()) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func Test_service_Create_operationTypeRules(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rules   entity.OperationTypeRules
+		mocks   func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository
+		amount  float64
+		wantErr error
+	}{
+		{
+			name:  "Error sub-limit exceeded",
+			rules: entity.OperationTypeRules{LimitRate: 0.3, Fee: 5},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+
+				repo.EXPECT().SumByOperationTypeSince(gomock.Any(), 1, entity.OperationTypeSaque, time.Time{}).
+					Return(0, 0.0, nil)
+				repo.EXPECT().SumBalanceBefore(gomock.Any(), 1, now).Return(0.0, 0.0, nil)
+
+				return repo
+			},
+			amount:  -301,
+			wantErr: entity.ErrOperationLimitExceeded,
+		},
+		{
+			name:  "Error sub-limit exceeded with the unpaid debits",
+			rules: entity.OperationTypeRules{LimitRate: 0.3},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+
+				repo.EXPECT().SumByOperationTypeSince(gomock.Any(), 1, entity.OperationTypeSaque, time.Time{}).
+					Return(3, 300.0, nil)
+				repo.EXPECT().SumBalanceBefore(gomock.Any(), 1, now).Return(500.0, 100.0, nil)
+
+				return repo
+			},
+			amount:  -150,
+			wantErr: entity.ErrOperationLimitExceeded,
+		},
+		{
+			name:  "Success fee left out of the sub-limit",
+			rules: entity.OperationTypeRules{LimitRate: 0.3, Fee: 5},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+
+				repo.EXPECT().SumByOperationTypeSince(gomock.Any(), 1, entity.OperationTypeSaque, time.Time{}).
+					Return(0, 0.0, nil)
+				repo.EXPECT().SumBalanceBefore(gomock.Any(), 1, now).Return(0.0, 0.0, nil)
+				accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), 1, 695.0).Return(&entity.Account{ID: 1}, nil)
+				repo.EXPECT().SaveWithLinked(gomock.Any(), newTransaction(entity.OperationTypeSaque, -300.0), gomock.Any()).
+					Return(&entity.Transaction{ID: 1, AccountID: 1}, []*entity.Transaction{{ID: 2, AccountID: 1, ParentID: 1}}, nil)
+
+				return repo
+			},
+			amount:  -300,
+			wantErr: nil,
+		},
+		{
+			name:  "Error summing the debits of the operation type",
+			rules: entity.OperationTypeRules{LimitRate: 0.3},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+
+				repo.EXPECT().SumByOperationTypeSince(gomock.Any(), 1, entity.OperationTypeSaque, time.Time{}).
+					Return(0, 0.0, errors.New("database error"))
+
+				return repo
+			},
+			amount:  -100,
+			wantErr: errors.New("checkSubLimit: database error"),
+		},
+		{
+			name:  "Error summing the debits of the day",
+			rules: entity.OperationTypeRules{DailyMaxCount: 2},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+
+				repo.EXPECT().SumByOperationTypeSince(gomock.Any(), 1, entity.OperationTypeSaque, gomock.Any()).
+					Return(0, 0.0, errors.New("database error"))
+
+				return repo
+			},
+			amount:  -100,
+			wantErr: errors.New("checkRules: database error"),
+		},
+		{
+			name:  "Error daily count exceeded",
+			rules: entity.OperationTypeRules{DailyMaxCount: 2},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+
+				repo.EXPECT().SumByOperationTypeSince(gomock.Any(), 1, entity.OperationTypeSaque, gomock.Any()).
+					Return(2, 100.0, nil)
+
+				return repo
+			},
+			amount:  -100,
+			wantErr: entity.ErrDailyLimitExceeded,
+		},
+		{
+			name:  "Error daily amount exceeded",
+			rules: entity.OperationTypeRules{DailyMaxAmount: 500},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+
+				repo.EXPECT().SumByOperationTypeSince(gomock.Any(), 1, entity.OperationTypeSaque, gomock.Any()).
+					Return(1, 450.0, nil)
+
+				return repo
+			},
+			amount:  -100,
+			wantErr: entity.ErrDailyLimitExceeded,
+		},
+		{
+			name:  "Error saving the debit with the fee gives both back",
+			rules: entity.OperationTypeRules{Fee: 5},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+
+				gomock.InOrder(
+					accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), 1, 895.0).Return(&entity.Account{ID: 1}, nil),
+					repo.EXPECT().SaveWithLinked(
+						gomock.Any(),
+						newTransaction(entity.OperationTypeSaque, -100.0),
+						[]*entity.Transaction{{OperationTypeID: entity.OperationTypeTarifa, Amount: -5}},
+					).Return(nil, nil, errors.New("database error")),
+					accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), 1, 1000.0).Return(&entity.Account{ID: 1}, nil),
+				)
+
+				return repo
+			},
+			amount:  -100,
+			wantErr: errors.New("Create: database error"),
+		},
+		{
+			name:  "Success fee linked to the debit",
+			rules: entity.OperationTypeRules{LimitRate: 0.5, Fee: 5, FeeRate: 0.01, DailyMaxCount: 3, DailyMaxAmount: 500},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+
+				repo.EXPECT().SumByOperationTypeSince(gomock.Any(), 1, entity.OperationTypeSaque, time.Time{}).
+					Return(1, 100.0, nil)
+				repo.EXPECT().SumBalanceBefore(gomock.Any(), 1, now).Return(100.0, 0.0, nil)
+				repo.EXPECT().SumByOperationTypeSince(gomock.Any(), 1, entity.OperationTypeSaque, time.Date(2022, 3, 30, 0, 0, 0, 0, time.UTC)).
+					Return(1, 100.0, nil)
+				accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), 1, 793.0).Return(&entity.Account{ID: 1}, nil)
+				repo.EXPECT().SaveWithLinked(
+					gomock.Any(),
+					newTransaction(entity.OperationTypeSaque, -200.0),
+					[]*entity.Transaction{{OperationTypeID: entity.OperationTypeTarifa, Amount: -7}},
+				).Return(
+					&entity.Transaction{ID: 1, AccountID: 1},
+					[]*entity.Transaction{{ID: 2, AccountID: 1, ParentID: 1}},
+					nil,
+				)
+
+				return repo
+			},
+			amount:  -200,
+			wantErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountSvc := mock_account.NewMockService(ctrl)
+			accountSvc.EXPECT().Get(gomock.Any(), 1).Return(&entity.Account{ID: 1, AvailabelCreditLimit: 1000}, nil)
+
+			opTypeSvc := mock_operationtype.NewMockService(ctrl)
+			opTypeSvc.EXPECT().Get(gomock.Any(), entity.OperationTypeSaque).
+				Return(&entity.OperationType{ID: entity.OperationTypeSaque, Rules: tc.rules}, nil)
+
+			s := NewService(tc.mocks(ctrl, accountSvc), accountSvc, opTypeSvc, clock.NewFake(now))
+
+			_, err := s.Create(context.TODO(), entity.TransactionInput{
+				AccountID:       1,
+				OperationTypeID: entity.OperationTypeSaque,
+				Amount:          tc.amount,
+			})
+			if (err != nil) != (tc.wantErr != nil) || (err != nil && err.Error() != tc.wantErr.Error()) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func Test_service_Create_withdrawalsInARow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rules := entity.OperationTypeRules{LimitRate: 0.3}
+
+	accountSvc := mock_account.NewMockService(ctrl)
+	opTypeSvc := mock_operationtype.NewMockService(ctrl)
+	repo := mock_transaction.NewMockRepository(ctrl)
+
+	opTypeSvc.EXPECT().Get(gomock.Any(), entity.OperationTypeSaque).
+		Return(&entity.OperationType{ID: entity.OperationTypeSaque, Rules: rules}, nil).Times(2)
+
+	gomock.InOrder(
+		accountSvc.EXPECT().Get(gomock.Any(), 1).Return(&entity.Account{ID: 1, AvailabelCreditLimit: 1000}, nil),
+		repo.EXPECT().SumByOperationTypeSince(gomock.Any(), 1, entity.OperationTypeSaque, time.Time{}).
+			Return(0, 0.0, nil),
+		repo.EXPECT().SumBalanceBefore(gomock.Any(), 1, now).Return(0.0, 0.0, nil),
+		accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), 1, 750.0).Return(&entity.Account{ID: 1}, nil),
+		repo.EXPECT().SaveDetailed(gomock.Any(), newTransaction(entity.OperationTypeSaque, -250.0)).
+			Return(&entity.Transaction{ID: 1, AccountID: 1}, nil),
+		// the second withdrawal fits 30% of the available 750 but not of the credit limit with the first one
+		accountSvc.EXPECT().Get(gomock.Any(), 1).Return(&entity.Account{ID: 1, AvailabelCreditLimit: 750}, nil),
+		repo.EXPECT().SumByOperationTypeSince(gomock.Any(), 1, entity.OperationTypeSaque, time.Time{}).
+			Return(1, 250.0, nil),
+		repo.EXPECT().SumBalanceBefore(gomock.Any(), 1, now).Return(250.0, 0.0, nil),
+	)
+
+	s := NewService(repo, accountSvc, opTypeSvc, clock.NewFake(now))
+
+	input := entity.TransactionInput{AccountID: 1, OperationTypeID: entity.OperationTypeSaque, Amount: -250}
+
+	_, err := s.Create(context.TODO(), input)
+	if err != nil {
+		t.Fatalf("Create() first withdrawal error = %v", err)
+	}
+
+	input.Amount = -200
+
+	_, err = s.Create(context.TODO(), input)
+	if !errors.Is(err, entity.ErrOperationLimitExceeded) {
+		t.Errorf("Create() second withdrawal error = %v, wantErr %v", err, entity.ErrOperationLimitExceeded)
+	}
+}
+
+func Test_service_Create_exchange(t *testing.T) {
+	testCases := []struct {
+		name     string
+		provider func(ctrl *gomock.Controller) exchange.RateProvider
+		mocks    func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository
+		currency string
+		want     *entity.Transaction
+		wantErr  error
+	}{
+		{
+			name: "Error currency without provider",
+			provider: func(ctrl *gomock.Controller) exchange.RateProvider {
+				return nil
+			},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				return mock_transaction.NewMockRepository(ctrl)
+			},
+			currency: "USD",
+			want:     nil,
+			wantErr:  errors.New("USD: currency not supported"),
+		},
+		{
+			name: "Error currency not supported",
+			provider: func(ctrl *gomock.Controller) exchange.RateProvider {
+				provider := mock_exchange.NewMockRateProvider(ctrl)
+				provider.EXPECT().Rate(gomock.Any(), "JPY", "BRL").
+					Return(0.0, errors.Wrap(entity.ErrCurrencyNotSupported, "JPY"))
+
+				return provider
+			},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				return mock_transaction.NewMockRepository(ctrl)
+			},
+			currency: "JPY",
+			want:     nil,
+			wantErr:  errors.New("JPY: currency not supported"),
+		},
+		{
+			name: "Error provider",
+			provider: func(ctrl *gomock.Controller) exchange.RateProvider {
+				provider := mock_exchange.NewMockRateProvider(ctrl)
+				provider.EXPECT().Rate(gomock.Any(), "USD", "BRL").Return(0.0, errors.New("rates error"))
+
+				return provider
+			},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				return mock_transaction.NewMockRepository(ctrl)
+			},
+			currency: "USD",
+			want:     nil,
+			wantErr:  errors.New("convert: rates error"),
+		},
+		{
+			name: "Error saving the IOF gives it back",
+			provider: func(ctrl *gomock.Controller) exchange.RateProvider {
+				provider := mock_exchange.NewMockRateProvider(ctrl)
+				provider.EXPECT().Rate(gomock.Any(), "USD", "BRL").Return(5.0, nil)
+
+				return provider
+			},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+
+				gomock.InOrder(
+					accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), 1, 945.4).Return(&entity.Account{ID: 1}, nil),
+					repo.EXPECT().SaveWithLinked(gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, nil, errors.New("database error")),
+					accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), 1, 1000.0).Return(&entity.Account{ID: 1}, nil),
+				)
+
+				return repo
+			},
+			currency: "USD",
+			want:     nil,
+			wantErr:  errors.New("Create: database error"),
+		},
+		{
+			name: "Success account currency",
+			provider: func(ctrl *gomock.Controller) exchange.RateProvider {
+				return mock_exchange.NewMockRateProvider(ctrl)
+			},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+
+				accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), 1, 990.0).Return(&entity.Account{ID: 1}, nil)
+				repo.EXPECT().SaveDetailed(gomock.Any(), newTransaction(entity.OperationTypeCompraAVista, -10.0)).
+					Return(&entity.Transaction{ID: 1, AccountID: 1, OperationTypeID: 1, Amount: -10}, nil)
+
+				return repo
+			},
+			currency: "brl",
+			want:     &entity.Transaction{ID: 1, AccountID: 1, OperationTypeID: 1, Amount: -10},
+			wantErr:  nil,
+		},
+		{
+			name: "Success converted with the spread and the IOF",
+			provider: func(ctrl *gomock.Controller) exchange.RateProvider {
+				provider := mock_exchange.NewMockRateProvider(ctrl)
+				provider.EXPECT().Rate(gomock.Any(), "usd", "BRL").Return(5.0, nil)
+
+				return provider
+			},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+
+				txn := &entity.Transaction{
+					AccountID:       1,
+					OperationTypeID: entity.OperationTypeCompraAVista,
+					Amount:          -52,
+					Exchange:        &entity.Exchange{Currency: "USD", OriginalAmount: -10, Rate: 5.2},
+					EventDate:       now,
+					CreatedAt:       now,
+				}
+				saved := *txn
+				saved.ID = 1
+
+				accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), 1, 945.4).Return(&entity.Account{ID: 1}, nil)
+				repo.EXPECT().SaveWithLinked(
+					gomock.Any(),
+					txn,
+					[]*entity.Transaction{{OperationTypeID: entity.OperationTypeIOF, Amount: -2.6}},
+				).Return(&saved, []*entity.Transaction{{ID: 2, AccountID: 1, ParentID: 1}}, nil)
+
+				return repo
+			},
+			currency: "usd",
+			want: &entity.Transaction{
+				ID:              1,
+				AccountID:       1,
+				OperationTypeID: entity.OperationTypeCompraAVista,
+				Amount:          -52,
+				Exchange:        &entity.Exchange{Currency: "USD", OriginalAmount: -10, Rate: 5.2},
+				EventDate:       now,
+				CreatedAt:       now,
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountSvc := mock_account.NewMockService(ctrl)
+			accountSvc.EXPECT().Get(gomock.Any(), 1).Return(&entity.Account{ID: 1, AvailabelCreditLimit: 1000}, nil)
+
+			opTypeSvc := mock_operationtype.NewMockService(ctrl)
+			opTypeSvc.EXPECT().Get(gomock.Any(), entity.OperationTypeCompraAVista).
+				Return(&entity.OperationType{ID: entity.OperationTypeCompraAVista}, nil)
+
+			s := NewService(
+				tc.mocks(ctrl, accountSvc),
+				accountSvc,
+				opTypeSvc,
+				clock.NewFake(now),
+				WithExchange(tc.provider(ctrl), Exchange{Currency: "BRL", SpreadRate: 0.04, IOFRate: 0.05}),
+			)
+
+			got, err := s.Create(context.TODO(), entity.TransactionInput{
+				AccountID:       1,
+				OperationTypeID: entity.OperationTypeCompraAVista,
+				Amount:          -10,
+				Currency:        tc.currency,
+			})
+			if (err != nil) != (tc.wantErr != nil) || (err != nil && err.Error() != tc.wantErr.Error()) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Create() got = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func Test_service_Create_card(t *testing.T) {
+	expiry := time.Now().UTC().AddDate(1, 0, 0)
+	activeCard := func(accountID int, limit float64) *entity.Card {
+		return &entity.Card{
+			ID:          7,
+			AccountID:   accountID,
+			ExpiryMonth: int(expiry.Month()),
+			ExpiryYear:  expiry.Year(),
+			Status:      entity.CardStatusActive,
+			Limit:       limit,
+		}
+	}
+
+	testCases := []struct {
+		name            string
+		cardService     func(ctrl *gomock.Controller) card.Service
+		mocks           func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository
+		operationTypeID int
+		want            *entity.Transaction
+		wantErr         error
+	}{
+		{
+			name: "Error without card service",
+			cardService: func(ctrl *gomock.Controller) card.Service {
+				return nil
+			},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				return mock_transaction.NewMockRepository(ctrl)
+			},
+			operationTypeID: entity.OperationTypeCompraAVista,
+			wantErr:         errors.Wrap(entity.ErrNotFound, "card"),
+		},
+		{
+			name: "Error card of another account",
+			cardService: func(ctrl *gomock.Controller) card.Service {
+				cardSvc := mock_card.NewMockService(ctrl)
+				cardSvc.EXPECT().Get(gomock.Any(), 7).Return(activeCard(2, 0), nil)
+
+				return cardSvc
+			},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				return mock_transaction.NewMockRepository(ctrl)
+			},
+			operationTypeID: entity.OperationTypeCompraAVista,
+			wantErr:         entity.ErrCardNotAllowed,
+		},
+		{
+			name: "Error payment with card",
+			cardService: func(ctrl *gomock.Controller) card.Service {
+				cardSvc := mock_card.NewMockService(ctrl)
+				cardSvc.EXPECT().Get(gomock.Any(), 7).Return(activeCard(1, 0), nil)
+
+				return cardSvc
+			},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				return mock_transaction.NewMockRepository(ctrl)
+			},
+			operationTypeID: entity.OperationTypePagamento,
+			wantErr:         entity.ErrCardNotAllowed,
+		},
+		{
+			name: "Error blocked card",
+			cardService: func(ctrl *gomock.Controller) card.Service {
+				c := activeCard(1, 0)
+				c.Status = entity.CardStatusBlocked
+
+				cardSvc := mock_card.NewMockService(ctrl)
+				cardSvc.EXPECT().Get(gomock.Any(), 7).Return(c, nil)
+
+				return cardSvc
+			},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				return mock_transaction.NewMockRepository(ctrl)
+			},
+			operationTypeID: entity.OperationTypeCompraAVista,
+			wantErr:         entity.ErrCardNotActive,
+		},
+		{
+			name: "Error card limit exceeded",
+			cardService: func(ctrl *gomock.Controller) card.Service {
+				cardSvc := mock_card.NewMockService(ctrl)
+				cardSvc.EXPECT().Get(gomock.Any(), 7).Return(activeCard(1, 100), nil)
+
+				return cardSvc
+			},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+				repo.EXPECT().SumByCardSince(gomock.Any(), 7, gomock.Any()).Return(95.0, nil)
+
+				return repo
+			},
+			operationTypeID: entity.OperationTypeCompraAVista,
+			wantErr:         entity.ErrCardLimitExceeded,
+		},
+		{
+			name: "Success within the card limit",
+			cardService: func(ctrl *gomock.Controller) card.Service {
+				cardSvc := mock_card.NewMockService(ctrl)
+				cardSvc.EXPECT().Get(gomock.Any(), 7).Return(activeCard(1, 100), nil)
+
+				return cardSvc
+			},
+			mocks: func(ctrl *gomock.Controller, accountSvc *mock_account.MockService) Repository {
+				txn := newTransaction(entity.OperationTypeCompraAVista, -10)
+				txn.CardID = 7
+				saved := *txn
+				saved.ID = 1
+
+				repo := mock_transaction.NewMockRepository(ctrl)
+				repo.EXPECT().SumByCardSince(gomock.Any(), 7, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, cardID int, since time.Time) (float64, error) {
+						if !since.Equal(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)) {
+							t.Errorf("SumByCardSince() since = %v, want the start of the month", since)
+						}
+						return 90.0, nil
+					})
+				accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), 1, 990.0).Return(&entity.Account{ID: 1}, nil)
+				repo.EXPECT().SaveDetailed(gomock.Any(), txn).Return(&saved, nil)
+
+				return repo
+			},
+			operationTypeID: entity.OperationTypeCompraAVista,
+			want: &entity.Transaction{
+				ID:              1,
+				AccountID:       1,
+				OperationTypeID: entity.OperationTypeCompraAVista,
+				Amount:          -10,
+				EventDate:       now,
+				CreatedAt:       now,
+				CardID:          7,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountSvc := mock_account.NewMockService(ctrl)
+			accountSvc.EXPECT().Get(gomock.Any(), 1).Return(&entity.Account{ID: 1, AvailabelCreditLimit: 1000}, nil)
+
+			opTypeSvc := mock_operationtype.NewMockService(ctrl)
+			opTypeSvc.EXPECT().Get(gomock.Any(), tc.operationTypeID).
+				Return(&entity.OperationType{ID: tc.operationTypeID}, nil)
+
+			opts := []Option{}
+			if cardSvc := tc.cardService(ctrl); cardSvc != nil {
+				opts = append(opts, WithCardService(cardSvc))
+			}
+
+			s := NewService(tc.mocks(ctrl, accountSvc), accountSvc, opTypeSvc, clock.NewFake(now), opts...)
+
+			amount := -10.0
+			if tc.operationTypeID == entity.OperationTypePagamento {
+				amount = 10
+			}
+
+			got, err := s.Create(context.TODO(), entity.TransactionInput{
+				AccountID:       1,
+				OperationTypeID: tc.operationTypeID,
+				Amount:          amount,
+				CardID:          7,
+			})
+			if (err != nil) != (tc.wantErr != nil) || (err != nil && err.Error() != tc.wantErr.Error()) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Create() got = %v, want %v, %v", got, tc.want, cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
+
+func Test_service_Create_eventDate(t *testing.T) {
+	yesterday := now.Add(-24 * time.Hour)
+
+	testCases := []struct {
+		name      string
+		window    time.Duration
+		eventDate time.Time
+		mocks     func(ctrl *gomock.Controller) (Repository, account.Service, operationtype.Service)
+		want      *entity.Transaction
+		wantErr   error
+	}{
+		{
+			name:      "Error backdating disabled",
+			eventDate: yesterday,
+			mocks: func(ctrl *gomock.Controller) (Repository, account.Service, operationtype.Service) {
+				return mock_transaction.NewMockRepository(ctrl), mock_account.NewMockService(ctrl),
+					mock_operationtype.NewMockService(ctrl)
+			},
+			wantErr: entity.ErrInvalidEventDate,
+		},
+		{
+			name:      "Error older than the window",
+			window:    12 * time.Hour,
+			eventDate: yesterday,
+			mocks: func(ctrl *gomock.Controller) (Repository, account.Service, operationtype.Service) {
+				return mock_transaction.NewMockRepository(ctrl), mock_account.NewMockService(ctrl),
+					mock_operationtype.NewMockService(ctrl)
+			},
+			wantErr: entity.ErrInvalidEventDate,
+		},
+		{
+			name:      "Error in the future",
+			window:    72 * time.Hour,
+			eventDate: now.Add(time.Hour),
+			mocks: func(ctrl *gomock.Controller) (Repository, account.Service, operationtype.Service) {
+				return mock_transaction.NewMockRepository(ctrl), mock_account.NewMockService(ctrl),
+					mock_operationtype.NewMockService(ctrl)
+			},
+			wantErr: entity.ErrInvalidEventDate,
+		},
+		{
+			name:      "Success inside the window",
+			window:    72 * time.Hour,
+			eventDate: yesterday.In(time.FixedZone("BRT", -3*60*60)),
+			mocks: func(ctrl *gomock.Controller) (Repository, account.Service, operationtype.Service) {
+				txn := &entity.Transaction{
+					AccountID:       1,
+					OperationTypeID: entity.OperationTypeCompraAVista,
+					Amount:          -10,
+					EventDate:       yesterday.UTC(),
+					CreatedAt:       now,
+				}
+				saved := *txn
+				saved.ID = 1
+
+				accountSvc := mock_account.NewMockService(ctrl)
+				accountSvc.EXPECT().Get(gomock.Any(), 1).Return(&entity.Account{ID: 1, AvailabelCreditLimit: 1000}, nil)
+				accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), 1, 990.0).Return(&entity.Account{ID: 1}, nil)
+
+				opTypeSvc := mock_operationtype.NewMockService(ctrl)
+				opTypeSvc.EXPECT().Get(gomock.Any(), entity.OperationTypeCompraAVista).
+					Return(&entity.OperationType{ID: entity.OperationTypeCompraAVista}, nil)
+
+				repo := mock_transaction.NewMockRepository(ctrl)
+				repo.EXPECT().SaveDetailed(gomock.Any(), txn).Return(&saved, nil)
+
+				return repo, accountSvc, opTypeSvc
+			},
+			want: &entity.Transaction{
+				ID:              1,
+				AccountID:       1,
+				OperationTypeID: entity.OperationTypeCompraAVista,
+				Amount:          -10,
+				EventDate:       yesterday.UTC(),
+				CreatedAt:       now,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, accountSvc, opTypeSvc := tc.mocks(ctrl)
+
+			s := NewService(repo, accountSvc, opTypeSvc, clock.NewFake(now), WithEventDateWindow(tc.window))
+
+			got, err := s.Create(context.TODO(), entity.TransactionInput{
+				AccountID:       1,
+				OperationTypeID: entity.OperationTypeCompraAVista,
+				Amount:          -10,
+				EventDate:       tc.eventDate,
+			})
+			if (err != nil) != (tc.wantErr != nil) || (err != nil && err.Error() != tc.wantErr.Error()) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Create() got = %v, want %v, %v", got, tc.want, cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
+
+func Test_service_Create_riskService(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mocks   func(ctrl *gomock.Controller) (Repository, risk.Service)
+		want    *entity.Transaction
+		wantErr error
+	}{
+		{
+			name: "Error evaluating",
+			mocks: func(ctrl *gomock.Controller) (Repository, risk.Service) {
+				riskSvc := mock_risk.NewMockService(ctrl)
+				riskSvc.EXPECT().Evaluate(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+
+				return mock_transaction.NewMockRepository(ctrl), riskSvc
+			},
+			want:    nil,
+			wantErr: errors.New("evaluateRisk: database error"),
+		},
+		{
+			name: "Error declined",
+			mocks: func(ctrl *gomock.Controller) (Repository, risk.Service) {
+				decision := &entity.RiskDecision{
+					AccountID:       1,
+					OperationTypeID: entity.OperationTypeSaque,
+					Amount:          -10,
+					Outcome:         entity.RiskOutcomeDecline,
+					ReasonCodes:     []string{"WITHDRAWAL_ON_NEW_ACCOUNT"},
+				}
+
+				riskSvc := mock_risk.NewMockService(ctrl)
+				riskSvc.EXPECT().Evaluate(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, input risk.Input) (*entity.RiskDecision, error) {
+						if input.Account.ID != 1 || input.OperationType.ID != entity.OperationTypeSaque || input.Amount != -10 {
+							t.Errorf("unexpected risk input %+v", input)
+						}
+
+						return decision, nil
+					})
+				riskSvc.EXPECT().Record(gomock.Any(), decision).Return(decision, nil)
+
+				return mock_transaction.NewMockRepository(ctrl), riskSvc
+			},
+			want:    nil,
+			wantErr: &entity.DeclineError{ReasonCodes: []string{"WITHDRAWAL_ON_NEW_ACCOUNT"}},
+		},
+		{
+			name: "Error recording decision keeps the transaction",
+			mocks: func(ctrl *gomock.Controller) (Repository, risk.Service) {
+				riskSvc := mock_risk.NewMockService(ctrl)
+				riskSvc.EXPECT().Evaluate(gomock.Any(), gomock.Any()).
+					Return(&entity.RiskDecision{Outcome: entity.RiskOutcomeReview, ReasonCodes: []string{"AMOUNT"}}, nil)
+				riskSvc.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+
+				repo := mock_transaction.NewMockRepository(ctrl)
+				repo.EXPECT().SaveDetailed(gomock.Any(), newTransaction(entity.OperationTypeSaque, -10.0)).
+					Return(&entity.Transaction{ID: 7}, nil)
+
+				return repo, riskSvc
+			},
+			want:    &entity.Transaction{ID: 7},
+			wantErr: nil,
+		},
+		{
+			name: "Success review",
+			mocks: func(ctrl *gomock.Controller) (Repository, risk.Service) {
+				riskSvc := mock_risk.NewMockService(ctrl)
+				riskSvc.EXPECT().Evaluate(gomock.Any(), gomock.Any()).
+					Return(&entity.RiskDecision{Outcome: entity.RiskOutcomeReview, ReasonCodes: []string{"AMOUNT"}}, nil)
+				riskSvc.EXPECT().Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, decision *entity.RiskDecision) (*entity.RiskDecision, error) {
+						if decision.TransactionID != 7 {
+							t.Errorf("decision recorded without the transaction, got %d", decision.TransactionID)
+						}
+
+						return decision, nil
+					})
+
+				repo := mock_transaction.NewMockRepository(ctrl)
+				repo.EXPECT().SaveDetailed(gomock.Any(), newTransaction(entity.OperationTypeSaque, -10.0)).
+					Return(&entity.Transaction{ID: 7}, nil)
+
+				return repo, riskSvc
+			},
+			want:    &entity.Transaction{ID: 7},
+			wantErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountSvc := mock_account.NewMockService(ctrl)
+			accountSvc.EXPECT().Get(gomock.Any(), 1).
+				Return(&entity.Account{ID: 1, AvailabelCreditLimit: 1000}, nil)
+			accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), 1, gomock.Any()).
+				Return(&entity.Account{ID: 1}, nil).AnyTimes()
+
+			opTypeSvc := mock_operationtype.NewMockService(ctrl)
+			opTypeSvc.EXPECT().Get(gomock.Any(), entity.OperationTypeSaque).
+				Return(&entity.OperationType{ID: entity.OperationTypeSaque, Description: "SAQUE"}, nil)
+
+			repo, riskSvc := tc.mocks(ctrl)
+			s := NewService(repo, accountSvc, opTypeSvc, clock.NewFake(now), WithRiskService(riskSvc))
+
+			got, err := s.Create(context.TODO(), entity.TransactionInput{
+				AccountID:       1,
+				OperationTypeID: entity.OperationTypeSaque,
+				Amount:          -10,
+			})
+			if (err != nil) != (tc.wantErr != nil) || (err != nil && err.Error() != tc.wantErr.Error()) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Create() got = %v, want %v, %v", got, tc.want, cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
+
+func Test_service_Create_attempts(t *testing.T) {
+	type args struct {
+		operationTypeID int
+		amount          float64
+	}
+	testCases := []struct {
+		name        string
+		repo        func(ctrl *gomock.Controller) Repository
+		attemptRepo func(ctrl *gomock.Controller) AttemptRepository
+		args        args
+		want        *entity.Transaction
+		wantErr     error
+	}{
+		{
+			name: "Approved",
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+				repo.EXPECT().SaveDetailed(gomock.Any(), newTransaction(entity.OperationTypeCompraAVista, -50.0)).
+					Return(&entity.Transaction{ID: 9}, nil)
+
+				return repo
+			},
+			attemptRepo: func(ctrl *gomock.Controller) AttemptRepository {
+				attemptRepo := mock_transaction.NewMockAttemptRepository(ctrl)
+				attemptRepo.EXPECT().Save(gomock.Any(), &entity.TransactionAttempt{
+					AccountID:       1,
+					OperationTypeID: entity.OperationTypeCompraAVista,
+					Amount:          -50,
+					Outcome:         entity.AttemptOutcomeApproved,
+					TransactionID:   9,
+				}).Return(&entity.TransactionAttempt{ID: 1}, nil)
+
+				return attemptRepo
+			},
+			args:    args{operationTypeID: entity.OperationTypeCompraAVista, amount: -50},
+			want:    &entity.Transaction{ID: 9},
+			wantErr: nil,
+		},
+		{
+			name: "Declined insufficient credit limit",
+			repo: func(ctrl *gomock.Controller) Repository {
+				return mock_transaction.NewMockRepository(ctrl)
+			},
+			attemptRepo: func(ctrl *gomock.Controller) AttemptRepository {
+				attemptRepo := mock_transaction.NewMockAttemptRepository(ctrl)
+				attemptRepo.EXPECT().Save(gomock.Any(), &entity.TransactionAttempt{
+					AccountID:       1,
+					OperationTypeID: entity.OperationTypeCompraAVista,
+					Amount:          -5000,
+					Outcome:         entity.AttemptOutcomeDeclined,
+					DeclineReason:   "INSUFFICIENT_CREDIT_LIMIT",
+				}).Return(&entity.TransactionAttempt{ID: 1}, nil)
+
+				return attemptRepo
+			},
+			args:    args{operationTypeID: entity.OperationTypeCompraAVista, amount: -5000},
+			want:    nil,
+			wantErr: entity.ErrInsufficientCreditLimit,
+		},
+		{
+			name: "Declined invalid amount",
+			repo: func(ctrl *gomock.Controller) Repository {
+				return mock_transaction.NewMockRepository(ctrl)
+			},
+			attemptRepo: func(ctrl *gomock.Controller) AttemptRepository {
+				attemptRepo := mock_transaction.NewMockAttemptRepository(ctrl)
+				attemptRepo.EXPECT().Save(gomock.Any(), &entity.TransactionAttempt{
+					AccountID:       1,
+					OperationTypeID: entity.OperationTypePagamento,
+					Amount:          -10,
+					Outcome:         entity.AttemptOutcomeDeclined,
+					DeclineReason:   "INVALID_AMOUNT",
+				}).Return(&entity.TransactionAttempt{ID: 1}, nil)
+
+				return attemptRepo
+			},
+			args:    args{operationTypeID: entity.OperationTypePagamento, amount: -10},
+			want:    nil,
+			wantErr: entity.ErrInvalidAmount,
+		},
+		{
+			name: "Failed saving",
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+				repo.EXPECT().SaveDetailed(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+
+				return repo
+			},
+			attemptRepo: func(ctrl *gomock.Controller) AttemptRepository {
+				attemptRepo := mock_transaction.NewMockAttemptRepository(ctrl)
+				attemptRepo.EXPECT().Save(gomock.Any(), &entity.TransactionAttempt{
+					AccountID:       1,
+					OperationTypeID: entity.OperationTypeCompraAVista,
+					Amount:          -50,
+					Outcome:         entity.AttemptOutcomeFailed,
+					DeclineReason:   "INTERNAL_ERROR",
+				}).Return(&entity.TransactionAttempt{ID: 1}, nil)
+
+				return attemptRepo
+			},
+			args:    args{operationTypeID: entity.OperationTypeCompraAVista, amount: -50},
+			want:    nil,
+			wantErr: errors.New("Create: database error"),
+		},
+		{
+			name: "Error recording the attempt keeps the decline",
+			repo: func(ctrl *gomock.Controller) Repository {
+				return mock_transaction.NewMockRepository(ctrl)
+			},
+			attemptRepo: func(ctrl *gomock.Controller) AttemptRepository {
+				attemptRepo := mock_transaction.NewMockAttemptRepository(ctrl)
+				attemptRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+
+				return attemptRepo
+			},
+			args:    args{operationTypeID: entity.OperationTypeCompraAVista, amount: -5000},
+			want:    nil,
+			wantErr: entity.ErrInsufficientCreditLimit,
+		},
+		{
+			name: "Error recording the attempt keeps the transaction",
+			repo: func(ctrl *gomock.Controller) Repository {
+				repo := mock_transaction.NewMockRepository(ctrl)
+				repo.EXPECT().SaveDetailed(gomock.Any(), newTransaction(entity.OperationTypeCompraAVista, -50.0)).
+					Return(&entity.Transaction{ID: 9}, nil)
+
+				return repo
+			},
+			attemptRepo: func(ctrl *gomock.Controller) AttemptRepository {
+				attemptRepo := mock_transaction.NewMockAttemptRepository(ctrl)
+				attemptRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+
+				return attemptRepo
+			},
+			args:    args{operationTypeID: entity.OperationTypeCompraAVista, amount: -50},
+			want:    &entity.Transaction{ID: 9},
+			wantErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			accountSvc := mock_account.NewMockService(ctrl)
+			accountSvc.EXPECT().Get(gomock.Any(), 1).
+				Return(&entity.Account{ID: 1, AvailabelCreditLimit: 1000}, nil)
+			accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), 1, gomock.Any()).
+				Return(&entity.Account{ID: 1}, nil).AnyTimes()
+
+			opTypeSvc := mock_operationtype.NewMockService(ctrl)
+			opTypeSvc.EXPECT().Get(gomock.Any(), tc.args.operationTypeID).
+				Return(&entity.OperationType{ID: tc.args.operationTypeID}, nil)
+
+			s := NewService(tc.repo(ctrl), accountSvc, opTypeSvc, clock.NewFake(now), WithAttemptRepository(tc.attemptRepo(ctrl)))
+
+			got, err := s.Create(context.TODO(), entity.TransactionInput{
+				AccountID:       1,
+				OperationTypeID: tc.args.operationTypeID,
+				Amount:          tc.args.amount,
+			})
+			if (err != nil) != (tc.wantErr != nil) || (err != nil && err.Error() != tc.wantErr.Error()) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Create() got = %v, want %v, %v", got, tc.want, cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
+
+func Test_service_Create_metrics(t *testing.T) {
+	testCases := []struct {
+		name   string
+		amount float64
+		want   *entity.TransactionAttempt
+	}{
+		{
+			name:   "Approved",
+			amount: -50,
+			want: &entity.TransactionAttempt{
+				AccountID:       1,
+				OperationTypeID: entity.OperationTypeCompraAVista,
+				Amount:          -50,
+				Outcome:         entity.AttemptOutcomeApproved,
+				TransactionID:   9,
+			},
+		},
+		{
+			name:   "Declined",
+			amount: -5000,
+			want: &entity.TransactionAttempt{
+				AccountID:       1,
+				OperationTypeID: entity.OperationTypeCompraAVista,
+				Amount:          -5000,
+				Outcome:         entity.AttemptOutcomeDeclined,
+				DeclineReason:   "INSUFFICIENT_CREDIT_LIMIT",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_transaction.NewMockRepository(ctrl)
+			repo.EXPECT().SaveDetailed(gomock.Any(), newTransaction(entity.OperationTypeCompraAVista, tc.amount)).
+				Return(&entity.Transaction{ID: 9}, nil).AnyTimes()
+
+			accountSvc := mock_account.NewMockService(ctrl)
+			accountSvc.EXPECT().Get(gomock.Any(), 1).
+				Return(&entity.Account{ID: 1, AvailabelCreditLimit: 1000}, nil)
+			accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), 1, gomock.Any()).
+				Return(&entity.Account{ID: 1}, nil).AnyTimes()
+
+			opTypeSvc := mock_operationtype.NewMockService(ctrl)
+			opTypeSvc.EXPECT().Get(gomock.Any(), entity.OperationTypeCompraAVista).
+				Return(&entity.OperationType{ID: entity.OperationTypeCompraAVista}, nil)
+
+			metrics := mock_transaction.NewMockMetrics(ctrl)
+			metrics.EXPECT().ObserveAttempt(tc.want)
+
+			s := NewService(repo, accountSvc, opTypeSvc, clock.NewFake(now), WithMetrics(metrics))
+
+			_, _ = s.Create(context.TODO(), entity.TransactionInput{
+				AccountID:       1,
+				OperationTypeID: entity.OperationTypeCompraAVista,
+				Amount:          tc.amount,
+			})
+		})
+	}
+}
+
+func Test_service_Create_audit(t *testing.T) {
+	debit := &entity.Transaction{ID: 9, AccountID: 1, OperationTypeID: entity.OperationTypeSaque, Amount: -50, EventDate: now}
+	fee := &entity.Transaction{ID: 10, AccountID: 1, OperationTypeID: entity.OperationTypeTarifa, Amount: -5, ParentID: 9}
+
+	testCases := []struct {
+		name       string
+		errAudit   error
+		want       *entity.Transaction
+		wantErrMsg string
+	}{
+		{
+			name:     "Error audit keeps the transaction",
+			errAudit: errors.New("database error"),
+			want:     debit,
+		},
+		{
+			name: "Success records the debit and the fee",
+			want: debit,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_transaction.NewMockRepository(ctrl)
+			repo.EXPECT().SaveWithLinked(
+				gomock.Any(),
+				newTransaction(entity.OperationTypeSaque, -50),
+				[]*entity.Transaction{{OperationTypeID: entity.OperationTypeTarifa, Amount: -5}},
+			).Return(debit, []*entity.Transaction{fee}, nil)
+
+			accountSvc := mock_account.NewMockService(ctrl)
+			accountSvc.EXPECT().Get(gomock.Any(), 1).Return(&entity.Account{ID: 1, AvailabelCreditLimit: 1000}, nil)
+			accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), 1, 945.0).Return(&entity.Account{ID: 1}, nil)
+
+			opTypeSvc := mock_operationtype.NewMockService(ctrl)
+			opTypeSvc.EXPECT().Get(gomock.Any(), entity.OperationTypeSaque).
+				Return(&entity.OperationType{ID: entity.OperationTypeSaque, Rules: entity.OperationTypeRules{Fee: 5}}, nil)
+
+			auditSvc := mock_audit.NewMockService(ctrl)
+			auditSvc.EXPECT().Record(gomock.Any(), entity.AuditActionCreate, entity.AuditEntityTransaction, 9, nil, debit).
+				Return(tc.errAudit)
+
+			auditSvc.EXPECT().Record(gomock.Any(), entity.AuditActionCreate, entity.AuditEntityTransaction, 10, nil, fee).
+				Return(nil)
+
+			s := NewService(repo, accountSvc, opTypeSvc, clock.NewFake(now), WithAuditService(auditSvc))
+
+			got, err := s.Create(context.TODO(), entity.TransactionInput{
+				AccountID:       1,
+				OperationTypeID: entity.OperationTypeSaque,
+				Amount:          -50,
+			})
+			if (err != nil) != (tc.wantErrMsg != "") || (err != nil && err.Error() != tc.wantErrMsg) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tc.wantErrMsg)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Create() got = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func Test_service_Charge(t *testing.T) {
+	testCases := []struct {
+		name            string
+		svcArgs         func(ctrl *gomock.Controller) (Repository, account.Service)
+		operationTypeID int
+		want            *entity.Transaction
+		wantErr         bool
+	}{
+		{
+			name: "Error not a system operation type",
+			svcArgs: func(ctrl *gomock.Controller) (Repository, account.Service) {
+				return mock_transaction.NewMockRepository(ctrl), mock_account.NewMockService(ctrl)
+			},
+			operationTypeID: entity.OperationTypeCompraAVista,
+			want:            nil,
+			wantErr:         true,
+		},
+		{
+			name: "Error account not found",
+			svcArgs: func(ctrl *gomock.Controller) (Repository, account.Service) {
+				accountSvc := mock_account.NewMockService(ctrl)
+				accountSvc.EXPECT().Get(gomock.Any(), 1).Return(nil, entity.ErrNotFound)
+
+				return mock_transaction.NewMockRepository(ctrl), accountSvc
+			},
+			operationTypeID: entity.OperationTypeJuros,
+			want:            nil,
+			wantErr:         true,
+		},
+		{
+			name: "Error save and success rollback credit limit",
+			svcArgs: func(ctrl *gomock.Controller) (Repository, account.Service) {
+				repo := mock_transaction.NewMockRepository(ctrl)
+				accountSvc := mock_account.NewMockService(ctrl)
+
+				accountSvc.EXPECT().Get(gomock.Any(), 1).Return(&entity.Account{ID: 1, AvailabelCreditLimit: 3}, nil)
+				gomock.InOrder(
+					accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), 1, -2.0).Return(&entity.Account{ID: 1}, nil),
+					accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), 1, 3.0).Return(&entity.Account{ID: 1}, nil),
+				)
+				repo.EXPECT().SaveDetailed(gomock.Any(), newTransaction(entity.OperationTypeJuros, -5.0)).Return(nil, errors.New("database error"))
+
+				return repo, accountSvc
+			},
+			operationTypeID: entity.OperationTypeJuros,
+			want:            nil,
+			wantErr:         true,
+		},
+		{
+			name: "Success beyond the available credit limit",
+			svcArgs: func(ctrl *gomock.Controller) (Repository, account.Service) {
+				repo := mock_transaction.NewMockRepository(ctrl)
+				accountSvc := mock_account.NewMockService(ctrl)
+
+				accountSvc.EXPECT().Get(gomock.Any(), 1).Return(&entity.Account{ID: 1, AvailabelCreditLimit: 3}, nil)
+				accountSvc.EXPECT().UpdateCreditLimit(gomock.Any(), 1, -2.0).Return(&entity.Account{ID: 1}, nil)
+				repo.EXPECT().SaveDetailed(gomock.Any(), newTransaction(entity.OperationTypeJuros, -5.0)).
+					Return(&entity.Transaction{ID: 9, AccountID: 1, OperationTypeID: entity.OperationTypeJuros, Amount: -5}, nil)
+
+				return repo, accountSvc
+			},
+			operationTypeID: entity.OperationTypeJuros,
+			want:            &entity.Transaction{ID: 9, AccountID: 1, OperationTypeID: entity.OperationTypeJuros, Amount: -5},
+			wantErr:         false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, accountSvc := tc.svcArgs(ctrl)
+			s := NewService(repo, accountSvc, mock_operationtype.NewMockService(ctrl), clock.NewFake(now))
+
+			got, err := s.Charge(context.TODO(), 1, tc.operationTypeID, 5)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Charge() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Charge() got = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func Test_service_ListAttempts(t *testing.T) {
+	testCases := []struct {
+		name        string
+		attemptRepo func(ctrl *gomock.Controller) AttemptRepository
+		want        []*entity.TransactionAttempt
+		wantErr     bool
+	}{
+		{
+			name: "Without attempt repository",
+			attemptRepo: func(ctrl *gomock.Controller) AttemptRepository {
+				return nil
+			},
+			want:    []*entity.TransactionAttempt{},
+			wantErr: false,
+		},
+		{
+			name: "Error database",
+			attemptRepo: func(ctrl *gomock.Controller) AttemptRepository {
+				attemptRepo := mock_transaction.NewMockAttemptRepository(ctrl)
+				attemptRepo.EXPECT().ListByAccountID(gomock.Any(), 1, 50).Return(nil, errors.New("database error"))
+
+				return attemptRepo
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			attemptRepo: func(ctrl *gomock.Controller) AttemptRepository {
+				attemptRepo := mock_transaction.NewMockAttemptRepository(ctrl)
+				attemptRepo.EXPECT().ListByAccountID(gomock.Any(), 1, 50).
+					Return([]*entity.TransactionAttempt{{ID: 1, AccountID: 1, Outcome: entity.AttemptOutcomeApproved}}, nil)
+
+				return attemptRepo
+			},
+			want:    []*entity.TransactionAttempt{{ID: 1, AccountID: 1, Outcome: entity.AttemptOutcomeApproved}},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var options []Option
+			if attemptRepo := tc.attemptRepo(ctrl); attemptRepo != nil {
+				options = append(options, WithAttemptRepository(attemptRepo))
+			}
+
+			s := NewService(
+				mock_transaction.NewMockRepository(ctrl),
+				mock_account.NewMockService(ctrl),
+				mock_operationtype.NewMockService(ctrl),
+				clock.NewFake(now),
+				options...,
+			)
+
+			got, err := s.ListAttempts(context.TODO(), 1, 50)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ListAttempts() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("ListAttempts() got = %v, want %v, %v", got, tc.want, cmp.Diff(got, tc.want))
+			}
+		})
+	}
+}
+
+func Test_service_PurgeAttempts(t *testing.T) {
+	before := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		attemptRepo func(ctrl *gomock.Controller) AttemptRepository
+		want        int
+		wantErr     bool
+	}{
+		{
+			name: "Without attempt repository",
+			attemptRepo: func(ctrl *gomock.Controller) AttemptRepository {
+				return nil
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "Error database",
+			attemptRepo: func(ctrl *gomock.Controller) AttemptRepository {
+				attemptRepo := mock_transaction.NewMockAttemptRepository(ctrl)
+				attemptRepo.EXPECT().DeleteBefore(gomock.Any(), before).Return(0, errors.New("database error"))
+
+				return attemptRepo
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			attemptRepo: func(ctrl *gomock.Controller) AttemptRepository {
+				attemptRepo := mock_transaction.NewMockAttemptRepository(ctrl)
+				attemptRepo.EXPECT().DeleteBefore(gomock.Any(), before).Return(12, nil)
+
+				return attemptRepo
+			},
+			want:    12,
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			auditSvc := mock_audit.NewMockService(ctrl)
+			if tc.want > 0 {
+				auditSvc.EXPECT().Record(
+					gomock.Any(),
+					entity.AuditActionPurge,
+					entity.AuditEntityTransactionAttempt,
+					0,
+					nil,
+					struct {
+						Before  time.Time
+						Deleted int
+					}{Before: before, Deleted: tc.want},
+				).Return(nil)
+			}
+
+			options := []Option{WithAuditService(auditSvc)}
+			if attemptRepo := tc.attemptRepo(ctrl); attemptRepo != nil {
+				options = append(options, WithAttemptRepository(attemptRepo))
+			}
+
+			s := NewService(
+				mock_transaction.NewMockRepository(ctrl),
+				mock_account.NewMockService(ctrl),
+				mock_operationtype.NewMockService(ctrl),
+				clock.NewFake(now),
+				options...,
+			)
+
+			got, err := s.PurgeAttempts(context.TODO(), before)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("PurgeAttempts() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if got != tc.want {
+				t.Errorf("PurgeAttempts() got = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func Test_service_SummarizeAttempts(t *testing.T) {
+	from := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	summaries := []*entity.AttemptSummary{
+		{OperationTypeID: 1, Outcome: entity.AttemptOutcomeApproved, Count: 2, Amount: 30},
+	}
+
+	testCases := []struct {
+		name        string
+		attemptRepo func(ctrl *gomock.Controller) AttemptRepository
+		from, to    time.Time
+		want        []*entity.AttemptSummary
+		wantErr     bool
+	}{
+		{
+			name: "Without attempt repository",
+			attemptRepo: func(ctrl *gomock.Controller) AttemptRepository {
+				return nil
+			},
+			from:    from,
+			to:      to,
+			want:    []*entity.AttemptSummary{},
+			wantErr: false,
+		},
+		{
+			name: "Error invalid period",
+			attemptRepo: func(ctrl *gomock.Controller) AttemptRepository {
+				return mock_transaction.NewMockAttemptRepository(ctrl)
+			},
+			from:    to,
+			to:      from,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Error database",
+			attemptRepo: func(ctrl *gomock.Controller) AttemptRepository {
+				attemptRepo := mock_transaction.NewMockAttemptRepository(ctrl)
+				attemptRepo.EXPECT().Summarize(gomock.Any(), from, to).Return(nil, errors.New("database error"))
+
+				return attemptRepo
+			},
+			from:    from,
+			to:      to,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			attemptRepo: func(ctrl *gomock.Controller) AttemptRepository {
+				attemptRepo := mock_transaction.NewMockAttemptRepository(ctrl)
+				attemptRepo.EXPECT().Summarize(gomock.Any(), from, to).Return(summaries, nil)
+
+				return attemptRepo
+			},
+			from:    from,
+			to:      to,
+			want:    summaries,
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var options []Option
+			if attemptRepo := tc.attemptRepo(ctrl); attemptRepo != nil {
+				options = append(options, WithAttemptRepository(attemptRepo))
+			}
+
+			s := NewService(
+				mock_transaction.NewMockRepository(ctrl),
+				mock_account.NewMockService(ctrl),
+				mock_operationtype.NewMockService(ctrl),
+				clock.NewFake(now),
+				options...,
+			)
+
+			got, err := s.SummarizeAttempts(context.TODO(), tc.from, tc.to)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("SummarizeAttempts() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("SummarizeAttempts() got = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
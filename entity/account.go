@@ -1,7 +1,21 @@
 package entity
 
+import "time"
+
 type Account struct {
 	ID                   int
 	DocumentNumber       string
 	AvailabelCreditLimit float64
+	// CustomerID is the holder of the account, zero on the accounts created without customer
+	CustomerID int
+	CreatedAt  time.Time
+}
+
+// CreditLimitAdjustment is a change of the available credit limit made outside the transactions, Amount is the
+// difference to the previous limit
+type CreditLimitAdjustment struct {
+	ID        int
+	AccountID int
+	Amount    float64
+	CreatedAt time.Time
 }
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate            AuditAction = "create"
+	AuditActionUpdateCreditLimit AuditAction = "update_credit_limit"
+	AuditActionUpdateRules       AuditAction = "update_rules"
+	AuditActionPurge             AuditAction = "purge"
+)
+
+type AuditEntity string
+
+const (
+	AuditEntityAccount            AuditEntity = "account"
+	AuditEntityOperationType      AuditEntity = "operation_type"
+	AuditEntityTransaction        AuditEntity = "transaction"
+	AuditEntityTransactionAttempt AuditEntity = "transaction_attempt"
+)
+
+// AuditEntry is a state change made on an entity, chained to the previous entry by its hash so any entry changed or
+// removed afterwards breaks the chain
+type AuditEntry struct {
+	ID        int
+	Actor     string
+	RequestID string
+	Action    AuditAction
+	Entity    AuditEntity
+	EntityID  int
+	// Before and After are JSON snapshots of the entity, empty when it did not exist before or after the action
+	Before    string
+	After     string
+	PrevHash  string
+	Hash      string
+	CreatedAt time.Time
+}
+
+// Seal chain the entry to the previous one of the trail
+func (e *AuditEntry) Seal(prevHash string) {
+	e.PrevHash = prevHash
+	e.Hash = e.ComputeHash()
+}
+
+// ComputeHash return the SHA-256 of every field but the ID and the hash itself, a stored entry whose hash differs was
+// tampered with
+func (e *AuditEntry) ComputeHash() string {
+	content, _ := json.Marshal([]interface{}{
+		e.PrevHash,
+		e.Actor,
+		e.RequestID,
+		e.Action,
+		e.Entity,
+		e.EntityID,
+		e.Before,
+		e.After,
+		e.CreatedAt.UTC().Format(time.RFC3339),
+	})
+
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify check the entry follows the one with prevHash and wasn't changed since it was sealed
+func (e *AuditEntry) Verify(prevHash string) bool {
+	return e.PrevHash == prevHash && e.ComputeHash() == e.Hash
+}
+
+// AuditVerification is the outcome of walking the audit chain, BrokenEntryID is the first entry changed or not linked
+// to the previous one, 0 when the chain is intact
+type AuditVerification struct {
+	Entries       int
+	BrokenEntryID int
+}
+
+// Valid check no entry of the chain was changed or removed
+func (v *AuditVerification) Valid() bool {
+	return v.BrokenEntryID == 0
+}
@@ -0,0 +1,54 @@
+package entity
+
+import "time"
+
+type CardType string
+
+const (
+	CardTypeVirtual  CardType = "virtual"
+	CardTypePhysical CardType = "physical"
+)
+
+type CardStatus string
+
+const (
+	CardStatusActive   CardStatus = "active"
+	CardStatusBlocked  CardStatus = "blocked"
+	CardStatusReplaced CardStatus = "replaced"
+)
+
+// Card of an account, the full PAN is only known by the card network and is never stored
+type Card struct {
+	ID        int
+	AccountID int
+	Type      CardType
+	// MaskedPAN keeps the first six and the last four digits of the PAN
+	MaskedPAN string
+	// Token identifies the card in place of the PAN
+	Token       string
+	ExpiryMonth int
+	ExpiryYear  int
+	Status      CardStatus
+	// Limit caps the debits made with the card on a UTC month, zero is unlimited
+	Limit float64
+	// ReplacesID is the card replaced by this one, zero on the cards issued without replacing another
+	ReplacesID int
+	CreatedAt  time.Time
+}
+
+// IsValidCardType check if the card type can be issued
+func IsValidCardType(cardType CardType) bool {
+	return cardType == CardTypeVirtual || cardType == CardTypePhysical
+}
+
+// Expired check if the card is past the last day of its expiry month
+func (c *Card) Expired(now time.Time) bool {
+	firstDayAfter := time.Date(c.ExpiryYear, time.Month(c.ExpiryMonth)+1, 1, 0, 0, 0, 0, time.UTC)
+
+	return !now.Before(firstDayAfter)
+}
+
+// Usable check if transactions can be made with the card
+func (c *Card) Usable(now time.Time) bool {
+	return c.Status == CardStatusActive && !c.Expired(now)
+}
@@ -0,0 +1,44 @@
+package entity
+
+import "time"
+
+type ChargeType string
+
+const (
+	ChargeTypeInterest ChargeType = "interest"
+	ChargeTypeLateFee  ChargeType = "late_fee"
+	ChargeTypePenalty  ChargeType = "penalty"
+)
+
+// OperationTypeID is the operation type of the transactions posted by the charge
+func (t ChargeType) OperationTypeID() int {
+	switch t {
+	case ChargeTypeLateFee:
+		return OperationTypeTarifaAtraso
+	case ChargeTypePenalty:
+		return OperationTypeMulta
+	default:
+		return OperationTypeJuros
+	}
+}
+
+// Charge is an interest or fee posted on the account for a day, an account is charged once a day by type
+type Charge struct {
+	ID        int
+	AccountID int
+	Type      ChargeType
+	// Date is the day charged, at midnight UTC
+	Date          time.Time
+	Amount        float64
+	TransactionID int
+	CreatedAt     time.Time
+}
+
+// ChargeRun is the outcome of charging every account for a day
+type ChargeRun struct {
+	Date     time.Time
+	Accounts int
+	Charges  int
+	Amount   float64
+	Failed   int
+}
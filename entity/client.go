@@ -0,0 +1,84 @@
+package entity
+
+import "strings"
+
+type Role string
+
+const (
+	RoleCustomer   Role = "customer"
+	RoleBackOffice Role = "backoffice"
+	RoleRisk       Role = "risk"
+)
+
+type Scope string
+
+const (
+	ScopeAccountsRead      Scope = "accounts:read"
+	ScopeAccountsWrite     Scope = "accounts:write"
+	ScopeCustomersRead     Scope = "customers:read"
+	ScopeCustomersWrite    Scope = "customers:write"
+	ScopeCardsRead         Scope = "cards:read"
+	ScopeCardsWrite        Scope = "cards:write"
+	ScopeTransactionsWrite Scope = "transactions:write"
+	ScopeLimitsAdmin       Scope = "limits:admin"
+	ScopeSchedulesAdmin    Scope = "schedules:admin"
+	ScopeAuditRead         Scope = "audit:read"
+	ScopeTimeTravelAdmin   Scope = "time_travel:admin"
+)
+
+// Client is a registered API client allowed to call the service
+type Client struct {
+	ID        int
+	Name      string
+	Role      Role
+	Scopes    []Scope
+	AccountID int
+}
+
+// HasScope check if the client was granted the given scope
+func (c *Client) HasScope(scope Scope) bool {
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanAccessAccount check if the client owns the account, only customers are restricted to their own account
+func (c *Client) CanAccessAccount(accountID int) bool {
+	if c.Role != RoleCustomer {
+		return true
+	}
+
+	return c.AccountID == accountID
+}
+
+// CanAccessCustomers check if the client may read and change the customers, the customer clients are linked to an
+// account and not to a customer so they can't
+func (c *Client) CanAccessCustomers() bool {
+	return c.Role != RoleCustomer
+}
+
+// CanAccessJob check if the client enqueued the job, only customers are restricted to their own jobs
+func (c *Client) CanAccessJob(job *Job) bool {
+	if c.Role != RoleCustomer {
+		return true
+	}
+
+	return c.ID == job.ClientID
+}
+
+// ParseScopes split the comma separated scopes stored with the client
+func ParseScopes(scopes string) []Scope {
+	var parsed []Scope
+	for _, scope := range strings.Split(scopes, ",") {
+		scope = strings.TrimSpace(scope)
+		if scope != "" {
+			parsed = append(parsed, Scope(scope))
+		}
+	}
+
+	return parsed
+}
@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+// Customer is the holder of accounts, a person with name and birth date or a company with its company name
+type Customer struct {
+	ID   int
+	Name string
+	// BirthDate of the person, formatted as 2006-01-02
+	BirthDate   string
+	CompanyName string
+	Email       string
+	Phone       string
+	Address     Address
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type Address struct {
+	Street     string
+	Number     string
+	Complement string
+	City       string
+	State      string
+	PostalCode string
+	Country    string
+}
+
+// IsCompany check if the customer is a company
+func (c *Customer) IsCompany() bool {
+	return c.CompanyName != ""
+}
+
+// Validate check the customer is either a person with name and birth date or a company, with an email
+func (c *Customer) Validate() error {
+	person := c.Name != "" && c.BirthDate != ""
+
+	if person == c.IsCompany() || c.Email == "" {
+		return ErrInvalidCustomer
+	}
+
+	if person {
+		if _, err := time.Parse("2006-01-02", c.BirthDate); err != nil {
+			return ErrInvalidCustomer
+		}
+	}
+
+	return nil
+}
@@ -5,3 +5,21 @@ import "errors"
 var ErrNotFound = errors.New("not found")
 var ErrInvalidAmount = errors.New("invalid amount")
 var ErrInsufficientCreditLimit = errors.New("available credit limit is insufficient")
+var ErrUnauthorized = errors.New("unauthorized")
+var ErrVelocityLimitExceeded = errors.New("account velocity limit exceeded")
+var ErrTransactionDeclined = errors.New("transaction declined")
+var ErrInvalidPeriod = errors.New("invalid period")
+var ErrLocked = errors.New("locked by another instance")
+var ErrAlreadyRunning = errors.New("already running")
+var ErrSystemOperationType = errors.New("operation type reserved to the system")
+var ErrAlreadyCharged = errors.New("already charged")
+var ErrInvalidRules = errors.New("invalid operation type rules")
+var ErrOperationLimitExceeded = errors.New("operation type limit exceeded")
+var ErrDailyLimitExceeded = errors.New("operation type daily limit exceeded")
+var ErrCurrencyNotSupported = errors.New("currency not supported")
+var ErrInvalidCustomer = errors.New("invalid customer")
+var ErrInvalidCard = errors.New("invalid card")
+var ErrCardNotActive = errors.New("card is not active")
+var ErrCardNotAllowed = errors.New("card not allowed on the transaction")
+var ErrCardLimitExceeded = errors.New("card limit exceeded")
+var ErrInvalidEventDate = errors.New("event date out of the allowed window")
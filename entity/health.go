@@ -0,0 +1,14 @@
+package entity
+
+type HealthStatus string
+
+const (
+	HealthStatusUp   HealthStatus = "up"
+	HealthStatusDown HealthStatus = "down"
+)
+
+type HealthCheck struct {
+	Name   string
+	Status HealthStatus
+	Detail string
+}
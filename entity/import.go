@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// ImportRow is a transaction read from an import file, Errors holds the parse and validation failures
+type ImportRow struct {
+	Line            int
+	AccountID       int
+	OperationTypeID int
+	Amount          float64
+	Currency        string
+	CardID          int
+	EventDate       time.Time
+	Errors          []string
+}
+
+// ImportOutcome is the attempt outcome of the row, or invalid when it was not processed
+type ImportOutcome string
+
+const ImportOutcomeInvalid ImportOutcome = "invalid"
+
+// ImportResult of a row of an import file
+type ImportResult struct {
+	Line          int
+	AccountID     int
+	Outcome       ImportOutcome
+	TransactionID int
+	Reason        string
+	Errors        []string
+}
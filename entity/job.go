@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+type JobStatus string
+
+const (
+	JobStatusQueued    JobStatus = "queued"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed"
+)
+
+// Job is an operation run in background by the workers, Payload and Result are JSON documents
+type Job struct {
+	ID          int
+	Type        string
+	Payload     []byte
+	Status      JobStatus
+	Progress    int
+	Attempts    int
+	MaxAttempts int
+	Result      []byte
+	Error       string
+	ClientID    int
+	// Actor and RequestID are who enqueued the job and the request they came from, its changes are audited as theirs
+	Actor     string
+	RequestID string
+	// RunAt is when a queued job is due or, while running, when its lease expires and another worker may claim it
+	RunAt     time.Time
+	CreatedAt time.Time
+}
+
+// Finished check if the job reached a final status
+func (j *Job) Finished() bool {
+	return j.Status == JobStatusSucceeded || j.Status == JobStatusFailed
+}
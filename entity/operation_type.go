@@ -1,6 +1,59 @@
 package entity
 
+import "math"
+
+const (
+	OperationTypeCompraAVista    = 1
+	OperationTypeCompraParcelada = 2
+	OperationTypeSaque           = 3
+	OperationTypePagamento       = 4
+	OperationTypeJuros           = 5
+	OperationTypeTarifaAtraso    = 6
+	OperationTypeMulta           = 7
+	OperationTypeTarifa          = 8
+	OperationTypeIOF             = 9
+)
+
 type OperationType struct {
 	ID          int
 	Description string
+	Rules       OperationTypeRules
+}
+
+// OperationTypeRules are the limits and the fee of the debits of an operation type, zero values disable each rule
+type OperationTypeRules struct {
+	// LimitRate is the share of the credit limit the outstanding debits of the operation type may use, 0.3 is 30%
+	LimitRate float64
+	// Fee is the fixed fee of each debit, posted as a transaction linked to the debit
+	Fee float64
+	// FeeRate is the fee on the amount of the debit, added to the fixed fee
+	FeeRate float64
+	// DailyMaxCount is the max number of debits of an account on a UTC day
+	DailyMaxCount int
+	// DailyMaxAmount is the max amount debited from an account on a UTC day
+	DailyMaxAmount float64
+}
+
+// FeeOf the debit of the given amount
+func (r OperationTypeRules) FeeOf(amount float64) float64 {
+	return math.Round((r.Fee+r.FeeRate*math.Abs(amount))*100) / 100
+}
+
+// Validate the rules, rates are between 0 and 1 and the other values are not negative
+func (r OperationTypeRules) Validate() error {
+	if r.LimitRate < 0 || r.LimitRate > 1 || r.FeeRate < 0 || r.FeeRate > 1 {
+		return ErrInvalidRules
+	}
+
+	if r.Fee < 0 || r.DailyMaxCount < 0 || r.DailyMaxAmount < 0 {
+		return ErrInvalidRules
+	}
+
+	return nil
+}
+
+// IsSystemOperationType check if the operation type is only posted by the service itself, like the charges and fees
+func IsSystemOperationType(id int) bool {
+	return id == OperationTypeJuros || id == OperationTypeTarifaAtraso || id == OperationTypeMulta ||
+		id == OperationTypeTarifa || id == OperationTypeIOF
 }
@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// LimitCheckpoint is the available credit limit of the account after the transaction, as seen by the last
+// reconciliation
+type LimitCheckpoint struct {
+	AccountID            int
+	AvailableCreditLimit float64
+	TransactionID        int
+	UpdatedAt            time.Time
+}
+
+// LimitDrift is an account whose available credit limit differs from its checkpoint plus the following transactions
+type LimitDrift struct {
+	AccountID int
+	Expected  float64
+	Actual    float64
+}
+
+// Reconciliation is the outcome of checking the available credit limit of every account
+type Reconciliation struct {
+	Accounts  int
+	Baselined int
+	Skipped   int
+	Drifts    []LimitDrift
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+type RiskOutcome string
+
+const (
+	RiskOutcomeApprove RiskOutcome = "approve"
+	RiskOutcomeReview  RiskOutcome = "review"
+	RiskOutcomeDecline RiskOutcome = "decline"
+)
+
+// Severity orders the outcomes, the most severe outcome of the rules is the final decision
+func (o RiskOutcome) Severity() int {
+	switch o {
+	case RiskOutcomeDecline:
+		return 2
+	case RiskOutcomeReview:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// RiskDecision is the result of the risk rules evaluated before authorizing a transaction
+type RiskDecision struct {
+	ID              int
+	TransactionID   int
+	AccountID       int
+	OperationTypeID int
+	Amount          float64
+	Outcome         RiskOutcome
+	ReasonCodes     []string
+	CreatedAt       time.Time
+}
+
+// DeclineError is returned when the risk rules decline a transaction
+type DeclineError struct {
+	ReasonCodes []string
+}
+
+func (e *DeclineError) Error() string {
+	return ErrTransactionDeclined.Error() + ": " + strings.Join(e.ReasonCodes, ", ")
+}
+
+func (e *DeclineError) Is(target error) bool {
+	return target == ErrTransactionDeclined
+}
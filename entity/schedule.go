@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// Schedule is a task run periodically by the scheduler, each run is a job of the task type
+type Schedule struct {
+	Name string
+	// Spec is the cron expression of the runs, empty when the task only runs on demand
+	Spec      string
+	NextRunAt time.Time
+	LastRun   *Job
+}
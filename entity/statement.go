@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// Statement of an account on the period from (inclusive) to (exclusive)
+type Statement struct {
+	Account                     *Account
+	From                        time.Time
+	To                          time.Time
+	OpeningAvailableCreditLimit float64
+	ClosingAvailableCreditLimit float64
+	GeneratedAt                 time.Time
+}
+
+// StatementLine is a transaction or a limit adjustment of the statement with the available credit limit after it,
+// only one of Transaction and Adjustment is set
+type StatementLine struct {
+	Transaction          *Transaction
+	Adjustment           *CreditLimitAdjustment
+	OperationType        string
+	Amount               float64
+	AvailableCreditLimit float64
+}
+
+// Date of the line, the event date of the transaction or the time of the adjustment
+func (l *StatementLine) Date() time.Time {
+	if l.Adjustment != nil {
+		return l.Adjustment.CreatedAt
+	}
+
+	return l.Transaction.EventDate
+}
@@ -1,11 +1,54 @@
 package entity
 
-import "time"
+import (
+	"math"
+	"time"
+)
 
 type Transaction struct {
 	ID              int
 	AccountID       int
 	OperationTypeID int
 	Amount          float64
-	EventDate       time.Time
+	// EventDate is when the transaction happened, the creation time unless it was backdated
+	EventDate time.Time
+	CreatedAt time.Time
+	// ParentID is the debit a fee transaction was charged for, zero on the other transactions
+	ParentID int
+	// Exchange is the original amount of a transaction made in a foreign currency, nil on the other transactions
+	Exchange *Exchange
+	// CardID is the card the transaction was made with, zero on the transactions made without a card
+	CardID int
+}
+
+// TransactionInput is the transaction requested by the client
+type TransactionInput struct {
+	AccountID       int
+	OperationTypeID int
+	// Amount is in the currency of the input, or in the account currency when the currency is empty
+	Amount   float64
+	Currency string
+	// CardID is the card of the account used on a debit, optional
+	CardID int
+	// EventDate backdate the transaction, zero is the creation time
+	EventDate time.Time
+}
+
+// Exchange of a foreign currency transaction, the amount of the transaction is the converted amount
+type Exchange struct {
+	// Currency is the ISO 4217 code of the original amount
+	Currency       string
+	OriginalAmount float64
+	// Rate is the price of a unit of the currency in the account currency, spread included
+	Rate float64
+}
+
+// LimitChange is the effect of the transaction on the available credit limit, payments increase it and the other
+// operation types decrease it
+func (t *Transaction) LimitChange() float64 {
+	if t.OperationTypeID == OperationTypePagamento {
+		return math.Abs(t.Amount)
+	}
+
+	return -math.Abs(t.Amount)
 }
@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+type AttemptOutcome string
+
+const (
+	AttemptOutcomeApproved AttemptOutcome = "approved"
+	AttemptOutcomeDeclined AttemptOutcome = "declined"
+	AttemptOutcomeFailed   AttemptOutcome = "failed"
+)
+
+// TransactionAttempt is every request to create a transaction, approved or not
+type TransactionAttempt struct {
+	ID              int
+	AccountID       int
+	OperationTypeID int
+	Amount          float64
+	Outcome         AttemptOutcome
+	DeclineReason   string
+	TransactionID   int
+	CreatedAt       time.Time
+}
+
+// AttemptSummary is the number and total amount of the attempts of an operation type with an outcome
+type AttemptSummary struct {
+	OperationTypeID int
+	Outcome         AttemptOutcome
+	Count           int
+	Amount          float64
+}
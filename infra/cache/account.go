@@ -0,0 +1,131 @@
+package cache
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/account"
+	"github.com/brunomdev/digital-account/entity"
+	"sync/atomic"
+	"time"
+)
+
+const accountCache = "account"
+
+type accountRepository struct {
+	repo    account.Repository
+	entries *lru
+	// updates counts the updates, a read that raced one is not cached since it may have read the account before it
+	updates uint64
+	options
+}
+
+// NewAccountRepository cache up to size accounts for the ttl. Update invalidates the account, keep the ttl short since
+// other instances may change it too. Only serve pure reads from it, the changes derived from the balance must read the
+// stored account, see Uncached
+func NewAccountRepository(repo account.Repository, ttl time.Duration, size int, opts ...Option) account.Repository {
+	return &accountRepository{
+		repo:    repo,
+		entries: newLRU(size, ttl),
+		options: newOptions(opts),
+	}
+}
+
+func (r *accountRepository) Save(
+	ctx context.Context,
+	docNumber string,
+	availableCreditLimit float64,
+	createdAt time.Time,
+) (*entity.Account, error) {
+	return r.repo.Save(ctx, docNumber, availableCreditLimit, createdAt)
+}
+
+func (r *accountRepository) SaveForCustomer(
+	ctx context.Context,
+	customerID int,
+	docNumber string,
+	availableCreditLimit float64,
+	createdAt time.Time,
+) (*entity.Account, error) {
+	return r.repo.SaveForCustomer(ctx, customerID, docNumber, availableCreditLimit, createdAt)
+}
+
+func (r *accountRepository) GetByID(ctx context.Context, id int) (*entity.Account, error) {
+	if cached, ok := r.entries.get(id); ok {
+		r.observe(accountCache, true)
+
+		acc := cached.(entity.Account)
+		return &acc, nil
+	}
+
+	r.observe(accountCache, false)
+
+	updates := atomic.LoadUint64(&r.updates)
+
+	acc, err := r.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if atomic.LoadUint64(&r.updates) == updates {
+		r.entries.set(id, *acc)
+	}
+
+	return acc, nil
+}
+
+func (r *accountRepository) Update(ctx context.Context, account *entity.Account) (*entity.Account, error) {
+	defer func() {
+		atomic.AddUint64(&r.updates, 1)
+		r.entries.remove(account.ID)
+	}()
+
+	return r.repo.Update(ctx, account)
+}
+
+func (r *accountRepository) SetCreditLimit(
+	ctx context.Context,
+	id int,
+	availableCreditLimit float64,
+	at time.Time,
+) (*entity.Account, error) {
+	defer func() {
+		atomic.AddUint64(&r.updates, 1)
+		r.entries.remove(id)
+	}()
+
+	return r.repo.SetCreditLimit(ctx, id, availableCreditLimit, at)
+}
+
+func (r *accountRepository) SumAdjustmentsSince(ctx context.Context, accountID int, since time.Time) (float64, error) {
+	return r.repo.SumAdjustmentsSince(ctx, accountID, since)
+}
+
+func (r *accountRepository) ListAdjustments(
+	ctx context.Context,
+	accountID int,
+	from, to time.Time,
+) ([]*entity.CreditLimitAdjustment, error) {
+	return r.repo.ListAdjustments(ctx, accountID, from, to)
+}
+
+func (r *accountRepository) List(ctx context.Context, afterID, limit int) ([]*entity.Account, error) {
+	return r.repo.List(ctx, afterID, limit)
+}
+
+// uncachedAccountRepository read the stored accounts, still invalidating the cached ones on the updates
+type uncachedAccountRepository struct {
+	*accountRepository
+}
+
+// Uncached returns the repository reading the stored accounts of a cached one, for the debits and the limit changes.
+// The updates made through it still invalidate the cached accounts. Any other repository is returned as is
+func Uncached(repo account.Repository) account.Repository {
+	if cached, ok := repo.(*accountRepository); ok {
+		return uncachedAccountRepository{cached}
+	}
+
+	return repo
+}
+
+func (r uncachedAccountRepository) GetByID(ctx context.Context, id int) (*entity.Account, error) {
+	return r.repo.GetByID(ctx, id)
+}
@@ -0,0 +1,120 @@
+package cache
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/account/mock_account"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_accountRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	acc := &entity.Account{ID: 1, DocumentNumber: "12345678900", AvailabelCreditLimit: 100}
+	updated := &entity.Account{ID: 1, DocumentNumber: "12345678900", AvailabelCreditLimit: 50}
+
+	repo := mock_account.NewMockRepository(ctrl)
+	gomock.InOrder(
+		repo.EXPECT().GetByID(gomock.Any(), 1).Return(acc, nil),
+		repo.EXPECT().Update(gomock.Any(), updated).Return(updated, nil),
+		repo.EXPECT().GetByID(gomock.Any(), 1).Return(updated, nil),
+	)
+
+	metrics := fakeMetrics{}
+	cached := NewAccountRepository(repo, time.Minute, 10, WithMetrics(metrics))
+
+	got, err := cached.GetByID(context.TODO(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, acc, got)
+
+	// the cached account can't be changed through the returned pointer
+	got.AvailabelCreditLimit = 0
+
+	got, err = cached.GetByID(context.TODO(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, got.AvailabelCreditLimit)
+
+	_, err = cached.Update(context.TODO(), updated)
+	assert.NoError(t, err)
+
+	got, err = cached.GetByID(context.TODO(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, updated, got)
+
+	assert.Equal(t, fakeMetrics{"account_hit": 1, "account_miss": 2}, metrics)
+}
+
+func TestUncached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	acc := &entity.Account{ID: 1, DocumentNumber: "12345678900", AvailabelCreditLimit: 100}
+	updated := &entity.Account{ID: 1, DocumentNumber: "12345678900", AvailabelCreditLimit: 50}
+
+	repo := mock_account.NewMockRepository(ctrl)
+	gomock.InOrder(
+		repo.EXPECT().GetByID(gomock.Any(), 1).Return(acc, nil),
+		repo.EXPECT().GetByID(gomock.Any(), 1).Return(acc, nil),
+		repo.EXPECT().Update(gomock.Any(), updated).Return(updated, nil),
+		repo.EXPECT().GetByID(gomock.Any(), 1).Return(updated, nil),
+	)
+
+	metrics := fakeMetrics{}
+	cached := NewAccountRepository(repo, time.Minute, 10, WithMetrics(metrics))
+	uncached := Uncached(cached)
+
+	got, err := cached.GetByID(context.TODO(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, acc, got)
+
+	// the stored account is read even when it is cached
+	got, err = uncached.GetByID(context.TODO(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, acc, got)
+
+	// and its updates invalidate the cached account
+	_, err = uncached.Update(context.TODO(), updated)
+	assert.NoError(t, err)
+
+	got, err = cached.GetByID(context.TODO(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, updated, got)
+
+	assert.Equal(t, fakeMetrics{"account_miss": 2}, metrics)
+	assert.Equal(t, repo, Uncached(repo))
+}
+
+func Test_accountRepository_GetByIDRacingUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stale := &entity.Account{ID: 1, DocumentNumber: "12345678900", AvailabelCreditLimit: 100}
+	updated := &entity.Account{ID: 1, DocumentNumber: "12345678900", AvailabelCreditLimit: 50}
+
+	repo := mock_account.NewMockRepository(ctrl)
+	cached := NewAccountRepository(repo, time.Minute, 10)
+
+	gomock.InOrder(
+		// the update is done after the stored account was read, before the read is cached
+		repo.EXPECT().GetByID(gomock.Any(), 1).DoAndReturn(func(ctx context.Context, _ int) (*entity.Account, error) {
+			_, err := cached.Update(ctx, updated)
+			assert.NoError(t, err)
+
+			return stale, nil
+		}),
+		repo.EXPECT().Update(gomock.Any(), updated).Return(updated, nil),
+		repo.EXPECT().GetByID(gomock.Any(), 1).Return(updated, nil),
+	)
+
+	got, err := cached.GetByID(context.TODO(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, stale, got)
+
+	got, err = cached.GetByID(context.TODO(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, updated, got)
+}
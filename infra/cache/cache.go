@@ -0,0 +1,43 @@
+// Package cache decorates the repositories with a read-through in-process cache.
+package cache
+
+// Metrics count the cache lookups of each repository
+type Metrics interface {
+	Hit(cache string)
+	Miss(cache string)
+}
+
+type Option func(o *options)
+
+type options struct {
+	metrics Metrics
+}
+
+// WithMetrics observe the hits and misses of the cache
+func WithMetrics(metrics Metrics) Option {
+	return func(o *options) {
+		o.metrics = metrics
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+func (o options) observe(cache string, hit bool) {
+	if o.metrics == nil {
+		return
+	}
+
+	if hit {
+		o.metrics.Hit(cache)
+		return
+	}
+
+	o.metrics.Miss(cache)
+}
@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_lru(t *testing.T) {
+	now := time.Date(2022, 3, 24, 10, 0, 0, 0, time.UTC)
+
+	c := newLRU(2, time.Minute)
+	c.now = func() time.Time {
+		return now
+	}
+
+	c.set(1, "one")
+	c.set(2, "two")
+
+	value, ok := c.get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "one", value)
+
+	// 2 is the least recently used
+	c.set(3, "three")
+
+	_, ok = c.get(2)
+	assert.False(t, ok, "evicted")
+
+	value, ok = c.get(3)
+	assert.True(t, ok)
+	assert.Equal(t, "three", value)
+
+	c.remove(3)
+
+	_, ok = c.get(3)
+	assert.False(t, ok, "removed")
+
+	now = now.Add(time.Minute)
+
+	_, ok = c.get(1)
+	assert.False(t, ok, "expired")
+	assert.Equal(t, 0, c.order.Len())
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/operationtype"
+	"github.com/brunomdev/digital-account/entity"
+	"time"
+)
+
+const operationTypeCache = "operation_type"
+
+type operationTypeRepository struct {
+	repo    operationtype.Repository
+	entries *lru
+	options
+}
+
+// NewOperationTypeRepository cache up to size operation types for the ttl. The rules updated through the instance are
+// evicted right away, the other instances see them once their entry expires.
+func NewOperationTypeRepository(
+	repo operationtype.Repository,
+	ttl time.Duration,
+	size int,
+	opts ...Option,
+) operationtype.Repository {
+	return &operationTypeRepository{
+		repo:    repo,
+		entries: newLRU(size, ttl),
+		options: newOptions(opts),
+	}
+}
+
+func (r *operationTypeRepository) GetByID(ctx context.Context, id int) (*entity.OperationType, error) {
+	if cached, ok := r.entries.get(id); ok {
+		r.observe(operationTypeCache, true)
+
+		opType := cached.(entity.OperationType)
+		return &opType, nil
+	}
+
+	r.observe(operationTypeCache, false)
+
+	opType, err := r.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.entries.set(id, *opType)
+
+	return opType, nil
+}
+
+func (r *operationTypeRepository) UpdateRules(ctx context.Context, id int, rules entity.OperationTypeRules) error {
+	err := r.repo.UpdateRules(ctx, id, rules)
+	if err != nil {
+		return err
+	}
+
+	r.entries.remove(id)
+
+	return nil
+}
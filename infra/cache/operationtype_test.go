@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"github.com/brunomdev/digital-account/domain/operationtype/mock_operationtype"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type fakeMetrics map[string]int
+
+func (m fakeMetrics) Hit(cache string) {
+	m[cache+"_hit"]++
+}
+
+func (m fakeMetrics) Miss(cache string) {
+	m[cache+"_miss"]++
+}
+
+func Test_operationTypeRepository_GetByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opType := &entity.OperationType{ID: 1, Description: "COMPRA A VISTA"}
+
+	repo := mock_operationtype.NewMockRepository(ctrl)
+	repo.EXPECT().GetByID(gomock.Any(), 1).Return(opType, nil).Times(1)
+	repo.EXPECT().GetByID(gomock.Any(), 99).Return(nil, entity.ErrNotFound).Times(2)
+
+	metrics := fakeMetrics{}
+	cached := NewOperationTypeRepository(repo, time.Minute, 10, WithMetrics(metrics))
+
+	for i := 0; i < 3; i++ {
+		got, err := cached.GetByID(context.TODO(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, opType, got)
+	}
+
+	// errors are not cached
+	for i := 0; i < 2; i++ {
+		_, err := cached.GetByID(context.TODO(), 99)
+		assert.True(t, errors.Is(err, entity.ErrNotFound))
+	}
+
+	assert.Equal(t, fakeMetrics{"operation_type_hit": 2, "operation_type_miss": 3}, metrics)
+}
+
+func Test_operationTypeRepository_UpdateRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rules := entity.OperationTypeRules{LimitRate: 0.3, Fee: 5}
+
+	repo := mock_operationtype.NewMockRepository(ctrl)
+	gomock.InOrder(
+		repo.EXPECT().GetByID(gomock.Any(), 3).Return(&entity.OperationType{ID: 3, Description: "SAQUE"}, nil),
+		repo.EXPECT().UpdateRules(gomock.Any(), 3, rules).Return(nil),
+		repo.EXPECT().GetByID(gomock.Any(), 3).Return(&entity.OperationType{ID: 3, Description: "SAQUE", Rules: rules}, nil),
+	)
+
+	cached := NewOperationTypeRepository(repo, time.Minute, 10)
+
+	_, err := cached.GetByID(context.TODO(), 3)
+	assert.NoError(t, err)
+
+	err = cached.UpdateRules(context.TODO(), 3, rules)
+	assert.NoError(t, err)
+
+	// the updated operation type is read again from the repository
+	got, err := cached.GetByID(context.TODO(), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, rules, got.Rules)
+}
@@ -0,0 +1,82 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"github.com/pkg/errors"
+	"io"
+)
+
+// KeySize is the length of the AES-256 key
+const KeySize = 32
+
+var ErrInvalidCiphertext = errors.New("invalid ciphertext")
+
+// Cipher encrypt values with AES-256-GCM, the ciphertext is the base64 of the random nonce followed by the sealed
+// value, so it fits the text columns
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// NewCipher create the cipher from the base64 encoded key
+func NewCipher(encodedKey string) (*Cipher, error) {
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewCipher")
+	}
+
+	if len(key) != KeySize {
+		return nil, errors.Errorf("NewCipher: the key must have %d bytes, got %d", KeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewCipher")
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewCipher")
+	}
+
+	return &Cipher{aead: aead}, nil
+}
+
+// Encrypt seal the value with a new nonce, the empty value is kept empty so optional fields stay blank
+func (c *Cipher) Encrypt(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", errors.Wrap(err, "Encrypt")
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, []byte(value), nil)
+
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt open a value sealed by Encrypt
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return "", ErrInvalidCiphertext
+	}
+
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+
+	plain, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+
+	return string(plain), nil
+}
@@ -0,0 +1,99 @@
+package encryption
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/customer"
+	"github.com/brunomdev/digital-account/entity"
+)
+
+type customerRepository struct {
+	repo   customer.Repository
+	cipher *Cipher
+}
+
+// NewCustomerRepository encrypt the personal data of the customers before it reaches the repo and decrypt it on the
+// way back. The company name isn't personal data and is stored in plain text
+func NewCustomerRepository(repo customer.Repository, cipher *Cipher) customer.Repository {
+	return &customerRepository{repo: repo, cipher: cipher}
+}
+
+func (r *customerRepository) Save(ctx context.Context, c *entity.Customer) (*entity.Customer, error) {
+	encrypted, err := r.encrypt(c)
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := r.repo.Save(ctx, encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.decrypt(saved)
+}
+
+func (r *customerRepository) GetByID(ctx context.Context, id int) (*entity.Customer, error) {
+	c, err := r.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.decrypt(c)
+}
+
+func (r *customerRepository) Update(ctx context.Context, c *entity.Customer) (*entity.Customer, error) {
+	encrypted, err := r.encrypt(c)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := r.repo.Update(ctx, encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.decrypt(updated)
+}
+
+func (r *customerRepository) encrypt(c *entity.Customer) (*entity.Customer, error) {
+	encrypted := *c
+
+	return &encrypted, r.apply(&encrypted, r.cipher.Encrypt)
+}
+
+func (r *customerRepository) decrypt(c *entity.Customer) (*entity.Customer, error) {
+	decrypted := *c
+
+	if err := r.apply(&decrypted, r.cipher.Decrypt); err != nil {
+		return nil, err
+	}
+
+	return &decrypted, nil
+}
+
+// apply replace each personal field of the customer by the result of fn
+func (r *customerRepository) apply(c *entity.Customer, fn func(string) (string, error)) error {
+	fields := []*string{
+		&c.Name,
+		&c.BirthDate,
+		&c.Email,
+		&c.Phone,
+		&c.Address.Street,
+		&c.Address.Number,
+		&c.Address.Complement,
+		&c.Address.City,
+		&c.Address.State,
+		&c.Address.PostalCode,
+		&c.Address.Country,
+	}
+
+	for _, field := range fields {
+		value, err := fn(*field)
+		if err != nil {
+			return err
+		}
+
+		*field = value
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/infra/memory"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func newTestCipher(t *testing.T) *Cipher {
+	c, err := NewCipher(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", KeySize))))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+func TestNewCipher(t *testing.T) {
+	_, err := NewCipher("not base64")
+	assert.Error(t, err)
+
+	_, err = NewCipher(base64.StdEncoding.EncodeToString([]byte("short")))
+	assert.Error(t, err)
+}
+
+func TestCipher(t *testing.T) {
+	c := newTestCipher(t)
+
+	first, err := c.Encrypt("Maria")
+	assert.NoError(t, err)
+
+	second, err := c.Encrypt("Maria")
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, second, "the nonce must be random")
+
+	plain, err := c.Decrypt(first)
+	assert.NoError(t, err)
+	assert.Equal(t, "Maria", plain)
+
+	empty, err := c.Encrypt("")
+	assert.NoError(t, err)
+	assert.Equal(t, "", empty)
+
+	_, err = c.Decrypt("Maria")
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+
+	_, err = newTestCipher(t).Decrypt(first[:len(first)-4])
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+}
+
+func Test_customerRepository(t *testing.T) {
+	inner := memory.NewCustomerRepository()
+	repo := NewCustomerRepository(inner, newTestCipher(t))
+
+	customer := &entity.Customer{
+		Name:      "Maria Silva",
+		BirthDate: "1990-01-31",
+		Email:     "maria@example.com",
+		Address:   entity.Address{Street: "Rua A", City: "Sao Paulo"},
+	}
+
+	saved, err := repo.Save(context.TODO(), customer)
+	assert.NoError(t, err)
+	assert.Equal(t, "Maria Silva", saved.Name)
+	assert.Equal(t, "Rua A", saved.Address.Street)
+
+	stored, err := inner.GetByID(context.TODO(), saved.ID)
+	assert.NoError(t, err)
+	assert.NotEqual(t, "Maria Silva", stored.Name)
+	assert.NotEqual(t, "maria@example.com", stored.Email)
+	assert.NotEqual(t, "Sao Paulo", stored.Address.City)
+	assert.Equal(t, "", stored.Phone)
+
+	saved.Phone = "+5511999999999"
+	_, err = repo.Update(context.TODO(), saved)
+	assert.NoError(t, err)
+
+	got, err := repo.GetByID(context.TODO(), saved.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "+5511999999999", got.Phone)
+	assert.Equal(t, "1990-01-31", got.BirthDate)
+	assert.Equal(t, "Maria Silva", customer.Name, "the input must not be changed")
+}
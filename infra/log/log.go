@@ -2,6 +2,7 @@ package log
 
 import (
 	"context"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"os"
@@ -39,8 +40,15 @@ func init() {
 		config.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
 	}
 
+	redactedFields := DefaultRedactedFields
+	if fields := os.Getenv("LOG_REDACT_FIELDS"); fields != "" {
+		redactedFields = strings.Split(fields, ",")
+	}
+
 	var err error
-	ZapLogger, err = config.Build()
+	ZapLogger, err = config.Build(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return NewRedactCore(core, redactedFields)
+	}))
 	if err != nil {
 		panic(err)
 	}
@@ -57,6 +65,10 @@ func addEvent(event ...Event) zap.Field {
 	return zap.Any("event", nil)
 }
 
+func Debug(context context.Context, msg string, event ...Event) {
+	WithContext(context).Debug(msg, addEvent(event...))
+}
+
 func Info(context context.Context, msg string, event ...Event) {
 	WithContext(context).Info(msg, addEvent(event...))
 }
@@ -73,16 +85,30 @@ func Fatal(context context.Context, msg string, err error, event ...Event) {
 	WithContext(context).Fatal(msg, zap.Error(err), addEvent(event...))
 }
 
+// WithContext returns the logger of the context with the trace and span ids of the current span
 func WithContext(ctx context.Context) *zap.Logger {
 	if ctx == nil || ctx == context.Background() || ctx == context.TODO() {
 		return ZapLogger
 	}
 
+	logger := ZapLogger
 	if ctxLogger, ok := ctx.Value(LoggerKeyType).(*zap.Logger); ok {
-		return ctxLogger
+		logger = ctxLogger
 	}
 
-	return ZapLogger
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+		logger = logger.With(
+			zap.String("trace_id", spanCtx.TraceID().String()),
+			zap.String("span_id", spanCtx.SpanID().String()),
+		)
+	}
+
+	return logger
+}
+
+// NewContext returns a copy of the context carrying the logger
+func NewContext(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, LoggerKeyType, logger)
 }
 
 // Close flushing any buffered log entries
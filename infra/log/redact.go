@@ -0,0 +1,160 @@
+package log
+
+import (
+	"fmt"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces the values of the redacted fields and the documents found on the logged text
+const Redacted = "[REDACTED]"
+
+// DefaultRedactedFields are the personal data fields masked when LOG_REDACT_FIELDS is not set
+var DefaultRedactedFields = []string{
+	"document_number",
+	"name",
+	"company_name",
+	"birth_date",
+	"email",
+	"phone",
+	"address",
+	"token",
+	"pan",
+	"api_key",
+	"x-api-key",
+	"authorization",
+	"password",
+}
+
+// documentPattern matches the CNPJs and the CPFs, formatted or not, not being part of a longer number
+var documentPattern = regexp.MustCompile(
+	`(^|[^0-9])(\d{2}\.?\d{3}\.?\d{3}/?\d{4}-?\d{2}|\d{3}\.?\d{3}\.?\d{3}-?\d{2})($|[^0-9])`,
+)
+
+// RedactString mask the CPFs and the CNPJs of the text
+func RedactString(s string) string {
+	// the pattern consumes the delimiters, adjacent documents take a second pass
+	for i := 0; i < 2 && documentPattern.MatchString(s); i++ {
+		s = documentPattern.ReplaceAllString(s, "${1}"+Redacted+"${3}")
+	}
+
+	return s
+}
+
+type redactCore struct {
+	zapcore.Core
+	fields map[string]struct{}
+}
+
+// NewRedactCore wrap the core to mask the values of the fields with the given names, at any depth of the logged
+// events, and the CPFs and CNPJs on the messages, the strings and the errors
+func NewRedactCore(core zapcore.Core, fields []string) zapcore.Core {
+	redacted := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		redacted[strings.ToLower(strings.TrimSpace(field))] = struct{}{}
+	}
+
+	return &redactCore{Core: core, fields: redacted}
+}
+
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(c.redactFields(fields)), fields: c.fields}
+}
+
+func (c *redactCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+
+	return checked
+}
+
+func (c *redactCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = RedactString(entry.Message)
+
+	return c.Core.Write(entry, c.redactFields(fields))
+}
+
+func (c *redactCore) redactFields(fields []zapcore.Field) []zapcore.Field {
+	redacted := make([]zapcore.Field, len(fields))
+
+	for i, field := range fields {
+		switch {
+		case c.isRedacted(field.Key):
+			redacted[i] = zap.String(field.Key, Redacted)
+		case field.Type == zapcore.StringType:
+			redacted[i] = zap.String(field.Key, RedactString(field.String))
+		case field.Type == zapcore.ErrorType:
+			redacted[i] = zap.NamedError(field.Key, redactedError{err: field.Interface.(error)})
+		case field.Type == zapcore.ReflectType:
+			redacted[i] = zap.Any(field.Key, c.redactValue(field.Interface))
+		default:
+			redacted[i] = field
+		}
+	}
+
+	return redacted
+}
+
+// redactValue mask the redacted keys and the documents of the events, other values are logged as they are
+func (c *redactCore) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case Event:
+		return Event(c.redactMap(v))
+	case map[string]interface{}:
+		return c.redactMap(v)
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = c.redactValue(item)
+		}
+
+		return redacted
+	case string:
+		return RedactString(v)
+	case error:
+		return RedactString(v.Error())
+	default:
+		return value
+	}
+}
+
+func (c *redactCore) redactMap(m map[string]interface{}) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		if c.isRedacted(key) {
+			redacted[key] = Redacted
+			continue
+		}
+
+		redacted[key] = c.redactValue(value)
+	}
+
+	return redacted
+}
+
+func (c *redactCore) isRedacted(key string) bool {
+	_, ok := c.fields[strings.ToLower(key)]
+
+	return ok
+}
+
+// redactedError mask the documents of the message and of the verbose form with the stack trace
+type redactedError struct {
+	err error
+}
+
+func (e redactedError) Error() string {
+	return RedactString(e.err.Error())
+}
+
+func (e redactedError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		fmt.Fprint(s, RedactString(fmt.Sprintf("%+v", e.err)))
+		return
+	}
+
+	fmt.Fprint(s, e.Error())
+}
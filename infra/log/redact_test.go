@@ -0,0 +1,87 @@
+package log
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"strings"
+	"testing"
+)
+
+func TestRedactString(t *testing.T) {
+	testCases := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{name: "CPF", s: "account 12345678900 exists", want: "account [REDACTED] exists"},
+		{name: "Formatted CPF", s: "document 123.456.789-00", want: "document [REDACTED]"},
+		{name: "CNPJ", s: "12345678000190", want: "[REDACTED]"},
+		{name: "Formatted CNPJ", s: "cnpj=12.345.678/0001-90;", want: "cnpj=[REDACTED];"},
+		{name: "Adjacent documents", s: "12345678900,98765432100", want: "[REDACTED],[REDACTED]"},
+		{name: "Longer number", s: "phone +5511999999999", want: "phone +5511999999999"},
+		{name: "Shorter number", s: "account 1234567890", want: "account 1234567890"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := RedactString(tc.s); got != tc.want {
+				t.Errorf("RedactString() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewRedactCore(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(NewRedactCore(core, []string{"document_number", "Email"})).
+		With(zap.String("email", "maria@example.com"))
+
+	err := errors.Wrap(errors.New("duplicate document 12345678900"), "Create")
+	logger.Error(
+		"unable to create account 12345678900",
+		zap.Error(err),
+		zap.String("path", "/accounts/123.456.789-00"),
+		zap.Int("account_id", 1),
+		zap.Any("event", Event{
+			"document_number": "12345678900",
+			"body":            map[string]interface{}{"EMAIL": "maria@example.com", "amount": 10.5},
+			"detail":          "document 12345678900",
+		}),
+	)
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("logged %d entries, want 1", len(entries))
+	}
+
+	entry := entries[0]
+	if entry.Message != "unable to create account [REDACTED]" {
+		t.Errorf("Message = %v", entry.Message)
+	}
+
+	fields := entry.ContextMap()
+	want := map[string]interface{}{
+		"email":      Redacted,
+		"error":      "Create: duplicate document [REDACTED]",
+		"path":       "/accounts/[REDACTED]",
+		"account_id": int64(1),
+		"event": Event{
+			"document_number": Redacted,
+			"body":            map[string]interface{}{"EMAIL": Redacted, "amount": 10.5},
+			"detail":          "document [REDACTED]",
+		},
+	}
+	for key, value := range want {
+		if fmt.Sprint(fields[key]) != fmt.Sprint(value) {
+			t.Errorf("field %s = %v, want %v", key, fields[key], value)
+		}
+	}
+
+	verbose := fmt.Sprint(fields["errorVerbose"])
+	if !strings.Contains(verbose, "duplicate document [REDACTED]") || strings.Contains(verbose, "12345678900") {
+		t.Errorf("errorVerbose = %v, want the document redacted", verbose)
+	}
+}
@@ -0,0 +1,172 @@
+package memory
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/account"
+	"github.com/brunomdev/digital-account/entity"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+type accountRepository struct {
+	mu          sync.RWMutex
+	lastID      int
+	accounts    map[int]entity.Account
+	adjustments []entity.CreditLimitAdjustment
+}
+
+func NewAccountRepository() account.Repository {
+	return &accountRepository{accounts: make(map[int]entity.Account)}
+}
+
+func (r *accountRepository) Save(
+	_ context.Context,
+	docNumber string,
+	availableCreditLimit float64,
+	createdAt time.Time,
+) (*entity.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	acc := entity.Account{
+		ID:                   r.lastID,
+		DocumentNumber:       docNumber,
+		AvailabelCreditLimit: availableCreditLimit,
+		CreatedAt:            createdAt,
+	}
+	r.accounts[acc.ID] = acc
+
+	return &acc, nil
+}
+
+func (r *accountRepository) SaveForCustomer(
+	_ context.Context,
+	customerID int,
+	docNumber string,
+	availableCreditLimit float64,
+	createdAt time.Time,
+) (*entity.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	acc := entity.Account{
+		ID:                   r.lastID,
+		DocumentNumber:       docNumber,
+		AvailabelCreditLimit: availableCreditLimit,
+		CustomerID:           customerID,
+		CreatedAt:            createdAt,
+	}
+	r.accounts[acc.ID] = acc
+
+	return &acc, nil
+}
+
+func (r *accountRepository) GetByID(_ context.Context, id int) (*entity.Account, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	acc, ok := r.accounts[id]
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+
+	return &acc, nil
+}
+
+func (r *accountRepository) Update(_ context.Context, account *entity.Account) (*entity.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.accounts[account.ID]
+	if !ok {
+		return account, nil
+	}
+
+	stored.DocumentNumber = account.DocumentNumber
+	stored.AvailabelCreditLimit = account.AvailabelCreditLimit
+	r.accounts[account.ID] = stored
+
+	return account, nil
+}
+
+func (r *accountRepository) SetCreditLimit(
+	_ context.Context,
+	id int,
+	availableCreditLimit float64,
+	at time.Time,
+) (*entity.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	acc, ok := r.accounts[id]
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+
+	r.adjustments = append(r.adjustments, entity.CreditLimitAdjustment{
+		ID:        len(r.adjustments) + 1,
+		AccountID: id,
+		Amount:    math.Round((availableCreditLimit-acc.AvailabelCreditLimit)*100) / 100,
+		CreatedAt: at,
+	})
+
+	acc.AvailabelCreditLimit = availableCreditLimit
+	r.accounts[id] = acc
+
+	return &acc, nil
+}
+
+func (r *accountRepository) SumAdjustmentsSince(_ context.Context, accountID int, since time.Time) (float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var amount float64
+	for _, adjustment := range r.adjustments {
+		if adjustment.AccountID == accountID && !adjustment.CreatedAt.Before(since) {
+			amount += adjustment.Amount
+		}
+	}
+
+	return amount, nil
+}
+
+func (r *accountRepository) ListAdjustments(
+	_ context.Context,
+	accountID int,
+	from, to time.Time,
+) ([]*entity.CreditLimitAdjustment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	adjustments := make([]*entity.CreditLimitAdjustment, 0)
+	for _, adjustment := range r.adjustments {
+		if adjustment.AccountID == accountID && !adjustment.CreatedAt.Before(from) && adjustment.CreatedAt.Before(to) {
+			adjustment := adjustment
+			adjustments = append(adjustments, &adjustment)
+		}
+	}
+
+	sort.SliceStable(adjustments, func(i, j int) bool {
+		return adjustments[i].CreatedAt.Before(adjustments[j].CreatedAt)
+	})
+
+	return adjustments, nil
+}
+
+func (r *accountRepository) List(_ context.Context, afterID, limit int) ([]*entity.Account, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	accounts := make([]*entity.Account, 0)
+	for id := afterID + 1; id <= r.lastID && len(accounts) < limit; id++ {
+		if acc, ok := r.accounts[id]; ok {
+			accounts = append(accounts, &acc)
+		}
+	}
+
+	return accounts, nil
+}
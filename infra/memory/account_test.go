@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_accountRepository_ConcurrentSave(t *testing.T) {
+	repo := NewAccountRepository()
+
+	const total = 50
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		ids = make(map[int]bool, total)
+	)
+	for i := 0; i < total; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			acc, err := repo.Save(context.TODO(), "12345678900", 100, time.Now())
+			assert.NoError(t, err)
+
+			mu.Lock()
+			ids[acc.ID] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, ids, total)
+
+	for id := range ids {
+		_, err := repo.GetByID(context.TODO(), id)
+		assert.NoError(t, err)
+	}
+}
@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/audit"
+	"github.com/brunomdev/digital-account/entity"
+	"sync"
+)
+
+type auditRepository struct {
+	mu      sync.RWMutex
+	entries []entity.AuditEntry
+}
+
+func NewAuditRepository() audit.Repository {
+	return &auditRepository{}
+}
+
+func (r *auditRepository) Append(_ context.Context, entry *entity.AuditEntry) (*entity.AuditEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var prevHash string
+	if len(r.entries) > 0 {
+		prevHash = r.entries[len(r.entries)-1].Hash
+	}
+
+	saved := *entry
+	saved.ID = len(r.entries) + 1
+	saved.Seal(prevHash)
+	r.entries = append(r.entries, saved)
+
+	return &saved, nil
+}
+
+func (r *auditRepository) ListByEntity(
+	_ context.Context,
+	entityName entity.AuditEntity,
+	entityID, limit int,
+) ([]*entity.AuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]*entity.AuditEntry, 0)
+	for i := len(r.entries) - 1; i >= 0 && len(entries) < limit; i-- {
+		if r.entries[i].Entity != entityName || r.entries[i].EntityID != entityID {
+			continue
+		}
+
+		entry := r.entries[i]
+		entries = append(entries, &entry)
+	}
+
+	return entries, nil
+}
+
+func (r *auditRepository) EachEntry(_ context.Context, fn func(entry *entity.AuditEntry) error) error {
+	r.mu.RLock()
+	entries := append([]entity.AuditEntry(nil), r.entries...)
+	r.mu.RUnlock()
+
+	for i := range entries {
+		err := fn(&entries[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
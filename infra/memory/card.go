@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/card"
+	"github.com/brunomdev/digital-account/entity"
+	"sync"
+	"time"
+)
+
+type cardRepository struct {
+	mu     sync.RWMutex
+	lastID int
+	cards  map[int]entity.Card
+}
+
+func NewCardRepository() card.Repository {
+	return &cardRepository{cards: make(map[int]entity.Card)}
+}
+
+func (r *cardRepository) Save(_ context.Context, c *entity.Card) (*entity.Card, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	saved := *c
+	saved.ID = r.lastID
+	saved.CreatedAt = time.Now()
+	r.cards[saved.ID] = saved
+
+	return &saved, nil
+}
+
+func (r *cardRepository) GetByID(_ context.Context, id int) (*entity.Card, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.cards[id]
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+
+	return &c, nil
+}
+
+func (r *cardRepository) UpdateStatus(_ context.Context, id int, status entity.CardStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.cards[id]
+	if !ok {
+		return entity.ErrNotFound
+	}
+
+	c.Status = status
+	r.cards[id] = c
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/charge"
+	"github.com/brunomdev/digital-account/entity"
+	"sync"
+	"time"
+)
+
+type chargeRepository struct {
+	mu      sync.Mutex
+	charges map[int]entity.Charge
+	lastID  int
+}
+
+func NewChargeRepository() charge.Repository {
+	return &chargeRepository{charges: make(map[int]entity.Charge)}
+}
+
+func (r *chargeRepository) Reserve(_ context.Context, c *entity.Charge) (*entity.Charge, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, saved := range r.charges {
+		if saved.AccountID == c.AccountID && saved.Type == c.Type && saved.Date.Equal(c.Date) {
+			return nil, entity.ErrAlreadyCharged
+		}
+	}
+
+	r.lastID++
+
+	reserved := *c
+	reserved.ID = r.lastID
+	reserved.CreatedAt = time.Now()
+	r.charges[reserved.ID] = reserved
+
+	return &reserved, nil
+}
+
+func (r *chargeRepository) SetTransactionID(_ context.Context, id, transactionID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.charges[id]
+	if !ok {
+		return entity.ErrNotFound
+	}
+
+	c.TransactionID = transactionID
+	r.charges[id] = c
+
+	return nil
+}
+
+func (r *chargeRepository) Delete(_ context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.charges, id)
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/client"
+	"github.com/brunomdev/digital-account/entity"
+	"sync"
+)
+
+type clientRepository struct {
+	mu      sync.RWMutex
+	clients map[string]entity.Client
+}
+
+// NewClientRepository create the repository with the given clients indexed by the hash of their API key
+func NewClientRepository(clients map[string]entity.Client) client.Repository {
+	r := &clientRepository{clients: make(map[string]entity.Client, len(clients))}
+	for keyHash, cl := range clients {
+		r.clients[keyHash] = cl
+	}
+
+	return r
+}
+
+func (r *clientRepository) GetByKeyHash(_ context.Context, keyHash string) (*entity.Client, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cl, ok := r.clients[keyHash]
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+
+	cl.Scopes = append([]entity.Scope(nil), cl.Scopes...)
+
+	return &cl, nil
+}
@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/customer"
+	"github.com/brunomdev/digital-account/entity"
+	"sync"
+	"time"
+)
+
+type customerRepository struct {
+	mu        sync.RWMutex
+	lastID    int
+	customers map[int]entity.Customer
+}
+
+func NewCustomerRepository() customer.Repository {
+	return &customerRepository{customers: make(map[int]entity.Customer)}
+}
+
+func (r *customerRepository) Save(_ context.Context, c *entity.Customer) (*entity.Customer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	saved := *c
+	saved.ID = r.lastID
+	saved.CreatedAt = time.Now()
+	saved.UpdatedAt = saved.CreatedAt
+	r.customers[saved.ID] = saved
+
+	return &saved, nil
+}
+
+func (r *customerRepository) GetByID(_ context.Context, id int) (*entity.Customer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.customers[id]
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+
+	return &c, nil
+}
+
+func (r *customerRepository) Update(_ context.Context, c *entity.Customer) (*entity.Customer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.customers[c.ID]
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+
+	updated := *c
+	updated.CreatedAt = stored.CreatedAt
+	updated.UpdatedAt = time.Now()
+	r.customers[updated.ID] = updated
+
+	return &updated, nil
+}
@@ -0,0 +1,21 @@
+package memory
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/health"
+)
+
+type healthRepository struct{}
+
+// NewHealthRepository create a repository always available, there is no schema to migrate in memory
+func NewHealthRepository() health.Repository {
+	return &healthRepository{}
+}
+
+func (r healthRepository) Ping(_ context.Context) error {
+	return nil
+}
+
+func (r healthRepository) SchemaVersion(_ context.Context) (uint, bool, error) {
+	return 0, false, nil
+}
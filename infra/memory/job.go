@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/job"
+	"github.com/brunomdev/digital-account/entity"
+	"sync"
+	"time"
+)
+
+type jobRepository struct {
+	mu     sync.Mutex
+	lastID int
+	jobs   map[int]entity.Job
+}
+
+func NewJobRepository() job.Repository {
+	return &jobRepository{jobs: make(map[int]entity.Job)}
+}
+
+func (r *jobRepository) Save(_ context.Context, j *entity.Job) (*entity.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	saved := *j
+	saved.ID = r.lastID
+	saved.CreatedAt = time.Now()
+	r.jobs[saved.ID] = saved
+
+	return &saved, nil
+}
+
+func (r *jobRepository) GetByID(_ context.Context, id int) (*entity.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	j, ok := r.jobs[id]
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+
+	return &j, nil
+}
+
+func (r *jobRepository) Claim(_ context.Context, now, leaseUntil time.Time) (*entity.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var next *entity.Job
+	for id := range r.jobs {
+		j := r.jobs[id]
+		if j.Finished() || j.RunAt.After(now) {
+			continue
+		}
+
+		if next == nil || j.RunAt.Before(next.RunAt) || (j.RunAt.Equal(next.RunAt) && j.ID < next.ID) {
+			next = &j
+		}
+	}
+
+	if next == nil {
+		return nil, entity.ErrNotFound
+	}
+
+	next.Status = entity.JobStatusRunning
+	next.Attempts++
+	next.RunAt = leaseUntil
+	r.jobs[next.ID] = *next
+
+	return next, nil
+}
+
+func (r *jobRepository) Update(_ context.Context, j *entity.Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.jobs[j.ID]
+	if !ok {
+		return entity.ErrNotFound
+	}
+
+	stored.Status = j.Status
+	stored.Progress = j.Progress
+	stored.Result = j.Result
+	stored.Error = j.Error
+	stored.RunAt = j.RunAt
+	r.jobs[j.ID] = stored
+
+	return nil
+}
+
+func (r *jobRepository) GetLatestByType(_ context.Context, jobType string) (*entity.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id := r.lastID; id > 0; id-- {
+		if j, ok := r.jobs[id]; ok && j.Type == jobType {
+			return &j, nil
+		}
+	}
+
+	return nil, entity.ErrNotFound
+}
+
+func (r *jobRepository) DeleteFinishedBefore(_ context.Context, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int
+	for id, j := range r.jobs {
+		if j.Finished() && j.CreatedAt.Before(before) {
+			delete(r.jobs, id)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/reconciliation"
+	"github.com/brunomdev/digital-account/entity"
+	"sync"
+	"time"
+)
+
+type limitCheckpointRepository struct {
+	mu          sync.RWMutex
+	checkpoints map[int]entity.LimitCheckpoint
+}
+
+func NewLimitCheckpointRepository() reconciliation.Repository {
+	return &limitCheckpointRepository{checkpoints: make(map[int]entity.LimitCheckpoint)}
+}
+
+func (r *limitCheckpointRepository) GetByAccountID(_ context.Context, accountID int) (*entity.LimitCheckpoint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	checkpoint, ok := r.checkpoints[accountID]
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+
+	return &checkpoint, nil
+}
+
+func (r *limitCheckpointRepository) Save(_ context.Context, checkpoint *entity.LimitCheckpoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	saved := *checkpoint
+	saved.UpdatedAt = time.Now()
+	r.checkpoints[checkpoint.AccountID] = saved
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/schedule"
+	"github.com/brunomdev/digital-account/entity"
+	"sync"
+)
+
+type locker struct {
+	mu   sync.Mutex
+	held map[string]bool
+}
+
+// NewLocker hold the locks in the process, enough when a single instance uses the storage
+func NewLocker() schedule.Locker {
+	return &locker{held: make(map[string]bool)}
+}
+
+func (l *locker) TryLock(_ context.Context, name string) (func(), error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.held[name] {
+		return nil, entity.ErrLocked
+	}
+	l.held[name] = true
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+
+			delete(l.held, name)
+		})
+	}, nil
+}
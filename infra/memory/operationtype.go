@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/operationtype"
+	"github.com/brunomdev/digital-account/entity"
+	"sync"
+)
+
+type operationTypeRepository struct {
+	mu             sync.RWMutex
+	operationTypes map[int]entity.OperationType
+}
+
+// NewOperationTypeRepository create the repository with the operation types inserted by the migrations
+func NewOperationTypeRepository() operationtype.Repository {
+	return &operationTypeRepository{
+		operationTypes: map[int]entity.OperationType{
+			entity.OperationTypeCompraAVista:    {ID: entity.OperationTypeCompraAVista, Description: "COMPRA A VISTA"},
+			entity.OperationTypeCompraParcelada: {ID: entity.OperationTypeCompraParcelada, Description: "COMPRA PARCELADA"},
+			entity.OperationTypeSaque:           {ID: entity.OperationTypeSaque, Description: "SAQUE"},
+			entity.OperationTypePagamento:       {ID: entity.OperationTypePagamento, Description: "PAGAMENTO"},
+			entity.OperationTypeJuros:           {ID: entity.OperationTypeJuros, Description: "JUROS"},
+			entity.OperationTypeTarifaAtraso:    {ID: entity.OperationTypeTarifaAtraso, Description: "TARIFA DE ATRASO"},
+			entity.OperationTypeMulta:           {ID: entity.OperationTypeMulta, Description: "MULTA"},
+			entity.OperationTypeTarifa:          {ID: entity.OperationTypeTarifa, Description: "TARIFA"},
+			entity.OperationTypeIOF:             {ID: entity.OperationTypeIOF, Description: "IOF"},
+		},
+	}
+}
+
+func (r *operationTypeRepository) GetByID(_ context.Context, id int) (*entity.OperationType, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	opType, ok := r.operationTypes[id]
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+
+	return &opType, nil
+}
+
+func (r *operationTypeRepository) UpdateRules(_ context.Context, id int, rules entity.OperationTypeRules) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	opType, ok := r.operationTypes[id]
+	if !ok {
+		return nil
+	}
+
+	opType.Rules = rules
+	r.operationTypes[id] = opType
+
+	return nil
+}
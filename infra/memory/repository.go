@@ -0,0 +1,26 @@
+package memory
+
+import (
+	"github.com/brunomdev/digital-account/domain"
+	"github.com/brunomdev/digital-account/entity"
+)
+
+// NewRepository create all the in-memory repositories, the data is lost when the process exits
+func NewRepository(clients map[string]entity.Client) *domain.Repository {
+	return &domain.Repository{
+		Account:            NewAccountRepository(),
+		Audit:              NewAuditRepository(),
+		Card:               NewCardRepository(),
+		Charge:             NewChargeRepository(),
+		Customer:           NewCustomerRepository(),
+		Client:             NewClientRepository(clients),
+		Health:             NewHealthRepository(),
+		Job:                NewJobRepository(),
+		LimitCheckpoint:    NewLimitCheckpointRepository(),
+		OperationType:      NewOperationTypeRepository(),
+		RiskDecision:       NewRiskDecisionRepository(),
+		Transaction:        NewTransactionRepository(),
+		TransactionAttempt: NewTransactionAttemptRepository(),
+		Locker:             NewLocker(),
+	}
+}
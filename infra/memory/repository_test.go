@@ -0,0 +1,13 @@
+package memory
+
+import (
+	"github.com/brunomdev/digital-account/domain"
+	"github.com/brunomdev/digital-account/infra/repositorytest"
+	"testing"
+)
+
+func TestRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T, c repositorytest.Case) *domain.Repository {
+		return NewRepository(nil)
+	})
+}
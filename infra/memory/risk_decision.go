@@ -0,0 +1,31 @@
+package memory
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/risk"
+	"github.com/brunomdev/digital-account/entity"
+	"sync"
+	"time"
+)
+
+type riskDecisionRepository struct {
+	mu        sync.Mutex
+	decisions []entity.RiskDecision
+}
+
+func NewRiskDecisionRepository() risk.Repository {
+	return &riskDecisionRepository{}
+}
+
+func (r *riskDecisionRepository) Save(_ context.Context, decision *entity.RiskDecision) (*entity.RiskDecision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	saved := *decision
+	saved.ID = len(r.decisions) + 1
+	saved.ReasonCodes = append([]string(nil), decision.ReasonCodes...)
+	saved.CreatedAt = time.Now()
+	r.decisions = append(r.decisions, saved)
+
+	return &saved, nil
+}
@@ -0,0 +1,265 @@
+package memory
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/transaction"
+	"github.com/brunomdev/digital-account/entity"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+type transactionRepository struct {
+	mu           sync.RWMutex
+	transactions []entity.Transaction
+}
+
+func NewTransactionRepository() transaction.Repository {
+	return &transactionRepository{}
+}
+
+func (r *transactionRepository) Save(_ context.Context, accountID, operationTypeID int, amount float64) (*entity.Transaction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	txn := entity.Transaction{
+		ID:              len(r.transactions) + 1,
+		AccountID:       accountID,
+		OperationTypeID: operationTypeID,
+		Amount:          amount,
+		EventDate:       now,
+		CreatedAt:       now,
+	}
+	r.transactions = append(r.transactions, txn)
+
+	return &txn, nil
+}
+
+func (r *transactionRepository) GetByID(_ context.Context, id int) (*entity.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if id < 1 || id > len(r.transactions) {
+		return nil, entity.ErrNotFound
+	}
+
+	txn := r.transactions[id-1]
+
+	return &txn, nil
+}
+
+func (r *transactionRepository) SaveDetailed(_ context.Context, txn *entity.Transaction) (*entity.Transaction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	saved := *txn
+	saved.ID = len(r.transactions) + 1
+	if saved.CreatedAt.IsZero() {
+		saved.CreatedAt = time.Now()
+	}
+	if saved.EventDate.IsZero() {
+		saved.EventDate = saved.CreatedAt
+	}
+	r.transactions = append(r.transactions, saved)
+
+	return &saved, nil
+}
+
+func (r *transactionRepository) SaveWithLinked(
+	_ context.Context,
+	txn *entity.Transaction,
+	linked []*entity.Transaction,
+) (*entity.Transaction, []*entity.Transaction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	saved := *txn
+	saved.ID = len(r.transactions) + 1
+	if saved.CreatedAt.IsZero() {
+		saved.CreatedAt = time.Now()
+	}
+	if saved.EventDate.IsZero() {
+		saved.EventDate = saved.CreatedAt
+	}
+	r.transactions = append(r.transactions, saved)
+
+	savedLinked := make([]*entity.Transaction, 0, len(linked))
+	for _, l := range linked {
+		t := entity.Transaction{
+			ID:              len(r.transactions) + 1,
+			AccountID:       saved.AccountID,
+			OperationTypeID: l.OperationTypeID,
+			Amount:          l.Amount,
+			EventDate:       saved.EventDate,
+			CreatedAt:       saved.CreatedAt,
+			ParentID:        saved.ID,
+		}
+		r.transactions = append(r.transactions, t)
+		savedLinked = append(savedLinked, &t)
+	}
+
+	return &saved, savedLinked, nil
+}
+
+func (r *transactionRepository) SumDebitsSince(_ context.Context, accountID int, since time.Time) (int, float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var (
+		count  int
+		amount float64
+	)
+	for _, txn := range r.transactions {
+		if txn.AccountID != accountID || txn.OperationTypeID == entity.OperationTypePagamento || txn.CreatedAt.Before(since) {
+			continue
+		}
+
+		count++
+		amount += math.Abs(txn.Amount)
+	}
+
+	return count, amount, nil
+}
+
+func (r *transactionRepository) SumByOperationTypeSince(
+	_ context.Context,
+	accountID, operationTypeID int,
+	since time.Time,
+) (int, float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var (
+		count  int
+		amount float64
+	)
+	for _, txn := range r.transactions {
+		if txn.AccountID != accountID || txn.OperationTypeID != operationTypeID || txn.CreatedAt.Before(since) {
+			continue
+		}
+
+		count++
+		amount += math.Abs(txn.Amount)
+	}
+
+	return count, amount, nil
+}
+
+func (r *transactionRepository) SumByCardSince(_ context.Context, cardID int, since time.Time) (float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var amount float64
+	for _, txn := range r.transactions {
+		if txn.CardID != cardID || txn.OperationTypeID == entity.OperationTypePagamento || txn.CreatedAt.Before(since) {
+			continue
+		}
+
+		amount += math.Abs(txn.Amount)
+	}
+
+	return amount, nil
+}
+
+func (r *transactionRepository) CountByAmountSince(
+	_ context.Context,
+	accountID, operationTypeID int,
+	amount float64,
+	since time.Time,
+) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int
+	for _, txn := range r.transactions {
+		if txn.AccountID == accountID &&
+			txn.OperationTypeID == operationTypeID &&
+			txn.Amount == amount &&
+			!txn.CreatedAt.Before(since) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (r *transactionRepository) SumLimitChangesSince(_ context.Context, accountID int, since time.Time) (float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var amount float64
+	for _, txn := range r.transactions {
+		if txn.AccountID == accountID && !txn.EventDate.Before(since) {
+			amount += txn.LimitChange()
+		}
+	}
+
+	return amount, nil
+}
+
+func (r *transactionRepository) ListByAccountID(
+	_ context.Context,
+	accountID int,
+	from, to time.Time,
+	fn func(txn *entity.Transaction) error,
+) error {
+	r.mu.RLock()
+	transactions := make([]entity.Transaction, 0)
+	for _, txn := range r.transactions {
+		if txn.AccountID == accountID && !txn.EventDate.Before(from) && txn.EventDate.Before(to) {
+			transactions = append(transactions, txn)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].EventDate.Before(transactions[j].EventDate)
+	})
+
+	for i := range transactions {
+		err := fn(&transactions[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *transactionRepository) SumLimitChangesAfter(_ context.Context, accountID, afterID int) (float64, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var amount float64
+	lastID := afterID
+	for _, txn := range r.transactions {
+		if txn.AccountID == accountID && txn.ID > afterID {
+			amount += txn.LimitChange()
+			lastID = txn.ID
+		}
+	}
+
+	return amount, lastID, nil
+}
+
+func (r *transactionRepository) SumBalanceBefore(_ context.Context, accountID int, before time.Time) (float64, float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var debits, payments float64
+	for _, txn := range r.transactions {
+		if txn.AccountID != accountID || !txn.EventDate.Before(before) {
+			continue
+		}
+
+		if txn.OperationTypeID == entity.OperationTypePagamento {
+			payments += math.Abs(txn.Amount)
+		} else {
+			debits += math.Abs(txn.Amount)
+		}
+	}
+
+	return debits, payments, nil
+}
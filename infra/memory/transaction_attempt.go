@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"github.com/brunomdev/digital-account/domain/transaction"
+	"github.com/brunomdev/digital-account/entity"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+type transactionAttemptRepository struct {
+	mu       sync.RWMutex
+	lastID   int
+	attempts []entity.TransactionAttempt
+}
+
+func NewTransactionAttemptRepository() transaction.AttemptRepository {
+	return &transactionAttemptRepository{}
+}
+
+func (r *transactionAttemptRepository) Save(_ context.Context, attempt *entity.TransactionAttempt) (*entity.TransactionAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	saved := *attempt
+	saved.ID = r.lastID
+	saved.CreatedAt = time.Now()
+	r.attempts = append(r.attempts, saved)
+
+	return &saved, nil
+}
+
+func (r *transactionAttemptRepository) ListByAccountID(_ context.Context, accountID, limit int) ([]*entity.TransactionAttempt, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	attempts := make([]*entity.TransactionAttempt, 0)
+	for i := len(r.attempts) - 1; i >= 0 && len(attempts) < limit; i-- {
+		if r.attempts[i].AccountID != accountID {
+			continue
+		}
+
+		attempt := r.attempts[i]
+		attempts = append(attempts, &attempt)
+	}
+
+	return attempts, nil
+}
+
+func (r *transactionAttemptRepository) DeleteBefore(_ context.Context, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.attempts[:0]
+	for _, attempt := range r.attempts {
+		if !attempt.CreatedAt.Before(before) {
+			kept = append(kept, attempt)
+		}
+	}
+
+	deleted := len(r.attempts) - len(kept)
+	r.attempts = kept
+
+	return deleted, nil
+}
+
+func (r *transactionAttemptRepository) Summarize(_ context.Context, from, to time.Time) ([]*entity.AttemptSummary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type key struct {
+		operationTypeID int
+		outcome         entity.AttemptOutcome
+	}
+
+	groups := make(map[key]*entity.AttemptSummary)
+	summaries := make([]*entity.AttemptSummary, 0)
+	for _, attempt := range r.attempts {
+		if attempt.CreatedAt.Before(from) || !attempt.CreatedAt.Before(to) {
+			continue
+		}
+
+		k := key{operationTypeID: attempt.OperationTypeID, outcome: attempt.Outcome}
+		summary, ok := groups[k]
+		if !ok {
+			summary = &entity.AttemptSummary{OperationTypeID: attempt.OperationTypeID, Outcome: attempt.Outcome}
+			groups[k] = summary
+			summaries = append(summaries, summary)
+		}
+
+		summary.Count++
+		summary.Amount += math.Abs(attempt.Amount)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].OperationTypeID != summaries[j].OperationTypeID {
+			return summaries[i].OperationTypeID < summaries[j].OperationTypeID
+		}
+
+		return summaries[i].Outcome < summaries[j].Outcome
+	})
+
+	return summaries, nil
+}
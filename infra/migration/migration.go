@@ -0,0 +1,37 @@
+package migration
+
+import (
+	"github.com/golang-migrate/migrate/v4/source"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/pkg/errors"
+	"os"
+)
+
+// LatestVersion returns the version of the last migration available on the source, e.g. file://migrations
+func LatestVersion(sourceURL string) (uint, error) {
+	driver, err := source.Open(sourceURL)
+	if err != nil {
+		return 0, errors.Wrap(err, "LatestVersion")
+	}
+	defer driver.Close()
+
+	version, err := driver.First()
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "LatestVersion")
+	}
+
+	for {
+		next, err := driver.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, errors.Wrap(err, "LatestVersion")
+		}
+
+		version = next
+	}
+}
@@ -0,0 +1,51 @@
+package migration
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLatestVersion(t *testing.T) {
+	testCases := []struct {
+		name    string
+		files   []string
+		want    uint
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "Without migrations",
+			files:   []string{},
+			want:    0,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Success",
+			files: []string{
+				"20220320103000_create_api_clients_table.up.sql",
+				"20220320103000_create_api_clients_table.down.sql",
+				"20220323100000_create_transaction_attempts_table.up.sql",
+				"20220323100000_create_transaction_attempts_table.down.sql",
+				"20220321090000_add_transactions_account_created_at_index.up.sql",
+			},
+			want:    20220323100000,
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, file := range tc.files {
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte("SELECT 1;"), 0o600))
+			}
+
+			got, err := LatestVersion("file://" + dir)
+			if !tc.wantErr(t, err, "LatestVersion()") {
+				return
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -9,10 +9,12 @@ import (
 	"github.com/brunomdev/digital-account/entity"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func Test_accountRepository_Save(t *testing.T) {
-	insertQuery := "INSERT INTO accounts (document_number, available_credit_limit) VALUES(?, ?)"
+	insertQuery := "INSERT INTO accounts (document_number, available_credit_limit, created_at) VALUES(?, ?, ?)"
+	createdAt := time.Date(2022, 3, 24, 10, 0, 0, 0, time.UTC)
 
 	type args struct {
 		ctx                  context.Context
@@ -56,7 +58,7 @@ func Test_accountRepository_Save(t *testing.T) {
 				}
 
 				mock.ExpectPrepare(insertQuery).ExpectExec().
-					WithArgs("12345678900", 50.00).
+					WithArgs("12345678900", 50.00, createdAt).
 					WillReturnError(errors.New("error"))
 
 				return db, mock, nil
@@ -111,6 +113,7 @@ func Test_accountRepository_Save(t *testing.T) {
 				ID:                   1,
 				DocumentNumber:       "12345678900",
 				AvailabelCreditLimit: 50.00,
+				CreatedAt:            createdAt,
 			},
 			wantErr: assert.NoError,
 		},
@@ -128,7 +131,7 @@ func Test_accountRepository_Save(t *testing.T) {
 
 			r := NewAccountRepository(db)
 
-			got, err := r.Save(tc.args.ctx, tc.args.docNumber, tc.args.availableCreditLimit)
+			got, err := r.Save(tc.args.ctx, tc.args.docNumber, tc.args.availableCreditLimit, createdAt)
 			if !tc.wantErr(t, err, fmt.Sprintf("Save(%v, %v)", tc.args.ctx, tc.args.docNumber)) {
 				return
 			}
@@ -138,7 +141,7 @@ func Test_accountRepository_Save(t *testing.T) {
 }
 
 func Test_accountRepository_GetByID(t *testing.T) {
-	selectQuery := "SELECT id, document_number, available_credit_limit FROM accounts WHERE id = ?"
+	selectQuery := "SELECT id, document_number, available_credit_limit, COALESCE(customer_id, 0), created_at FROM accounts WHERE id = ?"
 
 	type args struct {
 		ctx context.Context
@@ -202,8 +205,8 @@ func Test_accountRepository_GetByID(t *testing.T) {
 				mock.ExpectPrepare(selectQuery).
 					ExpectQuery().WithArgs(1).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "document_number", "available_credit_limit"}).
-							AddRow(false, "12345678900", 50.00),
+						sqlmock.NewRows([]string{"id", "document_number", "available_credit_limit", "customer_id", "created_at"}).
+							AddRow(false, "12345678900", 50.00, 0, time.Date(2022, 3, 17, 17, 30, 0, 0, time.UTC)),
 					)
 
 				return db, mock, nil
@@ -226,7 +229,7 @@ func Test_accountRepository_GetByID(t *testing.T) {
 				mock.ExpectPrepare(selectQuery).
 					ExpectQuery().WithArgs(1).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "document_number", "available_credit_limit"}),
+						sqlmock.NewRows([]string{"id", "document_number", "available_credit_limit", "customer_id", "created_at"}),
 					)
 
 				return db, mock, nil
@@ -249,8 +252,8 @@ func Test_accountRepository_GetByID(t *testing.T) {
 				mock.ExpectPrepare(selectQuery).
 					ExpectQuery().WithArgs(1).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "document_number", "available_credit_limit"}).
-							AddRow(1, "12345678900", 50.00),
+						sqlmock.NewRows([]string{"id", "document_number", "available_credit_limit", "customer_id", "created_at"}).
+							AddRow(1, "12345678900", 50.00, 0, time.Date(2022, 3, 17, 17, 30, 0, 0, time.UTC)),
 					)
 
 				return db, mock, nil
@@ -263,6 +266,7 @@ func Test_accountRepository_GetByID(t *testing.T) {
 				ID:                   1,
 				DocumentNumber:       "12345678900",
 				AvailabelCreditLimit: 50.00,
+				CreatedAt:            time.Date(2022, 3, 17, 17, 30, 0, 0, time.UTC),
 			},
 			wantErr: assert.NoError,
 		},
@@ -0,0 +1,171 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"github.com/brunomdev/digital-account/domain/audit"
+	"github.com/brunomdev/digital-account/entity"
+)
+
+type auditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) audit.Repository {
+	return &auditRepository{db: db}
+}
+
+// Append lock the head of the chain until the new entry is inserted, so concurrent appends chain one after the
+// other, the first ones of an empty trail included
+func (r auditRepository) Append(ctx context.Context, entry *entity.AuditEntry) (*entity.AuditEntry, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	var prevHash string
+
+	err = tx.QueryRowContext(ctx, `SELECT hash FROM audit_chain_head WHERE id = 1 FOR UPDATE`).Scan(&prevHash)
+	if err != nil {
+		return nil, err
+	}
+
+	saved := *entry
+	saved.Seal(prevHash)
+
+	result, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO audit_log (actor, request_id, action, entity, entity_id, before_state, after_state, prev_hash, hash, created_at) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		saved.Actor,
+		saved.RequestID,
+		saved.Action,
+		saved.Entity,
+		saved.EntityID,
+		auditState(saved.Before),
+		auditState(saved.After),
+		saved.PrevHash,
+		saved.Hash,
+		saved.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	saved.ID = int(id)
+
+	_, err = tx.ExecContext(ctx, `UPDATE audit_chain_head SET hash = ? WHERE id = 1`, saved.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, tx.Commit()
+}
+
+func (r auditRepository) ListByEntity(
+	ctx context.Context,
+	entityName entity.AuditEntity,
+	entityID, limit int,
+) ([]*entity.AuditEntry, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT id, actor, request_id, action, entity, entity_id, before_state, after_state, prev_hash, hash, created_at FROM audit_log WHERE entity = ? AND entity_id = ? ORDER BY id DESC LIMIT ?`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, entityName, entityID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	entries := make([]*entity.AuditEntry, 0)
+	for rows.Next() {
+		entry, err := scanAuditEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (r auditRepository) EachEntry(ctx context.Context, fn func(entry *entity.AuditEntry) error) error {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT id, actor, request_id, action, entity, entity_id, before_state, after_state, prev_hash, hash, created_at FROM audit_log ORDER BY id`,
+	)
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		entry, err := scanAuditEntry(rows)
+		if err != nil {
+			return err
+		}
+
+		err = fn(entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func scanAuditEntry(rows *sql.Rows) (*entity.AuditEntry, error) {
+	var (
+		entry         entity.AuditEntry
+		before, after sql.NullString
+	)
+
+	err := rows.Scan(
+		&entry.ID,
+		&entry.Actor,
+		&entry.RequestID,
+		&entry.Action,
+		&entry.Entity,
+		&entry.EntityID,
+		&before,
+		&after,
+		&entry.PrevHash,
+		&entry.Hash,
+		&entry.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Before = before.String
+	entry.After = after.String
+
+	return &entry, nil
+}
+
+// auditState store an empty snapshot as NULL
+func auditState(state string) sql.NullString {
+	return sql.NullString{String: state, Valid: state != ""}
+}
@@ -0,0 +1,81 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"github.com/brunomdev/digital-account/domain/charge"
+	"github.com/brunomdev/digital-account/entity"
+)
+
+// chargeDateFormat is the format of the charge_date column
+const chargeDateFormat = "2006-01-02"
+
+type chargeRepository struct {
+	db *sql.DB
+}
+
+func NewChargeRepository(db *sql.DB) charge.Repository {
+	return &chargeRepository{db: db}
+}
+
+func (r chargeRepository) Reserve(ctx context.Context, c *entity.Charge) (*entity.Charge, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`INSERT INTO charges (account_id, type, charge_date, amount) VALUES(?, ?, ?, ?) ON DUPLICATE KEY UPDATE id = id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, c.AccountID, c.Type, c.Date.Format(chargeDateFormat), c.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if affected == 0 {
+		return nil, entity.ErrAlreadyCharged
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	reserved := *c
+	reserved.ID = int(id)
+
+	return &reserved, nil
+}
+
+func (r chargeRepository) SetTransactionID(ctx context.Context, id, transactionID int) error {
+	stmt, err := r.db.PrepareContext(ctx, `UPDATE charges SET transaction_id = ? WHERE id = ?`)
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, transactionID, id)
+
+	return err
+}
+
+func (r chargeRepository) Delete(ctx context.Context, id int) error {
+	stmt, err := r.db.PrepareContext(ctx, `DELETE FROM charges WHERE id = ?`)
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, id)
+
+	return err
+}
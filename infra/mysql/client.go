@@ -0,0 +1,53 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"github.com/brunomdev/digital-account/domain/client"
+	"github.com/brunomdev/digital-account/entity"
+)
+
+type clientRepository struct {
+	db *sql.DB
+}
+
+func NewClientRepository(db *sql.DB) client.Repository {
+	return &clientRepository{db: db}
+}
+
+func (r clientRepository) GetByKeyHash(ctx context.Context, keyHash string) (*entity.Client, error) {
+	stmt, err := r.db.PrepareContext(ctx, `SELECT id, name, role, scopes, account_id FROM api_clients WHERE api_key_hash = ?`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, keyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var (
+		cl        entity.Client
+		scopes    string
+		accountID sql.NullInt64
+	)
+	for rows.Next() {
+		err = rows.Scan(&cl.ID, &cl.Name, &cl.Role, &scopes, &accountID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cl.ID < 1 {
+		return nil, entity.ErrNotFound
+	}
+
+	cl.AccountID = int(accountID.Int64)
+	cl.Scopes = entity.ParseScopes(scopes)
+
+	return &cl, nil
+}
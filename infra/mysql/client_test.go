@@ -0,0 +1,156 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_clientRepository_GetByKeyHash(t *testing.T) {
+	selectQuery := "SELECT id, name, role, scopes, account_id FROM api_clients WHERE api_key_hash = ?"
+
+	type args struct {
+		ctx     context.Context
+		keyHash string
+	}
+	testCases := []struct {
+		name    string
+		mock    func() (*sql.DB, sqlmock.Sqlmock, error)
+		args    args
+		want    *entity.Client
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Error prepare",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).
+					WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			args:    args{ctx: context.TODO(), keyHash: "hash"},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "Error query",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).
+					ExpectQuery().WithArgs("hash").WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			args:    args{ctx: context.TODO(), keyHash: "hash"},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "Error not found",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).
+					ExpectQuery().WithArgs("hash").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role", "scopes", "account_id"}))
+
+				return db, mock, nil
+			},
+			args: args{ctx: context.TODO(), keyHash: "hash"},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, entity.ErrNotFound, i...)
+			},
+		},
+		{
+			name: "Success customer",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).
+					ExpectQuery().WithArgs("hash").
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "name", "role", "scopes", "account_id"}).
+							AddRow(1, "mobile app", "customer", "accounts:read, transactions:write", 7),
+					)
+
+				return db, mock, nil
+			},
+			args: args{ctx: context.TODO(), keyHash: "hash"},
+			want: &entity.Client{
+				ID:        1,
+				Name:      "mobile app",
+				Role:      entity.RoleCustomer,
+				Scopes:    []entity.Scope{entity.ScopeAccountsRead, entity.ScopeTransactionsWrite},
+				AccountID: 7,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Success back-office without account",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).
+					ExpectQuery().WithArgs("hash").
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "name", "role", "scopes", "account_id"}).
+							AddRow(2, "back-office", "backoffice", "accounts:read", nil),
+					)
+
+				return db, mock, nil
+			},
+			args: args{ctx: context.TODO(), keyHash: "hash"},
+			want: &entity.Client{
+				ID:     2,
+				Name:   "back-office",
+				Role:   entity.RoleBackOffice,
+				Scopes: []entity.Scope{entity.ScopeAccountsRead},
+			},
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := tc.mock()
+			assert.NoError(t, err)
+
+			defer func() {
+				db.Close()
+				assert.NoError(t, mock.ExpectationsWereMet())
+			}()
+
+			r := NewClientRepository(db)
+
+			got, err := r.GetByKeyHash(tc.args.ctx, tc.args.keyHash)
+			if !tc.wantErr(t, err, fmt.Sprintf("GetByKeyHash(%v, %v)", tc.args.ctx, tc.args.keyHash)) {
+				return
+			}
+			assert.Equalf(t, tc.want, got, "GetByKeyHash(%v, %v)", tc.args.ctx, tc.args.keyHash)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/XSAM/otelsql"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/pkg/errors"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+// NewDB open the connection pool with the database/sql calls traced by OpenTelemetry
+func NewDB(dataSourceName string) (*sql.DB, error) {
+	driverName, err := otelsql.Register("mysql", semconv.DBSystemMySQL.Value.AsString())
+	if err != nil {
+		return nil, errors.Wrap(err, "NewDB")
+	}
+
+	return sql.Open(driverName, dataSourceName)
+}
@@ -0,0 +1,142 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_healthRepository_Ping(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func() (*sql.DB, sqlmock.Sqlmock, error)
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Error ping",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+
+				return db, mock, nil
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Success",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPing()
+
+				return db, mock, nil
+			},
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := tc.mock()
+			assert.NoError(t, err)
+
+			defer func() {
+				db.Close()
+				assert.NoError(t, mock.ExpectationsWereMet())
+			}()
+
+			r := NewHealthRepository(db)
+
+			tc.wantErr(t, r.Ping(context.TODO()), "Ping()")
+		})
+	}
+}
+
+func Test_healthRepository_SchemaVersion(t *testing.T) {
+	selectQuery := "SELECT version, dirty FROM schema_migrations LIMIT 1"
+
+	testCases := []struct {
+		name        string
+		mock        func() (*sql.DB, sqlmock.Sqlmock, error)
+		wantVersion uint
+		wantDirty   bool
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Error query",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectQuery(selectQuery).WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Without migrations",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectQuery(selectQuery).WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}))
+
+				return db, mock, nil
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Success",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectQuery(selectQuery).
+					WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(20220323100000, true))
+
+				return db, mock, nil
+			},
+			wantVersion: 20220323100000,
+			wantDirty:   true,
+			wantErr:     assert.NoError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := tc.mock()
+			assert.NoError(t, err)
+
+			defer func() {
+				db.Close()
+				assert.NoError(t, mock.ExpectationsWereMet())
+			}()
+
+			r := NewHealthRepository(db)
+
+			gotVersion, gotDirty, err := r.SchemaVersion(context.TODO())
+			if !tc.wantErr(t, err, "SchemaVersion()") {
+				return
+			}
+			assert.Equal(t, tc.wantVersion, gotVersion)
+			assert.Equal(t, tc.wantDirty, gotDirty)
+		})
+	}
+}
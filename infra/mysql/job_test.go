@@ -0,0 +1,138 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/infra/repositorytest"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_jobRepository_Claim(t *testing.T) {
+	now := time.Date(2022, 3, 24, 10, 0, 0, 0, time.UTC)
+	leaseUntil := now.Add(time.Minute)
+
+	testCases := []struct {
+		name    string
+		mock    func() (*sql.DB, sqlmock.Sqlmock, error)
+		want    *entity.Job
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Error begin",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectBegin().WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "Error empty queue",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(claimJobQuery).
+					WithArgs(entity.JobStatusQueued, entity.JobStatusRunning, now).
+					WillReturnRows(sqlmock.NewRows(jobColumns))
+				mock.ExpectRollback()
+
+				return db, mock, nil
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, entity.ErrNotFound, i...)
+			},
+		},
+		{
+			name: "Error update rollback",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(claimJobQuery).
+					WithArgs(entity.JobStatusQueued, entity.JobStatusRunning, now).
+					WillReturnRows(sqlmock.NewRows(jobColumns).AddRow(jobRow(entity.JobStatusQueued, 0, 0, nil, now, now)...))
+				mock.ExpectExec(claimJobUpdateQuery).
+					WithArgs(entity.JobStatusRunning, 1, leaseUntil, 1).
+					WillReturnError(errors.New("error"))
+				mock.ExpectRollback()
+
+				return db, mock, nil
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "Success expired lease",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectBegin()
+				mock.ExpectQuery(claimJobQuery).
+					WithArgs(entity.JobStatusQueued, entity.JobStatusRunning, now).
+					WillReturnRows(sqlmock.NewRows(jobColumns).AddRow(jobRow(entity.JobStatusRunning, 40, 1, nil, now, now)...))
+				mock.ExpectExec(claimJobUpdateQuery).
+					WithArgs(entity.JobStatusRunning, 2, leaseUntil, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+
+				return db, mock, nil
+			},
+			want: &entity.Job{
+				ID:          1,
+				Type:        "test",
+				Payload:     []byte(`{"rows":1}`),
+				Status:      entity.JobStatusRunning,
+				Progress:    40,
+				Attempts:    2,
+				MaxAttempts: 3,
+				Actor:       repositorytest.JobActor,
+				RequestID:   repositorytest.JobRequestID,
+				RunAt:       leaseUntil,
+				CreatedAt:   now,
+			},
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := tc.mock()
+			assert.NoError(t, err)
+
+			defer func() {
+				db.Close()
+				assert.NoError(t, mock.ExpectationsWereMet())
+			}()
+
+			r := NewJobRepository(db)
+
+			got, err := r.Claim(context.TODO(), now, leaseUntil)
+			if !tc.wantErr(t, err, "Claim()") {
+				return
+			}
+			assert.Equalf(t, tc.want, got, "Claim()")
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"github.com/brunomdev/digital-account/domain/schedule"
+	"github.com/brunomdev/digital-account/entity"
+)
+
+type locker struct {
+	db *sql.DB
+}
+
+// NewLocker use the MySQL named locks, every instance connected to the database shares them
+func NewLocker(db *sql.DB) schedule.Locker {
+	return &locker{db: db}
+}
+
+// TryLock hold GET_LOCK on a dedicated connection of the pool, MySQL releases it if the connection is lost
+func (l locker) TryLock(ctx context.Context, name string) (func(), error) {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var acquired sql.NullInt64
+	err = conn.QueryRowContext(ctx, `SELECT GET_LOCK(?, 0)`, name).Scan(&acquired)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	if acquired.Int64 != 1 {
+		conn.Close()
+
+		return nil, entity.ErrLocked
+	}
+
+	return func() {
+		_, err := conn.ExecContext(context.Background(), `DO RELEASE_LOCK(?)`, name)
+		if err != nil {
+			// a connection still holding the lock must not return to the pool
+			_ = conn.Raw(func(interface{}) error {
+				return driver.ErrBadConn
+			})
+		}
+
+		conn.Close()
+	}, nil
+}
@@ -16,7 +16,7 @@ func NewOperationTypeRepository(db *sql.DB) operationtype.Repository {
 }
 
 func (r operationTypeRepository) GetByID(ctx context.Context, id int) (*entity.OperationType, error) {
-	stmt, err := r.db.PrepareContext(ctx, `SELECT id, description FROM operation_types WHERE id = ?`)
+	stmt, err := r.db.PrepareContext(ctx, `SELECT id, description, limit_rate, fee, fee_rate, daily_max_count, daily_max_amount FROM operation_types WHERE id = ?`)
 	if err != nil {
 		return nil, err
 	}
@@ -28,7 +28,15 @@ func (r operationTypeRepository) GetByID(ctx context.Context, id int) (*entity.O
 	}
 
 	for rows.Next() {
-		err = rows.Scan(&opType.ID, &opType.Description)
+		err = rows.Scan(
+			&opType.ID,
+			&opType.Description,
+			&opType.Rules.LimitRate,
+			&opType.Rules.Fee,
+			&opType.Rules.FeeRate,
+			&opType.Rules.DailyMaxCount,
+			&opType.Rules.DailyMaxAmount,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -40,3 +48,19 @@ func (r operationTypeRepository) GetByID(ctx context.Context, id int) (*entity.O
 
 	return &opType, nil
 }
+
+func (r operationTypeRepository) UpdateRules(ctx context.Context, id int, rules entity.OperationTypeRules) error {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`UPDATE operation_types SET limit_rate = ?, fee = ?, fee_rate = ?, daily_max_count = ?, daily_max_amount = ? WHERE id = ?`,
+	)
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, rules.LimitRate, rules.Fee, rules.FeeRate, rules.DailyMaxCount, rules.DailyMaxAmount, id)
+
+	return err
+}
@@ -12,7 +12,7 @@ import (
 )
 
 func Test_operationTypeRepository_GetByID(t *testing.T) {
-	selectQuery := "SELECT id, description FROM operation_types WHERE id = ?"
+	selectQuery := "SELECT id, description, limit_rate, fee, fee_rate, daily_max_count, daily_max_amount FROM operation_types WHERE id = ?"
 
 	type args struct {
 		ctx context.Context
@@ -76,8 +76,8 @@ func Test_operationTypeRepository_GetByID(t *testing.T) {
 				mock.ExpectPrepare(selectQuery).
 					ExpectQuery().WithArgs(1).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "description"}).
-							AddRow(false, "PAGAMENTO A VISTA"),
+						sqlmock.NewRows([]string{"id", "description", "limit_rate", "fee", "fee_rate", "daily_max_count", "daily_max_amount"}).
+							AddRow(false, "PAGAMENTO A VISTA", 0, 0, 0, 0, 0),
 					)
 
 				return db, mock, nil
@@ -100,7 +100,7 @@ func Test_operationTypeRepository_GetByID(t *testing.T) {
 				mock.ExpectPrepare(selectQuery).
 					ExpectQuery().WithArgs(1).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "description"}),
+						sqlmock.NewRows([]string{"id", "description", "limit_rate", "fee", "fee_rate", "daily_max_count", "daily_max_amount"}),
 					)
 
 				return db, mock, nil
@@ -123,8 +123,8 @@ func Test_operationTypeRepository_GetByID(t *testing.T) {
 				mock.ExpectPrepare(selectQuery).
 					ExpectQuery().WithArgs(1).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "description"}).
-							AddRow(1, "PAGAMENTO A VISTA"),
+						sqlmock.NewRows([]string{"id", "description", "limit_rate", "fee", "fee_rate", "daily_max_count", "daily_max_amount"}).
+							AddRow(1, "PAGAMENTO A VISTA", 0, 0, 0, 0, 0),
 					)
 
 				return db, mock, nil
@@ -0,0 +1,26 @@
+package mysql
+
+import (
+	"database/sql"
+	"github.com/brunomdev/digital-account/domain"
+)
+
+// NewRepository create all the MySQL repositories sharing the connection pool
+func NewRepository(db *sql.DB) *domain.Repository {
+	return &domain.Repository{
+		Account:            NewAccountRepository(db),
+		Audit:              NewAuditRepository(db),
+		Card:               NewCardRepository(db),
+		Charge:             NewChargeRepository(db),
+		Customer:           NewCustomerRepository(db),
+		Client:             NewClientRepository(db),
+		Health:             NewHealthRepository(db),
+		Job:                NewJobRepository(db),
+		LimitCheckpoint:    NewLimitCheckpointRepository(db),
+		OperationType:      NewOperationTypeRepository(db),
+		RiskDecision:       NewRiskDecisionRepository(db),
+		Transaction:        NewTransactionRepository(db),
+		TransactionAttempt: NewTransactionAttemptRepository(db),
+		Locker:             NewLocker(db),
+	}
+}
@@ -0,0 +1,846 @@
+package mysql
+
+import (
+	"database/sql/driver"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/brunomdev/digital-account/domain"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/infra/repositorytest"
+	"testing"
+	"time"
+)
+
+const (
+	insertAccountQuery     = "INSERT INTO accounts (document_number, available_credit_limit, created_at) VALUES(?, ?, ?)"
+	selectAccountQuery     = "SELECT id, document_number, available_credit_limit, COALESCE(customer_id, 0), created_at FROM accounts WHERE id = ?"
+	updateAccountQuery     = "UPDATE accounts SET document_number = ?, available_credit_limit = ? WHERE id = ?"
+	selectOpTypeQuery      = "SELECT id, description, limit_rate, fee, fee_rate, daily_max_count, daily_max_amount FROM operation_types WHERE id = ?"
+	insertTransactionQuery = "INSERT INTO transactions (account_id, operation_type_id, amount) VALUES(?, ?, ?)"
+	selectTransactionQuery = "SELECT id, account_id, operation_type_id, amount, COALESCE(parent_id, 0), COALESCE(currency, ''), COALESCE(original_amount, 0), COALESCE(exchange_rate, 0), COALESCE(card_id, 0), event_date, created_at FROM transactions WHERE id = ?"
+	sumDebitsQuery         = "SELECT COUNT(id), COALESCE(SUM(ABS(amount)), 0) FROM transactions WHERE account_id = ? AND operation_type_id <> ? AND created_at >= ?"
+	countByAmountQuery     = "SELECT COUNT(id) FROM transactions WHERE account_id = ? AND operation_type_id = ? AND amount = ? AND created_at >= ?"
+	sumLimitChangesQuery   = "SELECT COALESCE(SUM(CASE WHEN operation_type_id = ? THEN ABS(amount) ELSE -ABS(amount) END), 0) FROM transactions WHERE account_id = ? AND event_date >= ?"
+	listTransactionsQuery  = "SELECT id, account_id, operation_type_id, amount, event_date FROM transactions WHERE account_id = ? AND event_date >= ? AND event_date < ? ORDER BY event_date, id"
+	insertAttemptQuery     = "INSERT INTO transaction_attempts (account_id, operation_type_id, amount, outcome, decline_reason, transaction_id) VALUES(?, ?, ?, ?, ?, ?)"
+	listAttemptsQuery      = "SELECT id, account_id, operation_type_id, amount, outcome, decline_reason, transaction_id, created_at FROM transaction_attempts WHERE account_id = ? ORDER BY id DESC LIMIT ?"
+	insertDecisionQuery    = "INSERT INTO transaction_decisions (transaction_id, account_id, operation_type_id, amount, outcome, reason_codes) VALUES(?, ?, ?, ?, ?, ?)"
+	selectClientQuery      = "SELECT id, name, role, scopes, account_id FROM api_clients WHERE api_key_hash = ?"
+	insertJobQuery         = "INSERT INTO jobs (type, payload, status, max_attempts, client_id, actor, request_id, run_at) VALUES(?, ?, ?, ?, ?, ?, ?, ?)"
+	selectJobQuery         = "SELECT id, type, payload, status, progress, attempts, max_attempts, result, error, client_id, actor, request_id, run_at, created_at FROM jobs WHERE id = ?"
+	claimJobQuery          = "SELECT id, type, payload, status, progress, attempts, max_attempts, result, error, client_id, actor, request_id, run_at, created_at FROM jobs WHERE status IN (?, ?) AND run_at <= ? ORDER BY run_at, id LIMIT 1 FOR UPDATE SKIP LOCKED"
+	claimJobUpdateQuery    = "UPDATE jobs SET status = ?, attempts = ?, run_at = ? WHERE id = ?"
+	updateJobQuery         = "UPDATE jobs SET status = ?, progress = ?, result = ?, error = ?, run_at = ? WHERE id = ?"
+	listAccountsQuery      = "SELECT id, document_number, available_credit_limit, COALESCE(customer_id, 0), created_at FROM accounts WHERE id > ? ORDER BY id LIMIT ?"
+	sumLimitAfterQuery     = "SELECT COALESCE(SUM(CASE WHEN operation_type_id = ? THEN ABS(amount) ELSE -ABS(amount) END), 0), COALESCE(MAX(id), ?) FROM transactions WHERE account_id = ? AND id > ?"
+	deleteAttemptsQuery    = "DELETE FROM transaction_attempts WHERE created_at < ?"
+	summarizeAttemptsQuery = "SELECT operation_type_id, outcome, COUNT(id), SUM(ABS(amount)) FROM transaction_attempts WHERE created_at >= ? AND created_at < ? GROUP BY operation_type_id, outcome ORDER BY operation_type_id, outcome"
+	latestJobQuery         = "SELECT id, type, payload, status, progress, attempts, max_attempts, result, error, client_id, actor, request_id, run_at, created_at FROM jobs WHERE type = ? ORDER BY id DESC LIMIT 1"
+	deleteJobsQuery        = "DELETE FROM jobs WHERE status IN (?, ?) AND created_at < ?"
+	selectCheckpointQuery  = "SELECT account_id, available_credit_limit, transaction_id, updated_at FROM limit_checkpoints WHERE account_id = ?"
+	saveCheckpointQuery    = "INSERT INTO limit_checkpoints (account_id, available_credit_limit, transaction_id) VALUES(?, ?, ?) ON DUPLICATE KEY UPDATE available_credit_limit = VALUES(available_credit_limit), transaction_id = VALUES(transaction_id), updated_at = CURRENT_TIMESTAMP"
+	getLockQuery           = "SELECT GET_LOCK(?, 0)"
+	releaseLockQuery       = "DO RELEASE_LOCK(?)"
+	sumBalanceQuery        = "SELECT COALESCE(SUM(CASE WHEN operation_type_id <> ? THEN ABS(amount) ELSE 0 END), 0), COALESCE(SUM(CASE WHEN operation_type_id = ? THEN ABS(amount) ELSE 0 END), 0) FROM transactions WHERE account_id = ? AND event_date < ?"
+	reserveChargeQuery     = "INSERT INTO charges (account_id, type, charge_date, amount) VALUES(?, ?, ?, ?) ON DUPLICATE KEY UPDATE id = id"
+	updateChargeQuery      = "UPDATE charges SET transaction_id = ? WHERE id = ?"
+	deleteChargeQuery      = "DELETE FROM charges WHERE id = ?"
+	updateOpTypeRulesQuery = "UPDATE operation_types SET limit_rate = ?, fee = ?, fee_rate = ?, daily_max_count = ?, daily_max_amount = ? WHERE id = ?"
+	insertLinkedQuery      = "INSERT INTO transactions (account_id, operation_type_id, amount, parent_id, event_date, created_at) SELECT account_id, ?, ?, id, event_date, created_at FROM transactions WHERE id = ?"
+	sumByOpTypeQuery       = "SELECT COUNT(id), COALESCE(SUM(ABS(amount)), 0) FROM transactions WHERE account_id = ? AND operation_type_id = ? AND created_at >= ?"
+	insertDetailedQuery    = "INSERT INTO transactions (account_id, operation_type_id, amount, currency, original_amount, exchange_rate, card_id, event_date, created_at) VALUES(?, ?, ?, ?, ?, ?, ?, COALESCE(?, CURRENT_TIMESTAMP), COALESCE(?, CURRENT_TIMESTAMP))"
+	insertCustomerQuery    = "INSERT INTO customers (name, birth_date, company_name, email, phone, address_street, address_number, address_complement, address_city, address_state, address_postal_code, address_country) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	selectCustomerQuery    = "SELECT id, name, birth_date, company_name, email, phone, address_street, address_number, address_complement, address_city, address_state, address_postal_code, address_country, created_at, updated_at FROM customers WHERE id = ?"
+	updateCustomerQuery    = "UPDATE customers SET name = ?, birth_date = ?, company_name = ?, email = ?, phone = ?, address_street = ?, address_number = ?, address_complement = ?, address_city = ?, address_state = ?, address_postal_code = ?, address_country = ? WHERE id = ?"
+	insertForCustomerQuery = "INSERT INTO accounts (document_number, available_credit_limit, customer_id, created_at) VALUES(?, ?, ?, ?)"
+	insertCardQuery        = "INSERT INTO cards (account_id, type, masked_pan, token, expiry_month, expiry_year, status, card_limit, replaces_card_id) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	selectCardQuery        = "SELECT id, account_id, type, masked_pan, token, expiry_month, expiry_year, status, card_limit, COALESCE(replaces_card_id, 0), created_at FROM cards WHERE id = ?"
+	updateCardStatusQuery  = "UPDATE cards SET status = ? WHERE id = ?"
+	sumByCardQuery         = "SELECT COALESCE(SUM(ABS(amount)), 0) FROM transactions WHERE card_id = ? AND operation_type_id <> ? AND created_at >= ?"
+	insertAuditQuery       = "INSERT INTO audit_log (actor, request_id, action, entity, entity_id, before_state, after_state, prev_hash, hash, created_at) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	auditChainHeadQuery    = "SELECT hash FROM audit_chain_head WHERE id = 1 FOR UPDATE"
+	updateChainHeadQuery   = "UPDATE audit_chain_head SET hash = ? WHERE id = 1"
+	listAuditQuery         = "SELECT id, actor, request_id, action, entity, entity_id, before_state, after_state, prev_hash, hash, created_at FROM audit_log WHERE entity = ? AND entity_id = ? ORDER BY id DESC LIMIT ?"
+	eachAuditQuery         = "SELECT id, actor, request_id, action, entity, entity_id, before_state, after_state, prev_hash, hash, created_at FROM audit_log ORDER BY id"
+	selectAccountLockQuery = "SELECT id, document_number, available_credit_limit, COALESCE(customer_id, 0), created_at FROM accounts WHERE id = ? FOR UPDATE"
+	setCreditLimitQuery    = "UPDATE accounts SET available_credit_limit = ? WHERE id = ?"
+	insertAdjustmentQuery  = "INSERT INTO credit_limit_adjustments (account_id, amount, created_at) VALUES(?, ?, ?)"
+	sumAdjustmentsQuery    = "SELECT COALESCE(SUM(amount), 0) FROM credit_limit_adjustments WHERE account_id = ? AND created_at >= ?"
+	listAdjustmentsQuery   = "SELECT id, account_id, amount, created_at FROM credit_limit_adjustments WHERE account_id = ? AND created_at >= ? AND created_at < ? ORDER BY created_at, id"
+)
+
+var (
+	accountColumns     = []string{"id", "document_number", "available_credit_limit", "customer_id", "created_at"}
+	opTypeColumns      = []string{"id", "description", "limit_rate", "fee", "fee_rate", "daily_max_count", "daily_max_amount"}
+	transactionColumns = []string{"id", "account_id", "operation_type_id", "amount", "parent_id", "currency", "original_amount", "exchange_rate", "card_id", "event_date", "created_at"}
+	summaryColumns     = []string{"operation_type_id", "outcome", "count", "amount"}
+	checkpointColumns  = []string{"account_id", "available_credit_limit", "transaction_id", "updated_at"}
+)
+
+var customerColumns = []string{
+	"id", "name", "birth_date", "company_name", "email", "phone", "address_street", "address_number", "address_complement",
+	"address_city", "address_state", "address_postal_code", "address_country", "created_at", "updated_at",
+}
+
+var cardColumns = []string{
+	"id", "account_id", "type", "masked_pan", "token", "expiry_month", "expiry_year", "status", "card_limit", "replaces_card_id", "created_at",
+}
+
+var auditColumns = []string{
+	"id", "actor", "request_id", "action", "entity", "entity_id", "before_state", "after_state", "prev_hash", "hash", "created_at",
+}
+
+var jobColumns = []string{
+	"id", "type", "payload", "status", "progress", "attempts", "max_attempts", "result", "error", "client_id", "actor", "request_id", "run_at", "created_at",
+}
+
+func TestRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T, c repositorytest.Case) *domain.Repository {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+
+		t.Cleanup(func() {
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+
+			db.Close()
+		})
+
+		expectConformanceCase(mock, c)
+
+		return NewRepository(db)
+	})
+}
+
+func expectConformanceCase(mock sqlmock.Sqlmock, c repositorytest.Case) {
+	now := time.Now()
+
+	switch c {
+	case repositorytest.AccountSaveAndGet:
+		expectAccountSave(mock)
+		expectAccountGet(mock, repositorytest.AvailableCreditLimit, repositorytest.AccountCreatedAt)
+	case repositorytest.AccountNotFound:
+		mock.ExpectPrepare(selectAccountQuery).
+			ExpectQuery().
+			WithArgs(repositorytest.MissingID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "available_credit_limit", "customer_id", "created_at"}))
+	case repositorytest.AccountUpdate:
+		expectAccountSave(mock)
+		mock.ExpectPrepare(updateAccountQuery).
+			ExpectExec().
+			WithArgs(repositorytest.DocumentNumber, repositorytest.UpdatedCreditLimit, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectAccountGet(mock, repositorytest.UpdatedCreditLimit, now)
+	case repositorytest.OperationTypeGet:
+		mock.ExpectPrepare(selectOpTypeQuery).
+			ExpectQuery().
+			WithArgs(entity.OperationTypeCompraAVista).
+			WillReturnRows(sqlmock.NewRows(opTypeColumns).AddRow(1, "COMPRA A VISTA", 0, 0, 0, 0, 0))
+	case repositorytest.OperationTypeNotFound:
+		mock.ExpectPrepare(selectOpTypeQuery).
+			ExpectQuery().
+			WithArgs(repositorytest.MissingID).
+			WillReturnRows(sqlmock.NewRows(opTypeColumns))
+	case repositorytest.TransactionSaveAndGet:
+		expectAccountSave(mock)
+		expectTransactionSave(mock, 1, entity.OperationTypeCompraAVista, -50, now)
+		expectTransactionGet(mock, 1, entity.OperationTypeCompraAVista, -50, now)
+	case repositorytest.TransactionNotFound:
+		mock.ExpectPrepare(selectTransactionQuery).
+			ExpectQuery().
+			WithArgs(repositorytest.MissingID).
+			WillReturnRows(sqlmock.NewRows(transactionColumns))
+	case repositorytest.TransactionSumDebitsSince:
+		expectTransactionsSave(mock, now)
+		mock.ExpectPrepare(sumDebitsQuery).
+			ExpectQuery().
+			WithArgs(1, entity.OperationTypePagamento, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count", "amount"}).AddRow(2, 80))
+	case repositorytest.TransactionCountByAmountSince:
+		expectAccountSave(mock)
+		expectTransactionSave(mock, 1, entity.OperationTypeCompraAVista, -50, now)
+		expectTransactionSave(mock, 2, entity.OperationTypeCompraAVista, -50, now)
+		expectTransactionSave(mock, 3, entity.OperationTypeCompraAVista, -20, now)
+		mock.ExpectPrepare(countByAmountQuery).
+			ExpectQuery().
+			WithArgs(1, entity.OperationTypeCompraAVista, -50.0, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	case repositorytest.TransactionSumLimitChanges:
+		expectTransactionsSave(mock, now)
+		mock.ExpectPrepare(sumLimitChangesQuery).
+			ExpectQuery().
+			WithArgs(entity.OperationTypePagamento, 1, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(20))
+	case repositorytest.TransactionListByAccountID:
+		expectTransactionsSave(mock, now)
+
+		columns := []string{"id", "account_id", "operation_type_id", "amount", "event_date"}
+		mock.ExpectPrepare(listTransactionsQuery).
+			ExpectQuery().
+			WithArgs(1, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(
+				sqlmock.NewRows(columns).
+					AddRow(1, 1, entity.OperationTypeCompraAVista, -50, now).
+					AddRow(2, 1, entity.OperationTypeSaque, -30, now).
+					AddRow(3, 1, entity.OperationTypePagamento, 100, now),
+			)
+		mock.ExpectPrepare(listTransactionsQuery).
+			ExpectQuery().
+			WithArgs(1, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(
+				sqlmock.NewRows(columns).
+					AddRow(1, 1, entity.OperationTypeCompraAVista, -50, now).
+					AddRow(2, 1, entity.OperationTypeSaque, -30, now),
+			)
+	case repositorytest.AttemptSaveAndList:
+		expectAccountSave(mock)
+		mock.ExpectPrepare(insertAttemptQuery).
+			ExpectExec().
+			WithArgs(1, entity.OperationTypeCompraAVista, -10.0, entity.AttemptOutcomeApproved, "", nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectPrepare(insertAttemptQuery).
+			ExpectExec().
+			WithArgs(1, entity.OperationTypeCompraAVista, -5000.0, entity.AttemptOutcomeDeclined, "INSUFFICIENT_CREDIT_LIMIT", nil).
+			WillReturnResult(sqlmock.NewResult(2, 1))
+
+		columns := []string{"id", "account_id", "operation_type_id", "amount", "outcome", "decline_reason", "transaction_id", "created_at"}
+		declined := []driver.Value{2, 1, entity.OperationTypeCompraAVista, -5000, entity.AttemptOutcomeDeclined, "INSUFFICIENT_CREDIT_LIMIT", nil, now}
+		approved := []driver.Value{1, 1, entity.OperationTypeCompraAVista, -10, entity.AttemptOutcomeApproved, "", nil, now}
+		mock.ExpectPrepare(listAttemptsQuery).
+			ExpectQuery().
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(declined...))
+		mock.ExpectPrepare(listAttemptsQuery).
+			ExpectQuery().
+			WithArgs(1, 10).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(declined...).AddRow(approved...))
+	case repositorytest.ClientNotFound:
+		mock.ExpectPrepare(selectClientQuery).
+			ExpectQuery().
+			WithArgs(repositorytest.UnknownKeyHash).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role", "scopes", "account_id"}))
+	case repositorytest.RiskDecisionSave:
+		expectAccountSave(mock)
+		mock.ExpectPrepare(insertDecisionQuery).
+			ExpectExec().
+			WithArgs(nil, 1, entity.OperationTypeCompraAVista, -900.0, entity.RiskOutcomeDecline, "HIGH_AMOUNT,NIGHT").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	case repositorytest.HealthPing:
+	case repositorytest.JobSaveAndGet:
+		expectJobSave(mock, now)
+		expectJobGet(mock, jobRow(entity.JobStatusQueued, 0, 0, nil, repositorytest.JobRunAt, now))
+	case repositorytest.JobNotFound:
+		mock.ExpectPrepare(selectJobQuery).
+			ExpectQuery().
+			WithArgs(repositorytest.MissingID).
+			WillReturnRows(sqlmock.NewRows(jobColumns))
+	case repositorytest.JobClaimAndUpdate:
+		leaseUntil := repositorytest.JobRunAt.Add(repositorytest.JobLease)
+
+		expectJobSave(mock, now)
+		expectJobClaimNotFound(mock, repositorytest.JobRunAt.Add(-time.Second))
+		mock.ExpectBegin()
+		mock.ExpectQuery(claimJobQuery).
+			WithArgs(entity.JobStatusQueued, entity.JobStatusRunning, repositorytest.JobRunAt).
+			WillReturnRows(sqlmock.NewRows(jobColumns).AddRow(jobRow(entity.JobStatusQueued, 0, 0, nil, repositorytest.JobRunAt, now)...))
+		mock.ExpectExec(claimJobUpdateQuery).
+			WithArgs(entity.JobStatusRunning, 1, leaseUntil, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		expectJobClaimNotFound(mock, repositorytest.JobRunAt)
+		mock.ExpectPrepare(updateJobQuery).
+			ExpectExec().
+			WithArgs(entity.JobStatusSucceeded, 100, []byte(repositorytest.JobResult), "", leaseUntil, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectJobGet(mock, jobRow(entity.JobStatusSucceeded, 100, 1, []byte(repositorytest.JobResult), leaseUntil, now))
+		expectJobClaimNotFound(mock, repositorytest.JobRunAt.Add(2*repositorytest.JobLease))
+	case repositorytest.AccountList:
+		expectAccountSave(mock)
+		mock.ExpectPrepare(listAccountsQuery).
+			ExpectQuery().
+			WithArgs(0, 10).
+			WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(1, repositorytest.DocumentNumber, repositorytest.AvailableCreditLimit, 0, now))
+		mock.ExpectPrepare(listAccountsQuery).
+			ExpectQuery().
+			WithArgs(1, 10).
+			WillReturnRows(sqlmock.NewRows(accountColumns))
+	case repositorytest.TransactionSumLimitAfter:
+		expectTransactionsSave(mock, now)
+		mock.ExpectPrepare(sumLimitAfterQuery).
+			ExpectQuery().
+			WithArgs(entity.OperationTypePagamento, 0, 1, 0).
+			WillReturnRows(sqlmock.NewRows([]string{"amount", "last_id"}).AddRow(20, 3))
+		mock.ExpectPrepare(sumLimitAfterQuery).
+			ExpectQuery().
+			WithArgs(entity.OperationTypePagamento, 3, 1, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"amount", "last_id"}).AddRow(0, 3))
+	case repositorytest.AttemptDeleteBefore:
+		expectAccountSave(mock)
+		mock.ExpectPrepare(insertAttemptQuery).
+			ExpectExec().
+			WithArgs(1, entity.OperationTypeCompraAVista, -10.0, entity.AttemptOutcomeApproved, "", nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectPrepare(deleteAttemptsQuery).
+			ExpectExec().
+			WithArgs(sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectPrepare(deleteAttemptsQuery).
+			ExpectExec().
+			WithArgs(sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectPrepare(listAttemptsQuery).
+			ExpectQuery().
+			WithArgs(1, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "outcome", "decline_reason", "transaction_id", "created_at"}))
+	case repositorytest.AttemptSummarize:
+		expectAccountSave(mock)
+		for i, attempt := range []struct {
+			operationTypeID int
+			amount          float64
+			outcome         entity.AttemptOutcome
+		}{
+			{operationTypeID: entity.OperationTypeCompraAVista, amount: -10, outcome: entity.AttemptOutcomeApproved},
+			{operationTypeID: entity.OperationTypeCompraAVista, amount: -5000, outcome: entity.AttemptOutcomeDeclined},
+			{operationTypeID: entity.OperationTypeCompraAVista, amount: -20, outcome: entity.AttemptOutcomeApproved},
+			{operationTypeID: entity.OperationTypePagamento, amount: 100, outcome: entity.AttemptOutcomeApproved},
+		} {
+			mock.ExpectPrepare(insertAttemptQuery).
+				ExpectExec().
+				WithArgs(1, attempt.operationTypeID, attempt.amount, attempt.outcome, "", nil).
+				WillReturnResult(sqlmock.NewResult(int64(i+1), 1))
+		}
+		mock.ExpectPrepare(summarizeAttemptsQuery).
+			ExpectQuery().
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(
+				sqlmock.NewRows(summaryColumns).
+					AddRow(entity.OperationTypeCompraAVista, entity.AttemptOutcomeApproved, 2, 30).
+					AddRow(entity.OperationTypeCompraAVista, entity.AttemptOutcomeDeclined, 1, 5000).
+					AddRow(entity.OperationTypePagamento, entity.AttemptOutcomeApproved, 1, 100),
+			)
+		mock.ExpectPrepare(summarizeAttemptsQuery).
+			ExpectQuery().
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows(summaryColumns))
+	case repositorytest.JobGetLatestByType:
+		expectJobSave(mock, now)
+		mock.ExpectPrepare(latestJobQuery).
+			ExpectQuery().
+			WithArgs(repositorytest.JobType).
+			WillReturnRows(sqlmock.NewRows(jobColumns).AddRow(jobRow(entity.JobStatusQueued, 0, 0, nil, repositorytest.JobRunAt, now)...))
+		mock.ExpectPrepare(latestJobQuery).
+			ExpectQuery().
+			WithArgs(repositorytest.MissingJobType).
+			WillReturnRows(sqlmock.NewRows(jobColumns))
+	case repositorytest.JobDeleteFinishedBefore:
+		expectJobSave(mock, now)
+		expectJobsDelete(mock, 0)
+		mock.ExpectPrepare(updateJobQuery).
+			ExpectExec().
+			WithArgs(entity.JobStatusSucceeded, 0, []byte(repositorytest.JobResult), "", repositorytest.JobRunAt, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectJobsDelete(mock, 0)
+		expectJobsDelete(mock, 1)
+		mock.ExpectPrepare(selectJobQuery).
+			ExpectQuery().
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(jobColumns))
+	case repositorytest.LimitCheckpointSaveAndGet:
+		expectAccountSave(mock)
+		mock.ExpectPrepare(selectCheckpointQuery).
+			ExpectQuery().
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(checkpointColumns))
+		expectCheckpointSave(mock, repositorytest.AvailableCreditLimit, 0, now)
+		expectCheckpointSave(mock, repositorytest.UpdatedCreditLimit, 3, now)
+	case repositorytest.LockExclusive:
+		expectLock(mock, 1)
+		expectLock(mock, 0)
+		expectUnlock(mock)
+		expectLock(mock, 1)
+		expectUnlock(mock)
+	case repositorytest.TransactionSumBalanceBefore:
+		expectTransactionsSave(mock, now)
+		mock.ExpectPrepare(sumBalanceQuery).
+			ExpectQuery().
+			WithArgs(entity.OperationTypePagamento, entity.OperationTypePagamento, 1, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"debits", "payments"}).AddRow(80, 100))
+		mock.ExpectPrepare(sumBalanceQuery).
+			ExpectQuery().
+			WithArgs(entity.OperationTypePagamento, entity.OperationTypePagamento, 1, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"debits", "payments"}).AddRow(0, 0))
+	case repositorytest.ChargeReserve:
+		expectAccountSave(mock)
+		expectChargeReserve(mock, entity.ChargeTypeInterest, sqlmock.NewResult(1, 1))
+		expectChargeReserve(mock, entity.ChargeTypeInterest, sqlmock.NewResult(0, 0))
+		expectChargeReserve(mock, entity.ChargeTypeLateFee, sqlmock.NewResult(2, 1))
+		mock.ExpectPrepare(updateChargeQuery).
+			ExpectExec().
+			WithArgs(1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectPrepare(deleteChargeQuery).
+			ExpectExec().
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectChargeReserve(mock, entity.ChargeTypeInterest, sqlmock.NewResult(3, 1))
+	case repositorytest.OperationTypeUpdateRules:
+		rules := repositorytest.WithdrawalRules
+		mock.ExpectPrepare(updateOpTypeRulesQuery).
+			ExpectExec().
+			WithArgs(rules.LimitRate, rules.Fee, rules.FeeRate, rules.DailyMaxCount, rules.DailyMaxAmount, entity.OperationTypeSaque).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectPrepare(selectOpTypeQuery).
+			ExpectQuery().
+			WithArgs(entity.OperationTypeSaque).
+			WillReturnRows(
+				sqlmock.NewRows(opTypeColumns).
+					AddRow(3, "SAQUE", rules.LimitRate, rules.Fee, rules.FeeRate, rules.DailyMaxCount, rules.DailyMaxAmount),
+			)
+	case repositorytest.TransactionSaveWithLinked:
+		createdAt := repositorytest.TransactionCreatedAt
+		expectAccountSave(mock)
+		expectSaveWithLinked(mock, 1, -50, nil, createdAt, now, createdAt)
+		mock.ExpectPrepare(selectTransactionQuery).
+			ExpectQuery().
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows(transactionColumns).AddRow(2, 1, entity.OperationTypeTarifa, -5, 1, "", 0, 0, 0, now, now))
+	case repositorytest.TransactionSumByOpTypeSince:
+		expectTransactionsSave(mock, now)
+		mock.ExpectPrepare(sumByOpTypeQuery).
+			ExpectQuery().
+			WithArgs(1, entity.OperationTypeSaque, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count", "amount"}).AddRow(1, 30))
+	case repositorytest.TransactionSaveDetailed:
+		fx := repositorytest.ForeignExchange
+		expectAccountSave(mock)
+		mock.ExpectPrepare(insertDetailedQuery).
+			ExpectExec().
+			WithArgs(1, entity.OperationTypeCompraAVista, -52.0, fx.Currency, fx.OriginalAmount, fx.Rate, nil, nil, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		for i := 0; i < 2; i++ {
+			mock.ExpectPrepare(selectTransactionQuery).
+				ExpectQuery().
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows(transactionColumns).
+					AddRow(1, 1, entity.OperationTypeCompraAVista, -52, 0, fx.Currency, fx.OriginalAmount, fx.Rate, 0, now, now))
+		}
+	case repositorytest.CustomerSaveAndGet:
+		expectCustomerSave(mock, now)
+		expectCustomerGet(mock, repositorytest.Customer.Email, now)
+	case repositorytest.CustomerNotFound:
+		mock.ExpectPrepare(selectCustomerQuery).
+			ExpectQuery().
+			WithArgs(repositorytest.MissingID).
+			WillReturnRows(sqlmock.NewRows(customerColumns))
+	case repositorytest.CustomerUpdate:
+		expectCustomerSave(mock, now)
+		updated := repositorytest.Customer
+		updated.Email = repositorytest.UpdatedEmail
+		mock.ExpectPrepare(updateCustomerQuery).
+			ExpectExec().
+			WithArgs(append(customerArgs(updated), 1)...).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectCustomerGet(mock, repositorytest.UpdatedEmail, now)
+		expectCustomerGet(mock, repositorytest.UpdatedEmail, now)
+	case repositorytest.AccountSaveForCustomer:
+		expectCustomerSave(mock, now)
+		mock.ExpectPrepare(insertForCustomerQuery).
+			ExpectExec().
+			WithArgs(repositorytest.DocumentNumber, repositorytest.AvailableCreditLimit, 1, repositorytest.AccountCreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectPrepare(selectAccountQuery).
+			ExpectQuery().
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(accountColumns).
+				AddRow(1, repositorytest.DocumentNumber, repositorytest.AvailableCreditLimit, 1, now))
+	case repositorytest.CardSaveAndGet:
+		expectCardSave(mock, now)
+		expectCardGet(mock, entity.CardStatusActive, now)
+	case repositorytest.CardNotFound:
+		mock.ExpectPrepare(selectCardQuery).
+			ExpectQuery().
+			WithArgs(repositorytest.MissingID).
+			WillReturnRows(sqlmock.NewRows(cardColumns))
+	case repositorytest.CardUpdateStatus:
+		expectCardSave(mock, now)
+		mock.ExpectPrepare(updateCardStatusQuery).
+			ExpectExec().
+			WithArgs(entity.CardStatusBlocked, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectCardGet(mock, entity.CardStatusBlocked, now)
+	case repositorytest.TransactionSumByCardSince:
+		expectCardSave(mock, now)
+		mock.ExpectPrepare(insertDetailedQuery).
+			ExpectExec().
+			WithArgs(1, entity.OperationTypeCompraAVista, -50.0, nil, nil, nil, 1, nil, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		expectCardTransactionGet(mock, now)
+		expectTransactionSave(mock, 2, entity.OperationTypeSaque, -30, now)
+		expectCardTransactionGet(mock, now)
+		mock.ExpectPrepare(sumByCardQuery).
+			ExpectQuery().
+			WithArgs(1, entity.OperationTypePagamento, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(50))
+	case repositorytest.TransactionSaveBackdated:
+		eventDate := repositorytest.EventDate
+		expectAccountSave(mock)
+		expectTransactionSave(mock, 1, entity.OperationTypeSaque, -30, now)
+		mock.ExpectPrepare(insertDetailedQuery).
+			ExpectExec().
+			WithArgs(1, entity.OperationTypeCompraAVista, -50.0, nil, nil, nil, nil, eventDate, nil).
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		for i := 0; i < 2; i++ {
+			mock.ExpectPrepare(selectTransactionQuery).
+				ExpectQuery().
+				WithArgs(2).
+				WillReturnRows(sqlmock.NewRows(transactionColumns).
+					AddRow(2, 1, entity.OperationTypeCompraAVista, -50, 0, "", 0, 0, 0, eventDate, now))
+		}
+		expectBackdatedList(mock, now)
+		expectSaveWithLinked(mock, 3, -20, eventDate, nil, eventDate, now)
+	case repositorytest.AccountSetCreditLimit:
+		expectAccountSave(mock)
+		expectSetCreditLimit(mock)
+	case repositorytest.AuditAppendAndList:
+		chain := repositorytest.AuditChain()
+		expectAuditAppend(mock, chain[0])
+		expectAuditAppend(mock, chain[1])
+		mock.ExpectPrepare(listAuditQuery).
+			ExpectQuery().
+			WithArgs(entity.AuditEntityAccount, 1, 10).
+			WillReturnRows(sqlmock.NewRows(auditColumns).AddRow(auditRow(chain[1])...).AddRow(auditRow(chain[0])...))
+		mock.ExpectPrepare(listAuditQuery).
+			ExpectQuery().
+			WithArgs(entity.AuditEntityAccount, repositorytest.MissingID, 10).
+			WillReturnRows(sqlmock.NewRows(auditColumns))
+		mock.ExpectPrepare(eachAuditQuery).
+			ExpectQuery().
+			WillReturnRows(sqlmock.NewRows(auditColumns).AddRow(auditRow(chain[0])...).AddRow(auditRow(chain[1])...))
+	}
+}
+
+// expectSaveWithLinked expect the withdrawal and its fee of 5, with the next id, saved in a single transaction
+func expectSaveWithLinked(
+	mock sqlmock.Sqlmock,
+	id int64,
+	amount float64,
+	eventDate, createdAt interface{},
+	savedEventDate, savedCreatedAt time.Time,
+) {
+	mock.ExpectBegin()
+	mock.ExpectPrepare(insertDetailedQuery).
+		ExpectExec().
+		WithArgs(1, entity.OperationTypeSaque, amount, nil, nil, nil, nil, eventDate, createdAt).
+		WillReturnResult(sqlmock.NewResult(id, 1))
+	mock.ExpectPrepare(insertLinkedQuery).
+		ExpectExec().
+		WithArgs(entity.OperationTypeTarifa, -5.0, id).
+		WillReturnResult(sqlmock.NewResult(id+1, 1))
+	mock.ExpectPrepare(selectTransactionQuery).
+		ExpectQuery().
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows(transactionColumns).
+			AddRow(id, 1, entity.OperationTypeSaque, amount, 0, "", 0, 0, 0, savedEventDate, savedCreatedAt))
+	mock.ExpectPrepare(selectTransactionQuery).
+		ExpectQuery().
+		WithArgs(id + 1).
+		WillReturnRows(sqlmock.NewRows(transactionColumns).
+			AddRow(id+1, 1, entity.OperationTypeTarifa, -5, id, "", 0, 0, 0, savedEventDate, savedCreatedAt))
+	mock.ExpectCommit()
+}
+
+// expectBackdatedList expect the backdated purchase listed before the withdrawal and left out of the debits since now
+func expectBackdatedList(mock sqlmock.Sqlmock, now time.Time) {
+	mock.ExpectPrepare(listTransactionsQuery).
+		ExpectQuery().
+		WithArgs(1, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "event_date"}).
+				AddRow(2, 1, entity.OperationTypeCompraAVista, -50, repositorytest.EventDate).
+				AddRow(1, 1, entity.OperationTypeSaque, -30, now),
+		)
+	mock.ExpectPrepare(sumDebitsQuery).
+		ExpectQuery().
+		WithArgs(1, entity.OperationTypePagamento, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "amount"}).AddRow(2, 80))
+}
+
+func expectCardSave(mock sqlmock.Sqlmock, now time.Time) {
+	expectAccountSave(mock)
+	mock.ExpectPrepare(insertCardQuery).
+		ExpectExec().
+		WithArgs(append(cardArgs(repositorytest.Card), nil)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectCardGet(mock, entity.CardStatusActive, now)
+}
+
+func expectCardGet(mock sqlmock.Sqlmock, status entity.CardStatus, now time.Time) {
+	c := repositorytest.Card
+	c.Status = status
+	row := append([]driver.Value{1}, cardArgs(c)...)
+
+	mock.ExpectPrepare(selectCardQuery).
+		ExpectQuery().
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(cardColumns).AddRow(append(row, 0, now)...))
+}
+
+// expectCardTransactionGet expect the purchase made with the card by the sum by card case
+func expectCardTransactionGet(mock sqlmock.Sqlmock, now time.Time) {
+	mock.ExpectPrepare(selectTransactionQuery).
+		ExpectQuery().
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(transactionColumns).
+			AddRow(1, 1, entity.OperationTypeCompraAVista, -50, 0, "", 0, 0, 1, now, now))
+}
+
+// cardArgs are the columns of the card of the first account, in the order of the insert and the select
+func cardArgs(c entity.Card) []driver.Value {
+	return []driver.Value{1, c.Type, c.MaskedPAN, c.Token, c.ExpiryMonth, c.ExpiryYear, c.Status, c.Limit}
+}
+
+func expectCustomerSave(mock sqlmock.Sqlmock, now time.Time) {
+	mock.ExpectPrepare(insertCustomerQuery).
+		ExpectExec().
+		WithArgs(customerArgs(repositorytest.Customer)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectCustomerGet(mock, repositorytest.Customer.Email, now)
+}
+
+func expectCustomerGet(mock sqlmock.Sqlmock, email string, now time.Time) {
+	c := repositorytest.Customer
+	c.Email = email
+	row := append([]driver.Value{1}, customerArgs(c)...)
+
+	mock.ExpectPrepare(selectCustomerQuery).
+		ExpectQuery().
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(customerColumns).AddRow(append(row, now, now)...))
+}
+
+// customerArgs are the profile columns of the customer, in the order of the insert and the select
+func customerArgs(c entity.Customer) []driver.Value {
+	return []driver.Value{
+		c.Name,
+		c.BirthDate,
+		c.CompanyName,
+		c.Email,
+		c.Phone,
+		c.Address.Street,
+		c.Address.Number,
+		c.Address.Complement,
+		c.Address.City,
+		c.Address.State,
+		c.Address.PostalCode,
+		c.Address.Country,
+	}
+}
+
+func expectChargeReserve(mock sqlmock.Sqlmock, chargeType entity.ChargeType, result driver.Result) {
+	mock.ExpectPrepare(reserveChargeQuery).
+		ExpectExec().
+		WithArgs(1, chargeType, "2022-03-25", repositorytest.ChargeAmount).
+		WillReturnResult(result)
+}
+
+func expectJobsDelete(mock sqlmock.Sqlmock, deleted int64) {
+	mock.ExpectPrepare(deleteJobsQuery).
+		ExpectExec().
+		WithArgs(entity.JobStatusSucceeded, entity.JobStatusFailed, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, deleted))
+}
+
+func expectCheckpointSave(mock sqlmock.Sqlmock, availableCreditLimit float64, transactionID int, updatedAt time.Time) {
+	mock.ExpectPrepare(saveCheckpointQuery).
+		ExpectExec().
+		WithArgs(1, availableCreditLimit, transactionID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare(selectCheckpointQuery).
+		ExpectQuery().
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(checkpointColumns).AddRow(1, availableCreditLimit, transactionID, updatedAt))
+}
+
+func expectLock(mock sqlmock.Sqlmock, acquired int) {
+	mock.ExpectQuery(getLockQuery).
+		WithArgs(repositorytest.LockName).
+		WillReturnRows(sqlmock.NewRows([]string{"acquired"}).AddRow(acquired))
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(releaseLockQuery).
+		WithArgs(repositorytest.LockName).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+// expectTransactionsSave expect the account and the transactions saved by the cases listing them
+func expectTransactionsSave(mock sqlmock.Sqlmock, now time.Time) {
+	expectAccountSave(mock)
+	expectTransactionSave(mock, 1, entity.OperationTypeCompraAVista, -50, now)
+	expectTransactionSave(mock, 2, entity.OperationTypeSaque, -30, now)
+	expectTransactionSave(mock, 3, entity.OperationTypePagamento, 100, now)
+}
+
+func expectAccountSave(mock sqlmock.Sqlmock) {
+	mock.ExpectPrepare(insertAccountQuery).
+		ExpectExec().
+		WithArgs(repositorytest.DocumentNumber, repositorytest.AvailableCreditLimit, repositorytest.AccountCreatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func expectAccountGet(mock sqlmock.Sqlmock, availableCreditLimit float64, createdAt time.Time) {
+	mock.ExpectPrepare(selectAccountQuery).
+		ExpectQuery().
+		WithArgs(1).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "document_number", "available_credit_limit", "customer_id", "created_at"}).
+				AddRow(1, repositorytest.DocumentNumber, availableCreditLimit, 0, createdAt),
+		)
+}
+
+func expectTransactionSave(mock sqlmock.Sqlmock, id int64, operationTypeID int, amount float64, createdAt time.Time) {
+	mock.ExpectPrepare(insertTransactionQuery).
+		ExpectExec().
+		WithArgs(1, operationTypeID, amount).
+		WillReturnResult(sqlmock.NewResult(id, 1))
+	expectTransactionGet(mock, id, operationTypeID, amount, createdAt)
+}
+
+func expectTransactionGet(mock sqlmock.Sqlmock, id int64, operationTypeID int, amount float64, createdAt time.Time) {
+	mock.ExpectPrepare(selectTransactionQuery).
+		ExpectQuery().
+		WithArgs(id).
+		WillReturnRows(
+			sqlmock.NewRows(transactionColumns).AddRow(id, 1, operationTypeID, amount, 0, "", 0, 0, 0, createdAt, createdAt),
+		)
+}
+
+func expectJobSave(mock sqlmock.Sqlmock, createdAt time.Time) {
+	mock.ExpectPrepare(insertJobQuery).
+		ExpectExec().
+		WithArgs(
+			repositorytest.JobType,
+			[]byte(repositorytest.JobPayload),
+			entity.JobStatusQueued,
+			repositorytest.JobMaxAttempts,
+			0,
+			repositorytest.JobActor,
+			repositorytest.JobRequestID,
+			repositorytest.JobRunAt,
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectJobGet(mock, jobRow(entity.JobStatusQueued, 0, 0, nil, repositorytest.JobRunAt, createdAt))
+}
+
+func expectJobGet(mock sqlmock.Sqlmock, row []driver.Value) {
+	mock.ExpectPrepare(selectJobQuery).
+		ExpectQuery().
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(jobColumns).AddRow(row...))
+}
+
+func expectJobClaimNotFound(mock sqlmock.Sqlmock, now time.Time) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(claimJobQuery).
+		WithArgs(entity.JobStatusQueued, entity.JobStatusRunning, now).
+		WillReturnRows(sqlmock.NewRows(jobColumns))
+	mock.ExpectRollback()
+}
+
+func jobRow(status entity.JobStatus, progress, attempts int, result []byte, runAt, createdAt time.Time) []driver.Value {
+	return []driver.Value{
+		1,
+		repositorytest.JobType,
+		[]byte(repositorytest.JobPayload),
+		status,
+		progress,
+		attempts,
+		repositorytest.JobMaxAttempts,
+		result,
+		"",
+		0,
+		repositorytest.JobActor,
+		repositorytest.JobRequestID,
+		runAt,
+		createdAt,
+	}
+}
+
+// expectAuditAppend expect the entry chained to the head of the chain, which is empty for the first entry
+func expectAuditAppend(mock sqlmock.Sqlmock, entry entity.AuditEntry) {
+	lastHash := sqlmock.NewRows([]string{"hash"}).AddRow(entry.PrevHash)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(auditChainHeadQuery).WillReturnRows(lastHash)
+	mock.ExpectExec(insertAuditQuery).
+		WithArgs(auditArgs(entry)...).
+		WillReturnResult(sqlmock.NewResult(int64(entry.ID), 1))
+	mock.ExpectExec(updateChainHeadQuery).WithArgs(entry.Hash).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+// auditArgs are the inserted columns of the entry, the empty snapshots are NULL
+func auditArgs(e entity.AuditEntry) []driver.Value {
+	return []driver.Value{
+		e.Actor, e.RequestID, e.Action, e.Entity, e.EntityID, nullState(e.Before), nullState(e.After), e.PrevHash, e.Hash, e.CreatedAt,
+	}
+}
+
+func auditRow(e entity.AuditEntry) []driver.Value {
+	return append([]driver.Value{e.ID}, auditArgs(e)...)
+}
+
+func nullState(state string) driver.Value {
+	if state == "" {
+		return nil
+	}
+
+	return state
+}
+
+// expectSetCreditLimit expect the limit of the saved account set to the updated one with its adjustment, then read
+// back with its history, and a missing account rolled back
+func expectSetCreditLimit(mock sqlmock.Sqlmock) {
+	adjustment := repositorytest.UpdatedCreditLimit - repositorytest.AvailableCreditLimit
+	adjustmentColumns := []string{"id", "account_id", "amount", "created_at"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectAccountLockQuery).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(accountColumns).
+			AddRow(1, repositorytest.DocumentNumber, repositorytest.AvailableCreditLimit, 0, repositorytest.AccountCreatedAt))
+	mock.ExpectExec(setCreditLimitQuery).
+		WithArgs(repositorytest.UpdatedCreditLimit, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertAdjustmentQuery).
+		WithArgs(1, adjustment, repositorytest.AdjustedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	expectAccountGet(mock, repositorytest.UpdatedCreditLimit, repositorytest.AccountCreatedAt)
+
+	mock.ExpectPrepare(sumAdjustmentsQuery).
+		ExpectQuery().
+		WithArgs(1, repositorytest.AdjustedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(adjustment))
+	mock.ExpectPrepare(sumAdjustmentsQuery).
+		ExpectQuery().
+		WithArgs(1, repositorytest.AdjustedAt.Add(time.Second)).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(0))
+	mock.ExpectPrepare(listAdjustmentsQuery).
+		ExpectQuery().
+		WithArgs(1, repositorytest.AdjustedAt, repositorytest.AdjustedAt.Add(time.Hour)).
+		WillReturnRows(sqlmock.NewRows(adjustmentColumns).AddRow(1, 1, adjustment, repositorytest.AdjustedAt))
+	mock.ExpectPrepare(listAdjustmentsQuery).
+		ExpectQuery().
+		WithArgs(1, repositorytest.AccountCreatedAt, repositorytest.AdjustedAt).
+		WillReturnRows(sqlmock.NewRows(adjustmentColumns))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectAccountLockQuery).
+		WithArgs(repositorytest.MissingID).
+		WillReturnRows(sqlmock.NewRows(accountColumns))
+	mock.ExpectRollback()
+}
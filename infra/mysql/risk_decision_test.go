@@ -0,0 +1,157 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_riskDecisionRepository_Save(t *testing.T) {
+	insertQuery := "INSERT INTO transaction_decisions (transaction_id, account_id, operation_type_id, amount, outcome, reason_codes) VALUES(?, ?, ?, ?, ?, ?)"
+
+	type args struct {
+		ctx      context.Context
+		decision *entity.RiskDecision
+	}
+	testCases := []struct {
+		name    string
+		mock    func() (*sql.DB, sqlmock.Sqlmock, error)
+		args    args
+		want    *entity.RiskDecision
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Error prepare",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(insertQuery).
+					WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			args: args{
+				ctx:      context.TODO(),
+				decision: &entity.RiskDecision{AccountID: 1, OperationTypeID: 3, Amount: -50, Outcome: entity.RiskOutcomeApprove},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "Error execution",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(insertQuery).ExpectExec().
+					WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			args: args{
+				ctx:      context.TODO(),
+				decision: &entity.RiskDecision{AccountID: 1, OperationTypeID: 3, Amount: -50, Outcome: entity.RiskOutcomeApprove},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "Success declined without transaction",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(insertQuery).ExpectExec().
+					WithArgs(nil, 1, 3, -50.0, entity.RiskOutcomeDecline, "NEW_ACCOUNT,NIGHT").
+					WillReturnResult(sqlmock.NewResult(5, 1))
+
+				return db, mock, nil
+			},
+			args: args{
+				ctx: context.TODO(),
+				decision: &entity.RiskDecision{
+					AccountID:       1,
+					OperationTypeID: 3,
+					Amount:          -50,
+					Outcome:         entity.RiskOutcomeDecline,
+					ReasonCodes:     []string{"NEW_ACCOUNT", "NIGHT"},
+				},
+			},
+			want: &entity.RiskDecision{
+				ID:              5,
+				AccountID:       1,
+				OperationTypeID: 3,
+				Amount:          -50,
+				Outcome:         entity.RiskOutcomeDecline,
+				ReasonCodes:     []string{"NEW_ACCOUNT", "NIGHT"},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Success approved with transaction",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(insertQuery).ExpectExec().
+					WithArgs(10, 1, 1, -50.0, entity.RiskOutcomeApprove, "").
+					WillReturnResult(sqlmock.NewResult(6, 1))
+
+				return db, mock, nil
+			},
+			args: args{
+				ctx: context.TODO(),
+				decision: &entity.RiskDecision{
+					TransactionID:   10,
+					AccountID:       1,
+					OperationTypeID: 1,
+					Amount:          -50,
+					Outcome:         entity.RiskOutcomeApprove,
+				},
+			},
+			want: &entity.RiskDecision{
+				ID:              6,
+				TransactionID:   10,
+				AccountID:       1,
+				OperationTypeID: 1,
+				Amount:          -50,
+				Outcome:         entity.RiskOutcomeApprove,
+			},
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := tc.mock()
+			assert.NoError(t, err)
+
+			defer func() {
+				db.Close()
+				assert.NoError(t, mock.ExpectationsWereMet())
+			}()
+
+			r := NewRiskDecisionRepository(db)
+
+			got, err := r.Save(tc.args.ctx, tc.args.decision)
+			if !tc.wantErr(t, err, fmt.Sprintf("Save(%v, %v)", tc.args.ctx, tc.args.decision)) {
+				return
+			}
+			assert.Equalf(t, tc.want, got, "Save(%v, %v)", tc.args.ctx, tc.args.decision)
+		})
+	}
+}
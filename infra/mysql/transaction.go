@@ -5,12 +5,18 @@ import (
 	"database/sql"
 	"github.com/brunomdev/digital-account/domain/transaction"
 	"github.com/brunomdev/digital-account/entity"
+	"time"
 )
 
 type transactionRepository struct {
 	db *sql.DB
 }
 
+// preparer is the database or one of its transactions
+type preparer interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
 func NewTransactionRepository(db *sql.DB) transaction.Repository {
 	return &transactionRepository{db: db}
 }
@@ -37,19 +43,43 @@ func (r transactionRepository) Save(ctx context.Context, accountID, operationTyp
 }
 
 func (r transactionRepository) GetByID(ctx context.Context, id int) (*entity.Transaction, error) {
-	stmt, err := r.db.PrepareContext(ctx, `SELECT id, account_id, operation_type_id, amount, created_at FROM transactions WHERE id = ?`)
+	return getTransaction(ctx, r.db, id)
+}
+
+// getTransaction read the transaction through the database or one of its transactions
+func getTransaction(ctx context.Context, db preparer, id int) (*entity.Transaction, error) {
+	stmt, err := db.PrepareContext(ctx, `SELECT id, account_id, operation_type_id, amount, COALESCE(parent_id, 0), COALESCE(currency, ''), COALESCE(original_amount, 0), COALESCE(exchange_rate, 0), COALESCE(card_id, 0), event_date, created_at FROM transactions WHERE id = ?`)
 	if err != nil {
 		return nil, err
 	}
 
-	var txn entity.Transaction
+	defer stmt.Close()
+
+	var (
+		txn      entity.Transaction
+		exchange entity.Exchange
+	)
 	rows, err := stmt.QueryContext(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	defer rows.Close()
+
 	for rows.Next() {
-		err = rows.Scan(&txn.ID, &txn.AccountID, &txn.OperationTypeID, &txn.Amount, &txn.EventDate)
+		err = rows.Scan(
+			&txn.ID,
+			&txn.AccountID,
+			&txn.OperationTypeID,
+			&txn.Amount,
+			&txn.ParentID,
+			&exchange.Currency,
+			&exchange.OriginalAmount,
+			&exchange.Rate,
+			&txn.CardID,
+			&txn.EventDate,
+			&txn.CreatedAt,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -59,5 +89,337 @@ func (r transactionRepository) GetByID(ctx context.Context, id int) (*entity.Tra
 		return nil, entity.ErrNotFound
 	}
 
+	if exchange.Currency != "" {
+		txn.Exchange = &exchange
+	}
+
 	return &txn, nil
 }
+
+func (r transactionRepository) SaveDetailed(ctx context.Context, txn *entity.Transaction) (*entity.Transaction, error) {
+	id, err := insertDetailed(ctx, r.db, txn)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+func (r transactionRepository) SaveWithLinked(
+	ctx context.Context,
+	txn *entity.Transaction,
+	linked []*entity.Transaction,
+) (*entity.Transaction, []*entity.Transaction, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer tx.Rollback()
+
+	id, err := insertDetailed(ctx, tx, txn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := []int{id}
+	for _, l := range linked {
+		linkedID, err := insertLinked(ctx, tx, id, l)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		ids = append(ids, linkedID)
+	}
+
+	saved := make([]*entity.Transaction, 0, len(ids))
+	for _, id := range ids {
+		t, err := getTransaction(ctx, tx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		saved = append(saved, t)
+	}
+
+	return saved[0], saved[1:], tx.Commit()
+}
+
+// insertDetailed insert the transaction with its details, returning its id
+func insertDetailed(ctx context.Context, db preparer, txn *entity.Transaction) (int, error) {
+	stmt, err := db.PrepareContext(
+		ctx,
+		`INSERT INTO transactions (account_id, operation_type_id, amount, currency, original_amount, exchange_rate, card_id, event_date, created_at) VALUES(?, ?, ?, ?, ?, ?, ?, COALESCE(?, CURRENT_TIMESTAMP), COALESCE(?, CURRENT_TIMESTAMP))`,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	defer stmt.Close()
+
+	var currency, originalAmount, rate, cardID, eventDate, createdAt interface{}
+	if txn.Exchange != nil {
+		currency, originalAmount, rate = txn.Exchange.Currency, txn.Exchange.OriginalAmount, txn.Exchange.Rate
+	}
+
+	if txn.CardID != 0 {
+		cardID = txn.CardID
+	}
+
+	if !txn.EventDate.IsZero() {
+		eventDate = txn.EventDate
+	}
+
+	if !txn.CreatedAt.IsZero() {
+		createdAt = txn.CreatedAt
+	}
+
+	result, err := stmt.ExecContext(
+		ctx,
+		txn.AccountID,
+		txn.OperationTypeID,
+		txn.Amount,
+		currency,
+		originalAmount,
+		rate,
+		cardID,
+		eventDate,
+		createdAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+// insertLinked insert the transaction linked to the parent, on its account, event date and creation date, returning its id
+func insertLinked(ctx context.Context, db preparer, parentID int, txn *entity.Transaction) (int, error) {
+	stmt, err := db.PrepareContext(
+		ctx,
+		`INSERT INTO transactions (account_id, operation_type_id, amount, parent_id, event_date, created_at) SELECT account_id, ?, ?, id, event_date, created_at FROM transactions WHERE id = ?`,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, txn.OperationTypeID, txn.Amount, parentID)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+func (r transactionRepository) SumDebitsSince(ctx context.Context, accountID int, since time.Time) (int, float64, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT COUNT(id), COALESCE(SUM(ABS(amount)), 0) FROM transactions WHERE account_id = ? AND operation_type_id <> ? AND created_at >= ?`,
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer stmt.Close()
+
+	var (
+		count  int
+		amount float64
+	)
+	err = stmt.QueryRowContext(ctx, accountID, entity.OperationTypePagamento, since).Scan(&count, &amount)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return count, amount, nil
+}
+
+func (r transactionRepository) SumByOperationTypeSince(
+	ctx context.Context,
+	accountID, operationTypeID int,
+	since time.Time,
+) (int, float64, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT COUNT(id), COALESCE(SUM(ABS(amount)), 0) FROM transactions WHERE account_id = ? AND operation_type_id = ? AND created_at >= ?`,
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer stmt.Close()
+
+	var (
+		count  int
+		amount float64
+	)
+	err = stmt.QueryRowContext(ctx, accountID, operationTypeID, since).Scan(&count, &amount)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return count, amount, nil
+}
+
+func (r transactionRepository) SumByCardSince(ctx context.Context, cardID int, since time.Time) (float64, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT COALESCE(SUM(ABS(amount)), 0) FROM transactions WHERE card_id = ? AND operation_type_id <> ? AND created_at >= ?`,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	defer stmt.Close()
+
+	var amount float64
+	err = stmt.QueryRowContext(ctx, cardID, entity.OperationTypePagamento, since).Scan(&amount)
+	if err != nil {
+		return 0, err
+	}
+
+	return amount, nil
+}
+
+func (r transactionRepository) CountByAmountSince(
+	ctx context.Context,
+	accountID, operationTypeID int,
+	amount float64,
+	since time.Time,
+) (int, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT COUNT(id) FROM transactions WHERE account_id = ? AND operation_type_id = ? AND amount = ? AND created_at >= ?`,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	defer stmt.Close()
+
+	var count int
+	err = stmt.QueryRowContext(ctx, accountID, operationTypeID, amount, since).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r transactionRepository) SumLimitChangesSince(ctx context.Context, accountID int, since time.Time) (float64, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT COALESCE(SUM(CASE WHEN operation_type_id = ? THEN ABS(amount) ELSE -ABS(amount) END), 0) FROM transactions WHERE account_id = ? AND event_date >= ?`,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	defer stmt.Close()
+
+	var amount float64
+	err = stmt.QueryRowContext(ctx, entity.OperationTypePagamento, accountID, since).Scan(&amount)
+	if err != nil {
+		return 0, err
+	}
+
+	return amount, nil
+}
+
+func (r transactionRepository) ListByAccountID(
+	ctx context.Context,
+	accountID int,
+	from, to time.Time,
+	fn func(txn *entity.Transaction) error,
+) error {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT id, account_id, operation_type_id, amount, event_date FROM transactions WHERE account_id = ? AND event_date >= ? AND event_date < ? ORDER BY event_date, id`,
+	)
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, accountID, from, to)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var txn entity.Transaction
+
+		err = rows.Scan(&txn.ID, &txn.AccountID, &txn.OperationTypeID, &txn.Amount, &txn.EventDate)
+		if err != nil {
+			return err
+		}
+
+		err = fn(&txn)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r transactionRepository) SumLimitChangesAfter(ctx context.Context, accountID, afterID int) (float64, int, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT COALESCE(SUM(CASE WHEN operation_type_id = ? THEN ABS(amount) ELSE -ABS(amount) END), 0), COALESCE(MAX(id), ?) FROM transactions WHERE account_id = ? AND id > ?`,
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer stmt.Close()
+
+	var (
+		amount float64
+		lastID int
+	)
+	err = stmt.QueryRowContext(ctx, entity.OperationTypePagamento, afterID, accountID, afterID).Scan(&amount, &lastID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return amount, lastID, nil
+}
+
+func (r transactionRepository) SumBalanceBefore(ctx context.Context, accountID int, before time.Time) (float64, float64, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT COALESCE(SUM(CASE WHEN operation_type_id <> ? THEN ABS(amount) ELSE 0 END), 0), COALESCE(SUM(CASE WHEN operation_type_id = ? THEN ABS(amount) ELSE 0 END), 0) FROM transactions WHERE account_id = ? AND event_date < ?`,
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer stmt.Close()
+
+	var (
+		debits   float64
+		payments float64
+	)
+	err = stmt.QueryRowContext(ctx, entity.OperationTypePagamento, entity.OperationTypePagamento, accountID, before).Scan(&debits, &payments)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return debits, payments, nil
+}
@@ -0,0 +1,218 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_transactionAttemptRepository_Save(t *testing.T) {
+	insertQuery := "INSERT INTO transaction_attempts (account_id, operation_type_id, amount, outcome, decline_reason, transaction_id) VALUES(?, ?, ?, ?, ?, ?)"
+
+	testCases := []struct {
+		name    string
+		mock    func() (*sql.DB, sqlmock.Sqlmock, error)
+		attempt *entity.TransactionAttempt
+		want    *entity.TransactionAttempt
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Error prepare",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(insertQuery).
+					WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			attempt: &entity.TransactionAttempt{AccountID: 1, OperationTypeID: 1, Amount: -10},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "Error without LastInsertId",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(insertQuery).ExpectExec().
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+
+				return db, mock, nil
+			},
+			attempt: &entity.TransactionAttempt{AccountID: 1, OperationTypeID: 1, Amount: -10},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "Success declined",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(insertQuery).ExpectExec().
+					WithArgs(1, 1, -10.0, entity.AttemptOutcomeDeclined, "INSUFFICIENT_CREDIT_LIMIT", nil).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+
+				return db, mock, nil
+			},
+			attempt: &entity.TransactionAttempt{
+				AccountID:       1,
+				OperationTypeID: 1,
+				Amount:          -10,
+				Outcome:         entity.AttemptOutcomeDeclined,
+				DeclineReason:   "INSUFFICIENT_CREDIT_LIMIT",
+			},
+			want: &entity.TransactionAttempt{
+				ID:              3,
+				AccountID:       1,
+				OperationTypeID: 1,
+				Amount:          -10,
+				Outcome:         entity.AttemptOutcomeDeclined,
+				DeclineReason:   "INSUFFICIENT_CREDIT_LIMIT",
+			},
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := tc.mock()
+			assert.NoError(t, err)
+
+			defer func() {
+				db.Close()
+				assert.NoError(t, mock.ExpectationsWereMet())
+			}()
+
+			r := NewTransactionAttemptRepository(db)
+
+			got, err := r.Save(context.TODO(), tc.attempt)
+			if !tc.wantErr(t, err, fmt.Sprintf("Save(%v)", tc.attempt)) {
+				return
+			}
+			assert.Equalf(t, tc.want, got, "Save(%v)", tc.attempt)
+		})
+	}
+}
+
+func Test_transactionAttemptRepository_ListByAccountID(t *testing.T) {
+	selectQuery := "SELECT id, account_id, operation_type_id, amount, outcome, decline_reason, transaction_id, created_at FROM transaction_attempts WHERE account_id = ? ORDER BY id DESC LIMIT ?"
+	columns := []string{"id", "account_id", "operation_type_id", "amount", "outcome", "decline_reason", "transaction_id", "created_at"}
+	createdAt := time.Date(2022, 3, 23, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		mock    func() (*sql.DB, sqlmock.Sqlmock, error)
+		want    []*entity.TransactionAttempt
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Error query",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).
+					ExpectQuery().WithArgs(1, 50).WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "Error row scan",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).
+					ExpectQuery().WithArgs(1, 50).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 1, 1, -10.0, "approved", "", 4, "2022"))
+
+				return db, mock, nil
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "Success",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).
+					ExpectQuery().WithArgs(1, 50).
+					WillReturnRows(
+						sqlmock.NewRows(columns).
+							AddRow(2, 1, 1, -5000.0, "declined", "INSUFFICIENT_CREDIT_LIMIT", nil, createdAt).
+							AddRow(1, 1, 1, -10.0, "approved", "", 4, createdAt),
+					)
+
+				return db, mock, nil
+			},
+			want: []*entity.TransactionAttempt{
+				{
+					ID:              2,
+					AccountID:       1,
+					OperationTypeID: 1,
+					Amount:          -5000,
+					Outcome:         entity.AttemptOutcomeDeclined,
+					DeclineReason:   "INSUFFICIENT_CREDIT_LIMIT",
+					CreatedAt:       createdAt,
+				},
+				{
+					ID:              1,
+					AccountID:       1,
+					OperationTypeID: 1,
+					Amount:          -10,
+					Outcome:         entity.AttemptOutcomeApproved,
+					TransactionID:   4,
+					CreatedAt:       createdAt,
+				},
+			},
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := tc.mock()
+			assert.NoError(t, err)
+
+			defer func() {
+				db.Close()
+				assert.NoError(t, mock.ExpectationsWereMet())
+			}()
+
+			r := NewTransactionAttemptRepository(db)
+
+			got, err := r.ListByAccountID(context.TODO(), 1, 50)
+			if !tc.wantErr(t, err, fmt.Sprintf("ListByAccountID(%v, %v)", 1, 50)) {
+				return
+			}
+			assert.Equalf(t, tc.want, got, "ListByAccountID(%v, %v)", 1, 50)
+		})
+	}
+}
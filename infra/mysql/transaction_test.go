@@ -14,7 +14,7 @@ import (
 
 func Test_transactionRepository_Save(t *testing.T) {
 	insertQuery := "INSERT INTO transactions (account_id, operation_type_id, amount) VALUES(?, ?, ?)"
-	selectQuery := "SELECT id, account_id, operation_type_id, amount, created_at FROM transactions WHERE id = ?"
+	selectQuery := "SELECT id, account_id, operation_type_id, amount, COALESCE(parent_id, 0), COALESCE(currency, ''), COALESCE(original_amount, 0), COALESCE(exchange_rate, 0), COALESCE(card_id, 0), event_date, created_at FROM transactions WHERE id = ?"
 
 	type args struct {
 		ctx             context.Context
@@ -111,8 +111,8 @@ func Test_transactionRepository_Save(t *testing.T) {
 				mock.ExpectPrepare(selectQuery).ExpectQuery().
 					WithArgs(1).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "created_at"}).
-							AddRow(1, 1, 4, 123.45, time.Date(2022, 3, 17, 17, 30, 0, 0, time.UTC)),
+						sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "parent_id", "currency", "original_amount", "exchange_rate", "card_id", "event_date", "created_at"}).
+							AddRow(1, 1, 4, 123.45, 0, "", 0, 0, 0, time.Date(2022, 3, 17, 17, 30, 0, 0, time.UTC), time.Date(2022, 3, 17, 17, 30, 0, 0, time.UTC)),
 					)
 
 				return db, mock, nil
@@ -129,6 +129,7 @@ func Test_transactionRepository_Save(t *testing.T) {
 				OperationTypeID: 4,
 				Amount:          123.45,
 				EventDate:       time.Date(2022, 3, 17, 17, 30, 0, 0, time.UTC),
+				CreatedAt:       time.Date(2022, 3, 17, 17, 30, 0, 0, time.UTC),
 			},
 			wantErr: assert.NoError,
 		},
@@ -164,7 +165,7 @@ func Test_transactionRepository_Save(t *testing.T) {
 }
 
 func Test_transactionRepository_GetByID(t *testing.T) {
-	selectQuery := "SELECT id, account_id, operation_type_id, amount, created_at FROM transactions WHERE id = ?"
+	selectQuery := "SELECT id, account_id, operation_type_id, amount, COALESCE(parent_id, 0), COALESCE(currency, ''), COALESCE(original_amount, 0), COALESCE(exchange_rate, 0), COALESCE(card_id, 0), event_date, created_at FROM transactions WHERE id = ?"
 
 	type args struct {
 		ctx context.Context
@@ -228,8 +229,8 @@ func Test_transactionRepository_GetByID(t *testing.T) {
 				mock.ExpectPrepare(selectQuery).
 					ExpectQuery().WithArgs(1).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "acount_id", "operation_type_id", "amount", "created_at"}).
-							AddRow(1, 1, 4, 123.45, "2022"),
+						sqlmock.NewRows([]string{"id", "acount_id", "operation_type_id", "amount", "parent_id", "currency", "original_amount", "exchange_rate", "card_id", "event_date", "created_at"}).
+							AddRow(1, 1, 4, 123.45, 0, "", 0, 0, 0, "2022", "2022"),
 					)
 
 				return db, mock, nil
@@ -252,7 +253,7 @@ func Test_transactionRepository_GetByID(t *testing.T) {
 				mock.ExpectPrepare(selectQuery).
 					ExpectQuery().WithArgs(1).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "acount_id", "operation_type_id", "amount", "created_at"}),
+						sqlmock.NewRows([]string{"id", "acount_id", "operation_type_id", "amount", "parent_id", "currency", "original_amount", "exchange_rate", "card_id", "event_date", "created_at"}),
 					)
 
 				return db, mock, nil
@@ -275,8 +276,8 @@ func Test_transactionRepository_GetByID(t *testing.T) {
 				mock.ExpectPrepare(selectQuery).
 					ExpectQuery().WithArgs(1).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "acount_id", "operation_type_id", "amount", "created_at"}).
-							AddRow(1, 1, 4, 123.45, time.Date(2022, 3, 17, 17, 30, 0, 0, time.UTC)),
+						sqlmock.NewRows([]string{"id", "acount_id", "operation_type_id", "amount", "parent_id", "currency", "original_amount", "exchange_rate", "card_id", "event_date", "created_at"}).
+							AddRow(1, 1, 4, 123.45, 0, "", 0, 0, 0, time.Date(2022, 3, 17, 17, 30, 0, 0, time.UTC), time.Date(2022, 3, 17, 17, 30, 0, 0, time.UTC)),
 					)
 
 				return db, mock, nil
@@ -291,6 +292,7 @@ func Test_transactionRepository_GetByID(t *testing.T) {
 				OperationTypeID: 4,
 				Amount:          123.45,
 				EventDate:       time.Date(2022, 3, 17, 17, 30, 0, 0, time.UTC),
+				CreatedAt:       time.Date(2022, 3, 17, 17, 30, 0, 0, time.UTC),
 			},
 			wantErr: assert.NoError,
 		},
@@ -316,3 +318,346 @@ func Test_transactionRepository_GetByID(t *testing.T) {
 		})
 	}
 }
+
+func Test_transactionRepository_SumDebitsSince(t *testing.T) {
+	selectQuery := "SELECT COUNT(id), COALESCE(SUM(ABS(amount)), 0) FROM transactions WHERE account_id = ? AND operation_type_id <> ? AND created_at >= ?"
+	since := time.Date(2022, 3, 17, 17, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		mock       func() (*sql.DB, sqlmock.Sqlmock, error)
+		wantCount  int
+		wantAmount float64
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Error prepare",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).
+					WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Error query",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).
+					ExpectQuery().WithArgs(1, entity.OperationTypePagamento, since).
+					WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Success",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).
+					ExpectQuery().WithArgs(1, entity.OperationTypePagamento, since).
+					WillReturnRows(sqlmock.NewRows([]string{"count", "amount"}).AddRow(3, 150.50))
+
+				return db, mock, nil
+			},
+			wantCount:  3,
+			wantAmount: 150.50,
+			wantErr:    assert.NoError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := tc.mock()
+			assert.NoError(t, err)
+
+			defer func() {
+				db.Close()
+				assert.NoError(t, mock.ExpectationsWereMet())
+			}()
+
+			r := NewTransactionRepository(db)
+
+			count, amount, err := r.SumDebitsSince(context.TODO(), 1, since)
+			if !tc.wantErr(t, err, fmt.Sprintf("SumDebitsSince(%v, %v)", 1, since)) {
+				return
+			}
+			assert.Equal(t, tc.wantCount, count)
+			assert.Equal(t, tc.wantAmount, amount)
+		})
+	}
+}
+
+func Test_transactionRepository_CountByAmountSince(t *testing.T) {
+	selectQuery := "SELECT COUNT(id) FROM transactions WHERE account_id = ? AND operation_type_id = ? AND amount = ? AND created_at >= ?"
+	since := time.Date(2022, 3, 17, 17, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		mock      func() (*sql.DB, sqlmock.Sqlmock, error)
+		wantCount int
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Error prepare",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).
+					WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Success",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).
+					ExpectQuery().WithArgs(1, 1, -10.0, since).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+				return db, mock, nil
+			},
+			wantCount: 2,
+			wantErr:   assert.NoError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := tc.mock()
+			assert.NoError(t, err)
+
+			defer func() {
+				db.Close()
+				assert.NoError(t, mock.ExpectationsWereMet())
+			}()
+
+			r := NewTransactionRepository(db)
+
+			count, err := r.CountByAmountSince(context.TODO(), 1, 1, -10, since)
+			if !tc.wantErr(t, err, fmt.Sprintf("CountByAmountSince(%v, %v, %v, %v)", 1, 1, -10, since)) {
+				return
+			}
+			assert.Equal(t, tc.wantCount, count)
+		})
+	}
+}
+
+func Test_transactionRepository_SumLimitChangesSince(t *testing.T) {
+	selectQuery := "SELECT COALESCE(SUM(CASE WHEN operation_type_id = ? THEN ABS(amount) ELSE -ABS(amount) END), 0) FROM transactions WHERE account_id = ? AND event_date >= ?"
+	since := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		mock       func() (*sql.DB, sqlmock.Sqlmock, error)
+		wantAmount float64
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Error prepare",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).
+					WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Error query",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).
+					ExpectQuery().WithArgs(entity.OperationTypePagamento, 1, since).
+					WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Success",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).
+					ExpectQuery().WithArgs(entity.OperationTypePagamento, 1, since).
+					WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(-75.25))
+
+				return db, mock, nil
+			},
+			wantAmount: -75.25,
+			wantErr:    assert.NoError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := tc.mock()
+			assert.NoError(t, err)
+
+			defer func() {
+				db.Close()
+				assert.NoError(t, mock.ExpectationsWereMet())
+			}()
+
+			r := NewTransactionRepository(db)
+
+			amount, err := r.SumLimitChangesSince(context.TODO(), 1, since)
+			if !tc.wantErr(t, err, fmt.Sprintf("SumLimitChangesSince(%v, %v)", 1, since)) {
+				return
+			}
+			assert.Equal(t, tc.wantAmount, amount)
+		})
+	}
+}
+
+func Test_transactionRepository_ListByAccountID(t *testing.T) {
+	selectQuery := "SELECT id, account_id, operation_type_id, amount, event_date FROM transactions WHERE account_id = ? AND event_date >= ? AND event_date < ? ORDER BY event_date, id"
+	from := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+	eventDate := time.Date(2022, 3, 17, 17, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		mock    func() (*sql.DB, sqlmock.Sqlmock, error)
+		want    []*entity.Transaction
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Error prepare",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).
+					WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Error query",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).
+					ExpectQuery().WithArgs(1, from, to).
+					WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Error scan",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).
+					ExpectQuery().WithArgs(1, from, to).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "event_date"}).
+							AddRow("a", 1, 1, -50, eventDate),
+					)
+
+				return db, mock, nil
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "Success",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).
+					ExpectQuery().WithArgs(1, from, to).
+					WillReturnRows(
+						sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "event_date"}).
+							AddRow(1, 1, 1, -50, eventDate).
+							AddRow(2, 1, 4, 100, eventDate),
+					)
+
+				return db, mock, nil
+			},
+			want: []*entity.Transaction{
+				{ID: 1, AccountID: 1, OperationTypeID: 1, Amount: -50, EventDate: eventDate},
+				{ID: 2, AccountID: 1, OperationTypeID: 4, Amount: 100, EventDate: eventDate},
+			},
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := tc.mock()
+			assert.NoError(t, err)
+
+			defer func() {
+				db.Close()
+				assert.NoError(t, mock.ExpectationsWereMet())
+			}()
+
+			r := NewTransactionRepository(db)
+
+			var got []*entity.Transaction
+			err = r.ListByAccountID(context.TODO(), 1, from, to, func(txn *entity.Transaction) error {
+				got = append(got, txn)
+				return nil
+			})
+			if !tc.wantErr(t, err, fmt.Sprintf("ListByAccountID(%v, %v, %v)", 1, from, to)) {
+				return
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
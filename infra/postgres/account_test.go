@@ -0,0 +1,260 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_accountRepository_Save(t *testing.T) {
+	insertQuery := "INSERT INTO accounts (document_number, available_credit_limit, created_at) VALUES($1, $2, $3) RETURNING id"
+	createdAt := time.Date(2022, 3, 24, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		mock    func() (*sql.DB, sqlmock.Sqlmock, error)
+		want    *entity.Account
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Error prepare",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(insertQuery).
+					WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "Error execution",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(insertQuery).ExpectQuery().
+					WithArgs("12345678900", 50.00, createdAt).
+					WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "Success",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(insertQuery).ExpectQuery().
+					WithArgs("12345678900", 50.00, createdAt).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+				return db, mock, nil
+			},
+			want: &entity.Account{
+				ID:                   1,
+				DocumentNumber:       "12345678900",
+				AvailabelCreditLimit: 50.00,
+				CreatedAt:            createdAt,
+			},
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := tc.mock()
+			assert.NoError(t, err)
+
+			defer func() {
+				db.Close()
+				assert.NoError(t, mock.ExpectationsWereMet())
+			}()
+
+			r := NewAccountRepository(db)
+
+			got, err := r.Save(context.TODO(), "12345678900", 50.00, createdAt)
+			if !tc.wantErr(t, err, fmt.Sprintf("Save(%v, %v)", "12345678900", 50.00)) {
+				return
+			}
+			assert.Equalf(t, tc.want, got, "Save(%v, %v)", "12345678900", 50.00)
+		})
+	}
+}
+
+func Test_accountRepository_GetByID(t *testing.T) {
+	selectQuery := "SELECT id, document_number, available_credit_limit, COALESCE(customer_id, 0), created_at FROM accounts WHERE id = $1"
+	columns := []string{"id", "document_number", "available_credit_limit", "customer_id", "created_at"}
+	createdAt := time.Date(2022, 3, 24, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		mock    func() (*sql.DB, sqlmock.Sqlmock, error)
+		want    *entity.Account
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Error query",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).ExpectQuery().
+					WithArgs(1).
+					WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "Error not found",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).ExpectQuery().
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(columns))
+
+				return db, mock, nil
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, entity.ErrNotFound, msgAndArgs...)
+			},
+		},
+		{
+			name: "Success",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).ExpectQuery().
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "12345678900", 50.00, 0, createdAt))
+
+				return db, mock, nil
+			},
+			want: &entity.Account{
+				ID:                   1,
+				DocumentNumber:       "12345678900",
+				AvailabelCreditLimit: 50.00,
+				CreatedAt:            createdAt,
+			},
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := tc.mock()
+			assert.NoError(t, err)
+
+			defer func() {
+				db.Close()
+				assert.NoError(t, mock.ExpectationsWereMet())
+			}()
+
+			r := NewAccountRepository(db)
+
+			got, err := r.GetByID(context.TODO(), 1)
+			if !tc.wantErr(t, err, fmt.Sprintf("GetByID(%v)", 1)) {
+				return
+			}
+			assert.Equalf(t, tc.want, got, "GetByID(%v)", 1)
+		})
+	}
+}
+
+func Test_accountRepository_Update(t *testing.T) {
+	updateQuery := "UPDATE accounts SET document_number = $1, available_credit_limit = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3"
+	acc := &entity.Account{ID: 1, DocumentNumber: "12345678900", AvailabelCreditLimit: 80.00}
+
+	testCases := []struct {
+		name    string
+		mock    func() (*sql.DB, sqlmock.Sqlmock, error)
+		want    *entity.Account
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Error execution",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(updateQuery).ExpectExec().
+					WithArgs("12345678900", 80.00, 1).
+					WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "Success",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(updateQuery).ExpectExec().
+					WithArgs("12345678900", 80.00, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				return db, mock, nil
+			},
+			want:    acc,
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := tc.mock()
+			assert.NoError(t, err)
+
+			defer func() {
+				db.Close()
+				assert.NoError(t, mock.ExpectationsWereMet())
+			}()
+
+			r := NewAccountRepository(db)
+
+			got, err := r.Update(context.TODO(), acc)
+			if !tc.wantErr(t, err, fmt.Sprintf("Update(%v)", acc)) {
+				return
+			}
+			assert.Equalf(t, tc.want, got, "Update(%v)", acc)
+		})
+	}
+}
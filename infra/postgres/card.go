@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/brunomdev/digital-account/domain/card"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+)
+
+type cardRepository struct {
+	db *sql.DB
+}
+
+func NewCardRepository(db *sql.DB) card.Repository {
+	return &cardRepository{db: db}
+}
+
+func (r cardRepository) Save(ctx context.Context, c *entity.Card) (*entity.Card, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`INSERT INTO cards (account_id, type, masked_pan, token, expiry_month, expiry_year, status, card_limit, replaces_card_id) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id, created_at`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	var replacesID interface{}
+	if c.ReplacesID != 0 {
+		replacesID = c.ReplacesID
+	}
+
+	saved := *c
+	err = stmt.QueryRowContext(
+		ctx,
+		c.AccountID,
+		c.Type,
+		c.MaskedPAN,
+		c.Token,
+		c.ExpiryMonth,
+		c.ExpiryYear,
+		c.Status,
+		c.Limit,
+		replacesID,
+	).Scan(&saved.ID, &saved.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+func (r cardRepository) GetByID(ctx context.Context, id int) (*entity.Card, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT id, account_id, type, masked_pan, token, expiry_month, expiry_year, status, card_limit, COALESCE(replaces_card_id, 0), created_at FROM cards WHERE id = $1`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	var c entity.Card
+	err = stmt.QueryRowContext(ctx, id).Scan(
+		&c.ID,
+		&c.AccountID,
+		&c.Type,
+		&c.MaskedPAN,
+		&c.Token,
+		&c.ExpiryMonth,
+		&c.ExpiryYear,
+		&c.Status,
+		&c.Limit,
+		&c.ReplacesID,
+		&c.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+func (r cardRepository) UpdateStatus(ctx context.Context, id int, status entity.CardStatus) error {
+	stmt, err := r.db.PrepareContext(ctx, `UPDATE cards SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`)
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, status, id)
+
+	return err
+}
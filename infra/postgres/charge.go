@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/brunomdev/digital-account/domain/charge"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+)
+
+// chargeDateFormat is the format of the charge_date column
+const chargeDateFormat = "2006-01-02"
+
+type chargeRepository struct {
+	db *sql.DB
+}
+
+func NewChargeRepository(db *sql.DB) charge.Repository {
+	return &chargeRepository{db: db}
+}
+
+func (r chargeRepository) Reserve(ctx context.Context, c *entity.Charge) (*entity.Charge, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`INSERT INTO charges (account_id, type, charge_date, amount) VALUES($1, $2, $3, $4) ON CONFLICT (account_id, type, charge_date) DO NOTHING RETURNING id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	reserved := *c
+	err = stmt.QueryRowContext(ctx, c.AccountID, c.Type, c.Date.Format(chargeDateFormat), c.Amount).Scan(&reserved.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrAlreadyCharged
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &reserved, nil
+}
+
+func (r chargeRepository) SetTransactionID(ctx context.Context, id, transactionID int) error {
+	stmt, err := r.db.PrepareContext(ctx, `UPDATE charges SET transaction_id = $1 WHERE id = $2`)
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, transactionID, id)
+
+	return err
+}
+
+func (r chargeRepository) Delete(ctx context.Context, id int) error {
+	stmt, err := r.db.PrepareContext(ctx, `DELETE FROM charges WHERE id = $1`)
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, id)
+
+	return err
+}
@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/brunomdev/digital-account/domain/client"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+)
+
+type clientRepository struct {
+	db *sql.DB
+}
+
+func NewClientRepository(db *sql.DB) client.Repository {
+	return &clientRepository{db: db}
+}
+
+func (r clientRepository) GetByKeyHash(ctx context.Context, keyHash string) (*entity.Client, error) {
+	stmt, err := r.db.PrepareContext(ctx, `SELECT id, name, role, scopes, account_id FROM api_clients WHERE api_key_hash = $1`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	var (
+		cl        entity.Client
+		scopes    string
+		accountID sql.NullInt64
+	)
+	err = stmt.QueryRowContext(ctx, keyHash).Scan(&cl.ID, &cl.Name, &cl.Role, &scopes, &accountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cl.AccountID = int(accountID.Int64)
+	cl.Scopes = entity.ParseScopes(scopes)
+
+	return &cl, nil
+}
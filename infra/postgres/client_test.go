@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_clientRepository_GetByKeyHash(t *testing.T) {
+	selectQuery := "SELECT id, name, role, scopes, account_id FROM api_clients WHERE api_key_hash = $1"
+	columns := []string{"id", "name", "role", "scopes", "account_id"}
+
+	testCases := []struct {
+		name    string
+		mock    func() (*sql.DB, sqlmock.Sqlmock, error)
+		want    *entity.Client
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Error not found",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).ExpectQuery().
+					WithArgs("hash").
+					WillReturnRows(sqlmock.NewRows(columns))
+
+				return db, mock, nil
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, entity.ErrNotFound, msgAndArgs...)
+			},
+		},
+		{
+			name: "Success",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).ExpectQuery().
+					WithArgs("hash").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "app", "customer", "accounts:read, transactions:write", 3))
+
+				return db, mock, nil
+			},
+			want: &entity.Client{
+				ID:        1,
+				Name:      "app",
+				Role:      entity.RoleCustomer,
+				Scopes:    []entity.Scope{entity.ScopeAccountsRead, entity.ScopeTransactionsWrite},
+				AccountID: 3,
+			},
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := tc.mock()
+			assert.NoError(t, err)
+
+			defer func() {
+				db.Close()
+				assert.NoError(t, mock.ExpectationsWereMet())
+			}()
+
+			r := NewClientRepository(db)
+
+			got, err := r.GetByKeyHash(context.TODO(), "hash")
+			if !tc.wantErr(t, err, fmt.Sprintf("GetByKeyHash(%v)", "hash")) {
+				return
+			}
+			assert.Equalf(t, tc.want, got, "GetByKeyHash(%v)", "hash")
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/brunomdev/digital-account/domain/customer"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+)
+
+type customerRepository struct {
+	db *sql.DB
+}
+
+func NewCustomerRepository(db *sql.DB) customer.Repository {
+	return &customerRepository{db: db}
+}
+
+func (r customerRepository) Save(ctx context.Context, c *entity.Customer) (*entity.Customer, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`INSERT INTO customers (name, birth_date, company_name, email, phone, address_street, address_number, address_complement, address_city, address_state, address_postal_code, address_country) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id, created_at, updated_at`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	saved := *c
+	err = stmt.QueryRowContext(
+		ctx,
+		c.Name,
+		c.BirthDate,
+		c.CompanyName,
+		c.Email,
+		c.Phone,
+		c.Address.Street,
+		c.Address.Number,
+		c.Address.Complement,
+		c.Address.City,
+		c.Address.State,
+		c.Address.PostalCode,
+		c.Address.Country,
+	).Scan(&saved.ID, &saved.CreatedAt, &saved.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+func (r customerRepository) GetByID(ctx context.Context, id int) (*entity.Customer, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT id, name, birth_date, company_name, email, phone, address_street, address_number, address_complement, address_city, address_state, address_postal_code, address_country, created_at, updated_at FROM customers WHERE id = $1`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	var c entity.Customer
+	err = stmt.QueryRowContext(ctx, id).Scan(
+		&c.ID,
+		&c.Name,
+		&c.BirthDate,
+		&c.CompanyName,
+		&c.Email,
+		&c.Phone,
+		&c.Address.Street,
+		&c.Address.Number,
+		&c.Address.Complement,
+		&c.Address.City,
+		&c.Address.State,
+		&c.Address.PostalCode,
+		&c.Address.Country,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+func (r customerRepository) Update(ctx context.Context, c *entity.Customer) (*entity.Customer, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`UPDATE customers SET name = $1, birth_date = $2, company_name = $3, email = $4, phone = $5, address_street = $6, address_number = $7, address_complement = $8, address_city = $9, address_state = $10, address_postal_code = $11, address_country = $12, updated_at = CURRENT_TIMESTAMP WHERE id = $13 RETURNING created_at, updated_at`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	updated := *c
+	err = stmt.QueryRowContext(
+		ctx,
+		c.Name,
+		c.BirthDate,
+		c.CompanyName,
+		c.Email,
+		c.Phone,
+		c.Address.Street,
+		c.Address.Number,
+		c.Address.Complement,
+		c.Address.City,
+		c.Address.State,
+		c.Address.PostalCode,
+		c.Address.Country,
+		c.ID,
+	).Scan(&updated.CreatedAt, &updated.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
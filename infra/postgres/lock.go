@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"github.com/brunomdev/digital-account/domain/schedule"
+	"github.com/brunomdev/digital-account/entity"
+)
+
+type locker struct {
+	db *sql.DB
+}
+
+// NewLocker use the PostgreSQL session advisory locks, every instance connected to the database shares them
+func NewLocker(db *sql.DB) schedule.Locker {
+	return &locker{db: db}
+}
+
+// TryLock hold the advisory lock of the name hash on a dedicated connection of the pool, PostgreSQL releases it if
+// the connection is lost
+func (l locker) TryLock(ctx context.Context, name string) (func(), error) {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var acquired bool
+	err = conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, name).Scan(&acquired)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	if !acquired {
+		conn.Close()
+
+		return nil, entity.ErrLocked
+	}
+
+	return func() {
+		_, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock(hashtext($1))`, name)
+		if err != nil {
+			// a connection still holding the lock must not return to the pool
+			_ = conn.Raw(func(interface{}) error {
+				return driver.ErrBadConn
+			})
+		}
+
+		conn.Close()
+	}, nil
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/brunomdev/digital-account/domain/operationtype"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+)
+
+type operationTypeRepository struct {
+	db *sql.DB
+}
+
+func NewOperationTypeRepository(db *sql.DB) operationtype.Repository {
+	return &operationTypeRepository{db: db}
+}
+
+func (r operationTypeRepository) GetByID(ctx context.Context, id int) (*entity.OperationType, error) {
+	stmt, err := r.db.PrepareContext(ctx, `SELECT id, description, limit_rate, fee, fee_rate, daily_max_count, daily_max_amount FROM operation_types WHERE id = $1`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	var opType entity.OperationType
+	err = stmt.QueryRowContext(ctx, id).Scan(
+		&opType.ID,
+		&opType.Description,
+		&opType.Rules.LimitRate,
+		&opType.Rules.Fee,
+		&opType.Rules.FeeRate,
+		&opType.Rules.DailyMaxCount,
+		&opType.Rules.DailyMaxAmount,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &opType, nil
+}
+
+func (r operationTypeRepository) UpdateRules(ctx context.Context, id int, rules entity.OperationTypeRules) error {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`UPDATE operation_types SET limit_rate = $1, fee = $2, fee_rate = $3, daily_max_count = $4, daily_max_amount = $5 WHERE id = $6`,
+	)
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, rules.LimitRate, rules.Fee, rules.FeeRate, rules.DailyMaxCount, rules.DailyMaxAmount, id)
+
+	return err
+}
@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_operationTypeRepository_GetByID(t *testing.T) {
+	selectQuery := "SELECT id, description, limit_rate, fee, fee_rate, daily_max_count, daily_max_amount FROM operation_types WHERE id = $1"
+
+	testCases := []struct {
+		name    string
+		mock    func() (*sql.DB, sqlmock.Sqlmock, error)
+		want    *entity.OperationType
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Error query",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).ExpectQuery().
+					WithArgs(1).
+					WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "Error not found",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).ExpectQuery().
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "description", "limit_rate", "fee", "fee_rate", "daily_max_count", "daily_max_amount"}))
+
+				return db, mock, nil
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, entity.ErrNotFound, msgAndArgs...)
+			},
+		},
+		{
+			name: "Success",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).ExpectQuery().
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "description", "limit_rate", "fee", "fee_rate", "daily_max_count", "daily_max_amount"}).AddRow(1, "COMPRA A VISTA", 0, 0, 0, 0, 0))
+
+				return db, mock, nil
+			},
+			want:    &entity.OperationType{ID: 1, Description: "COMPRA A VISTA"},
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := tc.mock()
+			assert.NoError(t, err)
+
+			defer func() {
+				db.Close()
+				assert.NoError(t, mock.ExpectationsWereMet())
+			}()
+
+			r := NewOperationTypeRepository(db)
+
+			got, err := r.GetByID(context.TODO(), 1)
+			if !tc.wantErr(t, err, fmt.Sprintf("GetByID(%v)", 1)) {
+				return
+			}
+			assert.Equalf(t, tc.want, got, "GetByID(%v)", 1)
+		})
+	}
+}
@@ -0,0 +1,817 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/brunomdev/digital-account/domain"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/infra/repositorytest"
+	"testing"
+	"time"
+)
+
+const (
+	insertAccountQuery     = "INSERT INTO accounts (document_number, available_credit_limit, created_at) VALUES($1, $2, $3) RETURNING id"
+	selectAccountQuery     = "SELECT id, document_number, available_credit_limit, COALESCE(customer_id, 0), created_at FROM accounts WHERE id = $1"
+	updateAccountQuery     = "UPDATE accounts SET document_number = $1, available_credit_limit = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3"
+	selectOpTypeQuery      = "SELECT id, description, limit_rate, fee, fee_rate, daily_max_count, daily_max_amount FROM operation_types WHERE id = $1"
+	insertTransactionQuery = "INSERT INTO transactions (account_id, operation_type_id, amount) VALUES($1, $2, $3) RETURNING id, event_date, created_at"
+	selectTransactionQuery = "SELECT id, account_id, operation_type_id, amount, COALESCE(parent_id, 0), COALESCE(currency, ''), COALESCE(original_amount, 0), COALESCE(exchange_rate, 0), COALESCE(card_id, 0), event_date, created_at FROM transactions WHERE id = $1"
+	sumDebitsQuery         = "SELECT COUNT(id), COALESCE(SUM(ABS(amount)), 0) FROM transactions WHERE account_id = $1 AND operation_type_id <> $2 AND created_at >= $3"
+	countByAmountQuery     = "SELECT COUNT(id) FROM transactions WHERE account_id = $1 AND operation_type_id = $2 AND amount = $3 AND created_at >= $4"
+	sumLimitChangesQuery   = "SELECT COALESCE(SUM(CASE WHEN operation_type_id = $1 THEN ABS(amount) ELSE -ABS(amount) END), 0) FROM transactions WHERE account_id = $2 AND event_date >= $3"
+	listTransactionsQuery  = "SELECT id, account_id, operation_type_id, amount, event_date FROM transactions WHERE account_id = $1 AND event_date >= $2 AND event_date < $3 ORDER BY event_date, id"
+	insertAttemptQuery     = "INSERT INTO transaction_attempts (account_id, operation_type_id, amount, outcome, decline_reason, transaction_id) VALUES($1, $2, $3, $4, $5, $6) RETURNING id, created_at"
+	listAttemptsQuery      = "SELECT id, account_id, operation_type_id, amount, outcome, decline_reason, transaction_id, created_at FROM transaction_attempts WHERE account_id = $1 ORDER BY id DESC LIMIT $2"
+	insertDecisionQuery    = "INSERT INTO transaction_decisions (transaction_id, account_id, operation_type_id, amount, outcome, reason_codes) VALUES($1, $2, $3, $4, $5, $6) RETURNING id"
+	selectClientQuery      = "SELECT id, name, role, scopes, account_id FROM api_clients WHERE api_key_hash = $1"
+	insertJobQuery         = "INSERT INTO jobs (type, payload, status, max_attempts, client_id, actor, request_id, run_at) VALUES($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, created_at"
+	selectJobQuery         = "SELECT id, type, payload, status, progress, attempts, max_attempts, result, error, client_id, actor, request_id, run_at, created_at FROM jobs WHERE id = $1"
+	claimJobQuery          = "UPDATE jobs SET status = $1, attempts = attempts + 1, run_at = $2 WHERE id = (SELECT id FROM jobs WHERE status IN ($3, $1) AND run_at <= $4 ORDER BY run_at, id LIMIT 1 FOR UPDATE SKIP LOCKED) RETURNING id, type, payload, status, progress, attempts, max_attempts, result, error, client_id, actor, request_id, run_at, created_at"
+	updateJobQuery         = "UPDATE jobs SET status = $1, progress = $2, result = $3, error = $4, run_at = $5 WHERE id = $6"
+	listAccountsQuery      = "SELECT id, document_number, available_credit_limit, COALESCE(customer_id, 0), created_at FROM accounts WHERE id > $1 ORDER BY id LIMIT $2"
+	sumLimitAfterQuery     = "SELECT COALESCE(SUM(CASE WHEN operation_type_id = $1 THEN ABS(amount) ELSE -ABS(amount) END), 0), COALESCE(MAX(id), $3) FROM transactions WHERE account_id = $2 AND id > $3"
+	deleteAttemptsQuery    = "DELETE FROM transaction_attempts WHERE created_at < $1"
+	summarizeAttemptsQuery = "SELECT operation_type_id, outcome, COUNT(id), SUM(ABS(amount)) FROM transaction_attempts WHERE created_at >= $1 AND created_at < $2 GROUP BY operation_type_id, outcome ORDER BY operation_type_id, outcome"
+	latestJobQuery         = "SELECT id, type, payload, status, progress, attempts, max_attempts, result, error, client_id, actor, request_id, run_at, created_at FROM jobs WHERE type = $1 ORDER BY id DESC LIMIT 1"
+	deleteJobsQuery        = "DELETE FROM jobs WHERE status IN ($1, $2) AND created_at < $3"
+	selectCheckpointQuery  = "SELECT account_id, available_credit_limit, transaction_id, updated_at FROM limit_checkpoints WHERE account_id = $1"
+	saveCheckpointQuery    = "INSERT INTO limit_checkpoints (account_id, available_credit_limit, transaction_id) VALUES($1, $2, $3) ON CONFLICT (account_id) DO UPDATE SET available_credit_limit = excluded.available_credit_limit, transaction_id = excluded.transaction_id, updated_at = CURRENT_TIMESTAMP"
+	getLockQuery           = "SELECT pg_try_advisory_lock(hashtext($1))"
+	releaseLockQuery       = "SELECT pg_advisory_unlock(hashtext($1))"
+	sumBalanceQuery        = "SELECT COALESCE(SUM(CASE WHEN operation_type_id <> $1 THEN ABS(amount) ELSE 0 END), 0), COALESCE(SUM(CASE WHEN operation_type_id = $1 THEN ABS(amount) ELSE 0 END), 0) FROM transactions WHERE account_id = $2 AND event_date < $3"
+	reserveChargeQuery     = "INSERT INTO charges (account_id, type, charge_date, amount) VALUES($1, $2, $3, $4) ON CONFLICT (account_id, type, charge_date) DO NOTHING RETURNING id"
+	updateChargeQuery      = "UPDATE charges SET transaction_id = $1 WHERE id = $2"
+	deleteChargeQuery      = "DELETE FROM charges WHERE id = $1"
+	updateOpTypeRulesQuery = "UPDATE operation_types SET limit_rate = $1, fee = $2, fee_rate = $3, daily_max_count = $4, daily_max_amount = $5 WHERE id = $6"
+	insertLinkedQuery      = "INSERT INTO transactions (account_id, operation_type_id, amount, parent_id, event_date, created_at) SELECT account_id, $1, $2, id, event_date, created_at FROM transactions WHERE id = $3 RETURNING id, event_date, created_at"
+	sumByOpTypeQuery       = "SELECT COUNT(id), COALESCE(SUM(ABS(amount)), 0) FROM transactions WHERE account_id = $1 AND operation_type_id = $2 AND created_at >= $3"
+	insertDetailedQuery    = "INSERT INTO transactions (account_id, operation_type_id, amount, currency, original_amount, exchange_rate, card_id, event_date, created_at) VALUES($1, $2, $3, $4, $5, $6, $7, COALESCE($8, CURRENT_TIMESTAMP), COALESCE($9, CURRENT_TIMESTAMP)) RETURNING id, event_date, created_at"
+	insertCustomerQuery    = "INSERT INTO customers (name, birth_date, company_name, email, phone, address_street, address_number, address_complement, address_city, address_state, address_postal_code, address_country) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id, created_at, updated_at"
+	selectCustomerQuery    = "SELECT id, name, birth_date, company_name, email, phone, address_street, address_number, address_complement, address_city, address_state, address_postal_code, address_country, created_at, updated_at FROM customers WHERE id = $1"
+	updateCustomerQuery    = "UPDATE customers SET name = $1, birth_date = $2, company_name = $3, email = $4, phone = $5, address_street = $6, address_number = $7, address_complement = $8, address_city = $9, address_state = $10, address_postal_code = $11, address_country = $12, updated_at = CURRENT_TIMESTAMP WHERE id = $13 RETURNING created_at, updated_at"
+	insertForCustomerQuery = "INSERT INTO accounts (document_number, available_credit_limit, customer_id, created_at) VALUES($1, $2, $3, $4) RETURNING id"
+	insertCardQuery        = "INSERT INTO cards (account_id, type, masked_pan, token, expiry_month, expiry_year, status, card_limit, replaces_card_id) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id, created_at"
+	selectCardQuery        = "SELECT id, account_id, type, masked_pan, token, expiry_month, expiry_year, status, card_limit, COALESCE(replaces_card_id, 0), created_at FROM cards WHERE id = $1"
+	updateCardStatusQuery  = "UPDATE cards SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2"
+	sumByCardQuery         = "SELECT COALESCE(SUM(ABS(amount)), 0) FROM transactions WHERE card_id = $1 AND operation_type_id <> $2 AND created_at >= $3"
+	insertAuditQuery       = "INSERT INTO audit_log (actor, request_id, action, entity, entity_id, before_state, after_state, prev_hash, hash, created_at) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id"
+	auditChainHeadQuery    = "SELECT hash FROM audit_chain_head WHERE id = 1 FOR UPDATE"
+	updateChainHeadQuery   = "UPDATE audit_chain_head SET hash = $1 WHERE id = 1"
+	listAuditQuery         = "SELECT id, actor, request_id, action, entity, entity_id, before_state, after_state, prev_hash, hash, created_at FROM audit_log WHERE entity = $1 AND entity_id = $2 ORDER BY id DESC LIMIT $3"
+	eachAuditQuery         = "SELECT id, actor, request_id, action, entity, entity_id, before_state, after_state, prev_hash, hash, created_at FROM audit_log ORDER BY id"
+	selectAccountLockQuery = "SELECT id, document_number, available_credit_limit, COALESCE(customer_id, 0), created_at FROM accounts WHERE id = $1 FOR UPDATE"
+	setCreditLimitQuery    = "UPDATE accounts SET available_credit_limit = $1 WHERE id = $2"
+	insertAdjustmentQuery  = "INSERT INTO credit_limit_adjustments (account_id, amount, created_at) VALUES($1, $2, $3)"
+	sumAdjustmentsQuery    = "SELECT COALESCE(SUM(amount), 0) FROM credit_limit_adjustments WHERE account_id = $1 AND created_at >= $2"
+	listAdjustmentsQuery   = "SELECT id, account_id, amount, created_at FROM credit_limit_adjustments WHERE account_id = $1 AND created_at >= $2 AND created_at < $3 ORDER BY created_at, id"
+)
+
+var (
+	accountColumns     = []string{"id", "document_number", "available_credit_limit", "customer_id", "created_at"}
+	opTypeColumns      = []string{"id", "description", "limit_rate", "fee", "fee_rate", "daily_max_count", "daily_max_amount"}
+	transactionColumns = []string{"id", "account_id", "operation_type_id", "amount", "parent_id", "currency", "original_amount", "exchange_rate", "card_id", "event_date", "created_at"}
+	summaryColumns     = []string{"operation_type_id", "outcome", "count", "amount"}
+	checkpointColumns  = []string{"account_id", "available_credit_limit", "transaction_id", "updated_at"}
+)
+
+var customerColumns = []string{
+	"id", "name", "birth_date", "company_name", "email", "phone", "address_street", "address_number", "address_complement",
+	"address_city", "address_state", "address_postal_code", "address_country", "created_at", "updated_at",
+}
+
+var cardColumns = []string{
+	"id", "account_id", "type", "masked_pan", "token", "expiry_month", "expiry_year", "status", "card_limit", "replaces_card_id", "created_at",
+}
+
+var auditColumns = []string{
+	"id", "actor", "request_id", "action", "entity", "entity_id", "before_state", "after_state", "prev_hash", "hash", "created_at",
+}
+
+var jobColumns = []string{
+	"id", "type", "payload", "status", "progress", "attempts", "max_attempts", "result", "error", "client_id", "actor", "request_id", "run_at", "created_at",
+}
+
+func TestRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T, c repositorytest.Case) *domain.Repository {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+
+		t.Cleanup(func() {
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+
+			db.Close()
+		})
+
+		expectConformanceCase(mock, c)
+
+		return NewRepository(db)
+	})
+}
+
+func expectConformanceCase(mock sqlmock.Sqlmock, c repositorytest.Case) {
+	now := time.Now()
+
+	switch c {
+	case repositorytest.AccountSaveAndGet:
+		expectAccountSave(mock)
+		expectAccountGet(mock, repositorytest.AvailableCreditLimit, repositorytest.AccountCreatedAt)
+	case repositorytest.AccountNotFound:
+		mock.ExpectPrepare(selectAccountQuery).
+			ExpectQuery().
+			WithArgs(repositorytest.MissingID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "document_number", "available_credit_limit", "customer_id", "created_at"}))
+	case repositorytest.AccountUpdate:
+		expectAccountSave(mock)
+		mock.ExpectPrepare(updateAccountQuery).
+			ExpectExec().
+			WithArgs(repositorytest.DocumentNumber, repositorytest.UpdatedCreditLimit, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectAccountGet(mock, repositorytest.UpdatedCreditLimit, now)
+	case repositorytest.OperationTypeGet:
+		mock.ExpectPrepare(selectOpTypeQuery).
+			ExpectQuery().
+			WithArgs(entity.OperationTypeCompraAVista).
+			WillReturnRows(sqlmock.NewRows(opTypeColumns).AddRow(1, "COMPRA A VISTA", 0, 0, 0, 0, 0))
+	case repositorytest.OperationTypeNotFound:
+		mock.ExpectPrepare(selectOpTypeQuery).
+			ExpectQuery().
+			WithArgs(repositorytest.MissingID).
+			WillReturnRows(sqlmock.NewRows(opTypeColumns))
+	case repositorytest.TransactionSaveAndGet:
+		expectAccountSave(mock)
+		expectTransactionSave(mock, 1, entity.OperationTypeCompraAVista, -50, now)
+		mock.ExpectPrepare(selectTransactionQuery).
+			ExpectQuery().
+			WithArgs(1).
+			WillReturnRows(
+				sqlmock.NewRows(transactionColumns).AddRow(1, 1, entity.OperationTypeCompraAVista, -50, 0, "", 0, 0, 0, now, now),
+			)
+	case repositorytest.TransactionNotFound:
+		mock.ExpectPrepare(selectTransactionQuery).
+			ExpectQuery().
+			WithArgs(repositorytest.MissingID).
+			WillReturnRows(sqlmock.NewRows(transactionColumns))
+	case repositorytest.TransactionSumDebitsSince:
+		expectTransactionsSave(mock, now)
+		mock.ExpectPrepare(sumDebitsQuery).
+			ExpectQuery().
+			WithArgs(1, entity.OperationTypePagamento, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count", "amount"}).AddRow(2, 80))
+	case repositorytest.TransactionCountByAmountSince:
+		expectAccountSave(mock)
+		expectTransactionSave(mock, 1, entity.OperationTypeCompraAVista, -50, now)
+		expectTransactionSave(mock, 2, entity.OperationTypeCompraAVista, -50, now)
+		expectTransactionSave(mock, 3, entity.OperationTypeCompraAVista, -20, now)
+		mock.ExpectPrepare(countByAmountQuery).
+			ExpectQuery().
+			WithArgs(1, entity.OperationTypeCompraAVista, -50.0, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	case repositorytest.TransactionSumLimitChanges:
+		expectTransactionsSave(mock, now)
+		mock.ExpectPrepare(sumLimitChangesQuery).
+			ExpectQuery().
+			WithArgs(entity.OperationTypePagamento, 1, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(20))
+	case repositorytest.TransactionListByAccountID:
+		expectTransactionsSave(mock, now)
+
+		columns := []string{"id", "account_id", "operation_type_id", "amount", "event_date"}
+		mock.ExpectPrepare(listTransactionsQuery).
+			ExpectQuery().
+			WithArgs(1, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(
+				sqlmock.NewRows(columns).
+					AddRow(1, 1, entity.OperationTypeCompraAVista, -50, now).
+					AddRow(2, 1, entity.OperationTypeSaque, -30, now).
+					AddRow(3, 1, entity.OperationTypePagamento, 100, now),
+			)
+		mock.ExpectPrepare(listTransactionsQuery).
+			ExpectQuery().
+			WithArgs(1, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(
+				sqlmock.NewRows(columns).
+					AddRow(1, 1, entity.OperationTypeCompraAVista, -50, now).
+					AddRow(2, 1, entity.OperationTypeSaque, -30, now),
+			)
+	case repositorytest.AttemptSaveAndList:
+		expectAccountSave(mock)
+		mock.ExpectPrepare(insertAttemptQuery).
+			ExpectQuery().
+			WithArgs(1, entity.OperationTypeCompraAVista, -10.0, entity.AttemptOutcomeApproved, "", nil).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, now))
+		mock.ExpectPrepare(insertAttemptQuery).
+			ExpectQuery().
+			WithArgs(1, entity.OperationTypeCompraAVista, -5000.0, entity.AttemptOutcomeDeclined, "INSUFFICIENT_CREDIT_LIMIT", nil).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(2, now))
+
+		columns := []string{"id", "account_id", "operation_type_id", "amount", "outcome", "decline_reason", "transaction_id", "created_at"}
+		declined := []driver.Value{2, 1, entity.OperationTypeCompraAVista, -5000, entity.AttemptOutcomeDeclined, "INSUFFICIENT_CREDIT_LIMIT", nil, now}
+		approved := []driver.Value{1, 1, entity.OperationTypeCompraAVista, -10, entity.AttemptOutcomeApproved, "", nil, now}
+		mock.ExpectPrepare(listAttemptsQuery).
+			ExpectQuery().
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(declined...))
+		mock.ExpectPrepare(listAttemptsQuery).
+			ExpectQuery().
+			WithArgs(1, 10).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(declined...).AddRow(approved...))
+	case repositorytest.ClientNotFound:
+		mock.ExpectPrepare(selectClientQuery).
+			ExpectQuery().
+			WithArgs(repositorytest.UnknownKeyHash).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role", "scopes", "account_id"}))
+	case repositorytest.RiskDecisionSave:
+		expectAccountSave(mock)
+		mock.ExpectPrepare(insertDecisionQuery).
+			ExpectQuery().
+			WithArgs(nil, 1, entity.OperationTypeCompraAVista, -900.0, entity.RiskOutcomeDecline, "HIGH_AMOUNT,NIGHT").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	case repositorytest.HealthPing:
+	case repositorytest.JobSaveAndGet:
+		expectJobSave(mock, now)
+		mock.ExpectPrepare(selectJobQuery).
+			ExpectQuery().
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(jobColumns).AddRow(jobRow(entity.JobStatusQueued, 0, 0, nil, repositorytest.JobRunAt, now)...))
+	case repositorytest.JobNotFound:
+		mock.ExpectPrepare(selectJobQuery).
+			ExpectQuery().
+			WithArgs(repositorytest.MissingID).
+			WillReturnRows(sqlmock.NewRows(jobColumns))
+	case repositorytest.JobClaimAndUpdate:
+		leaseUntil := repositorytest.JobRunAt.Add(repositorytest.JobLease)
+
+		expectJobSave(mock, now)
+		expectJobClaimNotFound(mock, repositorytest.JobRunAt.Add(-time.Second), leaseUntil)
+		mock.ExpectPrepare(claimJobQuery).
+			ExpectQuery().
+			WithArgs(entity.JobStatusRunning, leaseUntil, entity.JobStatusQueued, repositorytest.JobRunAt).
+			WillReturnRows(sqlmock.NewRows(jobColumns).AddRow(jobRow(entity.JobStatusRunning, 0, 1, nil, leaseUntil, now)...))
+		expectJobClaimNotFound(mock, repositorytest.JobRunAt, leaseUntil)
+		mock.ExpectPrepare(updateJobQuery).
+			ExpectExec().
+			WithArgs(entity.JobStatusSucceeded, 100, []byte(repositorytest.JobResult), "", leaseUntil, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectPrepare(selectJobQuery).
+			ExpectQuery().
+			WithArgs(1).
+			WillReturnRows(
+				sqlmock.NewRows(jobColumns).
+					AddRow(jobRow(entity.JobStatusSucceeded, 100, 1, []byte(repositorytest.JobResult), leaseUntil, now)...),
+			)
+		expectJobClaimNotFound(
+			mock,
+			repositorytest.JobRunAt.Add(2*repositorytest.JobLease),
+			repositorytest.JobRunAt.Add(3*repositorytest.JobLease),
+		)
+	case repositorytest.AccountList:
+		expectAccountSave(mock)
+		mock.ExpectPrepare(listAccountsQuery).
+			ExpectQuery().
+			WithArgs(0, 10).
+			WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(1, repositorytest.DocumentNumber, repositorytest.AvailableCreditLimit, 0, now))
+		mock.ExpectPrepare(listAccountsQuery).
+			ExpectQuery().
+			WithArgs(1, 10).
+			WillReturnRows(sqlmock.NewRows(accountColumns))
+	case repositorytest.TransactionSumLimitAfter:
+		expectTransactionsSave(mock, now)
+		mock.ExpectPrepare(sumLimitAfterQuery).
+			ExpectQuery().
+			WithArgs(entity.OperationTypePagamento, 1, 0).
+			WillReturnRows(sqlmock.NewRows([]string{"amount", "last_id"}).AddRow(20, 3))
+		mock.ExpectPrepare(sumLimitAfterQuery).
+			ExpectQuery().
+			WithArgs(entity.OperationTypePagamento, 1, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"amount", "last_id"}).AddRow(0, 3))
+	case repositorytest.AttemptDeleteBefore:
+		expectAccountSave(mock)
+		mock.ExpectPrepare(insertAttemptQuery).
+			ExpectQuery().
+			WithArgs(1, entity.OperationTypeCompraAVista, -10.0, entity.AttemptOutcomeApproved, "", nil).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, now))
+		mock.ExpectPrepare(deleteAttemptsQuery).
+			ExpectExec().
+			WithArgs(sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectPrepare(deleteAttemptsQuery).
+			ExpectExec().
+			WithArgs(sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectPrepare(listAttemptsQuery).
+			ExpectQuery().
+			WithArgs(1, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "outcome", "decline_reason", "transaction_id", "created_at"}))
+	case repositorytest.AttemptSummarize:
+		expectAccountSave(mock)
+		for i, attempt := range []struct {
+			operationTypeID int
+			amount          float64
+			outcome         entity.AttemptOutcome
+		}{
+			{operationTypeID: entity.OperationTypeCompraAVista, amount: -10, outcome: entity.AttemptOutcomeApproved},
+			{operationTypeID: entity.OperationTypeCompraAVista, amount: -5000, outcome: entity.AttemptOutcomeDeclined},
+			{operationTypeID: entity.OperationTypeCompraAVista, amount: -20, outcome: entity.AttemptOutcomeApproved},
+			{operationTypeID: entity.OperationTypePagamento, amount: 100, outcome: entity.AttemptOutcomeApproved},
+		} {
+			mock.ExpectPrepare(insertAttemptQuery).
+				ExpectQuery().
+				WithArgs(1, attempt.operationTypeID, attempt.amount, attempt.outcome, "", nil).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(i+1, now))
+		}
+		mock.ExpectPrepare(summarizeAttemptsQuery).
+			ExpectQuery().
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(
+				sqlmock.NewRows(summaryColumns).
+					AddRow(entity.OperationTypeCompraAVista, entity.AttemptOutcomeApproved, 2, 30).
+					AddRow(entity.OperationTypeCompraAVista, entity.AttemptOutcomeDeclined, 1, 5000).
+					AddRow(entity.OperationTypePagamento, entity.AttemptOutcomeApproved, 1, 100),
+			)
+		mock.ExpectPrepare(summarizeAttemptsQuery).
+			ExpectQuery().
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows(summaryColumns))
+	case repositorytest.JobGetLatestByType:
+		expectJobSave(mock, now)
+		mock.ExpectPrepare(latestJobQuery).
+			ExpectQuery().
+			WithArgs(repositorytest.JobType).
+			WillReturnRows(sqlmock.NewRows(jobColumns).AddRow(jobRow(entity.JobStatusQueued, 0, 0, nil, repositorytest.JobRunAt, now)...))
+		mock.ExpectPrepare(latestJobQuery).
+			ExpectQuery().
+			WithArgs(repositorytest.MissingJobType).
+			WillReturnRows(sqlmock.NewRows(jobColumns))
+	case repositorytest.JobDeleteFinishedBefore:
+		expectJobSave(mock, now)
+		expectJobsDelete(mock, 0)
+		mock.ExpectPrepare(updateJobQuery).
+			ExpectExec().
+			WithArgs(entity.JobStatusSucceeded, 0, []byte(repositorytest.JobResult), "", repositorytest.JobRunAt, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectJobsDelete(mock, 0)
+		expectJobsDelete(mock, 1)
+		mock.ExpectPrepare(selectJobQuery).
+			ExpectQuery().
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(jobColumns))
+	case repositorytest.LimitCheckpointSaveAndGet:
+		expectAccountSave(mock)
+		mock.ExpectPrepare(selectCheckpointQuery).
+			ExpectQuery().
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(checkpointColumns))
+		expectCheckpointSave(mock, repositorytest.AvailableCreditLimit, 0, now)
+		expectCheckpointSave(mock, repositorytest.UpdatedCreditLimit, 3, now)
+	case repositorytest.LockExclusive:
+		expectLock(mock, true)
+		expectLock(mock, false)
+		expectUnlock(mock)
+		expectLock(mock, true)
+		expectUnlock(mock)
+	case repositorytest.TransactionSumBalanceBefore:
+		expectTransactionsSave(mock, now)
+		mock.ExpectPrepare(sumBalanceQuery).
+			ExpectQuery().
+			WithArgs(entity.OperationTypePagamento, 1, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"debits", "payments"}).AddRow(80, 100))
+		mock.ExpectPrepare(sumBalanceQuery).
+			ExpectQuery().
+			WithArgs(entity.OperationTypePagamento, 1, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"debits", "payments"}).AddRow(0, 0))
+	case repositorytest.ChargeReserve:
+		expectAccountSave(mock)
+		expectChargeReserve(mock, entity.ChargeTypeInterest, sqlmock.NewRows([]string{"id"}).AddRow(1))
+		expectChargeReserve(mock, entity.ChargeTypeInterest, sqlmock.NewRows([]string{"id"}))
+		expectChargeReserve(mock, entity.ChargeTypeLateFee, sqlmock.NewRows([]string{"id"}).AddRow(2))
+		mock.ExpectPrepare(updateChargeQuery).
+			ExpectExec().
+			WithArgs(1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectPrepare(deleteChargeQuery).
+			ExpectExec().
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectChargeReserve(mock, entity.ChargeTypeInterest, sqlmock.NewRows([]string{"id"}).AddRow(3))
+	case repositorytest.OperationTypeUpdateRules:
+		rules := repositorytest.WithdrawalRules
+		mock.ExpectPrepare(updateOpTypeRulesQuery).
+			ExpectExec().
+			WithArgs(rules.LimitRate, rules.Fee, rules.FeeRate, rules.DailyMaxCount, rules.DailyMaxAmount, entity.OperationTypeSaque).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectPrepare(selectOpTypeQuery).
+			ExpectQuery().
+			WithArgs(entity.OperationTypeSaque).
+			WillReturnRows(
+				sqlmock.NewRows(opTypeColumns).
+					AddRow(3, "SAQUE", rules.LimitRate, rules.Fee, rules.FeeRate, rules.DailyMaxCount, rules.DailyMaxAmount),
+			)
+	case repositorytest.TransactionSaveWithLinked:
+		createdAt := repositorytest.TransactionCreatedAt
+		expectAccountSave(mock)
+		expectSaveWithLinked(mock, 1, -50, nil, createdAt, now, createdAt)
+		mock.ExpectPrepare(selectTransactionQuery).
+			ExpectQuery().
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows(transactionColumns).AddRow(2, 1, entity.OperationTypeTarifa, -5, 1, "", 0, 0, 0, now, now))
+	case repositorytest.TransactionSumByOpTypeSince:
+		expectTransactionsSave(mock, now)
+		mock.ExpectPrepare(sumByOpTypeQuery).
+			ExpectQuery().
+			WithArgs(1, entity.OperationTypeSaque, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count", "amount"}).AddRow(1, 30))
+	case repositorytest.TransactionSaveDetailed:
+		fx := repositorytest.ForeignExchange
+		expectAccountSave(mock)
+		mock.ExpectPrepare(insertDetailedQuery).
+			ExpectQuery().
+			WithArgs(1, entity.OperationTypeCompraAVista, -52.0, fx.Currency, fx.OriginalAmount, fx.Rate, nil, nil, nil).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_date", "created_at"}).AddRow(1, now, now))
+		mock.ExpectPrepare(selectTransactionQuery).
+			ExpectQuery().
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(transactionColumns).
+				AddRow(1, 1, entity.OperationTypeCompraAVista, -52, 0, fx.Currency, fx.OriginalAmount, fx.Rate, 0, now, now))
+	case repositorytest.CustomerSaveAndGet:
+		expectCustomerSave(mock, now)
+		expectCustomerGet(mock, repositorytest.Customer.Email, now)
+	case repositorytest.CustomerNotFound:
+		mock.ExpectPrepare(selectCustomerQuery).
+			ExpectQuery().
+			WithArgs(repositorytest.MissingID).
+			WillReturnRows(sqlmock.NewRows(customerColumns))
+	case repositorytest.CustomerUpdate:
+		expectCustomerSave(mock, now)
+		updated := repositorytest.Customer
+		updated.Email = repositorytest.UpdatedEmail
+		mock.ExpectPrepare(updateCustomerQuery).
+			ExpectQuery().
+			WithArgs(append(customerArgs(updated), 1)...).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+		expectCustomerGet(mock, repositorytest.UpdatedEmail, now)
+	case repositorytest.AccountSaveForCustomer:
+		expectCustomerSave(mock, now)
+		mock.ExpectPrepare(insertForCustomerQuery).
+			ExpectQuery().
+			WithArgs(repositorytest.DocumentNumber, repositorytest.AvailableCreditLimit, 1, repositorytest.AccountCreatedAt).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectPrepare(selectAccountQuery).
+			ExpectQuery().
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(accountColumns).
+				AddRow(1, repositorytest.DocumentNumber, repositorytest.AvailableCreditLimit, 1, now))
+	case repositorytest.CardSaveAndGet:
+		expectCardSave(mock, now)
+		expectCardGet(mock, entity.CardStatusActive, now)
+	case repositorytest.CardNotFound:
+		mock.ExpectPrepare(selectCardQuery).
+			ExpectQuery().
+			WithArgs(repositorytest.MissingID).
+			WillReturnRows(sqlmock.NewRows(cardColumns))
+	case repositorytest.CardUpdateStatus:
+		expectCardSave(mock, now)
+		mock.ExpectPrepare(updateCardStatusQuery).
+			ExpectExec().
+			WithArgs(entity.CardStatusBlocked, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectCardGet(mock, entity.CardStatusBlocked, now)
+	case repositorytest.TransactionSumByCardSince:
+		expectCardSave(mock, now)
+		mock.ExpectPrepare(insertDetailedQuery).
+			ExpectQuery().
+			WithArgs(1, entity.OperationTypeCompraAVista, -50.0, nil, nil, nil, 1, nil, nil).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_date", "created_at"}).AddRow(1, now, now))
+		expectTransactionSave(mock, 2, entity.OperationTypeSaque, -30, now)
+		mock.ExpectPrepare(selectTransactionQuery).
+			ExpectQuery().
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(transactionColumns).
+				AddRow(1, 1, entity.OperationTypeCompraAVista, -50, 0, "", 0, 0, 1, now, now))
+		mock.ExpectPrepare(sumByCardQuery).
+			ExpectQuery().
+			WithArgs(1, entity.OperationTypePagamento, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(50))
+	case repositorytest.TransactionSaveBackdated:
+		eventDate := repositorytest.EventDate
+		expectAccountSave(mock)
+		expectTransactionSave(mock, 1, entity.OperationTypeSaque, -30, now)
+		mock.ExpectPrepare(insertDetailedQuery).
+			ExpectQuery().
+			WithArgs(1, entity.OperationTypeCompraAVista, -50.0, nil, nil, nil, nil, eventDate, nil).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_date", "created_at"}).AddRow(2, eventDate, now))
+		mock.ExpectPrepare(selectTransactionQuery).
+			ExpectQuery().
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows(transactionColumns).
+				AddRow(2, 1, entity.OperationTypeCompraAVista, -50, 0, "", 0, 0, 0, eventDate, now))
+		expectBackdatedList(mock, now)
+		expectSaveWithLinked(mock, 3, -20, eventDate, nil, eventDate, now)
+	case repositorytest.AccountSetCreditLimit:
+		expectAccountSave(mock)
+		expectSetCreditLimit(mock)
+	case repositorytest.AuditAppendAndList:
+		chain := repositorytest.AuditChain()
+		expectAuditAppend(mock, chain[0])
+		expectAuditAppend(mock, chain[1])
+		mock.ExpectPrepare(listAuditQuery).
+			ExpectQuery().
+			WithArgs(entity.AuditEntityAccount, 1, 10).
+			WillReturnRows(sqlmock.NewRows(auditColumns).AddRow(auditRow(chain[1])...).AddRow(auditRow(chain[0])...))
+		mock.ExpectPrepare(listAuditQuery).
+			ExpectQuery().
+			WithArgs(entity.AuditEntityAccount, repositorytest.MissingID, 10).
+			WillReturnRows(sqlmock.NewRows(auditColumns))
+		mock.ExpectPrepare(eachAuditQuery).
+			ExpectQuery().
+			WillReturnRows(sqlmock.NewRows(auditColumns).AddRow(auditRow(chain[0])...).AddRow(auditRow(chain[1])...))
+	}
+}
+
+// expectSaveWithLinked expect the withdrawal and its fee of 5, with the next id, saved in a single transaction
+func expectSaveWithLinked(
+	mock sqlmock.Sqlmock,
+	id int64,
+	amount float64,
+	eventDate, createdAt interface{},
+	savedEventDate, savedCreatedAt time.Time,
+) {
+	mock.ExpectBegin()
+	mock.ExpectPrepare(insertDetailedQuery).
+		ExpectQuery().
+		WithArgs(1, entity.OperationTypeSaque, amount, nil, nil, nil, nil, eventDate, createdAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_date", "created_at"}).AddRow(id, savedEventDate, savedCreatedAt))
+	mock.ExpectPrepare(insertLinkedQuery).
+		ExpectQuery().
+		WithArgs(entity.OperationTypeTarifa, -5.0, id).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_date", "created_at"}).AddRow(id+1, savedEventDate, savedCreatedAt))
+	mock.ExpectCommit()
+}
+
+// expectBackdatedList expect the backdated purchase listed before the withdrawal and left out of the debits since now
+func expectBackdatedList(mock sqlmock.Sqlmock, now time.Time) {
+	mock.ExpectPrepare(listTransactionsQuery).
+		ExpectQuery().
+		WithArgs(1, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "account_id", "operation_type_id", "amount", "event_date"}).
+				AddRow(2, 1, entity.OperationTypeCompraAVista, -50, repositorytest.EventDate).
+				AddRow(1, 1, entity.OperationTypeSaque, -30, now),
+		)
+	mock.ExpectPrepare(sumDebitsQuery).
+		ExpectQuery().
+		WithArgs(1, entity.OperationTypePagamento, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "amount"}).AddRow(2, 80))
+}
+
+func expectCardSave(mock sqlmock.Sqlmock, now time.Time) {
+	expectAccountSave(mock)
+	mock.ExpectPrepare(insertCardQuery).
+		ExpectQuery().
+		WithArgs(append(cardArgs(repositorytest.Card), nil)...).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, now))
+}
+
+func expectCardGet(mock sqlmock.Sqlmock, status entity.CardStatus, now time.Time) {
+	c := repositorytest.Card
+	c.Status = status
+	row := append([]driver.Value{1}, cardArgs(c)...)
+
+	mock.ExpectPrepare(selectCardQuery).
+		ExpectQuery().
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(cardColumns).AddRow(append(row, 0, now)...))
+}
+
+// cardArgs are the columns of the card of the first account, in the order of the insert and the select
+func cardArgs(c entity.Card) []driver.Value {
+	return []driver.Value{1, c.Type, c.MaskedPAN, c.Token, c.ExpiryMonth, c.ExpiryYear, c.Status, c.Limit}
+}
+
+func expectCustomerSave(mock sqlmock.Sqlmock, now time.Time) {
+	mock.ExpectPrepare(insertCustomerQuery).
+		ExpectQuery().
+		WithArgs(customerArgs(repositorytest.Customer)...).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(1, now, now))
+}
+
+func expectCustomerGet(mock sqlmock.Sqlmock, email string, now time.Time) {
+	c := repositorytest.Customer
+	c.Email = email
+	row := append([]driver.Value{1}, customerArgs(c)...)
+
+	mock.ExpectPrepare(selectCustomerQuery).
+		ExpectQuery().
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(customerColumns).AddRow(append(row, now, now)...))
+}
+
+// customerArgs are the profile columns of the customer, in the order of the insert and the select
+func customerArgs(c entity.Customer) []driver.Value {
+	return []driver.Value{
+		c.Name,
+		c.BirthDate,
+		c.CompanyName,
+		c.Email,
+		c.Phone,
+		c.Address.Street,
+		c.Address.Number,
+		c.Address.Complement,
+		c.Address.City,
+		c.Address.State,
+		c.Address.PostalCode,
+		c.Address.Country,
+	}
+}
+
+func expectChargeReserve(mock sqlmock.Sqlmock, chargeType entity.ChargeType, rows *sqlmock.Rows) {
+	mock.ExpectPrepare(reserveChargeQuery).
+		ExpectQuery().
+		WithArgs(1, chargeType, "2022-03-25", repositorytest.ChargeAmount).
+		WillReturnRows(rows)
+}
+
+func expectJobsDelete(mock sqlmock.Sqlmock, deleted int64) {
+	mock.ExpectPrepare(deleteJobsQuery).
+		ExpectExec().
+		WithArgs(entity.JobStatusSucceeded, entity.JobStatusFailed, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, deleted))
+}
+
+func expectCheckpointSave(mock sqlmock.Sqlmock, availableCreditLimit float64, transactionID int, updatedAt time.Time) {
+	mock.ExpectPrepare(saveCheckpointQuery).
+		ExpectExec().
+		WithArgs(1, availableCreditLimit, transactionID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare(selectCheckpointQuery).
+		ExpectQuery().
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(checkpointColumns).AddRow(1, availableCreditLimit, transactionID, updatedAt))
+}
+
+func expectLock(mock sqlmock.Sqlmock, acquired bool) {
+	mock.ExpectQuery(getLockQuery).
+		WithArgs(repositorytest.LockName).
+		WillReturnRows(sqlmock.NewRows([]string{"acquired"}).AddRow(acquired))
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(releaseLockQuery).
+		WithArgs(repositorytest.LockName).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+// expectTransactionsSave expect the account and the transactions saved by the cases listing them
+func expectTransactionsSave(mock sqlmock.Sqlmock, now time.Time) {
+	expectAccountSave(mock)
+	expectTransactionSave(mock, 1, entity.OperationTypeCompraAVista, -50, now)
+	expectTransactionSave(mock, 2, entity.OperationTypeSaque, -30, now)
+	expectTransactionSave(mock, 3, entity.OperationTypePagamento, 100, now)
+}
+
+func expectAccountSave(mock sqlmock.Sqlmock) {
+	mock.ExpectPrepare(insertAccountQuery).
+		ExpectQuery().
+		WithArgs(repositorytest.DocumentNumber, repositorytest.AvailableCreditLimit, repositorytest.AccountCreatedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+}
+
+func expectAccountGet(mock sqlmock.Sqlmock, availableCreditLimit float64, createdAt time.Time) {
+	mock.ExpectPrepare(selectAccountQuery).
+		ExpectQuery().
+		WithArgs(1).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "document_number", "available_credit_limit", "customer_id", "created_at"}).
+				AddRow(1, repositorytest.DocumentNumber, availableCreditLimit, 0, createdAt),
+		)
+}
+
+func expectTransactionSave(mock sqlmock.Sqlmock, id int64, operationTypeID int, amount float64, createdAt time.Time) {
+	mock.ExpectPrepare(insertTransactionQuery).
+		ExpectQuery().
+		WithArgs(1, operationTypeID, amount).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_date", "created_at"}).AddRow(id, createdAt, createdAt))
+}
+
+func expectJobSave(mock sqlmock.Sqlmock, createdAt time.Time) {
+	mock.ExpectPrepare(insertJobQuery).
+		ExpectQuery().
+		WithArgs(
+			repositorytest.JobType,
+			[]byte(repositorytest.JobPayload),
+			entity.JobStatusQueued,
+			repositorytest.JobMaxAttempts,
+			0,
+			repositorytest.JobActor,
+			repositorytest.JobRequestID,
+			repositorytest.JobRunAt,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, createdAt))
+}
+
+func expectJobClaimNotFound(mock sqlmock.Sqlmock, now, leaseUntil time.Time) {
+	mock.ExpectPrepare(claimJobQuery).
+		ExpectQuery().
+		WithArgs(entity.JobStatusRunning, leaseUntil, entity.JobStatusQueued, now).
+		WillReturnRows(sqlmock.NewRows(jobColumns))
+}
+
+func jobRow(status entity.JobStatus, progress, attempts int, result []byte, runAt, createdAt time.Time) []driver.Value {
+	return []driver.Value{
+		1,
+		repositorytest.JobType,
+		[]byte(repositorytest.JobPayload),
+		status,
+		progress,
+		attempts,
+		repositorytest.JobMaxAttempts,
+		result,
+		"",
+		0,
+		repositorytest.JobActor,
+		repositorytest.JobRequestID,
+		runAt,
+		createdAt,
+	}
+}
+
+// expectAuditAppend expect the entry chained to the head of the chain, which is empty for the first entry
+func expectAuditAppend(mock sqlmock.Sqlmock, entry entity.AuditEntry) {
+	lastHash := sqlmock.NewRows([]string{"hash"}).AddRow(entry.PrevHash)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(auditChainHeadQuery).WillReturnRows(lastHash)
+	mock.ExpectQuery(insertAuditQuery).
+		WithArgs(auditArgs(entry)...).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(entry.ID))
+	mock.ExpectExec(updateChainHeadQuery).WithArgs(entry.Hash).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+// auditArgs are the inserted columns of the entry, the empty snapshots are NULL
+func auditArgs(e entity.AuditEntry) []driver.Value {
+	return []driver.Value{
+		e.Actor, e.RequestID, e.Action, e.Entity, e.EntityID, nullState(e.Before), nullState(e.After), e.PrevHash, e.Hash, e.CreatedAt,
+	}
+}
+
+func auditRow(e entity.AuditEntry) []driver.Value {
+	return append([]driver.Value{e.ID}, auditArgs(e)...)
+}
+
+func nullState(state string) driver.Value {
+	if state == "" {
+		return nil
+	}
+
+	return state
+}
+
+// expectSetCreditLimit expect the limit of the saved account set to the updated one with its adjustment, then read
+// back with its history, and a missing account rolled back
+func expectSetCreditLimit(mock sqlmock.Sqlmock) {
+	adjustment := repositorytest.UpdatedCreditLimit - repositorytest.AvailableCreditLimit
+	adjustmentColumns := []string{"id", "account_id", "amount", "created_at"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectAccountLockQuery).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(accountColumns).
+			AddRow(1, repositorytest.DocumentNumber, repositorytest.AvailableCreditLimit, 0, repositorytest.AccountCreatedAt))
+	mock.ExpectExec(setCreditLimitQuery).
+		WithArgs(repositorytest.UpdatedCreditLimit, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertAdjustmentQuery).
+		WithArgs(1, adjustment, repositorytest.AdjustedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	expectAccountGet(mock, repositorytest.UpdatedCreditLimit, repositorytest.AccountCreatedAt)
+
+	mock.ExpectPrepare(sumAdjustmentsQuery).
+		ExpectQuery().
+		WithArgs(1, repositorytest.AdjustedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(adjustment))
+	mock.ExpectPrepare(sumAdjustmentsQuery).
+		ExpectQuery().
+		WithArgs(1, repositorytest.AdjustedAt.Add(time.Second)).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow(0))
+	mock.ExpectPrepare(listAdjustmentsQuery).
+		ExpectQuery().
+		WithArgs(1, repositorytest.AdjustedAt, repositorytest.AdjustedAt.Add(time.Hour)).
+		WillReturnRows(sqlmock.NewRows(adjustmentColumns).AddRow(1, 1, adjustment, repositorytest.AdjustedAt))
+	mock.ExpectPrepare(listAdjustmentsQuery).
+		ExpectQuery().
+		WithArgs(1, repositorytest.AccountCreatedAt, repositorytest.AdjustedAt).
+		WillReturnRows(sqlmock.NewRows(adjustmentColumns))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectAccountLockQuery).
+		WithArgs(repositorytest.MissingID).
+		WillReturnRows(sqlmock.NewRows(accountColumns))
+	mock.ExpectRollback()
+}
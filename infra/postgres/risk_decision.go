@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/brunomdev/digital-account/domain/risk"
+	"github.com/brunomdev/digital-account/entity"
+	"strings"
+)
+
+type riskDecisionRepository struct {
+	db *sql.DB
+}
+
+func NewRiskDecisionRepository(db *sql.DB) risk.Repository {
+	return &riskDecisionRepository{db: db}
+}
+
+func (r riskDecisionRepository) Save(ctx context.Context, decision *entity.RiskDecision) (*entity.RiskDecision, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`INSERT INTO transaction_decisions (transaction_id, account_id, operation_type_id, amount, outcome, reason_codes) VALUES($1, $2, $3, $4, $5, $6) RETURNING id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	var transactionID sql.NullInt64
+	if decision.TransactionID > 0 {
+		transactionID = sql.NullInt64{Int64: int64(decision.TransactionID), Valid: true}
+	}
+
+	saved := *decision
+
+	err = stmt.QueryRowContext(
+		ctx,
+		transactionID,
+		decision.AccountID,
+		decision.OperationTypeID,
+		decision.Amount,
+		decision.Outcome,
+		strings.Join(decision.ReasonCodes, ","),
+	).Scan(&saved.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
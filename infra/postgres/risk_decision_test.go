@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_riskDecisionRepository_Save(t *testing.T) {
+	insertQuery := "INSERT INTO transaction_decisions (transaction_id, account_id, operation_type_id, amount, outcome, reason_codes) VALUES($1, $2, $3, $4, $5, $6) RETURNING id"
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+
+	defer func() {
+		db.Close()
+		assert.NoError(t, mock.ExpectationsWereMet())
+	}()
+
+	mock.ExpectPrepare(insertQuery).ExpectQuery().
+		WithArgs(9, 1, 1, -900.0, entity.RiskOutcomeReview, "HIGH_AMOUNT,NIGHT").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+
+	decision := &entity.RiskDecision{
+		TransactionID:   9,
+		AccountID:       1,
+		OperationTypeID: 1,
+		Amount:          -900,
+		Outcome:         entity.RiskOutcomeReview,
+		ReasonCodes:     []string{"HIGH_AMOUNT", "NIGHT"},
+	}
+
+	got, err := NewRiskDecisionRepository(db).Save(context.TODO(), decision)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, got.ID)
+	assert.Equal(t, 0, decision.ID)
+}
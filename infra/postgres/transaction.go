@@ -0,0 +1,404 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/brunomdev/digital-account/domain/transaction"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type transactionRepository struct {
+	db *sql.DB
+}
+
+// preparer is the database or one of its transactions
+type preparer interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+func NewTransactionRepository(db *sql.DB) transaction.Repository {
+	return &transactionRepository{db: db}
+}
+
+func (r transactionRepository) Save(ctx context.Context, accountID, operationTypeID int, amount float64) (*entity.Transaction, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`INSERT INTO transactions (account_id, operation_type_id, amount) VALUES($1, $2, $3) RETURNING id, event_date, created_at`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	txn := entity.Transaction{
+		AccountID:       accountID,
+		OperationTypeID: operationTypeID,
+		Amount:          amount,
+	}
+
+	err = stmt.QueryRowContext(ctx, accountID, operationTypeID, amount).Scan(&txn.ID, &txn.EventDate, &txn.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &txn, nil
+}
+
+func (r transactionRepository) GetByID(ctx context.Context, id int) (*entity.Transaction, error) {
+	return getTransaction(ctx, r.db, id)
+}
+
+// getTransaction read the transaction through the database or one of its transactions
+func getTransaction(ctx context.Context, db preparer, id int) (*entity.Transaction, error) {
+	stmt, err := db.PrepareContext(ctx, `SELECT id, account_id, operation_type_id, amount, COALESCE(parent_id, 0), COALESCE(currency, ''), COALESCE(original_amount, 0), COALESCE(exchange_rate, 0), COALESCE(card_id, 0), event_date, created_at FROM transactions WHERE id = $1`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	var (
+		txn      entity.Transaction
+		exchange entity.Exchange
+	)
+	err = stmt.QueryRowContext(ctx, id).Scan(
+		&txn.ID,
+		&txn.AccountID,
+		&txn.OperationTypeID,
+		&txn.Amount,
+		&txn.ParentID,
+		&exchange.Currency,
+		&exchange.OriginalAmount,
+		&exchange.Rate,
+		&txn.CardID,
+		&txn.EventDate,
+		&txn.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if exchange.Currency != "" {
+		txn.Exchange = &exchange
+	}
+
+	return &txn, nil
+}
+
+func (r transactionRepository) SaveDetailed(ctx context.Context, txn *entity.Transaction) (*entity.Transaction, error) {
+	return insertDetailed(ctx, r.db, txn)
+}
+
+func (r transactionRepository) SaveWithLinked(
+	ctx context.Context,
+	txn *entity.Transaction,
+	linked []*entity.Transaction,
+) (*entity.Transaction, []*entity.Transaction, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer tx.Rollback()
+
+	saved, err := insertDetailed(ctx, tx, txn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	savedLinked := make([]*entity.Transaction, 0, len(linked))
+	for _, l := range linked {
+		t, err := insertLinked(ctx, tx, saved, l)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		savedLinked = append(savedLinked, t)
+	}
+
+	return saved, savedLinked, tx.Commit()
+}
+
+// insertDetailed insert the transaction with its details
+func insertDetailed(ctx context.Context, db preparer, txn *entity.Transaction) (*entity.Transaction, error) {
+	stmt, err := db.PrepareContext(
+		ctx,
+		`INSERT INTO transactions (account_id, operation_type_id, amount, currency, original_amount, exchange_rate, card_id, event_date, created_at) VALUES($1, $2, $3, $4, $5, $6, $7, COALESCE($8, CURRENT_TIMESTAMP), COALESCE($9, CURRENT_TIMESTAMP)) RETURNING id, event_date, created_at`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	saved := *txn
+
+	var currency, originalAmount, rate, cardID, eventDate, createdAt interface{}
+	if txn.Exchange != nil {
+		currency, originalAmount, rate = txn.Exchange.Currency, txn.Exchange.OriginalAmount, txn.Exchange.Rate
+	}
+
+	if txn.CardID != 0 {
+		cardID = txn.CardID
+	}
+
+	if !txn.EventDate.IsZero() {
+		eventDate = txn.EventDate
+	}
+
+	if !txn.CreatedAt.IsZero() {
+		createdAt = txn.CreatedAt
+	}
+
+	err = stmt.QueryRowContext(
+		ctx,
+		txn.AccountID,
+		txn.OperationTypeID,
+		txn.Amount,
+		currency,
+		originalAmount,
+		rate,
+		cardID,
+		eventDate,
+		createdAt,
+	).Scan(&saved.ID, &saved.EventDate, &saved.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// insertLinked insert the transaction linked to the parent, on its account, event date and creation date
+func insertLinked(ctx context.Context, db preparer, parent, txn *entity.Transaction) (*entity.Transaction, error) {
+	stmt, err := db.PrepareContext(
+		ctx,
+		`INSERT INTO transactions (account_id, operation_type_id, amount, parent_id, event_date, created_at) SELECT account_id, $1, $2, id, event_date, created_at FROM transactions WHERE id = $3 RETURNING id, event_date, created_at`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	saved := entity.Transaction{
+		AccountID:       parent.AccountID,
+		OperationTypeID: txn.OperationTypeID,
+		Amount:          txn.Amount,
+		ParentID:        parent.ID,
+	}
+
+	err = stmt.QueryRowContext(ctx, txn.OperationTypeID, txn.Amount, parent.ID).Scan(&saved.ID, &saved.EventDate, &saved.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+func (r transactionRepository) SumDebitsSince(ctx context.Context, accountID int, since time.Time) (int, float64, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT COUNT(id), COALESCE(SUM(ABS(amount)), 0) FROM transactions WHERE account_id = $1 AND operation_type_id <> $2 AND created_at >= $3`,
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer stmt.Close()
+
+	var (
+		count  int
+		amount float64
+	)
+	err = stmt.QueryRowContext(ctx, accountID, entity.OperationTypePagamento, since).Scan(&count, &amount)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return count, amount, nil
+}
+
+func (r transactionRepository) SumByOperationTypeSince(
+	ctx context.Context,
+	accountID, operationTypeID int,
+	since time.Time,
+) (int, float64, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT COUNT(id), COALESCE(SUM(ABS(amount)), 0) FROM transactions WHERE account_id = $1 AND operation_type_id = $2 AND created_at >= $3`,
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer stmt.Close()
+
+	var (
+		count  int
+		amount float64
+	)
+	err = stmt.QueryRowContext(ctx, accountID, operationTypeID, since).Scan(&count, &amount)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return count, amount, nil
+}
+
+func (r transactionRepository) SumByCardSince(ctx context.Context, cardID int, since time.Time) (float64, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT COALESCE(SUM(ABS(amount)), 0) FROM transactions WHERE card_id = $1 AND operation_type_id <> $2 AND created_at >= $3`,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	defer stmt.Close()
+
+	var amount float64
+	err = stmt.QueryRowContext(ctx, cardID, entity.OperationTypePagamento, since).Scan(&amount)
+	if err != nil {
+		return 0, err
+	}
+
+	return amount, nil
+}
+
+func (r transactionRepository) CountByAmountSince(
+	ctx context.Context,
+	accountID, operationTypeID int,
+	amount float64,
+	since time.Time,
+) (int, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT COUNT(id) FROM transactions WHERE account_id = $1 AND operation_type_id = $2 AND amount = $3 AND created_at >= $4`,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	defer stmt.Close()
+
+	var count int
+	err = stmt.QueryRowContext(ctx, accountID, operationTypeID, amount, since).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r transactionRepository) SumLimitChangesSince(ctx context.Context, accountID int, since time.Time) (float64, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT COALESCE(SUM(CASE WHEN operation_type_id = $1 THEN ABS(amount) ELSE -ABS(amount) END), 0) FROM transactions WHERE account_id = $2 AND event_date >= $3`,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	defer stmt.Close()
+
+	var amount float64
+	err = stmt.QueryRowContext(ctx, entity.OperationTypePagamento, accountID, since).Scan(&amount)
+	if err != nil {
+		return 0, err
+	}
+
+	return amount, nil
+}
+
+func (r transactionRepository) ListByAccountID(
+	ctx context.Context,
+	accountID int,
+	from, to time.Time,
+	fn func(txn *entity.Transaction) error,
+) error {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT id, account_id, operation_type_id, amount, event_date FROM transactions WHERE account_id = $1 AND event_date >= $2 AND event_date < $3 ORDER BY event_date, id`,
+	)
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, accountID, from, to)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var txn entity.Transaction
+
+		err = rows.Scan(&txn.ID, &txn.AccountID, &txn.OperationTypeID, &txn.Amount, &txn.EventDate)
+		if err != nil {
+			return err
+		}
+
+		err = fn(&txn)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r transactionRepository) SumLimitChangesAfter(ctx context.Context, accountID, afterID int) (float64, int, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT COALESCE(SUM(CASE WHEN operation_type_id = $1 THEN ABS(amount) ELSE -ABS(amount) END), 0), COALESCE(MAX(id), $3) FROM transactions WHERE account_id = $2 AND id > $3`,
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer stmt.Close()
+
+	var (
+		amount float64
+		lastID int
+	)
+	err = stmt.QueryRowContext(ctx, entity.OperationTypePagamento, accountID, afterID).Scan(&amount, &lastID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return amount, lastID, nil
+}
+
+func (r transactionRepository) SumBalanceBefore(ctx context.Context, accountID int, before time.Time) (float64, float64, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT COALESCE(SUM(CASE WHEN operation_type_id <> $1 THEN ABS(amount) ELSE 0 END), 0), COALESCE(SUM(CASE WHEN operation_type_id = $1 THEN ABS(amount) ELSE 0 END), 0) FROM transactions WHERE account_id = $2 AND event_date < $3`,
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer stmt.Close()
+
+	var (
+		debits   float64
+		payments float64
+	)
+	err = stmt.QueryRowContext(ctx, entity.OperationTypePagamento, accountID, before).Scan(&debits, &payments)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return debits, payments, nil
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_transactionAttemptRepository_Save(t *testing.T) {
+	insertQuery := "INSERT INTO transaction_attempts (account_id, operation_type_id, amount, outcome, decline_reason, transaction_id) VALUES($1, $2, $3, $4, $5, $6) RETURNING id, created_at"
+	createdAt := time.Date(2022, 3, 24, 10, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+
+	defer func() {
+		db.Close()
+		assert.NoError(t, mock.ExpectationsWereMet())
+	}()
+
+	mock.ExpectPrepare(insertQuery).ExpectQuery().
+		WithArgs(1, 1, -5000.0, entity.AttemptOutcomeDeclined, "INSUFFICIENT_CREDIT_LIMIT", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(3, createdAt))
+
+	got, err := NewTransactionAttemptRepository(db).Save(context.TODO(), &entity.TransactionAttempt{
+		AccountID:       1,
+		OperationTypeID: 1,
+		Amount:          -5000,
+		Outcome:         entity.AttemptOutcomeDeclined,
+		DeclineReason:   "INSUFFICIENT_CREDIT_LIMIT",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.TransactionAttempt{
+		ID:              3,
+		AccountID:       1,
+		OperationTypeID: 1,
+		Amount:          -5000,
+		Outcome:         entity.AttemptOutcomeDeclined,
+		DeclineReason:   "INSUFFICIENT_CREDIT_LIMIT",
+		CreatedAt:       createdAt,
+	}, got)
+}
+
+func Test_transactionAttemptRepository_ListByAccountID(t *testing.T) {
+	selectQuery := "SELECT id, account_id, operation_type_id, amount, outcome, decline_reason, transaction_id, created_at FROM transaction_attempts WHERE account_id = $1 ORDER BY id DESC LIMIT $2"
+	columns := []string{"id", "account_id", "operation_type_id", "amount", "outcome", "decline_reason", "transaction_id", "created_at"}
+	createdAt := time.Date(2022, 3, 24, 10, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+
+	defer func() {
+		db.Close()
+		assert.NoError(t, mock.ExpectationsWereMet())
+	}()
+
+	mock.ExpectPrepare(selectQuery).ExpectQuery().
+		WithArgs(1, 50).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 1, 1, -10.0, "approved", "", 4, createdAt))
+
+	got, err := NewTransactionAttemptRepository(db).ListByAccountID(context.TODO(), 1, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.TransactionAttempt{
+		{
+			ID:              1,
+			AccountID:       1,
+			OperationTypeID: 1,
+			Amount:          -10,
+			Outcome:         entity.AttemptOutcomeApproved,
+			TransactionID:   4,
+			CreatedAt:       createdAt,
+		},
+	}, got)
+}
@@ -0,0 +1,223 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_transactionRepository_Save(t *testing.T) {
+	insertQuery := "INSERT INTO transactions (account_id, operation_type_id, amount) VALUES($1, $2, $3) RETURNING id, event_date, created_at"
+	createdAt := time.Date(2022, 3, 24, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		mock    func() (*sql.DB, sqlmock.Sqlmock, error)
+		want    *entity.Transaction
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Error prepare",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(insertQuery).
+					WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "Error execution",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(insertQuery).ExpectQuery().
+					WithArgs(1, 1, -50.0).
+					WillReturnError(errors.New("error"))
+
+				return db, mock, nil
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "Success",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(insertQuery).ExpectQuery().
+					WithArgs(1, 1, -50.0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "event_date", "created_at"}).AddRow(7, createdAt, createdAt))
+
+				return db, mock, nil
+			},
+			want: &entity.Transaction{
+				ID:              7,
+				AccountID:       1,
+				OperationTypeID: 1,
+				Amount:          -50,
+				EventDate:       createdAt,
+				CreatedAt:       createdAt,
+			},
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := tc.mock()
+			assert.NoError(t, err)
+
+			defer func() {
+				db.Close()
+				assert.NoError(t, mock.ExpectationsWereMet())
+			}()
+
+			r := NewTransactionRepository(db)
+
+			got, err := r.Save(context.TODO(), 1, 1, -50)
+			if !tc.wantErr(t, err, fmt.Sprintf("Save(%v, %v, %v)", 1, 1, -50)) {
+				return
+			}
+			assert.Equalf(t, tc.want, got, "Save(%v, %v, %v)", 1, 1, -50)
+		})
+	}
+}
+
+func Test_transactionRepository_GetByID(t *testing.T) {
+	selectQuery := "SELECT id, account_id, operation_type_id, amount, COALESCE(parent_id, 0), COALESCE(currency, ''), COALESCE(original_amount, 0), COALESCE(exchange_rate, 0), COALESCE(card_id, 0), event_date, created_at FROM transactions WHERE id = $1"
+	columns := []string{"id", "account_id", "operation_type_id", "amount", "parent_id", "currency", "original_amount", "exchange_rate", "card_id", "event_date", "created_at"}
+	createdAt := time.Date(2022, 3, 24, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name    string
+		mock    func() (*sql.DB, sqlmock.Sqlmock, error)
+		want    *entity.Transaction
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "Error not found",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).ExpectQuery().
+					WithArgs(7).
+					WillReturnRows(sqlmock.NewRows(columns))
+
+				return db, mock, nil
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, entity.ErrNotFound, msgAndArgs...)
+			},
+		},
+		{
+			name: "Success",
+			mock: func() (*sql.DB, sqlmock.Sqlmock, error) {
+				db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+				if err != nil {
+					return nil, nil, err
+				}
+
+				mock.ExpectPrepare(selectQuery).ExpectQuery().
+					WithArgs(7).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(7, 1, 1, -50.0, 0, "", 0, 0, 0, createdAt, createdAt))
+
+				return db, mock, nil
+			},
+			want: &entity.Transaction{
+				ID:              7,
+				AccountID:       1,
+				OperationTypeID: 1,
+				Amount:          -50,
+				EventDate:       createdAt,
+				CreatedAt:       createdAt,
+			},
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := tc.mock()
+			assert.NoError(t, err)
+
+			defer func() {
+				db.Close()
+				assert.NoError(t, mock.ExpectationsWereMet())
+			}()
+
+			r := NewTransactionRepository(db)
+
+			got, err := r.GetByID(context.TODO(), 7)
+			if !tc.wantErr(t, err, fmt.Sprintf("GetByID(%v)", 7)) {
+				return
+			}
+			assert.Equalf(t, tc.want, got, "GetByID(%v)", 7)
+		})
+	}
+}
+
+func Test_transactionRepository_SumDebitsSince(t *testing.T) {
+	selectQuery := "SELECT COUNT(id), COALESCE(SUM(ABS(amount)), 0) FROM transactions WHERE account_id = $1 AND operation_type_id <> $2 AND created_at >= $3"
+	since := time.Date(2022, 3, 24, 10, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+
+	defer func() {
+		db.Close()
+		assert.NoError(t, mock.ExpectationsWereMet())
+	}()
+
+	mock.ExpectPrepare(selectQuery).ExpectQuery().
+		WithArgs(1, entity.OperationTypePagamento, since).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "sum"}).AddRow(3, 150.0))
+
+	count, amount, err := NewTransactionRepository(db).SumDebitsSince(context.TODO(), 1, since)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, 150.0, amount)
+}
+
+func Test_transactionRepository_CountByAmountSince(t *testing.T) {
+	selectQuery := "SELECT COUNT(id) FROM transactions WHERE account_id = $1 AND operation_type_id = $2 AND amount = $3 AND created_at >= $4"
+	since := time.Date(2022, 3, 24, 10, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+
+	defer func() {
+		db.Close()
+		assert.NoError(t, mock.ExpectationsWereMet())
+	}()
+
+	mock.ExpectPrepare(selectQuery).ExpectQuery().
+		WithArgs(1, 1, -50.0, since).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	count, err := NewTransactionRepository(db).CountByAmountSince(context.TODO(), 1, 1, -50, since)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
@@ -0,0 +1,36 @@
+package prometheus
+
+import (
+	"github.com/brunomdev/digital-account/infra/cache"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type cacheMetrics struct {
+	lookups *prometheus.CounterVec
+}
+
+func NewCacheMetrics(registerer prometheus.Registerer) (cache.Metrics, error) {
+	m := &cacheMetrics{
+		lookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_lookups_total",
+			Help:      "Number of repository cache lookups by cache and result.",
+		}, []string{"cache", "result"}),
+	}
+
+	err := registerer.Register(m.lookups)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewCacheMetrics")
+	}
+
+	return m, nil
+}
+
+func (m *cacheMetrics) Hit(cache string) {
+	m.lookups.WithLabelValues(cache, "hit").Inc()
+}
+
+func (m *cacheMetrics) Miss(cache string) {
+	m.lookups.WithLabelValues(cache, "miss").Inc()
+}
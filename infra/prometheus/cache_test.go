@@ -0,0 +1,32 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func Test_cacheMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	m, err := NewCacheMetrics(registry)
+	assert.NoError(t, err)
+
+	m.Miss("operation_type")
+	m.Hit("operation_type")
+	m.Hit("operation_type")
+	m.Miss("account")
+
+	want := `
+# HELP digital_account_cache_lookups_total Number of repository cache lookups by cache and result.
+# TYPE digital_account_cache_lookups_total counter
+digital_account_cache_lookups_total{cache="account",result="miss"} 1
+digital_account_cache_lookups_total{cache="operation_type",result="hit"} 2
+digital_account_cache_lookups_total{cache="operation_type",result="miss"} 1
+`
+
+	err = testutil.GatherAndCompare(registry, strings.NewReader(want), "digital_account_cache_lookups_total")
+	assert.NoError(t, err)
+}
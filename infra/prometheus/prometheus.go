@@ -0,0 +1,26 @@
+package prometheus
+
+import (
+	"database/sql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "digital_account"
+
+// NewRegistry create the registry exposed on /metrics with the Go runtime, process and database pool collectors,
+// the pool collector is skipped when there is no database
+func NewRegistry(db *sql.DB, dbName string) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	if db != nil {
+		registry.MustRegister(collectors.NewDBStatsCollector(db, dbName))
+	}
+
+	return registry
+}
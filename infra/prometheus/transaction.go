@@ -0,0 +1,62 @@
+package prometheus
+
+import (
+	"github.com/brunomdev/digital-account/domain/transaction"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type transactionMetrics struct {
+	attempts *prometheus.CounterVec
+	declines *prometheus.CounterVec
+	amounts  *prometheus.HistogramVec
+}
+
+func NewTransactionMetrics(registerer prometheus.Registerer) (transaction.Metrics, error) {
+	m := &transactionMetrics{
+		attempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transactions_total",
+			Help:      "Number of transaction attempts by operation type and outcome.",
+		}, []string{"operation_type", "outcome"}),
+		declines: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transaction_declines_total",
+			Help:      "Number of declined transactions by reason.",
+		}, []string{"operation_type", "reason"}),
+		amounts: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "transaction_amount",
+			Help:      "Absolute amount of the approved transactions.",
+			Buckets:   []float64{10, 50, 100, 250, 500, 1000, 2500, 5000, 10000},
+		}, []string{"operation_type"}),
+	}
+
+	for _, collector := range []prometheus.Collector{m.attempts, m.declines, m.amounts} {
+		err := registerer.Register(collector)
+		if err != nil {
+			return nil, errors.Wrap(err, "NewTransactionMetrics")
+		}
+	}
+
+	return m, nil
+}
+
+func (m *transactionMetrics) ObserveAttempt(attempt *entity.TransactionAttempt) {
+	opType := strconv.Itoa(attempt.OperationTypeID)
+
+	m.attempts.WithLabelValues(opType, string(attempt.Outcome)).Inc()
+
+	switch attempt.Outcome {
+	case entity.AttemptOutcomeApproved:
+		m.amounts.WithLabelValues(opType).Observe(math.Abs(attempt.Amount))
+	case entity.AttemptOutcomeDeclined:
+		for _, reason := range strings.Split(attempt.DeclineReason, ",") {
+			m.declines.WithLabelValues(opType, reason).Inc()
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package prometheus
+
+import (
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func Test_transactionMetrics_ObserveAttempt(t *testing.T) {
+	testCases := []struct {
+		name     string
+		attempts []*entity.TransactionAttempt
+		metric   string
+		want     string
+	}{
+		{
+			name: "Attempts by outcome",
+			attempts: []*entity.TransactionAttempt{
+				{OperationTypeID: 1, Amount: -50, Outcome: entity.AttemptOutcomeApproved},
+				{OperationTypeID: 1, Amount: -5000, Outcome: entity.AttemptOutcomeDeclined, DeclineReason: "INSUFFICIENT_CREDIT_LIMIT"},
+				{OperationTypeID: 4, Amount: 100, Outcome: entity.AttemptOutcomeApproved},
+			},
+			metric: "digital_account_transactions_total",
+			want: `
+# HELP digital_account_transactions_total Number of transaction attempts by operation type and outcome.
+# TYPE digital_account_transactions_total counter
+digital_account_transactions_total{operation_type="1",outcome="approved"} 1
+digital_account_transactions_total{operation_type="1",outcome="declined"} 1
+digital_account_transactions_total{operation_type="4",outcome="approved"} 1
+`,
+		},
+		{
+			name: "Declines by reason",
+			attempts: []*entity.TransactionAttempt{
+				{OperationTypeID: 1, Amount: -5000, Outcome: entity.AttemptOutcomeDeclined, DeclineReason: "INSUFFICIENT_CREDIT_LIMIT"},
+				{OperationTypeID: 3, Amount: -900, Outcome: entity.AttemptOutcomeDeclined, DeclineReason: "NIGHT_WITHDRAWAL,HIGH_AMOUNT"},
+				{OperationTypeID: 1, Amount: -50, Outcome: entity.AttemptOutcomeFailed, DeclineReason: "INTERNAL_ERROR"},
+			},
+			metric: "digital_account_transaction_declines_total",
+			want: `
+# HELP digital_account_transaction_declines_total Number of declined transactions by reason.
+# TYPE digital_account_transaction_declines_total counter
+digital_account_transaction_declines_total{operation_type="1",reason="INSUFFICIENT_CREDIT_LIMIT"} 1
+digital_account_transaction_declines_total{operation_type="3",reason="HIGH_AMOUNT"} 1
+digital_account_transaction_declines_total{operation_type="3",reason="NIGHT_WITHDRAWAL"} 1
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry := prometheus.NewRegistry()
+
+			m, err := NewTransactionMetrics(registry)
+			assert.NoError(t, err)
+
+			for _, attempt := range tc.attempts {
+				m.ObserveAttempt(attempt)
+			}
+
+			err = testutil.GatherAndCompare(registry, strings.NewReader(tc.want), tc.metric)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,1080 @@
+// Package repositorytest provides the conformance suite every storage backend must pass, so the domain services
+// behave the same regardless of DB_DRIVER.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"github.com/brunomdev/digital-account/domain"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Case identifies a scenario of the suite, backends using SQL mocks set the expectations of each case
+type Case string
+
+const (
+	AccountSaveAndGet             Case = "Account save and get"
+	AccountNotFound               Case = "Account not found"
+	AccountUpdate                 Case = "Account update"
+	AccountList                   Case = "Account list"
+	OperationTypeGet              Case = "Operation type get"
+	OperationTypeNotFound         Case = "Operation type not found"
+	TransactionSaveAndGet         Case = "Transaction save and get"
+	TransactionNotFound           Case = "Transaction not found"
+	TransactionSumDebitsSince     Case = "Transaction sum debits since"
+	TransactionCountByAmountSince Case = "Transaction count by amount since"
+	TransactionSumLimitChanges    Case = "Transaction sum limit changes since"
+	TransactionListByAccountID    Case = "Transaction list by account"
+	TransactionSumLimitAfter      Case = "Transaction sum limit changes after"
+	AttemptSaveAndList            Case = "Attempt save and list"
+	AttemptDeleteBefore           Case = "Attempt delete before"
+	AttemptSummarize              Case = "Attempt summarize"
+	ClientNotFound                Case = "Client not found"
+	RiskDecisionSave              Case = "Risk decision save"
+	HealthPing                    Case = "Health ping"
+	JobSaveAndGet                 Case = "Job save and get"
+	JobNotFound                   Case = "Job not found"
+	JobClaimAndUpdate             Case = "Job claim and update"
+	JobGetLatestByType            Case = "Job get latest by type"
+	JobDeleteFinishedBefore       Case = "Job delete finished before"
+	LimitCheckpointSaveAndGet     Case = "Limit checkpoint save and get"
+	LockExclusive                 Case = "Lock exclusive"
+	TransactionSumBalanceBefore   Case = "Transaction sum balance before"
+	ChargeReserve                 Case = "Charge reserve"
+	OperationTypeUpdateRules      Case = "Operation type update rules"
+	TransactionSaveWithLinked     Case = "Transaction save with linked"
+	TransactionSumByOpTypeSince   Case = "Transaction sum by operation type since"
+	TransactionSaveDetailed       Case = "Transaction save detailed"
+	CustomerSaveAndGet            Case = "Customer save and get"
+	CustomerNotFound              Case = "Customer not found"
+	CustomerUpdate                Case = "Customer update"
+	AccountSaveForCustomer        Case = "Account save for customer"
+	CardSaveAndGet                Case = "Card save and get"
+	CardNotFound                  Case = "Card not found"
+	CardUpdateStatus              Case = "Card update status"
+	TransactionSumByCardSince     Case = "Transaction sum by card since"
+	TransactionSaveBackdated      Case = "Transaction save backdated"
+	AuditAppendAndList            Case = "Audit append and list"
+	AccountSetCreditLimit         Case = "Account set credit limit"
+)
+
+// Fixtures used by the cases, the SQL mocks return them as the stored rows
+const (
+	DocumentNumber       = "12345678900"
+	AvailableCreditLimit = 100.0
+	UpdatedCreditLimit   = 50.0
+	MissingID            = 999
+	UnknownKeyHash       = "unknown"
+	JobType              = "test"
+	JobPayload           = `{"rows":1}`
+	JobResult            = `{"ok":true}`
+	JobMaxAttempts       = 3
+	JobActor             = "backoffice"
+	JobRequestID         = "request-1"
+	JobLease             = time.Minute
+	MissingJobType       = "missing"
+	LockName             = "test"
+	ChargeAmount         = 1.5
+	UpdatedEmail         = "maria.silva@example.com"
+)
+
+// AccountCreatedAt is the creation time of the saved accounts
+var AccountCreatedAt = time.Date(2022, 3, 1, 9, 0, 0, 0, time.UTC)
+
+// AdjustedAt is the time of the credit limit adjustment
+var AdjustedAt = time.Date(2022, 3, 29, 15, 0, 0, 0, time.UTC)
+
+// JobRunAt is the due time of the saved jobs, truncated to the second every backend stores
+var JobRunAt = time.Date(2022, 3, 24, 10, 0, 0, 0, time.UTC)
+
+// WithdrawalRules are the rules updated on the SAQUE operation type
+var WithdrawalRules = entity.OperationTypeRules{LimitRate: 0.3, Fee: 5, FeeRate: 0.01, DailyMaxCount: 3, DailyMaxAmount: 500}
+
+// ForeignExchange is the exchange of the foreign currency transaction
+var ForeignExchange = entity.Exchange{Currency: "USD", OriginalAmount: -10, Rate: 5.2}
+
+// Customer is the person saved by the customer cases
+var Customer = entity.Customer{
+	Name:      "Maria Silva",
+	BirthDate: "1990-01-31",
+	Email:     "maria@example.com",
+	Phone:     "+5511999999999",
+	Address: entity.Address{
+		Street:     "Rua Augusta",
+		Number:     "100",
+		City:       "Sao Paulo",
+		State:      "SP",
+		PostalCode: "01304-000",
+		Country:    "BR",
+	},
+}
+
+// EventDate is the event date of the backdated transaction
+var EventDate = time.Date(2022, 3, 28, 12, 0, 0, 0, time.UTC)
+
+// TransactionCreatedAt is the creation time given by the clock to the transaction saved with its fee
+var TransactionCreatedAt = time.Date(2022, 3, 30, 10, 0, 0, 0, time.UTC)
+
+// Card is the card of the account saved by the card cases
+var Card = entity.Card{
+	Type:        entity.CardTypeVirtual,
+	MaskedPAN:   "516292******1234",
+	Token:       "tok_0123456789abcdef0123456789abcdef",
+	ExpiryMonth: 3,
+	ExpiryYear:  2027,
+	Status:      entity.CardStatusActive,
+	Limit:       500,
+}
+
+// ChargeDate is the day of the reserved charges
+var ChargeDate = time.Date(2022, 3, 25, 0, 0, 0, 0, time.UTC)
+
+// AuditEntries are appended in order by the audit case, the changes of the credit limit of account 1
+var AuditEntries = []entity.AuditEntry{
+	{
+		Actor:     "backoffice",
+		RequestID: "request-1",
+		Action:    entity.AuditActionCreate,
+		Entity:    entity.AuditEntityAccount,
+		EntityID:  1,
+		After:     `{"limit":100}`,
+		CreatedAt: time.Date(2022, 3, 31, 9, 0, 0, 0, time.UTC),
+	},
+	{
+		Actor:     "backoffice",
+		RequestID: "request-2",
+		Action:    entity.AuditActionUpdateCreditLimit,
+		Entity:    entity.AuditEntityAccount,
+		EntityID:  1,
+		Before:    `{"limit":100}`,
+		After:     `{"limit":50}`,
+		CreatedAt: time.Date(2022, 3, 31, 9, 30, 0, 0, time.UTC),
+	},
+}
+
+// AuditChain return the AuditEntries as stored, sealed one after the other with ids from 1
+func AuditChain() []entity.AuditEntry {
+	chain := make([]entity.AuditEntry, len(AuditEntries))
+
+	var prevHash string
+	for i, entry := range AuditEntries {
+		entry.ID = i + 1
+		entry.Seal(prevHash)
+		chain[i] = entry
+		prevHash = entry.Hash
+	}
+
+	return chain
+}
+
+// NewBackend create the repositories for a single case
+type NewBackend func(t *testing.T, c Case) *domain.Repository
+
+// Run the conformance suite against the backend
+func Run(t *testing.T, newBackend NewBackend) {
+	testCases := []struct {
+		c   Case
+		run func(t *testing.T, repo *domain.Repository)
+	}{
+		{c: AccountSaveAndGet, run: accountSaveAndGet},
+		{c: AccountNotFound, run: accountNotFound},
+		{c: AccountUpdate, run: accountUpdate},
+		{c: AccountList, run: accountList},
+		{c: OperationTypeGet, run: operationTypeGet},
+		{c: OperationTypeNotFound, run: operationTypeNotFound},
+		{c: TransactionSaveAndGet, run: transactionSaveAndGet},
+		{c: TransactionNotFound, run: transactionNotFound},
+		{c: TransactionSumDebitsSince, run: transactionSumDebitsSince},
+		{c: TransactionCountByAmountSince, run: transactionCountByAmountSince},
+		{c: TransactionSumLimitChanges, run: transactionSumLimitChanges},
+		{c: TransactionListByAccountID, run: transactionListByAccountID},
+		{c: TransactionSumLimitAfter, run: transactionSumLimitAfter},
+		{c: AttemptSaveAndList, run: attemptSaveAndList},
+		{c: AttemptDeleteBefore, run: attemptDeleteBefore},
+		{c: AttemptSummarize, run: attemptSummarize},
+		{c: ClientNotFound, run: clientNotFound},
+		{c: RiskDecisionSave, run: riskDecisionSave},
+		{c: HealthPing, run: healthPing},
+		{c: JobSaveAndGet, run: jobSaveAndGet},
+		{c: JobNotFound, run: jobNotFound},
+		{c: JobClaimAndUpdate, run: jobClaimAndUpdate},
+		{c: JobGetLatestByType, run: jobGetLatestByType},
+		{c: JobDeleteFinishedBefore, run: jobDeleteFinishedBefore},
+		{c: LimitCheckpointSaveAndGet, run: limitCheckpointSaveAndGet},
+		{c: LockExclusive, run: lockExclusive},
+		{c: TransactionSumBalanceBefore, run: transactionSumBalanceBefore},
+		{c: ChargeReserve, run: chargeReserve},
+		{c: OperationTypeUpdateRules, run: operationTypeUpdateRules},
+		{c: TransactionSaveWithLinked, run: transactionSaveWithLinked},
+		{c: TransactionSumByOpTypeSince, run: transactionSumByOpTypeSince},
+		{c: TransactionSaveDetailed, run: transactionSaveDetailed},
+		{c: CustomerSaveAndGet, run: customerSaveAndGet},
+		{c: CustomerNotFound, run: customerNotFound},
+		{c: CustomerUpdate, run: customerUpdate},
+		{c: AccountSaveForCustomer, run: accountSaveForCustomer},
+		{c: CardSaveAndGet, run: cardSaveAndGet},
+		{c: CardNotFound, run: cardNotFound},
+		{c: CardUpdateStatus, run: cardUpdateStatus},
+		{c: TransactionSumByCardSince, run: transactionSumByCardSince},
+		{c: TransactionSaveBackdated, run: transactionSaveBackdated},
+		{c: AuditAppendAndList, run: auditAppendAndList},
+		{c: AccountSetCreditLimit, run: accountSetCreditLimit},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.c), func(t *testing.T) {
+			tc.run(t, newBackend(t, tc.c))
+		})
+	}
+}
+
+func accountSaveAndGet(t *testing.T, repo *domain.Repository) {
+	saved, err := repo.Account.Save(context.TODO(), DocumentNumber, AvailableCreditLimit, AccountCreatedAt)
+	require.NoError(t, err)
+	assert.Greater(t, saved.ID, 0)
+	assert.Equal(t, DocumentNumber, saved.DocumentNumber)
+	assert.Equal(t, AvailableCreditLimit, saved.AvailabelCreditLimit)
+	assert.True(t, AccountCreatedAt.Equal(saved.CreatedAt))
+
+	got, err := repo.Account.GetByID(context.TODO(), saved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, saved.ID, got.ID)
+	assert.Equal(t, DocumentNumber, got.DocumentNumber)
+	assert.Equal(t, AvailableCreditLimit, got.AvailabelCreditLimit)
+	assert.True(t, AccountCreatedAt.Equal(got.CreatedAt), "created at %v", got.CreatedAt)
+}
+
+func accountNotFound(t *testing.T, repo *domain.Repository) {
+	_, err := repo.Account.GetByID(context.TODO(), MissingID)
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+}
+
+func accountUpdate(t *testing.T, repo *domain.Repository) {
+	saved, err := repo.Account.Save(context.TODO(), DocumentNumber, AvailableCreditLimit, AccountCreatedAt)
+	require.NoError(t, err)
+
+	saved.AvailabelCreditLimit = UpdatedCreditLimit
+
+	updated, err := repo.Account.Update(context.TODO(), saved)
+	require.NoError(t, err)
+	assert.Equal(t, UpdatedCreditLimit, updated.AvailabelCreditLimit)
+
+	got, err := repo.Account.GetByID(context.TODO(), saved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, UpdatedCreditLimit, got.AvailabelCreditLimit)
+}
+
+func accountList(t *testing.T, repo *domain.Repository) {
+	saved, err := repo.Account.Save(context.TODO(), DocumentNumber, AvailableCreditLimit, AccountCreatedAt)
+	require.NoError(t, err)
+
+	got, err := repo.Account.List(context.TODO(), 0, 10)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, saved.ID, got[0].ID)
+	assert.Equal(t, DocumentNumber, got[0].DocumentNumber)
+	assert.Equal(t, AvailableCreditLimit, got[0].AvailabelCreditLimit)
+
+	got, err = repo.Account.List(context.TODO(), saved.ID, 10)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func accountSetCreditLimit(t *testing.T, repo *domain.Repository) {
+	saved, err := repo.Account.Save(context.TODO(), DocumentNumber, AvailableCreditLimit, AccountCreatedAt)
+	require.NoError(t, err)
+
+	updated, err := repo.Account.SetCreditLimit(context.TODO(), saved.ID, UpdatedCreditLimit, AdjustedAt)
+	require.NoError(t, err)
+	assert.Equal(t, saved.ID, updated.ID)
+	assert.Equal(t, UpdatedCreditLimit, updated.AvailabelCreditLimit)
+
+	got, err := repo.Account.GetByID(context.TODO(), saved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, UpdatedCreditLimit, got.AvailabelCreditLimit)
+
+	sum, err := repo.Account.SumAdjustmentsSince(context.TODO(), saved.ID, AdjustedAt)
+	require.NoError(t, err)
+	assert.Equal(t, UpdatedCreditLimit-AvailableCreditLimit, sum)
+
+	sum, err = repo.Account.SumAdjustmentsSince(context.TODO(), saved.ID, AdjustedAt.Add(time.Second))
+	require.NoError(t, err)
+	assert.Zero(t, sum)
+
+	adjustments, err := repo.Account.ListAdjustments(context.TODO(), saved.ID, AdjustedAt, AdjustedAt.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, adjustments, 1)
+	assert.Greater(t, adjustments[0].ID, 0)
+	assert.Equal(t, saved.ID, adjustments[0].AccountID)
+	assert.Equal(t, UpdatedCreditLimit-AvailableCreditLimit, adjustments[0].Amount)
+	assert.True(t, AdjustedAt.Equal(adjustments[0].CreatedAt), "created at %v", adjustments[0].CreatedAt)
+
+	// the end of the period is exclusive
+	adjustments, err = repo.Account.ListAdjustments(context.TODO(), saved.ID, AccountCreatedAt, AdjustedAt)
+	require.NoError(t, err)
+	assert.Empty(t, adjustments)
+
+	_, err = repo.Account.SetCreditLimit(context.TODO(), MissingID, UpdatedCreditLimit, AdjustedAt)
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+}
+
+func operationTypeGet(t *testing.T, repo *domain.Repository) {
+	got, err := repo.OperationType.GetByID(context.TODO(), entity.OperationTypeCompraAVista)
+	require.NoError(t, err)
+	assert.Equal(t, &entity.OperationType{ID: entity.OperationTypeCompraAVista, Description: "COMPRA A VISTA"}, got)
+}
+
+func operationTypeNotFound(t *testing.T, repo *domain.Repository) {
+	_, err := repo.OperationType.GetByID(context.TODO(), MissingID)
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+}
+
+func transactionSaveAndGet(t *testing.T, repo *domain.Repository) {
+	acc, err := repo.Account.Save(context.TODO(), DocumentNumber, AvailableCreditLimit, AccountCreatedAt)
+	require.NoError(t, err)
+
+	saved, err := repo.Transaction.Save(context.TODO(), acc.ID, entity.OperationTypeCompraAVista, -50)
+	require.NoError(t, err)
+	assert.Greater(t, saved.ID, 0)
+	assert.Equal(t, acc.ID, saved.AccountID)
+	assert.Equal(t, entity.OperationTypeCompraAVista, saved.OperationTypeID)
+	assert.Equal(t, -50.0, saved.Amount)
+
+	got, err := repo.Transaction.GetByID(context.TODO(), saved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, saved.ID, got.ID)
+	assert.Equal(t, acc.ID, got.AccountID)
+	assert.Equal(t, entity.OperationTypeCompraAVista, got.OperationTypeID)
+	assert.Equal(t, -50.0, got.Amount)
+	assert.Nil(t, got.Exchange)
+	assert.False(t, got.EventDate.IsZero())
+}
+
+func transactionNotFound(t *testing.T, repo *domain.Repository) {
+	_, err := repo.Transaction.GetByID(context.TODO(), MissingID)
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+}
+
+func transactionSumDebitsSince(t *testing.T, repo *domain.Repository) {
+	acc := saveTransactions(t, repo)
+
+	count, amount, err := repo.Transaction.SumDebitsSince(context.TODO(), acc.ID, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, 80.0, amount)
+}
+
+func transactionCountByAmountSince(t *testing.T, repo *domain.Repository) {
+	acc, err := repo.Account.Save(context.TODO(), DocumentNumber, AvailableCreditLimit, AccountCreatedAt)
+	require.NoError(t, err)
+
+	for _, amount := range []float64{-50, -50, -20} {
+		_, err = repo.Transaction.Save(context.TODO(), acc.ID, entity.OperationTypeCompraAVista, amount)
+		require.NoError(t, err)
+	}
+
+	count, err := repo.Transaction.CountByAmountSince(
+		context.TODO(),
+		acc.ID,
+		entity.OperationTypeCompraAVista,
+		-50,
+		time.Now().Add(-time.Hour),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func transactionSumLimitChanges(t *testing.T, repo *domain.Repository) {
+	acc := saveTransactions(t, repo)
+
+	amount, err := repo.Transaction.SumLimitChangesSince(context.TODO(), acc.ID, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, amount)
+}
+
+func transactionListByAccountID(t *testing.T, repo *domain.Repository) {
+	acc := saveTransactions(t, repo)
+
+	var got []float64
+	err := repo.Transaction.ListByAccountID(
+		context.TODO(),
+		acc.ID,
+		time.Now().Add(-time.Hour),
+		time.Now().Add(time.Hour),
+		func(txn *entity.Transaction) error {
+			assert.Equal(t, acc.ID, txn.AccountID)
+			got = append(got, txn.Amount)
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{-50, -30, 100}, got)
+
+	errStop := errors.New("stop")
+	calls := 0
+	err = repo.Transaction.ListByAccountID(
+		context.TODO(),
+		acc.ID,
+		time.Now().Add(-time.Hour),
+		time.Now().Add(time.Hour),
+		func(txn *entity.Transaction) error {
+			calls++
+			return errStop
+		},
+	)
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, calls)
+}
+
+func transactionSumLimitAfter(t *testing.T, repo *domain.Repository) {
+	acc := saveTransactions(t, repo)
+
+	amount, lastID, err := repo.Transaction.SumLimitChangesAfter(context.TODO(), acc.ID, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, amount)
+	assert.Greater(t, lastID, 0)
+
+	amount, after, err := repo.Transaction.SumLimitChangesAfter(context.TODO(), acc.ID, lastID)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, amount)
+	assert.Equal(t, lastID, after)
+}
+
+// saveTransactions save a purchase, a withdrawal and a payment on a new account
+func saveTransactions(t *testing.T, repo *domain.Repository) *entity.Account {
+	acc, err := repo.Account.Save(context.TODO(), DocumentNumber, AvailableCreditLimit, AccountCreatedAt)
+	require.NoError(t, err)
+
+	for _, txn := range []struct {
+		operationTypeID int
+		amount          float64
+	}{
+		{operationTypeID: entity.OperationTypeCompraAVista, amount: -50},
+		{operationTypeID: entity.OperationTypeSaque, amount: -30},
+		{operationTypeID: entity.OperationTypePagamento, amount: 100},
+	} {
+		_, err = repo.Transaction.Save(context.TODO(), acc.ID, txn.operationTypeID, txn.amount)
+		require.NoError(t, err)
+	}
+
+	return acc
+}
+
+func attemptSaveAndList(t *testing.T, repo *domain.Repository) {
+	acc, err := repo.Account.Save(context.TODO(), DocumentNumber, AvailableCreditLimit, AccountCreatedAt)
+	require.NoError(t, err)
+
+	approved, err := repo.TransactionAttempt.Save(context.TODO(), &entity.TransactionAttempt{
+		AccountID:       acc.ID,
+		OperationTypeID: entity.OperationTypeCompraAVista,
+		Amount:          -10,
+		Outcome:         entity.AttemptOutcomeApproved,
+	})
+	require.NoError(t, err)
+	assert.Greater(t, approved.ID, 0)
+
+	declined, err := repo.TransactionAttempt.Save(context.TODO(), &entity.TransactionAttempt{
+		AccountID:       acc.ID,
+		OperationTypeID: entity.OperationTypeCompraAVista,
+		Amount:          -5000,
+		Outcome:         entity.AttemptOutcomeDeclined,
+		DeclineReason:   "INSUFFICIENT_CREDIT_LIMIT",
+	})
+	require.NoError(t, err)
+	assert.Greater(t, declined.ID, approved.ID)
+
+	latest, err := repo.TransactionAttempt.ListByAccountID(context.TODO(), acc.ID, 1)
+	require.NoError(t, err)
+	require.Len(t, latest, 1)
+	assert.Equal(t, declined.ID, latest[0].ID)
+	assert.Equal(t, entity.AttemptOutcomeDeclined, latest[0].Outcome)
+	assert.Equal(t, "INSUFFICIENT_CREDIT_LIMIT", latest[0].DeclineReason)
+
+	all, err := repo.TransactionAttempt.ListByAccountID(context.TODO(), acc.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, []int{declined.ID, approved.ID}, []int{all[0].ID, all[1].ID})
+}
+
+func attemptDeleteBefore(t *testing.T, repo *domain.Repository) {
+	acc, err := repo.Account.Save(context.TODO(), DocumentNumber, AvailableCreditLimit, AccountCreatedAt)
+	require.NoError(t, err)
+
+	_, err = repo.TransactionAttempt.Save(context.TODO(), &entity.TransactionAttempt{
+		AccountID:       acc.ID,
+		OperationTypeID: entity.OperationTypeCompraAVista,
+		Amount:          -10,
+		Outcome:         entity.AttemptOutcomeApproved,
+	})
+	require.NoError(t, err)
+
+	deleted, err := repo.TransactionAttempt.DeleteBefore(context.TODO(), time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+
+	deleted, err = repo.TransactionAttempt.DeleteBefore(context.TODO(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	got, err := repo.TransactionAttempt.ListByAccountID(context.TODO(), acc.ID, 10)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func attemptSummarize(t *testing.T, repo *domain.Repository) {
+	acc, err := repo.Account.Save(context.TODO(), DocumentNumber, AvailableCreditLimit, AccountCreatedAt)
+	require.NoError(t, err)
+
+	for _, attempt := range []entity.TransactionAttempt{
+		{OperationTypeID: entity.OperationTypeCompraAVista, Amount: -10, Outcome: entity.AttemptOutcomeApproved},
+		{OperationTypeID: entity.OperationTypeCompraAVista, Amount: -5000, Outcome: entity.AttemptOutcomeDeclined},
+		{OperationTypeID: entity.OperationTypeCompraAVista, Amount: -20, Outcome: entity.AttemptOutcomeApproved},
+		{OperationTypeID: entity.OperationTypePagamento, Amount: 100, Outcome: entity.AttemptOutcomeApproved},
+	} {
+		attempt.AccountID = acc.ID
+
+		_, err = repo.TransactionAttempt.Save(context.TODO(), &attempt)
+		require.NoError(t, err)
+	}
+
+	got, err := repo.TransactionAttempt.Summarize(context.TODO(), time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	assert.Equal(t, []entity.AttemptSummary{
+		{OperationTypeID: entity.OperationTypeCompraAVista, Outcome: entity.AttemptOutcomeApproved, Count: 2, Amount: 30},
+		{OperationTypeID: entity.OperationTypeCompraAVista, Outcome: entity.AttemptOutcomeDeclined, Count: 1, Amount: 5000},
+		{OperationTypeID: entity.OperationTypePagamento, Outcome: entity.AttemptOutcomeApproved, Count: 1, Amount: 100},
+	}, []entity.AttemptSummary{*got[0], *got[1], *got[2]})
+
+	got, err = repo.TransactionAttempt.Summarize(context.TODO(), time.Now().Add(time.Hour), time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func clientNotFound(t *testing.T, repo *domain.Repository) {
+	_, err := repo.Client.GetByKeyHash(context.TODO(), UnknownKeyHash)
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+}
+
+func riskDecisionSave(t *testing.T, repo *domain.Repository) {
+	acc, err := repo.Account.Save(context.TODO(), DocumentNumber, AvailableCreditLimit, AccountCreatedAt)
+	require.NoError(t, err)
+
+	decision := &entity.RiskDecision{
+		AccountID:       acc.ID,
+		OperationTypeID: entity.OperationTypeCompraAVista,
+		Amount:          -900,
+		Outcome:         entity.RiskOutcomeDecline,
+		ReasonCodes:     []string{"HIGH_AMOUNT", "NIGHT"},
+	}
+
+	saved, err := repo.RiskDecision.Save(context.TODO(), decision)
+	require.NoError(t, err)
+	assert.Greater(t, saved.ID, 0)
+	assert.Equal(t, decision.ReasonCodes, saved.ReasonCodes)
+	assert.Equal(t, 0, decision.ID)
+}
+
+func healthPing(t *testing.T, repo *domain.Repository) {
+	assert.NoError(t, repo.Health.Ping(context.TODO()))
+}
+
+func saveJob(t *testing.T, repo *domain.Repository) *entity.Job {
+	saved, err := repo.Job.Save(context.TODO(), &entity.Job{
+		Type:        JobType,
+		Payload:     []byte(JobPayload),
+		Status:      entity.JobStatusQueued,
+		MaxAttempts: JobMaxAttempts,
+		Actor:       JobActor,
+		RequestID:   JobRequestID,
+		RunAt:       JobRunAt,
+	})
+	require.NoError(t, err)
+	assert.Greater(t, saved.ID, 0)
+
+	return saved
+}
+
+func jobSaveAndGet(t *testing.T, repo *domain.Repository) {
+	saved := saveJob(t, repo)
+
+	got, err := repo.Job.GetByID(context.TODO(), saved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, saved.ID, got.ID)
+	assert.Equal(t, JobType, got.Type)
+	assert.JSONEq(t, JobPayload, string(got.Payload))
+	assert.Equal(t, entity.JobStatusQueued, got.Status)
+	assert.Equal(t, 0, got.Attempts)
+	assert.Equal(t, JobMaxAttempts, got.MaxAttempts)
+	assert.Equal(t, JobActor, got.Actor)
+	assert.Equal(t, JobRequestID, got.RequestID)
+	assert.Empty(t, got.Result)
+	assert.True(t, JobRunAt.Equal(got.RunAt))
+	assert.False(t, got.CreatedAt.IsZero())
+}
+
+func jobNotFound(t *testing.T, repo *domain.Repository) {
+	_, err := repo.Job.GetByID(context.TODO(), MissingID)
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+}
+
+func jobClaimAndUpdate(t *testing.T, repo *domain.Repository) {
+	saved := saveJob(t, repo)
+
+	_, err := repo.Job.Claim(context.TODO(), JobRunAt.Add(-time.Second), JobRunAt.Add(JobLease))
+	assert.ErrorIs(t, err, entity.ErrNotFound, "claimed a job before it is due")
+
+	claimed, err := repo.Job.Claim(context.TODO(), JobRunAt, JobRunAt.Add(JobLease))
+	require.NoError(t, err)
+	assert.Equal(t, saved.ID, claimed.ID)
+	assert.Equal(t, entity.JobStatusRunning, claimed.Status)
+	assert.Equal(t, 1, claimed.Attempts)
+	assert.True(t, JobRunAt.Add(JobLease).Equal(claimed.RunAt))
+
+	_, err = repo.Job.Claim(context.TODO(), JobRunAt, JobRunAt.Add(JobLease))
+	assert.ErrorIs(t, err, entity.ErrNotFound, "claimed a job while its lease is valid")
+
+	claimed.Status = entity.JobStatusSucceeded
+	claimed.Progress = 100
+	claimed.Result = []byte(JobResult)
+
+	err = repo.Job.Update(context.TODO(), claimed)
+	require.NoError(t, err)
+
+	got, err := repo.Job.GetByID(context.TODO(), saved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.JobStatusSucceeded, got.Status)
+	assert.Equal(t, 100, got.Progress)
+	assert.Equal(t, 1, got.Attempts)
+	assert.JSONEq(t, JobResult, string(got.Result))
+
+	_, err = repo.Job.Claim(context.TODO(), JobRunAt.Add(2*JobLease), JobRunAt.Add(3*JobLease))
+	assert.ErrorIs(t, err, entity.ErrNotFound, "claimed a finished job")
+}
+
+func jobGetLatestByType(t *testing.T, repo *domain.Repository) {
+	saved := saveJob(t, repo)
+
+	got, err := repo.Job.GetLatestByType(context.TODO(), JobType)
+	require.NoError(t, err)
+	assert.Equal(t, saved.ID, got.ID)
+	assert.Equal(t, JobType, got.Type)
+
+	_, err = repo.Job.GetLatestByType(context.TODO(), MissingJobType)
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+}
+
+func jobDeleteFinishedBefore(t *testing.T, repo *domain.Repository) {
+	saved := saveJob(t, repo)
+
+	deleted, err := repo.Job.DeleteFinishedBefore(context.TODO(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted, "deleted a queued job")
+
+	saved.Status = entity.JobStatusSucceeded
+	saved.Result = []byte(JobResult)
+
+	err = repo.Job.Update(context.TODO(), saved)
+	require.NoError(t, err)
+
+	deleted, err = repo.Job.DeleteFinishedBefore(context.TODO(), time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted, "deleted a job created after the time")
+
+	deleted, err = repo.Job.DeleteFinishedBefore(context.TODO(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	_, err = repo.Job.GetByID(context.TODO(), saved.ID)
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+}
+
+func limitCheckpointSaveAndGet(t *testing.T, repo *domain.Repository) {
+	acc, err := repo.Account.Save(context.TODO(), DocumentNumber, AvailableCreditLimit, AccountCreatedAt)
+	require.NoError(t, err)
+
+	_, err = repo.LimitCheckpoint.GetByAccountID(context.TODO(), acc.ID)
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+
+	for _, checkpoint := range []entity.LimitCheckpoint{
+		{AccountID: acc.ID, AvailableCreditLimit: AvailableCreditLimit},
+		{AccountID: acc.ID, AvailableCreditLimit: UpdatedCreditLimit, TransactionID: 3},
+	} {
+		err = repo.LimitCheckpoint.Save(context.TODO(), &checkpoint)
+		require.NoError(t, err)
+
+		got, err := repo.LimitCheckpoint.GetByAccountID(context.TODO(), acc.ID)
+		require.NoError(t, err)
+		assert.Equal(t, acc.ID, got.AccountID)
+		assert.Equal(t, checkpoint.AvailableCreditLimit, got.AvailableCreditLimit)
+		assert.Equal(t, checkpoint.TransactionID, got.TransactionID)
+		assert.False(t, got.UpdatedAt.IsZero())
+	}
+}
+
+func lockExclusive(t *testing.T, repo *domain.Repository) {
+	release, err := repo.Locker.TryLock(context.TODO(), LockName)
+	require.NoError(t, err)
+
+	_, err = repo.Locker.TryLock(context.TODO(), LockName)
+	assert.ErrorIs(t, err, entity.ErrLocked)
+
+	release()
+
+	release, err = repo.Locker.TryLock(context.TODO(), LockName)
+	require.NoError(t, err)
+
+	release()
+}
+
+func transactionSumBalanceBefore(t *testing.T, repo *domain.Repository) {
+	acc := saveTransactions(t, repo)
+
+	debits, payments, err := repo.Transaction.SumBalanceBefore(context.TODO(), acc.ID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 80.0, debits)
+	assert.Equal(t, 100.0, payments)
+
+	debits, payments, err = repo.Transaction.SumBalanceBefore(context.TODO(), acc.ID, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, debits)
+	assert.Equal(t, 0.0, payments)
+}
+
+func chargeReserve(t *testing.T, repo *domain.Repository) {
+	acc, err := repo.Account.Save(context.TODO(), DocumentNumber, AvailableCreditLimit, AccountCreatedAt)
+	require.NoError(t, err)
+
+	interest := &entity.Charge{AccountID: acc.ID, Type: entity.ChargeTypeInterest, Date: ChargeDate, Amount: ChargeAmount}
+
+	reserved, err := repo.Charge.Reserve(context.TODO(), interest)
+	require.NoError(t, err)
+	assert.Greater(t, reserved.ID, 0)
+	assert.Equal(t, ChargeAmount, reserved.Amount)
+
+	_, err = repo.Charge.Reserve(context.TODO(), interest)
+	assert.ErrorIs(t, err, entity.ErrAlreadyCharged)
+
+	lateFee, err := repo.Charge.Reserve(
+		context.TODO(),
+		&entity.Charge{AccountID: acc.ID, Type: entity.ChargeTypeLateFee, Date: ChargeDate, Amount: ChargeAmount},
+	)
+	require.NoError(t, err)
+	assert.NotEqual(t, reserved.ID, lateFee.ID)
+
+	err = repo.Charge.SetTransactionID(context.TODO(), lateFee.ID, 1)
+	require.NoError(t, err)
+
+	err = repo.Charge.Delete(context.TODO(), reserved.ID)
+	require.NoError(t, err)
+
+	_, err = repo.Charge.Reserve(context.TODO(), interest)
+	require.NoError(t, err)
+}
+
+func operationTypeUpdateRules(t *testing.T, repo *domain.Repository) {
+	err := repo.OperationType.UpdateRules(context.TODO(), entity.OperationTypeSaque, WithdrawalRules)
+	require.NoError(t, err)
+
+	got, err := repo.OperationType.GetByID(context.TODO(), entity.OperationTypeSaque)
+	require.NoError(t, err)
+	assert.Equal(t, &entity.OperationType{ID: entity.OperationTypeSaque, Description: "SAQUE", Rules: WithdrawalRules}, got)
+}
+
+func transactionSaveWithLinked(t *testing.T, repo *domain.Repository) {
+	acc, err := repo.Account.Save(context.TODO(), DocumentNumber, AvailableCreditLimit, AccountCreatedAt)
+	require.NoError(t, err)
+
+	debit, fees, err := repo.Transaction.SaveWithLinked(
+		context.TODO(),
+		&entity.Transaction{
+			AccountID:       acc.ID,
+			OperationTypeID: entity.OperationTypeSaque,
+			Amount:          -50,
+			CreatedAt:       TransactionCreatedAt,
+		},
+		[]*entity.Transaction{{OperationTypeID: entity.OperationTypeTarifa, Amount: -5}},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 0, debit.ParentID)
+	assert.True(t, TransactionCreatedAt.Equal(debit.CreatedAt))
+	require.Len(t, fees, 1)
+
+	fee := fees[0]
+	assert.Greater(t, fee.ID, debit.ID)
+	assert.Equal(t, acc.ID, fee.AccountID)
+	assert.Equal(t, entity.OperationTypeTarifa, fee.OperationTypeID)
+	assert.Equal(t, -5.0, fee.Amount)
+	assert.Equal(t, debit.ID, fee.ParentID)
+	assert.True(t, TransactionCreatedAt.Equal(fee.CreatedAt))
+
+	got, err := repo.Transaction.GetByID(context.TODO(), fee.ID)
+	require.NoError(t, err)
+	assert.Equal(t, debit.ID, got.ParentID)
+}
+
+func transactionSumByOpTypeSince(t *testing.T, repo *domain.Repository) {
+	acc := saveTransactions(t, repo)
+
+	count, amount, err := repo.Transaction.SumByOperationTypeSince(
+		context.TODO(),
+		acc.ID,
+		entity.OperationTypeSaque,
+		time.Now().Add(-time.Hour),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, 30.0, amount)
+}
+
+func transactionSaveDetailed(t *testing.T, repo *domain.Repository) {
+	acc, err := repo.Account.Save(context.TODO(), DocumentNumber, AvailableCreditLimit, AccountCreatedAt)
+	require.NoError(t, err)
+
+	exchange := ForeignExchange
+	saved, err := repo.Transaction.SaveDetailed(context.TODO(), &entity.Transaction{
+		AccountID:       acc.ID,
+		OperationTypeID: entity.OperationTypeCompraAVista,
+		Amount:          -52,
+		Exchange:        &exchange,
+	})
+	require.NoError(t, err)
+	assert.Greater(t, saved.ID, 0)
+	assert.Equal(t, -52.0, saved.Amount)
+	assert.Equal(t, &ForeignExchange, saved.Exchange)
+
+	got, err := repo.Transaction.GetByID(context.TODO(), saved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, acc.ID, got.AccountID)
+	assert.Equal(t, -52.0, got.Amount)
+	assert.Equal(t, &ForeignExchange, got.Exchange)
+	assert.False(t, got.EventDate.IsZero())
+}
+
+func saveCustomer(t *testing.T, repo *domain.Repository) *entity.Customer {
+	customer := Customer
+
+	saved, err := repo.Customer.Save(context.TODO(), &customer)
+	require.NoError(t, err)
+	assert.Greater(t, saved.ID, 0)
+
+	return saved
+}
+
+func customerSaveAndGet(t *testing.T, repo *domain.Repository) {
+	saved := saveCustomer(t, repo)
+	assert.Equal(t, Customer.Name, saved.Name)
+	assert.Equal(t, Customer.Address, saved.Address)
+
+	got, err := repo.Customer.GetByID(context.TODO(), saved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, saved.ID, got.ID)
+	assert.Equal(t, Customer.Name, got.Name)
+	assert.Equal(t, Customer.BirthDate, got.BirthDate)
+	assert.Equal(t, Customer.Email, got.Email)
+	assert.Equal(t, Customer.Phone, got.Phone)
+	assert.Equal(t, Customer.Address, got.Address)
+	assert.Empty(t, got.CompanyName)
+	assert.False(t, got.CreatedAt.IsZero())
+}
+
+func customerNotFound(t *testing.T, repo *domain.Repository) {
+	_, err := repo.Customer.GetByID(context.TODO(), MissingID)
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+}
+
+func customerUpdate(t *testing.T, repo *domain.Repository) {
+	saved := saveCustomer(t, repo)
+	saved.Email = UpdatedEmail
+
+	updated, err := repo.Customer.Update(context.TODO(), saved)
+	require.NoError(t, err)
+	assert.Equal(t, UpdatedEmail, updated.Email)
+
+	got, err := repo.Customer.GetByID(context.TODO(), saved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, UpdatedEmail, got.Email)
+	assert.Equal(t, Customer.Name, got.Name)
+}
+
+func accountSaveForCustomer(t *testing.T, repo *domain.Repository) {
+	customer := saveCustomer(t, repo)
+
+	saved, err := repo.Account.SaveForCustomer(context.TODO(), customer.ID, DocumentNumber, AvailableCreditLimit, AccountCreatedAt)
+	require.NoError(t, err)
+	assert.Greater(t, saved.ID, 0)
+	assert.Equal(t, customer.ID, saved.CustomerID)
+
+	got, err := repo.Account.GetByID(context.TODO(), saved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, customer.ID, got.CustomerID)
+	assert.Equal(t, DocumentNumber, got.DocumentNumber)
+}
+
+func saveCard(t *testing.T, repo *domain.Repository) *entity.Card {
+	acc, err := repo.Account.Save(context.TODO(), DocumentNumber, AvailableCreditLimit, AccountCreatedAt)
+	require.NoError(t, err)
+
+	c := Card
+	c.AccountID = acc.ID
+
+	saved, err := repo.Card.Save(context.TODO(), &c)
+	require.NoError(t, err)
+	assert.Greater(t, saved.ID, 0)
+
+	return saved
+}
+
+func cardSaveAndGet(t *testing.T, repo *domain.Repository) {
+	saved := saveCard(t, repo)
+
+	got, err := repo.Card.GetByID(context.TODO(), saved.ID)
+	require.NoError(t, err)
+	assert.False(t, got.CreatedAt.IsZero())
+
+	want := Card
+	want.ID = saved.ID
+	want.AccountID = saved.AccountID
+	want.CreatedAt = got.CreatedAt
+	assert.Equal(t, &want, got)
+}
+
+func cardNotFound(t *testing.T, repo *domain.Repository) {
+	_, err := repo.Card.GetByID(context.TODO(), MissingID)
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+}
+
+func cardUpdateStatus(t *testing.T, repo *domain.Repository) {
+	saved := saveCard(t, repo)
+
+	err := repo.Card.UpdateStatus(context.TODO(), saved.ID, entity.CardStatusBlocked)
+	require.NoError(t, err)
+
+	got, err := repo.Card.GetByID(context.TODO(), saved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.CardStatusBlocked, got.Status)
+}
+
+func transactionSumByCardSince(t *testing.T, repo *domain.Repository) {
+	c := saveCard(t, repo)
+
+	saved, err := repo.Transaction.SaveDetailed(context.TODO(), &entity.Transaction{
+		AccountID:       c.AccountID,
+		OperationTypeID: entity.OperationTypeCompraAVista,
+		Amount:          -50,
+		CardID:          c.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, c.ID, saved.CardID)
+	assert.Nil(t, saved.Exchange)
+
+	_, err = repo.Transaction.Save(context.TODO(), c.AccountID, entity.OperationTypeSaque, -30)
+	require.NoError(t, err)
+
+	got, err := repo.Transaction.GetByID(context.TODO(), saved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, c.ID, got.CardID)
+
+	amount, err := repo.Transaction.SumByCardSince(context.TODO(), c.ID, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 50.0, amount)
+}
+
+func transactionSaveBackdated(t *testing.T, repo *domain.Repository) {
+	acc, err := repo.Account.Save(context.TODO(), DocumentNumber, AvailableCreditLimit, AccountCreatedAt)
+	require.NoError(t, err)
+
+	_, err = repo.Transaction.Save(context.TODO(), acc.ID, entity.OperationTypeSaque, -30)
+	require.NoError(t, err)
+
+	saved, err := repo.Transaction.SaveDetailed(context.TODO(), &entity.Transaction{
+		AccountID:       acc.ID,
+		OperationTypeID: entity.OperationTypeCompraAVista,
+		Amount:          -50,
+		EventDate:       EventDate,
+	})
+	require.NoError(t, err)
+	assert.True(t, EventDate.Equal(saved.EventDate))
+
+	got, err := repo.Transaction.GetByID(context.TODO(), saved.ID)
+	require.NoError(t, err)
+	assert.True(t, EventDate.Equal(got.EventDate))
+	assert.True(t, got.CreatedAt.After(EventDate))
+
+	var amounts []float64
+	err = repo.Transaction.ListByAccountID(
+		context.TODO(),
+		acc.ID,
+		EventDate.Add(-time.Hour),
+		time.Now().Add(time.Hour),
+		func(txn *entity.Transaction) error {
+			amounts = append(amounts, txn.Amount)
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{-50, -30}, amounts)
+
+	// the limits are kept on the creation time, a backdated debit can't be dated out of their windows
+	count, amount, err := repo.Transaction.SumDebitsSince(context.TODO(), acc.ID, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, 80.0, amount)
+
+	_, fees, err := repo.Transaction.SaveWithLinked(
+		context.TODO(),
+		&entity.Transaction{AccountID: acc.ID, OperationTypeID: entity.OperationTypeSaque, Amount: -20, EventDate: EventDate},
+		[]*entity.Transaction{{OperationTypeID: entity.OperationTypeTarifa, Amount: -5}},
+	)
+	require.NoError(t, err)
+	require.Len(t, fees, 1)
+	assert.True(t, EventDate.Equal(fees[0].EventDate))
+}
+
+func auditAppendAndList(t *testing.T, repo *domain.Repository) {
+	var prevHash string
+	for i := range AuditEntries {
+		saved, err := repo.Audit.Append(context.TODO(), &AuditEntries[i])
+		require.NoError(t, err)
+		assert.Greater(t, saved.ID, 0)
+		assert.Equal(t, prevHash, saved.PrevHash)
+		assert.Equal(t, saved.ComputeHash(), saved.Hash)
+
+		prevHash = saved.Hash
+	}
+
+	got, err := repo.Audit.ListByEntity(context.TODO(), entity.AuditEntityAccount, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, entity.AuditActionUpdateCreditLimit, got[0].Action)
+	assert.Equal(t, entity.AuditActionCreate, got[1].Action)
+	assert.Equal(t, got[1].Hash, got[0].PrevHash)
+	assert.Empty(t, got[1].Before)
+
+	// the stored entries hash the same, nothing is lost on the round trip
+	for _, entry := range got {
+		assert.Equal(t, entry.ComputeHash(), entry.Hash, "entry %d", entry.ID)
+	}
+
+	got, err = repo.Audit.ListByEntity(context.TODO(), entity.AuditEntityAccount, MissingID, 10)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	// the whole chain is streamed oldest first, each entry linked to the previous one
+	prevHash = ""
+	var ids []int
+	err = repo.Audit.EachEntry(context.TODO(), func(entry *entity.AuditEntry) error {
+		assert.True(t, entry.Verify(prevHash), "entry %d", entry.ID)
+
+		prevHash = entry.Hash
+		ids = append(ids, entry.ID)
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ids)
+}
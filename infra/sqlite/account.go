@@ -0,0 +1,274 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"github.com/brunomdev/digital-account/domain/account"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+	"math"
+	"time"
+)
+
+type accountRepository struct {
+	db *sql.DB
+}
+
+func NewAccountRepository(db *sql.DB) account.Repository {
+	return &accountRepository{db: db}
+}
+
+func (r *accountRepository) Save(
+	ctx context.Context,
+	docNumber string,
+	availableCreditLimit float64,
+	createdAt time.Time,
+) (*entity.Account, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`INSERT INTO accounts (document_number, available_credit_limit, created_at) VALUES(?, ?, ?)`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := stmt.ExecContext(ctx, docNumber, availableCreditLimit, formatTime(createdAt))
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Account{
+		ID:                   int(id),
+		DocumentNumber:       docNumber,
+		AvailabelCreditLimit: availableCreditLimit,
+		CreatedAt:            createdAt,
+	}, nil
+}
+
+func (r *accountRepository) SaveForCustomer(
+	ctx context.Context,
+	customerID int,
+	docNumber string,
+	availableCreditLimit float64,
+	createdAt time.Time,
+) (*entity.Account, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`INSERT INTO accounts (document_number, available_credit_limit, customer_id, created_at) VALUES(?, ?, ?, ?)`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, docNumber, availableCreditLimit, customerID, formatTime(createdAt))
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Account{
+		ID:                   int(id),
+		DocumentNumber:       docNumber,
+		AvailabelCreditLimit: availableCreditLimit,
+		CustomerID:           customerID,
+		CreatedAt:            createdAt,
+	}, nil
+}
+
+func (r accountRepository) GetByID(ctx context.Context, id int) (*entity.Account, error) {
+	stmt, err := r.db.PrepareContext(ctx, `SELECT id, document_number, available_credit_limit, COALESCE(customer_id, 0), created_at FROM accounts WHERE id = ?`)
+	if err != nil {
+		return nil, err
+	}
+
+	var acc entity.Account
+	rows, err := stmt.QueryContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		err = rows.Scan(&acc.ID, &acc.DocumentNumber, &acc.AvailabelCreditLimit, &acc.CustomerID, &acc.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if acc.ID < 1 {
+		return nil, entity.ErrNotFound
+	}
+
+	return &acc, nil
+}
+
+func (r accountRepository) Update(ctx context.Context, account *entity.Account) (*entity.Account, error) {
+	stmt, err := r.db.PrepareContext(ctx, `UPDATE accounts SET document_number = ?, available_credit_limit = ? WHERE id = ?`)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := stmt.ExecContext(ctx, account.DocumentNumber, account.AvailabelCreditLimit, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	_, err = result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (r accountRepository) SetCreditLimit(
+	ctx context.Context,
+	id int,
+	availableCreditLimit float64,
+	at time.Time,
+) (*entity.Account, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	var acc entity.Account
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT id, document_number, available_credit_limit, COALESCE(customer_id, 0), created_at FROM accounts WHERE id = ?`,
+		id,
+	).Scan(&acc.ID, &acc.DocumentNumber, &acc.AvailabelCreditLimit, &acc.CustomerID, &acc.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	amount := math.Round((availableCreditLimit-acc.AvailabelCreditLimit)*100) / 100
+	acc.AvailabelCreditLimit = availableCreditLimit
+
+	_, err = tx.ExecContext(ctx, `UPDATE accounts SET available_credit_limit = ? WHERE id = ?`, availableCreditLimit, id)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO credit_limit_adjustments (account_id, amount, created_at) VALUES(?, ?, ?)`,
+		id,
+		amount,
+		formatTime(at),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &acc, tx.Commit()
+}
+
+func (r accountRepository) SumAdjustmentsSince(ctx context.Context, accountID int, since time.Time) (float64, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT COALESCE(SUM(amount), 0) FROM credit_limit_adjustments WHERE account_id = ? AND created_at >= ?`,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	defer stmt.Close()
+
+	var amount float64
+	err = stmt.QueryRowContext(ctx, accountID, formatTime(since)).Scan(&amount)
+	if err != nil {
+		return 0, err
+	}
+
+	return amount, nil
+}
+
+func (r accountRepository) ListAdjustments(
+	ctx context.Context,
+	accountID int,
+	from, to time.Time,
+) ([]*entity.CreditLimitAdjustment, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT id, account_id, amount, created_at FROM credit_limit_adjustments WHERE account_id = ? AND created_at >= ? AND created_at < ? ORDER BY created_at, id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, accountID, formatTime(from), formatTime(to))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	adjustments := make([]*entity.CreditLimitAdjustment, 0)
+	for rows.Next() {
+		var adjustment entity.CreditLimitAdjustment
+
+		err = rows.Scan(&adjustment.ID, &adjustment.AccountID, &adjustment.Amount, &adjustment.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		adjustments = append(adjustments, &adjustment)
+	}
+
+	return adjustments, rows.Err()
+}
+
+func (r accountRepository) List(ctx context.Context, afterID, limit int) ([]*entity.Account, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT id, document_number, available_credit_limit, COALESCE(customer_id, 0), created_at FROM accounts WHERE id > ? ORDER BY id LIMIT ?`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	accounts := make([]*entity.Account, 0)
+	for rows.Next() {
+		var acc entity.Account
+
+		err = rows.Scan(&acc.ID, &acc.DocumentNumber, &acc.AvailabelCreditLimit, &acc.CustomerID, &acc.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		accounts = append(accounts, &acc)
+	}
+
+	return accounts, rows.Err()
+}
@@ -0,0 +1,129 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"github.com/brunomdev/digital-account/domain/customer"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+)
+
+type customerRepository struct {
+	db *sql.DB
+}
+
+func NewCustomerRepository(db *sql.DB) customer.Repository {
+	return &customerRepository{db: db}
+}
+
+func (r customerRepository) Save(ctx context.Context, c *entity.Customer) (*entity.Customer, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`INSERT INTO customers (name, birth_date, company_name, email, phone, address_street, address_number, address_complement, address_city, address_state, address_postal_code, address_country) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(
+		ctx,
+		c.Name,
+		c.BirthDate,
+		c.CompanyName,
+		c.Email,
+		c.Phone,
+		c.Address.Street,
+		c.Address.Number,
+		c.Address.Complement,
+		c.Address.City,
+		c.Address.State,
+		c.Address.PostalCode,
+		c.Address.Country,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, int(id))
+}
+
+func (r customerRepository) GetByID(ctx context.Context, id int) (*entity.Customer, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT id, name, birth_date, company_name, email, phone, address_street, address_number, address_complement, address_city, address_state, address_postal_code, address_country, created_at, updated_at FROM customers WHERE id = ?`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	var c entity.Customer
+	err = stmt.QueryRowContext(ctx, id).Scan(
+		&c.ID,
+		&c.Name,
+		&c.BirthDate,
+		&c.CompanyName,
+		&c.Email,
+		&c.Phone,
+		&c.Address.Street,
+		&c.Address.Number,
+		&c.Address.Complement,
+		&c.Address.City,
+		&c.Address.State,
+		&c.Address.PostalCode,
+		&c.Address.Country,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+func (r customerRepository) Update(ctx context.Context, c *entity.Customer) (*entity.Customer, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`UPDATE customers SET name = ?, birth_date = ?, company_name = ?, email = ?, phone = ?, address_street = ?, address_number = ?, address_complement = ?, address_city = ?, address_state = ?, address_postal_code = ?, address_country = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(
+		ctx,
+		c.Name,
+		c.BirthDate,
+		c.CompanyName,
+		c.Email,
+		c.Phone,
+		c.Address.Street,
+		c.Address.Number,
+		c.Address.Complement,
+		c.Address.City,
+		c.Address.State,
+		c.Address.PostalCode,
+		c.Address.Country,
+		c.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, c.ID)
+}
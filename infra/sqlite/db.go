@@ -0,0 +1,27 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/XSAM/otelsql"
+	"github.com/pkg/errors"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	_ "modernc.org/sqlite"
+)
+
+// timeFormat is the layout of CURRENT_TIMESTAMP, in UTC
+const timeFormat = "2006-01-02 15:04:05"
+
+// NewDB open the database file in WAL mode, so readers don't block the writer, with the database/sql calls traced
+// by OpenTelemetry
+func NewDB(path string) (*sql.DB, error) {
+	driverName, err := otelsql.Register("sqlite", semconv.DBSystemSqlite.Value.AsString())
+	if err != nil {
+		return nil, errors.Wrap(err, "NewDB")
+	}
+
+	return sql.Open(driverName, fmt.Sprintf(
+		"file:%s?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)",
+		path,
+	))
+}
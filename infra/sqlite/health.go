@@ -0,0 +1,37 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"github.com/brunomdev/digital-account/domain/health"
+	"github.com/pkg/errors"
+)
+
+type healthRepository struct {
+	db *sql.DB
+}
+
+func NewHealthRepository(db *sql.DB) health.Repository {
+	return &healthRepository{db: db}
+}
+
+func (r healthRepository) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+func (r healthRepository) SchemaVersion(ctx context.Context) (uint, bool, error) {
+	var (
+		version uint
+		dirty   bool
+	)
+
+	err := r.db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	return version, dirty, nil
+}
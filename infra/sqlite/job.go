@@ -0,0 +1,158 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"github.com/brunomdev/digital-account/domain/job"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+	"time"
+)
+
+type jobRepository struct {
+	db *sql.DB
+}
+
+func NewJobRepository(db *sql.DB) job.Repository {
+	return &jobRepository{db: db}
+}
+
+func (r jobRepository) Save(ctx context.Context, j *entity.Job) (*entity.Job, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`INSERT INTO jobs (type, payload, status, max_attempts, client_id, actor, request_id, run_at) VALUES(?, ?, ?, ?, ?, ?, ?, ?)`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, j.Type, j.Payload, j.Status, j.MaxAttempts, j.ClientID, j.Actor, j.RequestID, formatTime(j.RunAt))
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, int(id))
+}
+
+func (r jobRepository) GetByID(ctx context.Context, id int) (*entity.Job, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT id, type, payload, status, progress, attempts, max_attempts, result, error, client_id, actor, request_id, run_at, created_at FROM jobs WHERE id = ?`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	return scanJob(stmt.QueryRowContext(ctx, id))
+}
+
+// Claim select and update the next due job on a single statement, SQLite serializes the writers
+func (r jobRepository) Claim(ctx context.Context, now, leaseUntil time.Time) (*entity.Job, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`UPDATE jobs SET status = ?, attempts = attempts + 1, run_at = ? WHERE id = (SELECT id FROM jobs WHERE status IN (?, ?) AND run_at <= ? ORDER BY run_at, id LIMIT 1) RETURNING id, type, payload, status, progress, attempts, max_attempts, result, error, client_id, actor, request_id, run_at, created_at`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	return scanJob(stmt.QueryRowContext(
+		ctx,
+		entity.JobStatusRunning,
+		formatTime(leaseUntil),
+		entity.JobStatusQueued,
+		entity.JobStatusRunning,
+		formatTime(now),
+	))
+}
+
+func (r jobRepository) Update(ctx context.Context, j *entity.Job) error {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`UPDATE jobs SET status = ?, progress = ?, result = ?, error = ?, run_at = ? WHERE id = ?`,
+	)
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, j.Status, j.Progress, j.Result, j.Error, formatTime(j.RunAt), j.ID)
+
+	return err
+}
+
+func (r jobRepository) GetLatestByType(ctx context.Context, jobType string) (*entity.Job, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT id, type, payload, status, progress, attempts, max_attempts, result, error, client_id, actor, request_id, run_at, created_at FROM jobs WHERE type = ? ORDER BY id DESC LIMIT 1`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	return scanJob(stmt.QueryRowContext(ctx, jobType))
+}
+
+func (r jobRepository) DeleteFinishedBefore(ctx context.Context, before time.Time) (int, error) {
+	stmt, err := r.db.PrepareContext(ctx, `DELETE FROM jobs WHERE status IN (?, ?) AND created_at < ?`)
+	if err != nil {
+		return 0, err
+	}
+
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, entity.JobStatusSucceeded, entity.JobStatusFailed, formatTime(before))
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
+
+func scanJob(row *sql.Row) (*entity.Job, error) {
+	var j entity.Job
+
+	err := row.Scan(
+		&j.ID,
+		&j.Type,
+		&j.Payload,
+		&j.Status,
+		&j.Progress,
+		&j.Attempts,
+		&j.MaxAttempts,
+		&j.Result,
+		&j.Error,
+		&j.ClientID,
+		&j.Actor,
+		&j.RequestID,
+		&j.RunAt,
+		&j.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &j, nil
+}
@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"github.com/brunomdev/digital-account/domain/reconciliation"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/pkg/errors"
+)
+
+type limitCheckpointRepository struct {
+	db *sql.DB
+}
+
+func NewLimitCheckpointRepository(db *sql.DB) reconciliation.Repository {
+	return &limitCheckpointRepository{db: db}
+}
+
+func (r limitCheckpointRepository) GetByAccountID(ctx context.Context, accountID int) (*entity.LimitCheckpoint, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT account_id, available_credit_limit, transaction_id, updated_at FROM limit_checkpoints WHERE account_id = ?`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	var checkpoint entity.LimitCheckpoint
+	err = stmt.QueryRowContext(ctx, accountID).Scan(
+		&checkpoint.AccountID,
+		&checkpoint.AvailableCreditLimit,
+		&checkpoint.TransactionID,
+		&checkpoint.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, entity.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &checkpoint, nil
+}
+
+func (r limitCheckpointRepository) Save(ctx context.Context, checkpoint *entity.LimitCheckpoint) error {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`INSERT INTO limit_checkpoints (account_id, available_credit_limit, transaction_id) VALUES(?, ?, ?) ON CONFLICT (account_id) DO UPDATE SET available_credit_limit = excluded.available_credit_limit, transaction_id = excluded.transaction_id, updated_at = CURRENT_TIMESTAMP`,
+	)
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, checkpoint.AccountID, checkpoint.AvailableCreditLimit, checkpoint.TransactionID)
+
+	return err
+}
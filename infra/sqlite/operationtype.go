@@ -0,0 +1,66 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"github.com/brunomdev/digital-account/domain/operationtype"
+	"github.com/brunomdev/digital-account/entity"
+)
+
+type operationTypeRepository struct {
+	db *sql.DB
+}
+
+func NewOperationTypeRepository(db *sql.DB) operationtype.Repository {
+	return &operationTypeRepository{db: db}
+}
+
+func (r operationTypeRepository) GetByID(ctx context.Context, id int) (*entity.OperationType, error) {
+	stmt, err := r.db.PrepareContext(ctx, `SELECT id, description, limit_rate, fee, fee_rate, daily_max_count, daily_max_amount FROM operation_types WHERE id = ?`)
+	if err != nil {
+		return nil, err
+	}
+
+	var opType entity.OperationType
+	rows, err := stmt.QueryContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		err = rows.Scan(
+			&opType.ID,
+			&opType.Description,
+			&opType.Rules.LimitRate,
+			&opType.Rules.Fee,
+			&opType.Rules.FeeRate,
+			&opType.Rules.DailyMaxCount,
+			&opType.Rules.DailyMaxAmount,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if opType.ID < 1 {
+		return nil, entity.ErrNotFound
+	}
+
+	return &opType, nil
+}
+
+func (r operationTypeRepository) UpdateRules(ctx context.Context, id int, rules entity.OperationTypeRules) error {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`UPDATE operation_types SET limit_rate = ?, fee = ?, fee_rate = ?, daily_max_count = ?, daily_max_amount = ? WHERE id = ?`,
+	)
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx, rules.LimitRate, rules.Fee, rules.FeeRate, rules.DailyMaxCount, rules.DailyMaxAmount, id)
+
+	return err
+}
@@ -0,0 +1,28 @@
+package sqlite
+
+import (
+	"database/sql"
+	"github.com/brunomdev/digital-account/domain"
+	"github.com/brunomdev/digital-account/infra/memory"
+)
+
+// NewRepository create all the SQLite repositories sharing the connection pool, the locks are held in the process
+// since a single instance uses the database file
+func NewRepository(db *sql.DB) *domain.Repository {
+	return &domain.Repository{
+		Account:            NewAccountRepository(db),
+		Audit:              NewAuditRepository(db),
+		Card:               NewCardRepository(db),
+		Charge:             NewChargeRepository(db),
+		Customer:           NewCustomerRepository(db),
+		Client:             NewClientRepository(db),
+		Health:             NewHealthRepository(db),
+		Job:                NewJobRepository(db),
+		LimitCheckpoint:    NewLimitCheckpointRepository(db),
+		OperationType:      NewOperationTypeRepository(db),
+		RiskDecision:       NewRiskDecisionRepository(db),
+		Transaction:        NewTransactionRepository(db),
+		TransactionAttempt: NewTransactionAttemptRepository(db),
+		Locker:             memory.NewLocker(),
+	}
+}
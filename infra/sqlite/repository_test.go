@@ -0,0 +1,112 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/brunomdev/digital-account/domain"
+	"github.com/brunomdev/digital-account/entity"
+	"github.com/brunomdev/digital-account/infra/repositorytest"
+	"github.com/golang-migrate/migrate/v4"
+	migrateSqlite "github.com/golang-migrate/migrate/v4/database/sqlite"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+)
+
+const migrationsSource = "file://../../migrations/sqlite"
+
+func TestRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T, c repositorytest.Case) *domain.Repository {
+		return NewRepository(newMigratedDB(t))
+	})
+}
+
+func TestNewDB(t *testing.T) {
+	db := newMigratedDB(t)
+
+	var journalMode string
+	err := db.QueryRow(`PRAGMA journal_mode`).Scan(&journalMode)
+	require.NoError(t, err)
+	assert.Equal(t, "wal", journalMode)
+
+	var foreignKeys bool
+	err = db.QueryRow(`PRAGMA foreign_keys`).Scan(&foreignKeys)
+	require.NoError(t, err)
+	assert.True(t, foreignKeys)
+}
+
+func Test_healthRepository_SchemaVersion(t *testing.T) {
+	db := newMigratedDB(t)
+
+	version, dirty, err := NewHealthRepository(db).SchemaVersion(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, uint(20220404090000), version)
+	assert.False(t, dirty)
+}
+
+func Test_auditRepository_AppendOnly(t *testing.T) {
+	db := newMigratedDB(t)
+
+	_, err := NewAuditRepository(db).Append(context.TODO(), &repositorytest.AuditEntries[0])
+	require.NoError(t, err)
+
+	_, err = db.Exec(`UPDATE audit_log SET actor = 'someone else'`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "audit_log is append only")
+
+	_, err = db.Exec(`DELETE FROM audit_log`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "audit_log is append only")
+}
+
+func Test_transactionRepository_SaveWithLinkedRollsBack(t *testing.T) {
+	db := newMigratedDB(t)
+	repo := NewRepository(db)
+
+	acc, err := repo.Account.Save(
+		context.TODO(),
+		repositorytest.DocumentNumber,
+		repositorytest.AvailableCreditLimit,
+		repositorytest.AccountCreatedAt,
+	)
+	require.NoError(t, err)
+
+	// the fee of a missing operation type fails, the debit saved before it must not be kept
+	_, _, err = repo.Transaction.SaveWithLinked(
+		context.TODO(),
+		&entity.Transaction{AccountID: acc.ID, OperationTypeID: entity.OperationTypeSaque, Amount: -50},
+		[]*entity.Transaction{{OperationTypeID: repositorytest.MissingID, Amount: -5}},
+	)
+	require.Error(t, err)
+
+	var count int
+	err = db.QueryRow(`SELECT COUNT(id) FROM transactions`).Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+// newMigratedDB open a database file on a temporary directory with all the migrations applied
+func newMigratedDB(t *testing.T) *sql.DB {
+	db, err := NewDB(filepath.Join(t.TempDir(), "digital-account.db"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	driver, err := migrateSqlite.WithInstance(db, &migrateSqlite.Config{})
+	require.NoError(t, err)
+
+	m, err := migrate.NewWithDatabaseInstance(migrationsSource, "sqlite", driver)
+	require.NoError(t, err)
+
+	err = m.Up()
+	if !errors.Is(err, migrate.ErrNoChange) {
+		require.NoError(t, err)
+	}
+
+	return db
+}
@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"github.com/brunomdev/digital-account/domain/risk"
+	"github.com/brunomdev/digital-account/entity"
+	"strings"
+)
+
+type riskDecisionRepository struct {
+	db *sql.DB
+}
+
+func NewRiskDecisionRepository(db *sql.DB) risk.Repository {
+	return &riskDecisionRepository{db: db}
+}
+
+func (r riskDecisionRepository) Save(ctx context.Context, decision *entity.RiskDecision) (*entity.RiskDecision, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`INSERT INTO transaction_decisions (transaction_id, account_id, operation_type_id, amount, outcome, reason_codes) VALUES(?, ?, ?, ?, ?, ?)`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	var transactionID sql.NullInt64
+	if decision.TransactionID > 0 {
+		transactionID = sql.NullInt64{Int64: int64(decision.TransactionID), Valid: true}
+	}
+
+	result, err := stmt.ExecContext(
+		ctx,
+		transactionID,
+		decision.AccountID,
+		decision.OperationTypeID,
+		decision.Amount,
+		decision.Outcome,
+		strings.Join(decision.ReasonCodes, ","),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	saved := *decision
+	saved.ID = int(id)
+
+	return &saved, nil
+}
@@ -0,0 +1,157 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"github.com/brunomdev/digital-account/domain/transaction"
+	"github.com/brunomdev/digital-account/entity"
+	"time"
+)
+
+type transactionAttemptRepository struct {
+	db *sql.DB
+}
+
+func NewTransactionAttemptRepository(db *sql.DB) transaction.AttemptRepository {
+	return &transactionAttemptRepository{db: db}
+}
+
+func (r transactionAttemptRepository) Save(ctx context.Context, attempt *entity.TransactionAttempt) (*entity.TransactionAttempt, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`INSERT INTO transaction_attempts (account_id, operation_type_id, amount, outcome, decline_reason, transaction_id) VALUES(?, ?, ?, ?, ?, ?)`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	var transactionID sql.NullInt64
+	if attempt.TransactionID > 0 {
+		transactionID = sql.NullInt64{Int64: int64(attempt.TransactionID), Valid: true}
+	}
+
+	result, err := stmt.ExecContext(
+		ctx,
+		attempt.AccountID,
+		attempt.OperationTypeID,
+		attempt.Amount,
+		attempt.Outcome,
+		attempt.DeclineReason,
+		transactionID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	saved := *attempt
+	saved.ID = int(id)
+
+	return &saved, nil
+}
+
+func (r transactionAttemptRepository) ListByAccountID(ctx context.Context, accountID, limit int) ([]*entity.TransactionAttempt, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT id, account_id, operation_type_id, amount, outcome, decline_reason, transaction_id, created_at FROM transaction_attempts WHERE account_id = ? ORDER BY id DESC LIMIT ?`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, accountID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	attempts := make([]*entity.TransactionAttempt, 0)
+	for rows.Next() {
+		var (
+			attempt       entity.TransactionAttempt
+			transactionID sql.NullInt64
+		)
+
+		err = rows.Scan(
+			&attempt.ID,
+			&attempt.AccountID,
+			&attempt.OperationTypeID,
+			&attempt.Amount,
+			&attempt.Outcome,
+			&attempt.DeclineReason,
+			&transactionID,
+			&attempt.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		attempt.TransactionID = int(transactionID.Int64)
+		attempts = append(attempts, &attempt)
+	}
+
+	return attempts, rows.Err()
+}
+
+func (r transactionAttemptRepository) DeleteBefore(ctx context.Context, before time.Time) (int, error) {
+	stmt, err := r.db.PrepareContext(ctx, `DELETE FROM transaction_attempts WHERE created_at < ?`)
+	if err != nil {
+		return 0, err
+	}
+
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, formatTime(before))
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
+
+func (r transactionAttemptRepository) Summarize(ctx context.Context, from, to time.Time) ([]*entity.AttemptSummary, error) {
+	stmt, err := r.db.PrepareContext(
+		ctx,
+		`SELECT operation_type_id, outcome, COUNT(id), SUM(ABS(amount)) FROM transaction_attempts WHERE created_at >= ? AND created_at < ? GROUP BY operation_type_id, outcome ORDER BY operation_type_id, outcome`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, formatTime(from), formatTime(to))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	summaries := make([]*entity.AttemptSummary, 0)
+	for rows.Next() {
+		var summary entity.AttemptSummary
+
+		err = rows.Scan(&summary.OperationTypeID, &summary.Outcome, &summary.Count, &summary.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		summaries = append(summaries, &summary)
+	}
+
+	return summaries, rows.Err()
+}
@@ -2,21 +2,33 @@ package main
 
 import (
 	"context"
-	"database/sql"
-	"errors"
 	"fmt"
 	"github.com/brunomdev/digital-account/app/api"
+	"github.com/brunomdev/digital-account/app/scheduler"
+	"github.com/brunomdev/digital-account/app/worker"
 	"github.com/brunomdev/digital-account/config"
 	"github.com/brunomdev/digital-account/domain"
 	"github.com/brunomdev/digital-account/domain/account"
+	"github.com/brunomdev/digital-account/domain/audit"
+	"github.com/brunomdev/digital-account/domain/card"
+	"github.com/brunomdev/digital-account/domain/charge"
+	"github.com/brunomdev/digital-account/domain/client"
+	"github.com/brunomdev/digital-account/domain/customer"
+	"github.com/brunomdev/digital-account/domain/exchange"
+	"github.com/brunomdev/digital-account/domain/health"
+	"github.com/brunomdev/digital-account/domain/importer"
+	"github.com/brunomdev/digital-account/domain/job"
 	"github.com/brunomdev/digital-account/domain/operationtype"
+	"github.com/brunomdev/digital-account/domain/reconciliation"
+	"github.com/brunomdev/digital-account/domain/risk"
+	"github.com/brunomdev/digital-account/domain/statement"
 	"github.com/brunomdev/digital-account/domain/transaction"
+	"github.com/brunomdev/digital-account/infra/cache"
 	"github.com/brunomdev/digital-account/infra/log"
-	repo "github.com/brunomdev/digital-account/infra/mysql"
 	"github.com/brunomdev/digital-account/infra/newrelic"
-	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/mysql"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/brunomdev/digital-account/infra/prometheus"
+	"github.com/brunomdev/digital-account/infra/tracing"
+	"github.com/brunomdev/digital-account/pkg/clock"
 	"os/signal"
 	"syscall"
 	"time"
@@ -36,71 +48,208 @@ func main() {
 		log.Fatal(ctx, "unable to connect to newrelic", err)
 	}
 
-	dataSourceName := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?multiStatements=true&parseTime=true", cfg.DBUser, cfg.DBPass, cfg.DBHost, cfg.DBPort, cfg.DBDatabase)
-	db, err := sql.Open("mysql", dataSourceName)
+	tracerProvider, err := tracing.NewTracerProvider(ctx, cfg)
 	if err != nil {
-		log.Fatal(ctx, "unable connect with database", err)
+		log.Fatal(ctx, "unable to create tracer provider", err)
 	}
 
-	driver, err := mysql.WithInstance(db, &mysql.Config{})
+	store, err := openStorage(cfg)
 	if err != nil {
-		log.Fatal(ctx, "unable to get mysql driver", err)
+		log.Fatal(ctx, "unable to open the storage", err)
 	}
 
-	m, err := migrate.NewWithDatabaseInstance(
-		"file://migrations",
-		cfg.DBDatabase,
-		driver,
-	)
+	registry := prometheus.NewRegistry(store.db, cfg.DBDatabase)
+	transactionMetrics, err := prometheus.NewTransactionMetrics(registry)
 	if err != nil {
-		log.Fatal(ctx, "unable to define initiate migrate", err)
+		log.Fatal(ctx, "unable to register transaction metrics", err)
 	}
 
-	err = m.Up()
-	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
-		log.Fatal(ctx, "unable to migrate database", err)
+	// the reconciliation compares the stored limits, a cached account would be reported as a drift
+	uncachedAccountRepo := store.repository.Account
+
+	if cfg.CacheEnabled {
+		cacheMetrics, err := prometheus.NewCacheMetrics(registry)
+		if err != nil {
+			log.Fatal(ctx, "unable to register cache metrics", err)
+		}
+
+		store.repository.OperationType = cache.NewOperationTypeRepository(
+			store.repository.OperationType,
+			cfg.CacheOperationTypeTTL,
+			cfg.CacheSize,
+			cache.WithMetrics(cacheMetrics),
+		)
+		store.repository.Account = cache.NewAccountRepository(
+			store.repository.Account,
+			cfg.CacheAccountTTL,
+			cfg.CacheSize,
+			cache.WithMetrics(cacheMetrics),
+		)
 	}
 
-	accountRepo := repo.NewAccountRepository(db)
-	accountSvc := account.NewService(accountRepo)
-	opTypeRepo := repo.NewOperationTypeRepository(db)
-	opTypeSvc := operationtype.NewService(opTypeRepo)
-	transactionRepo := repo.NewTransactionRepository(db)
-	transactionSvc := transaction.NewService(transactionRepo, accountSvc, opTypeSvc)
+	clk := clock.New()
+
+	auditSvc := audit.NewService(store.repository.Audit, clk)
+	accountSvc := account.NewService(store.repository.Account, clk, auditSvc)
+	// the debits derive the new limit from the balance, a cached account may be stale
+	ledgerAccountSvc := account.NewService(cache.Uncached(store.repository.Account), clk, auditSvc)
+	clientSvc := client.NewService(store.repository.Client)
+	opTypeSvc := operationtype.NewService(store.repository.OperationType, auditSvc)
+	riskRules, err := risk.LoadRules(cfg.RiskRulesFile, store.repository.Transaction)
+	if err != nil {
+		log.Fatal(ctx, "unable to load risk rules", err)
+	}
+	riskSvc := risk.NewService(store.repository.RiskDecision, riskRules...)
+	rates, err := exchange.LoadRates(cfg.ExchangeRatesFile)
+	if err != nil {
+		log.Fatal(ctx, "unable to load exchange rates", err)
+	}
+	cardSvc := card.NewService(store.repository.Card, accountSvc, clk, card.Issuing{
+		BIN:           cfg.CardBIN,
+		ValidityYears: cfg.CardValidityYears,
+	})
+	transactionSvc := transaction.NewService(
+		store.repository.Transaction,
+		ledgerAccountSvc,
+		opTypeSvc,
+		clk,
+		transaction.WithVelocityLimit(transaction.VelocityLimit{
+			MaxDebits: cfg.VelocityMaxDebits,
+			MaxAmount: cfg.VelocityMaxAmount,
+			Window:    cfg.VelocityWindow,
+		}),
+		transaction.WithRiskService(riskSvc),
+		transaction.WithAttemptRepository(store.repository.TransactionAttempt),
+		transaction.WithMetrics(transactionMetrics),
+		transaction.WithExchange(rates, transaction.Exchange{
+			Currency:   cfg.AccountCurrency,
+			SpreadRate: cfg.ExchangeSpreadRate,
+			IOFRate:    cfg.ExchangeIOFRate,
+		}),
+		transaction.WithCardService(cardSvc),
+		transaction.WithAuditService(auditSvc),
+		transaction.WithEventDateWindow(cfg.TransactionEventDateWindow),
+	)
+
+	importerSvc := importer.NewService(transactionSvc)
+	jobSvc := job.NewService(store.repository.Job, clk, cfg.JobsMaxAttempts)
 
 	service := &domain.Service{
 		Account:       accountSvc,
+		Audit:         auditSvc,
+		Card:          cardSvc,
+		Client:        clientSvc,
+		Customer:      customer.NewService(store.repository.Customer, store.repository.Account, clk),
+		Health:        health.NewService(store.repository.Health, store.schemaVersion),
+		Importer:      importerSvc,
+		Job:           jobSvc,
 		OperationType: opTypeSvc,
+		Statement:     statement.NewService(store.repository.Transaction, cache.Uncached(store.repository.Account), opTypeSvc, clk),
 		Transaction:   transactionSvc,
 	}
 
+	pool := worker.NewPool(
+		store.repository.Job,
+		worker.WithWorkers(cfg.JobsWorkers),
+		worker.WithPollInterval(cfg.JobsPollInterval),
+		worker.WithTimeout(cfg.JobsTimeout),
+		worker.WithBackoff(cfg.JobsBackoff, cfg.JobsMaxBackoff),
+	)
+	pool.Register(worker.JobTypeImport, worker.NewImportHandler(importerSvc))
+
+	cron := scheduler.NewScheduler(pool, jobSvc, store.repository.Locker)
+	tasks := []struct {
+		name string
+		spec string
+		task scheduler.Task
+	}{
+		{scheduler.TaskCleanup, cfg.ScheduleCleanup, scheduler.NewCleanupTask(transactionSvc, jobSvc, cfg.CleanupRetention)},
+		{
+			scheduler.TaskLimitReconciliation,
+			cfg.ScheduleLimitReconciliation,
+			scheduler.NewReconciliationTask(reconciliation.NewService(
+				store.repository.LimitCheckpoint,
+				uncachedAccountRepo,
+				store.repository.Transaction,
+			)),
+		},
+		{scheduler.TaskTransactionReport, cfg.ScheduleTransactionReport, scheduler.NewReportTask(transactionSvc)},
+		{
+			scheduler.TaskCharges,
+			cfg.ScheduleCharges,
+			scheduler.NewChargesTask(charge.NewService(
+				store.repository.Charge,
+				uncachedAccountRepo,
+				store.repository.Transaction,
+				transactionSvc,
+				charge.Config{
+					MonthlyInterestRate: cfg.ChargesMonthlyInterestRate,
+					GraceDays:           cfg.ChargesGraceDays,
+					LateFee:             cfg.ChargesLateFee,
+					PenaltyRate:         cfg.ChargesPenaltyRate,
+				},
+			)),
+		},
+	}
+	for _, t := range tasks {
+		err = cron.Register(t.name, t.spec, t.task)
+		if err != nil {
+			log.Fatal(ctx, "unable to register scheduled task", err)
+		}
+	}
+	service.Schedule = cron
+
 	srv, err := api.NewServer(
 		api.WithConfig(cfg),
 		api.WithService(service),
 		api.WithNewRelic(newRelic),
+		api.WithRegistry(registry),
 	)
 	if err != nil {
 		log.Fatal(ctx, "new server: ", err)
 	}
 
+	pool.Start(ctx)
+
+	if cfg.SchedulerEnabled {
+		cron.Start(ctx)
+	}
+
 	<-ctx.Done()
 
 	stop()
 
 	log.Info(ctx, "shutting down gracefully")
 
+	srv.Drain(cfg.ShutdownDrainDelay)
+
 	err = srv.Close()
 	if err != nil {
 		log.Error(ctx, "forced server to shutdown: ", err)
 	}
 
-	err = db.Close()
-	if err != nil {
-		log.Error(ctx, "forced db to shutdown: ", err)
+	log.Info(ctx, "waiting for the running jobs")
+
+	cron.Wait()
+	pool.Wait()
+
+	if store.db != nil {
+		err = store.db.Close()
+		if err != nil {
+			log.Error(ctx, "forced db to shutdown: ", err)
+		}
 	}
 
 	newRelic.Shutdown(time.Second * 10)
 
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	err = tracerProvider.Shutdown(shutdownCtx)
+	if err != nil {
+		log.Error(ctx, "forced tracer provider to shutdown: ", err)
+	}
+
 	log.Info(ctx, "exiting")
 
 	err = log.Close()
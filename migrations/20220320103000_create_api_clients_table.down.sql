@@ -0,0 +1 @@
+DROP TABLE IF EXISTS api_clients;
\ No newline at end of file
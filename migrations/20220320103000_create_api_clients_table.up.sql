@@ -0,0 +1,14 @@
+CREATE TABLE api_clients
+(
+    id           INT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    name         VARCHAR(255) NOT NULL,
+    api_key_hash CHAR(64)     NOT NULL UNIQUE,
+    role         VARCHAR(32)  NOT NULL,
+    scopes       VARCHAR(255) NOT NULL DEFAULT '',
+    account_id   INT,
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (account_id)
+        REFERENCES accounts (id)
+        ON DELETE CASCADE
+);
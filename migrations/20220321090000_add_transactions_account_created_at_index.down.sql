@@ -0,0 +1 @@
+DROP INDEX transactions_account_id_created_at_index ON transactions;
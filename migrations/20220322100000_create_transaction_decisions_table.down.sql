@@ -0,0 +1 @@
+DROP TABLE IF EXISTS transaction_decisions;
\ No newline at end of file
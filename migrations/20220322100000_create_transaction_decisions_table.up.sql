@@ -0,0 +1,20 @@
+CREATE TABLE transaction_decisions
+(
+    id                INT            NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    transaction_id    INT,
+    account_id        INT            NOT NULL,
+    operation_type_id INT            NOT NULL,
+    amount            DECIMAL(10, 2) NOT NULL,
+    outcome           VARCHAR(16)    NOT NULL,
+    reason_codes      VARCHAR(255)   NOT NULL DEFAULT '',
+    created_at        TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (transaction_id)
+        REFERENCES transactions (id)
+        ON DELETE CASCADE,
+    FOREIGN KEY (account_id)
+        REFERENCES accounts (id)
+        ON DELETE CASCADE,
+    FOREIGN KEY (operation_type_id)
+        REFERENCES operation_types (id)
+        ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS transaction_attempts;
\ No newline at end of file
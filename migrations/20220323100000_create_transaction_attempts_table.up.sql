@@ -0,0 +1,15 @@
+CREATE TABLE transaction_attempts
+(
+    id                INT            NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    account_id        INT            NOT NULL,
+    operation_type_id INT            NOT NULL,
+    amount            DECIMAL(10, 2) NOT NULL,
+    outcome           VARCHAR(16)    NOT NULL,
+    decline_reason    VARCHAR(255)   NOT NULL DEFAULT '',
+    transaction_id    INT,
+    created_at        TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX transaction_attempts_account_id_index (account_id),
+    FOREIGN KEY (transaction_id)
+        REFERENCES transactions (id)
+        ON DELETE CASCADE
+);
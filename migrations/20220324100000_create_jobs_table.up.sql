@@ -0,0 +1,16 @@
+CREATE TABLE jobs
+(
+    id           INT           NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    type         VARCHAR(64)   NOT NULL,
+    payload      LONGTEXT      NOT NULL,
+    status       VARCHAR(16)   NOT NULL,
+    progress     INT           NOT NULL DEFAULT 0,
+    attempts     INT           NOT NULL DEFAULT 0,
+    max_attempts INT           NOT NULL,
+    result       LONGTEXT,
+    error        VARCHAR(1024) NOT NULL DEFAULT '',
+    client_id    INT           NOT NULL DEFAULT 0,
+    run_at       DATETIME      NOT NULL,
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX jobs_status_run_at_index (status, run_at)
+);
@@ -0,0 +1,2 @@
+DROP INDEX jobs_type_index ON jobs;
+DROP INDEX transaction_attempts_created_at_index ON transaction_attempts;
@@ -0,0 +1,10 @@
+CREATE TABLE limit_checkpoints
+(
+    account_id             INT            NOT NULL PRIMARY KEY,
+    available_credit_limit DECIMAL(10, 2) NOT NULL,
+    transaction_id         INT            NOT NULL DEFAULT 0,
+    updated_at             TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (account_id)
+        REFERENCES accounts (id)
+        ON DELETE CASCADE
+);
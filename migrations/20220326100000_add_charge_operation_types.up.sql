@@ -0,0 +1,4 @@
+INSERT INTO operation_types (id, description)
+VALUES (5, 'JUROS'),
+       (6, 'TARIFA DE ATRASO'),
+       (7, 'MULTA');
@@ -0,0 +1,14 @@
+CREATE TABLE charges
+(
+    id             INT            NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    account_id     INT            NOT NULL,
+    type           VARCHAR(16)    NOT NULL,
+    charge_date    DATE           NOT NULL,
+    amount         DECIMAL(10, 2) NOT NULL,
+    transaction_id INT            NOT NULL DEFAULT 0,
+    created_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE INDEX charges_account_type_date_unique (account_id, type, charge_date),
+    FOREIGN KEY (account_id)
+        REFERENCES accounts (id)
+        ON DELETE CASCADE
+);
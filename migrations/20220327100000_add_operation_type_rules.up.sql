@@ -0,0 +1,9 @@
+ALTER TABLE operation_types
+    ADD COLUMN limit_rate       DECIMAL(5, 4)  NOT NULL DEFAULT 0 AFTER description,
+    ADD COLUMN fee              DECIMAL(10, 2) NOT NULL DEFAULT 0 AFTER limit_rate,
+    ADD COLUMN fee_rate         DECIMAL(5, 4)  NOT NULL DEFAULT 0 AFTER fee,
+    ADD COLUMN daily_max_count  INT            NOT NULL DEFAULT 0 AFTER fee_rate,
+    ADD COLUMN daily_max_amount DECIMAL(10, 2) NOT NULL DEFAULT 0 AFTER daily_max_count;
+
+INSERT INTO operation_types (id, description)
+VALUES (8, 'TARIFA');
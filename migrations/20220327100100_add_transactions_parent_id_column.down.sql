@@ -0,0 +1,3 @@
+ALTER TABLE transactions
+    DROP FOREIGN KEY transactions_parent_id_foreign,
+    DROP COLUMN parent_id;
@@ -0,0 +1,5 @@
+ALTER TABLE transactions
+    ADD COLUMN parent_id INT NULL AFTER amount,
+    ADD CONSTRAINT transactions_parent_id_foreign FOREIGN KEY (parent_id)
+        REFERENCES transactions (id)
+        ON DELETE CASCADE;
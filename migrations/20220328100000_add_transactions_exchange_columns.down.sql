@@ -0,0 +1,6 @@
+DELETE FROM operation_types WHERE id = 9;
+
+ALTER TABLE transactions
+    DROP COLUMN exchange_rate,
+    DROP COLUMN original_amount,
+    DROP COLUMN currency;
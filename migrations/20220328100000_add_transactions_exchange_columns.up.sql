@@ -0,0 +1,7 @@
+ALTER TABLE transactions
+    ADD COLUMN currency        CHAR(3)        NULL AFTER parent_id,
+    ADD COLUMN original_amount DECIMAL(10, 2) NULL AFTER currency,
+    ADD COLUMN exchange_rate   DECIMAL(18, 8) NULL AFTER original_amount;
+
+INSERT INTO operation_types (id, description)
+VALUES (9, 'IOF');
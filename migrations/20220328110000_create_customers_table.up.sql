@@ -0,0 +1,18 @@
+CREATE TABLE customers
+(
+    id                  INT          NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    name                VARCHAR(512) NOT NULL DEFAULT '',
+    birth_date          VARCHAR(512) NOT NULL DEFAULT '',
+    company_name        VARCHAR(255) NOT NULL DEFAULT '',
+    email               VARCHAR(512) NOT NULL,
+    phone               VARCHAR(512) NOT NULL DEFAULT '',
+    address_street      VARCHAR(512) NOT NULL DEFAULT '',
+    address_number      VARCHAR(512) NOT NULL DEFAULT '',
+    address_complement  VARCHAR(512) NOT NULL DEFAULT '',
+    address_city        VARCHAR(512) NOT NULL DEFAULT '',
+    address_state       VARCHAR(512) NOT NULL DEFAULT '',
+    address_postal_code VARCHAR(512) NOT NULL DEFAULT '',
+    address_country     VARCHAR(512) NOT NULL DEFAULT '',
+    created_at          TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at          TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+ALTER TABLE accounts
+    DROP FOREIGN KEY accounts_customer_id_foreign,
+    DROP COLUMN customer_id;
@@ -0,0 +1,5 @@
+ALTER TABLE accounts
+    ADD COLUMN customer_id INT NULL AFTER available_credit_limit,
+    ADD CONSTRAINT accounts_customer_id_foreign FOREIGN KEY (customer_id)
+        REFERENCES customers (id)
+        ON DELETE SET NULL;
@@ -0,0 +1,22 @@
+CREATE TABLE cards
+(
+    id               INT            NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    account_id       INT            NOT NULL,
+    type             VARCHAR(16)    NOT NULL,
+    masked_pan       VARCHAR(19)    NOT NULL,
+    token            VARCHAR(64)    NOT NULL,
+    expiry_month     TINYINT        NOT NULL,
+    expiry_year      SMALLINT       NOT NULL,
+    status           VARCHAR(16)    NOT NULL,
+    card_limit       DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    replaces_card_id INT            NULL,
+    created_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    CONSTRAINT cards_token_unique UNIQUE (token),
+    CONSTRAINT cards_account_id_foreign FOREIGN KEY (account_id)
+        REFERENCES accounts (id)
+        ON DELETE CASCADE,
+    CONSTRAINT cards_replaces_card_id_foreign FOREIGN KEY (replaces_card_id)
+        REFERENCES cards (id)
+        ON DELETE SET NULL
+);
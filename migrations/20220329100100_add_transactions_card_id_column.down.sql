@@ -0,0 +1,4 @@
+ALTER TABLE transactions
+    DROP FOREIGN KEY transactions_card_id_foreign,
+    DROP INDEX transactions_card_id_created_at_index,
+    DROP COLUMN card_id;
@@ -0,0 +1,6 @@
+ALTER TABLE transactions
+    ADD COLUMN card_id INT NULL AFTER exchange_rate,
+    ADD CONSTRAINT transactions_card_id_foreign FOREIGN KEY (card_id)
+        REFERENCES cards (id)
+        ON DELETE SET NULL,
+    ADD INDEX transactions_card_id_created_at_index (card_id, created_at);
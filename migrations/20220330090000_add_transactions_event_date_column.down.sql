@@ -0,0 +1,5 @@
+ALTER TABLE transactions
+    ADD INDEX transactions_card_id_created_at_index (card_id, created_at),
+    DROP INDEX transactions_card_id_event_date_index,
+    DROP INDEX transactions_account_id_event_date_index,
+    DROP COLUMN event_date;
@@ -0,0 +1,9 @@
+ALTER TABLE transactions
+    ADD COLUMN event_date TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP AFTER amount;
+
+UPDATE transactions SET event_date = created_at WHERE created_at IS NOT NULL;
+
+ALTER TABLE transactions
+    ADD INDEX transactions_account_id_event_date_index (account_id, event_date),
+    ADD INDEX transactions_card_id_event_date_index (card_id, event_date),
+    DROP INDEX transactions_card_id_created_at_index;
@@ -0,0 +1,27 @@
+CREATE TABLE audit_log
+(
+    id           INT         NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    actor        VARCHAR(64) NOT NULL,
+    request_id   VARCHAR(64) NOT NULL DEFAULT '',
+    action       VARCHAR(32) NOT NULL,
+    entity       VARCHAR(32) NOT NULL,
+    entity_id    INT         NOT NULL,
+    before_state TEXT        NULL,
+    after_state  TEXT        NULL,
+    prev_hash    CHAR(64)    NOT NULL,
+    hash         CHAR(64)    NOT NULL,
+    created_at   TIMESTAMP   NOT NULL,
+    CONSTRAINT audit_log_prev_hash_unique UNIQUE (prev_hash),
+    INDEX audit_log_entity_entity_id_index (entity, entity_id, id)
+);
+
+-- the trail is append only, a changed or deleted entry would still break the hash chain
+CREATE TRIGGER audit_log_prevent_update
+    BEFORE UPDATE
+    ON audit_log
+    FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_log is append only';
+
+CREATE TRIGGER audit_log_prevent_delete
+    BEFORE DELETE
+    ON audit_log
+    FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_log is append only';
@@ -0,0 +1 @@
+CREATE INDEX transactions_card_id_created_at_index ON transactions (card_id, created_at);
@@ -0,0 +1,13 @@
+-- history of the available credit limit changes made outside the transactions, the statements revert them like the
+-- transactions to find the limit at the start of a period
+CREATE TABLE credit_limit_adjustments
+(
+    id         INT            NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    account_id INT            NOT NULL,
+    amount     DECIMAL(10, 2) NOT NULL,
+    created_at TIMESTAMP      NOT NULL,
+    INDEX credit_limit_adjustments_account_id_created_at_index (account_id, created_at),
+    FOREIGN KEY (account_id)
+        REFERENCES accounts (id)
+        ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+ALTER TABLE jobs
+    DROP COLUMN actor,
+    DROP COLUMN request_id;
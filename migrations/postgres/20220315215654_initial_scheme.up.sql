@@ -0,0 +1,29 @@
+CREATE TABLE accounts
+(
+    id              SERIAL      NOT NULL PRIMARY KEY,
+    document_number VARCHAR(14) NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP
+);
+
+CREATE TABLE operation_types
+(
+    id          SERIAL       NOT NULL PRIMARY KEY,
+    description VARCHAR(255) NOT NULL,
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP
+);
+
+CREATE TABLE transactions
+(
+    id                SERIAL         NOT NULL PRIMARY KEY,
+    account_id        INT
+        REFERENCES accounts (id)
+            ON DELETE CASCADE,
+    operation_type_id INT
+        REFERENCES operation_types (id)
+            ON DELETE CASCADE,
+    amount            NUMERIC(10, 2) NOT NULL,
+    created_at        TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at        TIMESTAMP
+);
@@ -0,0 +1 @@
+TRUNCATE operation_types RESTART IDENTITY CASCADE;
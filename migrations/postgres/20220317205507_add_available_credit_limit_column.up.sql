@@ -0,0 +1,2 @@
+ALTER TABLE accounts
+    ADD COLUMN available_credit_limit NUMERIC(10, 2) NOT NULL DEFAULT 0;
@@ -0,0 +1,13 @@
+CREATE TABLE api_clients
+(
+    id           SERIAL       NOT NULL PRIMARY KEY,
+    name         VARCHAR(255) NOT NULL,
+    api_key_hash CHAR(64)     NOT NULL UNIQUE,
+    role         VARCHAR(32)  NOT NULL,
+    scopes       VARCHAR(255) NOT NULL DEFAULT '',
+    account_id   INT
+        REFERENCES accounts (id)
+            ON DELETE CASCADE,
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP
+);
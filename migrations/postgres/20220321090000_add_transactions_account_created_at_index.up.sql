@@ -0,0 +1 @@
+CREATE INDEX transactions_account_id_created_at_index ON transactions (account_id, created_at);
@@ -0,0 +1,17 @@
+CREATE TABLE transaction_decisions
+(
+    id                SERIAL         NOT NULL PRIMARY KEY,
+    transaction_id    INT
+        REFERENCES transactions (id)
+            ON DELETE CASCADE,
+    account_id        INT            NOT NULL
+        REFERENCES accounts (id)
+            ON DELETE CASCADE,
+    operation_type_id INT            NOT NULL
+        REFERENCES operation_types (id)
+            ON DELETE CASCADE,
+    amount            NUMERIC(10, 2) NOT NULL,
+    outcome           VARCHAR(16)    NOT NULL,
+    reason_codes      VARCHAR(255)   NOT NULL DEFAULT '',
+    created_at        TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,15 @@
+CREATE TABLE transaction_attempts
+(
+    id                SERIAL         NOT NULL PRIMARY KEY,
+    account_id        INT            NOT NULL,
+    operation_type_id INT            NOT NULL,
+    amount            NUMERIC(10, 2) NOT NULL,
+    outcome           VARCHAR(16)    NOT NULL,
+    decline_reason    VARCHAR(255)   NOT NULL DEFAULT '',
+    transaction_id    INT
+        REFERENCES transactions (id)
+            ON DELETE CASCADE,
+    created_at        TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX transaction_attempts_account_id_index ON transaction_attempts (account_id);
@@ -0,0 +1,17 @@
+CREATE TABLE jobs
+(
+    id           SERIAL        NOT NULL PRIMARY KEY,
+    type         VARCHAR(64)   NOT NULL,
+    payload      TEXT          NOT NULL,
+    status       VARCHAR(16)   NOT NULL,
+    progress     INT           NOT NULL DEFAULT 0,
+    attempts     INT           NOT NULL DEFAULT 0,
+    max_attempts INT           NOT NULL,
+    result       TEXT,
+    error        VARCHAR(1024) NOT NULL DEFAULT '',
+    client_id    INT           NOT NULL DEFAULT 0,
+    run_at       TIMESTAMP     NOT NULL,
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX jobs_status_run_at_index ON jobs (status, run_at);
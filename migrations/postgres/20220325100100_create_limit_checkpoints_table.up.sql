@@ -0,0 +1,9 @@
+CREATE TABLE limit_checkpoints
+(
+    account_id             INT            NOT NULL PRIMARY KEY
+        REFERENCES accounts (id)
+            ON DELETE CASCADE,
+    available_credit_limit NUMERIC(10, 2) NOT NULL,
+    transaction_id         INT            NOT NULL DEFAULT 0,
+    updated_at             TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,6 @@
+INSERT INTO operation_types (id, description)
+VALUES (5, 'JUROS'),
+       (6, 'TARIFA DE ATRASO'),
+       (7, 'MULTA');
+
+SELECT setval('operation_types_id_seq', (SELECT MAX(id) FROM operation_types));
@@ -0,0 +1,13 @@
+CREATE TABLE charges
+(
+    id             SERIAL         NOT NULL PRIMARY KEY,
+    account_id     INT            NOT NULL
+        REFERENCES accounts (id)
+            ON DELETE CASCADE,
+    type           VARCHAR(16)    NOT NULL,
+    charge_date    DATE           NOT NULL,
+    amount         NUMERIC(10, 2) NOT NULL,
+    transaction_id INT            NOT NULL DEFAULT 0,
+    created_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (account_id, type, charge_date)
+);
@@ -0,0 +1,8 @@
+DELETE FROM operation_types WHERE id = 8;
+
+ALTER TABLE operation_types
+    DROP COLUMN limit_rate,
+    DROP COLUMN fee,
+    DROP COLUMN fee_rate,
+    DROP COLUMN daily_max_count,
+    DROP COLUMN daily_max_amount;
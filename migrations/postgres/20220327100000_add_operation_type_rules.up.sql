@@ -0,0 +1,11 @@
+ALTER TABLE operation_types
+    ADD COLUMN limit_rate       NUMERIC(5, 4)  NOT NULL DEFAULT 0,
+    ADD COLUMN fee              NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN fee_rate         NUMERIC(5, 4)  NOT NULL DEFAULT 0,
+    ADD COLUMN daily_max_count  INT            NOT NULL DEFAULT 0,
+    ADD COLUMN daily_max_amount NUMERIC(10, 2) NOT NULL DEFAULT 0;
+
+INSERT INTO operation_types (id, description)
+VALUES (8, 'TARIFA');
+
+SELECT setval('operation_types_id_seq', (SELECT MAX(id) FROM operation_types));
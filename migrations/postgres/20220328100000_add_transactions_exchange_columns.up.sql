@@ -0,0 +1,9 @@
+ALTER TABLE transactions
+    ADD COLUMN currency        CHAR(3),
+    ADD COLUMN original_amount NUMERIC(10, 2),
+    ADD COLUMN exchange_rate   NUMERIC(18, 8);
+
+INSERT INTO operation_types (id, description)
+VALUES (9, 'IOF');
+
+SELECT setval('operation_types_id_seq', (SELECT MAX(id) FROM operation_types));
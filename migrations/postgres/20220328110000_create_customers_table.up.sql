@@ -0,0 +1,18 @@
+CREATE TABLE customers
+(
+    id                  SERIAL       NOT NULL PRIMARY KEY,
+    name                TEXT         NOT NULL DEFAULT '',
+    birth_date          TEXT         NOT NULL DEFAULT '',
+    company_name        VARCHAR(255) NOT NULL DEFAULT '',
+    email               TEXT         NOT NULL,
+    phone               TEXT         NOT NULL DEFAULT '',
+    address_street      TEXT         NOT NULL DEFAULT '',
+    address_number      TEXT         NOT NULL DEFAULT '',
+    address_complement  TEXT         NOT NULL DEFAULT '',
+    address_city        TEXT         NOT NULL DEFAULT '',
+    address_state       TEXT         NOT NULL DEFAULT '',
+    address_postal_code TEXT         NOT NULL DEFAULT '',
+    address_country     TEXT         NOT NULL DEFAULT '',
+    created_at          TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at          TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,4 @@
+ALTER TABLE accounts
+    ADD COLUMN customer_id INT
+        REFERENCES customers (id)
+            ON DELETE SET NULL;
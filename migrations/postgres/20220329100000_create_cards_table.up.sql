@@ -0,0 +1,19 @@
+CREATE TABLE cards
+(
+    id               SERIAL         NOT NULL PRIMARY KEY,
+    account_id       INT            NOT NULL
+        REFERENCES accounts (id)
+            ON DELETE CASCADE,
+    type             VARCHAR(16)    NOT NULL,
+    masked_pan       VARCHAR(19)    NOT NULL,
+    token            VARCHAR(64)    NOT NULL UNIQUE,
+    expiry_month     SMALLINT       NOT NULL,
+    expiry_year      SMALLINT       NOT NULL,
+    status           VARCHAR(16)    NOT NULL,
+    card_limit       DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    replaces_card_id INT
+        REFERENCES cards (id)
+            ON DELETE SET NULL,
+    created_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
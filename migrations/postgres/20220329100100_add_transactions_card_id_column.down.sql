@@ -0,0 +1,4 @@
+DROP INDEX transactions_card_id_created_at_index;
+
+ALTER TABLE transactions
+    DROP COLUMN card_id;
@@ -0,0 +1,6 @@
+ALTER TABLE transactions
+    ADD COLUMN card_id INT
+        REFERENCES cards (id)
+            ON DELETE SET NULL;
+
+CREATE INDEX transactions_card_id_created_at_index ON transactions (card_id, created_at);
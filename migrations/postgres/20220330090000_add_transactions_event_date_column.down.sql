@@ -0,0 +1,7 @@
+DROP INDEX transactions_card_id_event_date_index;
+DROP INDEX transactions_account_id_event_date_index;
+
+CREATE INDEX transactions_card_id_created_at_index ON transactions (card_id, created_at);
+
+ALTER TABLE transactions
+    DROP COLUMN event_date;
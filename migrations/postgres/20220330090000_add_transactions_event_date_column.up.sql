@@ -0,0 +1,9 @@
+ALTER TABLE transactions
+    ADD COLUMN event_date TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+UPDATE transactions SET event_date = created_at WHERE created_at IS NOT NULL;
+
+DROP INDEX transactions_card_id_created_at_index;
+
+CREATE INDEX transactions_account_id_event_date_index ON transactions (account_id, event_date);
+CREATE INDEX transactions_card_id_event_date_index ON transactions (card_id, event_date);
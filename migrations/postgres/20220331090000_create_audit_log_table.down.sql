@@ -0,0 +1,3 @@
+DROP TRIGGER audit_log_prevent_change ON audit_log;
+DROP FUNCTION audit_log_prevent_change();
+DROP TABLE audit_log;
@@ -0,0 +1,30 @@
+CREATE TABLE audit_log
+(
+    id           SERIAL      NOT NULL PRIMARY KEY,
+    actor        VARCHAR(64) NOT NULL,
+    request_id   VARCHAR(64) NOT NULL DEFAULT '',
+    action       VARCHAR(32) NOT NULL,
+    entity       VARCHAR(32) NOT NULL,
+    entity_id    INT         NOT NULL,
+    before_state TEXT,
+    after_state  TEXT,
+    prev_hash    CHAR(64)    NOT NULL UNIQUE,
+    hash         CHAR(64)    NOT NULL,
+    created_at   TIMESTAMP   NOT NULL
+);
+
+CREATE INDEX audit_log_entity_entity_id_index ON audit_log (entity, entity_id, id);
+
+-- the trail is append only, a changed or deleted entry would still break the hash chain
+CREATE FUNCTION audit_log_prevent_change() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_prevent_change
+    BEFORE UPDATE OR DELETE
+    ON audit_log
+    FOR EACH ROW
+EXECUTE PROCEDURE audit_log_prevent_change();
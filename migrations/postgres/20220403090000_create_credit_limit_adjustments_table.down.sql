@@ -0,0 +1 @@
+DROP TABLE credit_limit_adjustments;
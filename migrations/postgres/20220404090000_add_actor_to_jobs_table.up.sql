@@ -0,0 +1,3 @@
+ALTER TABLE jobs
+    ADD COLUMN actor      VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN request_id VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,29 @@
+CREATE TABLE accounts
+(
+    id              INTEGER     NOT NULL PRIMARY KEY AUTOINCREMENT,
+    document_number VARCHAR(14) NOT NULL,
+    created_at      DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at      DATETIME
+);
+
+CREATE TABLE operation_types
+(
+    id          INTEGER      NOT NULL PRIMARY KEY AUTOINCREMENT,
+    description VARCHAR(255) NOT NULL,
+    created_at  DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at  DATETIME
+);
+
+CREATE TABLE transactions
+(
+    id                INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    account_id        INTEGER
+        REFERENCES accounts (id)
+            ON DELETE CASCADE,
+    operation_type_id INTEGER
+        REFERENCES operation_types (id)
+            ON DELETE CASCADE,
+    amount            REAL    NOT NULL,
+    created_at        DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at        DATETIME
+);
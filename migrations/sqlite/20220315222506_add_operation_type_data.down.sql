@@ -0,0 +1,2 @@
+DELETE FROM operation_types;
+DELETE FROM sqlite_sequence WHERE name = 'operation_types';
@@ -0,0 +1,5 @@
+INSERT INTO operation_types (description)
+VALUES ('COMPRA A VISTA'),
+       ('COMPRA PARCELADA'),
+       ('SAQUE'),
+       ('PAGAMENTO');
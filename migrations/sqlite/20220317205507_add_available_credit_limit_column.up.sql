@@ -0,0 +1,2 @@
+ALTER TABLE accounts
+    ADD COLUMN available_credit_limit REAL NOT NULL DEFAULT 0;
@@ -0,0 +1,13 @@
+CREATE TABLE api_clients
+(
+    id           INTEGER      NOT NULL PRIMARY KEY AUTOINCREMENT,
+    name         VARCHAR(255) NOT NULL,
+    api_key_hash CHAR(64)     NOT NULL UNIQUE,
+    role         VARCHAR(32)  NOT NULL,
+    scopes       VARCHAR(255) NOT NULL DEFAULT '',
+    account_id   INTEGER
+        REFERENCES accounts (id)
+            ON DELETE CASCADE,
+    created_at   DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at   DATETIME
+);
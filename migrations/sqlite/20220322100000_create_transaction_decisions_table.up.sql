@@ -0,0 +1,17 @@
+CREATE TABLE transaction_decisions
+(
+    id                INTEGER      NOT NULL PRIMARY KEY AUTOINCREMENT,
+    transaction_id    INTEGER
+        REFERENCES transactions (id)
+            ON DELETE CASCADE,
+    account_id        INTEGER      NOT NULL
+        REFERENCES accounts (id)
+            ON DELETE CASCADE,
+    operation_type_id INTEGER      NOT NULL
+        REFERENCES operation_types (id)
+            ON DELETE CASCADE,
+    amount            REAL         NOT NULL,
+    outcome           VARCHAR(16)  NOT NULL,
+    reason_codes      VARCHAR(255) NOT NULL DEFAULT '',
+    created_at        DATETIME DEFAULT CURRENT_TIMESTAMP
+);
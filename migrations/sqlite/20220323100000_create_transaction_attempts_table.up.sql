@@ -0,0 +1,15 @@
+CREATE TABLE transaction_attempts
+(
+    id                INTEGER      NOT NULL PRIMARY KEY AUTOINCREMENT,
+    account_id        INTEGER      NOT NULL,
+    operation_type_id INTEGER      NOT NULL,
+    amount            REAL         NOT NULL,
+    outcome           VARCHAR(16)  NOT NULL,
+    decline_reason    VARCHAR(255) NOT NULL DEFAULT '',
+    transaction_id    INTEGER
+        REFERENCES transactions (id)
+            ON DELETE CASCADE,
+    created_at        DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX transaction_attempts_account_id_index ON transaction_attempts (account_id);
@@ -0,0 +1,17 @@
+CREATE TABLE jobs
+(
+    id           INTEGER       NOT NULL PRIMARY KEY AUTOINCREMENT,
+    type         VARCHAR(64)   NOT NULL,
+    payload      TEXT          NOT NULL,
+    status       VARCHAR(16)   NOT NULL,
+    progress     INTEGER       NOT NULL DEFAULT 0,
+    attempts     INTEGER       NOT NULL DEFAULT 0,
+    max_attempts INTEGER       NOT NULL,
+    result       TEXT,
+    error        VARCHAR(1024) NOT NULL DEFAULT '',
+    client_id    INTEGER       NOT NULL DEFAULT 0,
+    run_at       DATETIME      NOT NULL,
+    created_at   DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX jobs_status_run_at_index ON jobs (status, run_at);
@@ -0,0 +1,2 @@
+DROP INDEX jobs_type_index;
+DROP INDEX transaction_attempts_created_at_index;
@@ -0,0 +1,2 @@
+CREATE INDEX jobs_type_index ON jobs (type);
+CREATE INDEX transaction_attempts_created_at_index ON transaction_attempts (created_at);
@@ -0,0 +1 @@
+DROP TABLE limit_checkpoints;
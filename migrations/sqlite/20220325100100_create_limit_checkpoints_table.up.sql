@@ -0,0 +1,9 @@
+CREATE TABLE limit_checkpoints
+(
+    account_id             INTEGER NOT NULL PRIMARY KEY
+        REFERENCES accounts (id)
+            ON DELETE CASCADE,
+    available_credit_limit REAL    NOT NULL,
+    transaction_id         INTEGER NOT NULL DEFAULT 0,
+    updated_at             DATETIME DEFAULT CURRENT_TIMESTAMP
+);
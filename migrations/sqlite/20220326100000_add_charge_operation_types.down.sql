@@ -0,0 +1 @@
+DELETE FROM operation_types WHERE id IN (5, 6, 7);
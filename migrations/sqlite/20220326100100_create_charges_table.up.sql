@@ -0,0 +1,13 @@
+CREATE TABLE charges
+(
+    id             INTEGER     NOT NULL PRIMARY KEY AUTOINCREMENT,
+    account_id     INTEGER     NOT NULL
+        REFERENCES accounts (id)
+            ON DELETE CASCADE,
+    type           VARCHAR(16) NOT NULL,
+    charge_date    VARCHAR(10) NOT NULL,
+    amount         REAL        NOT NULL,
+    transaction_id INTEGER     NOT NULL DEFAULT 0,
+    created_at     DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (account_id, type, charge_date)
+);
@@ -0,0 +1,12 @@
+DELETE FROM operation_types WHERE id = 8;
+
+ALTER TABLE operation_types
+    DROP COLUMN limit_rate;
+ALTER TABLE operation_types
+    DROP COLUMN fee;
+ALTER TABLE operation_types
+    DROP COLUMN fee_rate;
+ALTER TABLE operation_types
+    DROP COLUMN daily_max_count;
+ALTER TABLE operation_types
+    DROP COLUMN daily_max_amount;
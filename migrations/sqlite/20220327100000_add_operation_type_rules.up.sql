@@ -0,0 +1,13 @@
+ALTER TABLE operation_types
+    ADD COLUMN limit_rate REAL NOT NULL DEFAULT 0;
+ALTER TABLE operation_types
+    ADD COLUMN fee REAL NOT NULL DEFAULT 0;
+ALTER TABLE operation_types
+    ADD COLUMN fee_rate REAL NOT NULL DEFAULT 0;
+ALTER TABLE operation_types
+    ADD COLUMN daily_max_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE operation_types
+    ADD COLUMN daily_max_amount REAL NOT NULL DEFAULT 0;
+
+INSERT INTO operation_types (id, description)
+VALUES (8, 'TARIFA');
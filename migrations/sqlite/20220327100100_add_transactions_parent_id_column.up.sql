@@ -0,0 +1,4 @@
+ALTER TABLE transactions
+    ADD COLUMN parent_id INTEGER
+        REFERENCES transactions (id)
+            ON DELETE CASCADE;
@@ -0,0 +1,8 @@
+DELETE FROM operation_types WHERE id = 9;
+
+ALTER TABLE transactions
+    DROP COLUMN exchange_rate;
+ALTER TABLE transactions
+    DROP COLUMN original_amount;
+ALTER TABLE transactions
+    DROP COLUMN currency;
@@ -0,0 +1,9 @@
+ALTER TABLE transactions
+    ADD COLUMN currency TEXT;
+ALTER TABLE transactions
+    ADD COLUMN original_amount REAL;
+ALTER TABLE transactions
+    ADD COLUMN exchange_rate REAL;
+
+INSERT INTO operation_types (id, description)
+VALUES (9, 'IOF');
@@ -0,0 +1,19 @@
+CREATE TABLE cards
+(
+    id               INTEGER        NOT NULL PRIMARY KEY AUTOINCREMENT,
+    account_id       INTEGER        NOT NULL
+        REFERENCES accounts (id)
+            ON DELETE CASCADE,
+    type             VARCHAR(16)    NOT NULL,
+    masked_pan       VARCHAR(19)    NOT NULL,
+    token            VARCHAR(64)    NOT NULL UNIQUE,
+    expiry_month     INTEGER        NOT NULL,
+    expiry_year      INTEGER        NOT NULL,
+    status           VARCHAR(16)    NOT NULL,
+    card_limit       DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    replaces_card_id INTEGER
+        REFERENCES cards (id)
+            ON DELETE SET NULL,
+    created_at       DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at       DATETIME DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,20 @@
+-- sqlite can't add a column defaulting to CURRENT_TIMESTAMP, the trigger gives the creation time to the transactions
+-- inserted without an event date
+ALTER TABLE transactions
+    ADD COLUMN event_date DATETIME;
+
+UPDATE transactions SET event_date = created_at;
+
+CREATE TRIGGER transactions_event_date_default
+    AFTER INSERT
+    ON transactions
+    FOR EACH ROW
+    WHEN NEW.event_date IS NULL
+BEGIN
+    UPDATE transactions SET event_date = NEW.created_at WHERE id = NEW.id;
+END;
+
+DROP INDEX transactions_card_id_created_at_index;
+
+CREATE INDEX transactions_account_id_event_date_index ON transactions (account_id, event_date);
+CREATE INDEX transactions_card_id_event_date_index ON transactions (card_id, event_date);
@@ -0,0 +1,3 @@
+DROP TRIGGER audit_log_prevent_delete;
+DROP TRIGGER audit_log_prevent_update;
+DROP TABLE audit_log;
@@ -0,0 +1,31 @@
+CREATE TABLE audit_log
+(
+    id           INTEGER     NOT NULL PRIMARY KEY AUTOINCREMENT,
+    actor        VARCHAR(64) NOT NULL,
+    request_id   VARCHAR(64) NOT NULL DEFAULT '',
+    action       VARCHAR(32) NOT NULL,
+    entity       VARCHAR(32) NOT NULL,
+    entity_id    INTEGER     NOT NULL,
+    before_state TEXT,
+    after_state  TEXT,
+    prev_hash    CHAR(64)    NOT NULL UNIQUE,
+    hash         CHAR(64)    NOT NULL,
+    created_at   DATETIME    NOT NULL
+);
+
+CREATE INDEX audit_log_entity_entity_id_index ON audit_log (entity, entity_id, id);
+
+-- the trail is append only, a changed or deleted entry would still break the hash chain
+CREATE TRIGGER audit_log_prevent_update
+    BEFORE UPDATE
+    ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append only');
+END;
+
+CREATE TRIGGER audit_log_prevent_delete
+    BEFORE DELETE
+    ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append only');
+END;
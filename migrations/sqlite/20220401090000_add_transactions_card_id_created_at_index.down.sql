@@ -0,0 +1 @@
+DROP INDEX transactions_card_id_created_at_index;
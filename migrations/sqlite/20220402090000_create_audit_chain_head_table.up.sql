@@ -0,0 +1,10 @@
+-- single row with the hash of the last entry of the trail, locked by every append so the first appends of an empty
+-- trail are chained one after the other too
+CREATE TABLE audit_chain_head
+(
+    id   INTEGER NOT NULL PRIMARY KEY,
+    hash TEXT    NOT NULL
+);
+
+INSERT INTO audit_chain_head (id, hash)
+SELECT 1, COALESCE((SELECT hash FROM audit_log ORDER BY id DESC LIMIT 1), '');
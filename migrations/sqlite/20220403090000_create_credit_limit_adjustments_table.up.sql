@@ -0,0 +1,13 @@
+-- history of the available credit limit changes made outside the transactions, the statements revert them like the
+-- transactions to find the limit at the start of a period
+CREATE TABLE credit_limit_adjustments
+(
+    id         INTEGER  NOT NULL PRIMARY KEY AUTOINCREMENT,
+    account_id INTEGER  NOT NULL
+        REFERENCES accounts (id)
+            ON DELETE CASCADE,
+    amount     REAL     NOT NULL,
+    created_at DATETIME NOT NULL
+);
+
+CREATE INDEX credit_limit_adjustments_account_id_created_at_index ON credit_limit_adjustments (account_id, created_at);
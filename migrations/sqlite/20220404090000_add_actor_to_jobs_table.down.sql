@@ -0,0 +1,4 @@
+ALTER TABLE jobs
+    DROP COLUMN actor;
+ALTER TABLE jobs
+    DROP COLUMN request_id;
@@ -0,0 +1,4 @@
+ALTER TABLE jobs
+    ADD COLUMN actor VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE jobs
+    ADD COLUMN request_id VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,74 @@
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock tells the current time to the services, a Fake replaces it on the tests of the date logic
+type Clock interface {
+	// Now return the current time shifted by the offset of the context, see WithOffset
+	Now(ctx context.Context) time.Time
+}
+
+type offsetKeyType struct{}
+
+var offsetKey = offsetKeyType{}
+
+// WithOffset shift the time told by the clocks to the calls with the returned context, used to travel in time on
+// local environments
+func WithOffset(ctx context.Context, offset time.Duration) context.Context {
+	return context.WithValue(ctx, offsetKey, offset)
+}
+
+// Offset return the shift of the context, zero when it did not travel in time
+func Offset(ctx context.Context) time.Duration {
+	offset, _ := ctx.Value(offsetKey).(time.Duration)
+
+	return offset
+}
+
+type systemClock struct{}
+
+// New create the clock of the system time
+func New() Clock {
+	return systemClock{}
+}
+
+func (systemClock) Now(ctx context.Context) time.Time {
+	return time.Now().Add(Offset(ctx))
+}
+
+// Fake is a clock stopped at a time, which only changes with Set and Advance
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+func (f *Fake) Now(ctx context.Context) time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now.Add(Offset(ctx))
+}
+
+// Set stop the clock at now
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.now = now
+}
+
+// Advance move the clock forward by d, backwards when negative
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.now = f.now.Add(d)
+}
@@ -0,0 +1,40 @@
+package clock
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFake(t *testing.T) {
+	now := time.Date(2022, 3, 30, 10, 0, 0, 0, time.UTC)
+
+	f := NewFake(now)
+	if got := f.Now(context.TODO()); !got.Equal(now) {
+		t.Errorf("Now() = %v, want %v", got, now)
+	}
+
+	f.Advance(time.Hour)
+	if got, want := f.Now(context.TODO()), now.Add(time.Hour); !got.Equal(want) {
+		t.Errorf("Now() after Advance = %v, want %v", got, want)
+	}
+
+	f.Set(now)
+	ctx := WithOffset(context.TODO(), -24*time.Hour)
+	if got, want := f.Now(ctx), now.Add(-24*time.Hour); !got.Equal(want) {
+		t.Errorf("Now() with offset = %v, want %v", got, want)
+	}
+}
+
+func TestNew(t *testing.T) {
+	ctx := WithOffset(context.TODO(), 48*time.Hour)
+
+	got := New().Now(ctx)
+	if got.Before(time.Now().Add(47*time.Hour)) || got.After(time.Now().Add(49*time.Hour)) {
+		t.Errorf("Now() with offset = %v, want two days from now", got)
+	}
+
+	if Offset(context.TODO()) != 0 {
+		t.Errorf("Offset() = %v, want 0", Offset(context.TODO()))
+	}
+}